@@ -28,10 +28,14 @@ CREATE TABLE `Inventory_Logs` (
   `user_id` binary(16) NOT NULL,
   `change_quantity` int NOT NULL,
   `reason` varchar(255) DEFAULT NULL,
+  `operation_id` varchar(64) DEFAULT NULL,
   `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `location_id` binary(16) NOT NULL,
   PRIMARY KEY (`log_id`),
   KEY `idx_logs_product_id` (`product_id`),
-  CONSTRAINT `fk_logs_product` FOREIGN KEY (`product_id`) REFERENCES `Product_Stocks` (`product_id`) ON DELETE CASCADE
+  KEY `idx_logs_operation_id` (`operation_id`),
+  KEY `idx_logs_location_product` (`location_id`,`product_id`),
+  CONSTRAINT `fk_logs_stock` FOREIGN KEY (`location_id`, `product_id`) REFERENCES `Product_Stocks` (`location_id`, `product_id`) ON DELETE CASCADE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
 /*!40101 SET character_set_client = @saved_cs_client */;
 
@@ -41,10 +45,37 @@ CREATE TABLE `Inventory_Logs` (
 
 LOCK TABLES `Inventory_Logs` WRITE;
 /*!40000 ALTER TABLE `Inventory_Logs` DISABLE KEYS */;
-INSERT INTO `Inventory_Logs` VALUES (_binary ']Y\⏝m',_binary 'V>:\\vLa﹓[',_binary 'V>:\\vLa﹓[',100,'testing aja','2025-11-20 21:17:10'),(_binary '&G..',_binary 'V>:\\vLa﹓[',_binary 'V>:\\vLa﹓[',-5,'Transaction: TX-12345','2025-11-20 21:20:13'),(_binary '\[\\\8\\',_binary '\X.\Y\Zǒ',_binary '\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0',100,'init stock from monolith','2025-11-20 21:44:56'),(_binary 'Bm\"\:\\\0?/L',_binary 'BlKEW(4c',_binary '\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0',100,'init stock from monolith','2025-11-20 21:46:33'),(_binary '0\M<UE\5s',_binary 'BlKEW(4c',_binary 'Ȅ\	7 >',-1,'Transaction: 01KAJBTC70RT93JKPH7KAR92DP','2025-11-20 21:47:34'),(_binary '0\M<UE\H',_binary '\X.\Y\Zǒ',_binary 'Ȅ\	7 >',-2,'Transaction: 01KAJBTC70RT93JKPH7KAR92DP','2025-11-20 21:47:34'),(_binary '\0\"`\f1s',_binary '\0\}nQ',_binary '\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0',200,'init stock from monolith','2025-11-20 23:00:38'),(_binary '\\Ϝ\\/j',_binary '\0\}nQ',_binary 'Ȅ\	7 >',10,'Incoming Stock','2025-11-20 23:02:27'),(_binary '\\)\\\	',_binary '\0\}nQ',_binary 'Ȅ\	7 >',-1,'Transaction: 01KAJG5WNG9XSWBJ5B078X7TRK','2025-11-20 23:03:45'),(_binary '~#\Z{\\',_binary '\0\}nQ',_binary 'Ȅ\	7 >',10,'Incoming Stock','2025-11-20 23:21:49'),(_binary '\\7绀6PF',_binary '\0\}nQ',_binary 'Ȅ\	7 >',10,'Incoming Stock','2025-11-20 23:22:09'),(_binary 'i\U՗=',_binary 'i\Q3r$e\쓏',_binary '\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0',200,'init stock from monolith','2025-11-21 00:56:08'),(_binary 'k%\Ħ)\'',_binary 'k%\\\Q0EQ3Pq',_binary '\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0',250,'init stock from monolith','2025-11-21 00:57:34'),(_binary 'ks:#',_binary 'kq^V-70',_binary '\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0',225,'init stock from monolith','2025-11-21 00:58:06'),(_binary 'l)A:B^V',_binary 'l)VS\{r',_binary '\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0',175,'init stock from monolith','2025-11-21 00:58:41'),(_binary 'o[x\-.t1d9',_binary 'l)VS\{r',_binary '[*}tb8_\',10,'Incoming Stock','2025-11-21 01:02:10'),(_binary 'p:Ā\k\',_binary 'i\Q3r$e\쓏',_binary '[*}tb8_\',-3,'Transaction: 01KAJQ143GQSEJNDAM3XERK2QG','2025-11-21 01:03:29'),(_binary 'p:Ā\L\ɺ',_binary 'k%\\\Q0EQ3Pq',_binary '[*}tb8_\',-5,'Transaction: 01KAJQ143GQSEJNDAM3XERK2QG','2025-11-21 01:03:29'),(_binary 'p:Ā\Ϻ\',_binary 'kq^V-70',_binary '[*}tb8_\',-2,'Transaction: 01KAJQ143GQSEJNDAM3XERK2QG','2025-11-21 01:03:29');
+INSERT INTO `Inventory_Logs` VALUES (_binary ']Y\⏝m',_binary 'V>:\\vLa﹓[',_binary 'V>:\\vLa﹓[',100,'testing aja',NULL,'2025-11-20 21:17:10',0x019AA31120804055595EE6D24ACBD2BC),(_binary '&G..',_binary 'V>:\\vLa﹓[',_binary 'V>:\\vLa﹓[',-5,'Transaction: TX-12345',NULL,'2025-11-20 21:20:13',0x019AA31120804055595EE6D24ACBD2BC),(_binary '\[\\\8\\',_binary '\X.\Y\Zǒ',_binary '\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0',100,'init stock from monolith',NULL,'2025-11-20 21:44:56',0x019AA31120804055595EE6D24ACBD2BC),(_binary 'Bm\"\:\\\0?/L',_binary 'BlKEW(4c',_binary '\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0',100,'init stock from monolith',NULL,'2025-11-20 21:46:33',0x019AA31120804055595EE6D24ACBD2BC),(_binary '0\M<UE\5s',_binary 'BlKEW(4c',_binary 'Ȅ\	7 >',-1,'Transaction: 01KAJBTC70RT93JKPH7KAR92DP',NULL,'2025-11-20 21:47:34',0x019AA31120804055595EE6D24ACBD2BC),(_binary '0\M<UE\H',_binary '\X.\Y\Zǒ',_binary 'Ȅ\	7 >',-2,'Transaction: 01KAJBTC70RT93JKPH7KAR92DP',NULL,'2025-11-20 21:47:34',0x019AA31120804055595EE6D24ACBD2BC),(_binary '\0\"`\f1s',_binary '\0\}nQ',_binary '\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0',200,'init stock from monolith',NULL,'2025-11-20 23:00:38',0x019AA31120804055595EE6D24ACBD2BC),(_binary '\\Ϝ\\/j',_binary '\0\}nQ',_binary 'Ȅ\	7 >',10,'Incoming Stock',NULL,'2025-11-20 23:02:27',0x019AA31120804055595EE6D24ACBD2BC),(_binary '\\)\\\	',_binary '\0\}nQ',_binary 'Ȅ\	7 >',-1,'Transaction: 01KAJG5WNG9XSWBJ5B078X7TRK',NULL,'2025-11-20 23:03:45',0x019AA31120804055595EE6D24ACBD2BC),(_binary '~#\Z{\\',_binary '\0\}nQ',_binary 'Ȅ\	7 >',10,'Incoming Stock',NULL,'2025-11-20 23:21:49',0x019AA31120804055595EE6D24ACBD2BC),(_binary '\\7绀6PF',_binary '\0\}nQ',_binary 'Ȅ\	7 >',10,'Incoming Stock',NULL,'2025-11-20 23:22:09',0x019AA31120804055595EE6D24ACBD2BC),(_binary 'i\U՗=',_binary 'i\Q3r$e\쓏',_binary '\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0',200,'init stock from monolith',NULL,'2025-11-21 00:56:08',0x019AA31120804055595EE6D24ACBD2BC),(_binary 'k%\Ħ)\'',_binary 'k%\\\Q0EQ3Pq',_binary '\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0',250,'init stock from monolith',NULL,'2025-11-21 00:57:34',0x019AA31120804055595EE6D24ACBD2BC),(_binary 'ks:#',_binary 'kq^V-70',_binary '\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0',225,'init stock from monolith',NULL,'2025-11-21 00:58:06',0x019AA31120804055595EE6D24ACBD2BC),(_binary 'l)A:B^V',_binary 'l)VS\{r',_binary '\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0\0',175,'init stock from monolith',NULL,'2025-11-21 00:58:41',0x019AA31120804055595EE6D24ACBD2BC),(_binary 'o[x\-.t1d9',_binary 'l)VS\{r',_binary '[*}tb8_\',10,'Incoming Stock',NULL,'2025-11-21 01:02:10',0x019AA31120804055595EE6D24ACBD2BC),(_binary 'p:Ā\k\',_binary 'i\Q3r$e\쓏',_binary '[*}tb8_\',-3,'Transaction: 01KAJQ143GQSEJNDAM3XERK2QG',NULL,'2025-11-21 01:03:29',0x019AA31120804055595EE6D24ACBD2BC),(_binary 'p:Ā\L\ɺ',_binary 'k%\\\Q0EQ3Pq',_binary '[*}tb8_\',-5,'Transaction: 01KAJQ143GQSEJNDAM3XERK2QG',NULL,'2025-11-21 01:03:29',0x019AA31120804055595EE6D24ACBD2BC),(_binary 'p:Ā\Ϻ\',_binary 'kq^V-70',_binary '[*}tb8_\',-2,'Transaction: 01KAJQ143GQSEJNDAM3XERK2QG',NULL,'2025-11-21 01:03:29',0x019AA31120804055595EE6D24ACBD2BC);
 /*!40000 ALTER TABLE `Inventory_Logs` ENABLE KEYS */;
 UNLOCK TABLES;
 
+--
+-- Table structure for table `Locations`
+--
+
+DROP TABLE IF EXISTS `Locations`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Locations` (
+  `location_id` binary(16) NOT NULL,
+  `location_name` varchar(100) NOT NULL,
+  `location_type` varchar(20) NOT NULL DEFAULT 'store' COMMENT 'store, warehouse',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`location_id`),
+  UNIQUE KEY `location_name` (`location_name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Locations`
+--
+
+LOCK TABLES `Locations` WRITE;
+/*!40000 ALTER TABLE `Locations` DISABLE KEYS */;
+INSERT INTO `Locations` VALUES (0x019AA31120804055595EE6D24ACBD2BC,'Main Store','store','2025-11-20 21:00:00');
+/*!40000 ALTER TABLE `Locations` ENABLE KEYS */;
+UNLOCK TABLES;
+
 --
 -- Table structure for table `Product_Stocks`
 --
@@ -53,9 +84,12 @@ DROP TABLE IF EXISTS `Product_Stocks`;
 /*!40101 SET @saved_cs_client     = @@character_set_client */;
 /*!50503 SET character_set_client = utf8mb4 */;
 CREATE TABLE `Product_Stocks` (
+  `location_id` binary(16) NOT NULL,
   `product_id` binary(16) NOT NULL,
   `quantity` int NOT NULL DEFAULT '0',
-  PRIMARY KEY (`product_id`)
+  PRIMARY KEY (`location_id`,`product_id`),
+  KEY `idx_stocks_product_id` (`product_id`),
+  CONSTRAINT `fk_stocks_location` FOREIGN KEY (`location_id`) REFERENCES `Locations` (`location_id`) ON DELETE RESTRICT
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
 /*!40101 SET character_set_client = @saved_cs_client */;
 
@@ -65,9 +99,217 @@ CREATE TABLE `Product_Stocks` (
 
 LOCK TABLES `Product_Stocks` WRITE;
 /*!40000 ALTER TABLE `Product_Stocks` DISABLE KEYS */;
-INSERT INTO `Product_Stocks` VALUES (_binary 'V>:\\vLa﹓[',95),(_binary '\X.\Y\Zǒ',98),(_binary 'BlKEW(4c',99),(_binary '\0\}nQ',229),(_binary 'i\Q3r$e\쓏',197),(_binary 'k%\\\Q0EQ3Pq',245),(_binary 'kq^V-70',223),(_binary 'l)VS\{r',185);
+INSERT INTO `Product_Stocks` VALUES (0x019AA31120804055595EE6D24ACBD2BC,_binary 'V>:\\vLa﹓[',95),(0x019AA31120804055595EE6D24ACBD2BC,_binary '\X.\Y\Zǒ',98),(0x019AA31120804055595EE6D24ACBD2BC,_binary 'BlKEW(4c',99),(0x019AA31120804055595EE6D24ACBD2BC,_binary '\0\}nQ',229),(0x019AA31120804055595EE6D24ACBD2BC,_binary 'i\Q3r$e\쓏',197),(0x019AA31120804055595EE6D24ACBD2BC,_binary 'k%\\\Q0EQ3Pq',245),(0x019AA31120804055595EE6D24ACBD2BC,_binary 'kq^V-70',223),(0x019AA31120804055595EE6D24ACBD2BC,_binary 'l)VS\{r',185);
 /*!40000 ALTER TABLE `Product_Stocks` ENABLE KEYS */;
 UNLOCK TABLES;
+--
+-- Table structure for table `Stock_Lot_Movements`
+--
+
+DROP TABLE IF EXISTS `Stock_Lot_Movements`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Stock_Lot_Movements` (
+  `movement_id` binary(16) NOT NULL,
+  `lot_id` binary(16) NOT NULL,
+  `operation_id` varchar(64) DEFAULT NULL,
+  `source_operation_id` varchar(64) DEFAULT NULL COMMENT 'operation whose consumed stock this gives back, or takes back again when the giving operation is cancelled',
+  `source_lot_id` binary(16) DEFAULT NULL COMMENT 'lot the stock was taken from when it is given back into a lot at another location',
+  `change_quantity` int NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`movement_id`),
+  KEY `lot_id` (`lot_id`),
+  KEY `operation_id` (`operation_id`),
+  KEY `source_operation_id` (`source_operation_id`),
+  CONSTRAINT `fk_lot_movements_lot` FOREIGN KEY (`lot_id`) REFERENCES `Stock_Lots` (`lot_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Stock_Lot_Movements`
+--
+
+LOCK TABLES `Stock_Lot_Movements` WRITE;
+/*!40000 ALTER TABLE `Stock_Lot_Movements` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Stock_Lot_Movements` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Stock_Lots`
+--
+
+DROP TABLE IF EXISTS `Stock_Lots`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Stock_Lots` (
+  `lot_id` binary(16) NOT NULL,
+  `location_id` binary(16) NOT NULL,
+  `product_id` binary(16) NOT NULL,
+  `lot_number` varchar(64) NOT NULL DEFAULT '',
+  `received_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `expiry_date` date DEFAULT NULL,
+  `quantity` int NOT NULL DEFAULT '0' COMMENT 'remaining quantity, stock received without a lot number or expiry is held in lots without them',
+  PRIMARY KEY (`lot_id`),
+  KEY `location_product_expiry` (`location_id`,`product_id`,`expiry_date`),
+  KEY `expiry_date` (`expiry_date`),
+  CONSTRAINT `fk_lots_stock` FOREIGN KEY (`location_id`, `product_id`) REFERENCES `Product_Stocks` (`location_id`, `product_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Stock_Lots`
+--
+
+LOCK TABLES `Stock_Lots` WRITE;
+/*!40000 ALTER TABLE `Stock_Lots` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Stock_Lots` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Stock_Operations`
+--
+
+DROP TABLE IF EXISTS `Stock_Operations`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Stock_Operations` (
+  `operation_id` varchar(64) NOT NULL COMMENT 'e.g. transaction:<transaction_id>, void:<transaction_id>',
+  `operation_type` varchar(32) NOT NULL,
+  `result_message` varchar(255) NOT NULL DEFAULT '' COMMENT 'returned again when the operation is replayed',
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`operation_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Stock_Operations`
+--
+
+LOCK TABLES `Stock_Operations` WRITE;
+/*!40000 ALTER TABLE `Stock_Operations` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Stock_Operations` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Stock_Reservation_Items`
+--
+
+DROP TABLE IF EXISTS `Stock_Reservation_Items`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Stock_Reservation_Items` (
+  `reservation_id` binary(16) NOT NULL,
+  `product_id` binary(16) NOT NULL,
+  `quantity` int NOT NULL,
+  PRIMARY KEY (`reservation_id`,`product_id`),
+  KEY `idx_reservation_items_product_id` (`product_id`),
+  CONSTRAINT `fk_reservation_items_reservation` FOREIGN KEY (`reservation_id`) REFERENCES `Stock_Reservations` (`reservation_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Stock_Reservation_Items`
+--
+
+LOCK TABLES `Stock_Reservation_Items` WRITE;
+/*!40000 ALTER TABLE `Stock_Reservation_Items` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Stock_Reservation_Items` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Stock_Reservations`
+--
+
+DROP TABLE IF EXISTS `Stock_Reservations`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Stock_Reservations` (
+  `reservation_id` binary(16) NOT NULL,
+  `reference` varchar(64) NOT NULL DEFAULT '' COMMENT 'cart or order the stock is held for',
+  `location_id` binary(16) NOT NULL,
+  `user_id` binary(16) NOT NULL,
+  `status` varchar(20) NOT NULL DEFAULT 'active' COMMENT 'active, committed, released',
+  `expires_at` timestamp NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`reservation_id`),
+  KEY `idx_reservations_status_expires_at` (`status`,`expires_at`),
+  KEY `idx_reservations_location_id` (`location_id`),
+  CONSTRAINT `fk_reservations_location` FOREIGN KEY (`location_id`) REFERENCES `Locations` (`location_id`) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Stock_Reservations`
+--
+
+LOCK TABLES `Stock_Reservations` WRITE;
+/*!40000 ALTER TABLE `Stock_Reservations` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Stock_Reservations` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Stock_Transfer_Items`
+--
+
+DROP TABLE IF EXISTS `Stock_Transfer_Items`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Stock_Transfer_Items` (
+  `transfer_id` binary(16) NOT NULL,
+  `product_id` binary(16) NOT NULL,
+  `quantity` int NOT NULL,
+  `quantity_received` int NOT NULL DEFAULT '0',
+  `quantity_discrepancy` int NOT NULL DEFAULT '0' COMMENT 'lost or damaged in transit',
+  `discrepancy_note` varchar(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (`transfer_id`,`product_id`),
+  CONSTRAINT `fk_transfer_items_transfer` FOREIGN KEY (`transfer_id`) REFERENCES `Stock_Transfers` (`transfer_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Stock_Transfer_Items`
+--
+
+LOCK TABLES `Stock_Transfer_Items` WRITE;
+/*!40000 ALTER TABLE `Stock_Transfer_Items` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Stock_Transfer_Items` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Stock_Transfers`
+--
+
+DROP TABLE IF EXISTS `Stock_Transfers`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Stock_Transfers` (
+  `transfer_id` binary(16) NOT NULL,
+  `source_location_id` binary(16) NOT NULL,
+  `destination_location_id` binary(16) NOT NULL,
+  `status` varchar(20) NOT NULL DEFAULT 'draft' COMMENT 'draft, in_transit, partially_received, received, cancelled',
+  `note` varchar(255) NOT NULL DEFAULT '',
+  `created_by` binary(16) NOT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `dispatched_at` timestamp NULL DEFAULT NULL,
+  `received_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`transfer_id`),
+  KEY `idx_transfers_source` (`source_location_id`),
+  KEY `idx_transfers_destination` (`destination_location_id`),
+  KEY `idx_transfers_status` (`status`),
+  CONSTRAINT `fk_transfers_source` FOREIGN KEY (`source_location_id`) REFERENCES `Locations` (`location_id`) ON DELETE RESTRICT,
+  CONSTRAINT `fk_transfers_destination` FOREIGN KEY (`destination_location_id`) REFERENCES `Locations` (`location_id`) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Stock_Transfers`
+--
+
+LOCK TABLES `Stock_Transfers` WRITE;
+/*!40000 ALTER TABLE `Stock_Transfers` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Stock_Transfers` ENABLE KEYS */;
+UNLOCK TABLES;
+
 /*!40103 SET TIME_ZONE=@OLD_TIME_ZONE */;
 
 /*!40101 SET SQL_MODE=@OLD_SQL_MODE */;
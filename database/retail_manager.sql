@@ -25,8 +25,11 @@ DROP TABLE IF EXISTS `Categories`;
 CREATE TABLE `Categories` (
   `category_id` binary(16) NOT NULL,
   `category_name` varchar(100) NOT NULL,
+  `tax_rate_id` binary(16) DEFAULT NULL COMMENT 'default tax of the products in the category',
   PRIMARY KEY (`category_id`),
-  UNIQUE KEY `category_name` (`category_name`)
+  UNIQUE KEY `category_name` (`category_name`),
+  KEY `tax_rate_id` (`tax_rate_id`),
+  CONSTRAINT `Categories_ibfk_1` FOREIGN KEY (`tax_rate_id`) REFERENCES `Tax_Rates` (`tax_rate_id`) ON DELETE SET NULL
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
 /*!40101 SET character_set_client = @saved_cs_client */;
 
@@ -36,10 +39,257 @@ CREATE TABLE `Categories` (
 
 LOCK TABLES `Categories` WRITE;
 /*!40000 ALTER TABLE `Categories` DISABLE KEYS */;
-INSERT INTO `Categories` VALUES (_binary 'eք\\>\{ȝC\\','Herbs');
+INSERT INTO `Categories` VALUES (_binary 'eք\\>\{ȝC\\','Herbs',NULL);
 /*!40000 ALTER TABLE `Categories` ENABLE KEYS */;
 UNLOCK TABLES;
 
+--
+-- Table structure for table `Cost_Layers`
+--
+
+DROP TABLE IF EXISTS `Cost_Layers`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Cost_Layers` (
+  `layer_id` binary(16) NOT NULL,
+  `product_id` binary(16) NOT NULL,
+  `unit_cost` decimal(12,4) NOT NULL,
+  `quantity_received` int NOT NULL,
+  `quantity_remaining` int NOT NULL COMMENT 'drawn down oldest layer first on every issue',
+  `reference` varchar(100) NOT NULL COMMENT 'source document, e.g. purchase-receipt:<id>',
+  `received_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`layer_id`),
+  KEY `idx_layers_open` (`product_id`,`quantity_remaining`,`received_at`),
+  CONSTRAINT `Cost_Layers_ibfk_1` FOREIGN KEY (`product_id`) REFERENCES `Products` (`product_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Cost_Layers`
+--
+
+LOCK TABLES `Cost_Layers` WRITE;
+/*!40000 ALTER TABLE `Cost_Layers` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Cost_Layers` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Cost_Ledger`
+--
+
+DROP TABLE IF EXISTS `Cost_Ledger`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Cost_Ledger` (
+  `entry_id` binary(16) NOT NULL,
+  `product_id` binary(16) NOT NULL,
+  `change_quantity` int NOT NULL,
+  `change_value` decimal(14,2) NOT NULL,
+  `balance_quantity` int NOT NULL COMMENT 'company-wide quantity on hand after this entry',
+  `balance_value` decimal(14,2) NOT NULL COMMENT 'inventory value of the product after this entry',
+  `reference` varchar(100) NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`entry_id`),
+  KEY `idx_ledger_product` (`product_id`,`entry_id`),
+  KEY `idx_ledger_created` (`created_at`),
+  CONSTRAINT `Cost_Ledger_ibfk_1` FOREIGN KEY (`product_id`) REFERENCES `Products` (`product_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Cost_Ledger`
+--
+
+LOCK TABLES `Cost_Ledger` WRITE;
+/*!40000 ALTER TABLE `Cost_Ledger` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Cost_Ledger` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Customers`
+--
+
+DROP TABLE IF EXISTS `Customers`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Customers` (
+  `customer_id` binary(16) NOT NULL,
+  `customer_name` varchar(100) NOT NULL,
+  `phone_number` varchar(20) DEFAULT NULL,
+  `email` varchar(100) DEFAULT NULL,
+  `notes` varchar(255) DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`customer_id`),
+  UNIQUE KEY `phone_number` (`phone_number`),
+  UNIQUE KEY `email` (`email`),
+  KEY `idx_customers_name` (`customer_name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Customers`
+--
+
+LOCK TABLES `Customers` WRITE;
+/*!40000 ALTER TABLE `Customers` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Customers` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Gift_Card_Ledger`
+--
+
+DROP TABLE IF EXISTS `Gift_Card_Ledger`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Gift_Card_Ledger` (
+  `entry_id` binary(16) NOT NULL,
+  `gift_card_id` binary(16) NOT NULL,
+  `entry_type` enum('issue','redeem','refund','expire') NOT NULL,
+  `change_amount` decimal(14,2) NOT NULL,
+  `balance_amount` decimal(14,2) NOT NULL COMMENT 'running balance of the card after this entry',
+  `transaction_id` binary(16) DEFAULT NULL,
+  `return_id` binary(16) DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`entry_id`),
+  KEY `idx_gift_card_ledger_card` (`gift_card_id`,`entry_id`),
+  KEY `transaction_id` (`transaction_id`),
+  KEY `return_id` (`return_id`),
+  CONSTRAINT `Gift_Card_Ledger_ibfk_1` FOREIGN KEY (`gift_card_id`) REFERENCES `Gift_Cards` (`gift_card_id`) ON DELETE CASCADE,
+  CONSTRAINT `Gift_Card_Ledger_ibfk_2` FOREIGN KEY (`transaction_id`) REFERENCES `Transactions` (`transaction_id`) ON DELETE CASCADE,
+  CONSTRAINT `Gift_Card_Ledger_ibfk_3` FOREIGN KEY (`return_id`) REFERENCES `Transaction_Returns` (`return_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Gift_Card_Ledger`
+--
+
+LOCK TABLES `Gift_Card_Ledger` WRITE;
+/*!40000 ALTER TABLE `Gift_Card_Ledger` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Gift_Card_Ledger` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Gift_Cards`
+--
+
+DROP TABLE IF EXISTS `Gift_Cards`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Gift_Cards` (
+  `gift_card_id` binary(16) NOT NULL,
+  `card_code` varchar(32) NOT NULL,
+  `card_type` enum('gift_card','store_credit') NOT NULL,
+  `customer_id` binary(16) DEFAULT NULL,
+  `issued_by` binary(16) NOT NULL COMMENT 'ID of the user who issued the card or processed the refund',
+  `expires_at` timestamp NULL DEFAULT NULL COMMENT 'the remaining balance is written off once it passes, NULL never expires',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`gift_card_id`),
+  UNIQUE KEY `card_code` (`card_code`),
+  KEY `customer_id` (`customer_id`),
+  KEY `issued_by` (`issued_by`),
+  CONSTRAINT `Gift_Cards_ibfk_1` FOREIGN KEY (`customer_id`) REFERENCES `Customers` (`customer_id`) ON DELETE SET NULL,
+  CONSTRAINT `Gift_Cards_ibfk_2` FOREIGN KEY (`issued_by`) REFERENCES `Users` (`user_id`) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Gift_Cards`
+--
+
+LOCK TABLES `Gift_Cards` WRITE;
+/*!40000 ALTER TABLE `Gift_Cards` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Gift_Cards` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Goods_Receipt_Items`
+--
+
+DROP TABLE IF EXISTS `Goods_Receipt_Items`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Goods_Receipt_Items` (
+  `receipt_id` binary(16) NOT NULL,
+  `product_id` binary(16) NOT NULL,
+  `quantity` int NOT NULL,
+  `purchase_price` decimal(10,2) NOT NULL,
+  PRIMARY KEY (`receipt_id`,`product_id`),
+  KEY `product_id` (`product_id`),
+  CONSTRAINT `Goods_Receipt_Items_ibfk_1` FOREIGN KEY (`receipt_id`) REFERENCES `Goods_Receipts` (`receipt_id`) ON DELETE CASCADE,
+  CONSTRAINT `Goods_Receipt_Items_ibfk_2` FOREIGN KEY (`product_id`) REFERENCES `Products` (`product_id`) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Goods_Receipt_Items`
+--
+
+LOCK TABLES `Goods_Receipt_Items` WRITE;
+/*!40000 ALTER TABLE `Goods_Receipt_Items` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Goods_Receipt_Items` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Goods_Receipts`
+--
+
+DROP TABLE IF EXISTS `Goods_Receipts`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Goods_Receipts` (
+  `receipt_id` binary(16) NOT NULL,
+  `purchase_order_id` binary(16) NOT NULL,
+  `received_by` binary(16) NOT NULL,
+  `note` varchar(255) NOT NULL DEFAULT '',
+  `received_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `idempotency_key` varchar(64) DEFAULT NULL COMMENT 'value of the Idempotency-Key request header',
+  PRIMARY KEY (`receipt_id`),
+  UNIQUE KEY `purchase_order_idempotency_key` (`purchase_order_id`,`idempotency_key`),
+  KEY `purchase_order_id` (`purchase_order_id`),
+  KEY `received_by` (`received_by`),
+  CONSTRAINT `Goods_Receipts_ibfk_1` FOREIGN KEY (`purchase_order_id`) REFERENCES `Purchase_Orders` (`purchase_order_id`) ON DELETE CASCADE,
+  CONSTRAINT `Goods_Receipts_ibfk_2` FOREIGN KEY (`received_by`) REFERENCES `Users` (`user_id`) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Goods_Receipts`
+--
+
+LOCK TABLES `Goods_Receipts` WRITE;
+/*!40000 ALTER TABLE `Goods_Receipts` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Goods_Receipts` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Idempotency_Keys`
+--
+
+DROP TABLE IF EXISTS `Idempotency_Keys`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Idempotency_Keys` (
+  `idempotency_key` varchar(64) NOT NULL COMMENT 'value of the Idempotency-Key request header',
+  `user_id` binary(16) NOT NULL,
+  `transaction_id` binary(16) NOT NULL COMMENT 'transaction created (or being created) for this key',
+  `request_hash` char(64) NOT NULL COMMENT 'sha256 of the request, the key cannot be reused for another request',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`user_id`,`idempotency_key`),
+  CONSTRAINT `Idempotency_Keys_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `Users` (`user_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Idempotency_Keys`
+--
+
+LOCK TABLES `Idempotency_Keys` WRITE;
+/*!40000 ALTER TABLE `Idempotency_Keys` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Idempotency_Keys` ENABLE KEYS */;
+UNLOCK TABLES;
+
 --
 -- Table structure for table `Inventory_Log`
 --
@@ -71,6 +321,147 @@ LOCK TABLES `Inventory_Log` WRITE;
 /*!40000 ALTER TABLE `Inventory_Log` ENABLE KEYS */;
 UNLOCK TABLES;
 
+--
+-- Table structure for table `Loyalty_Ledger`
+--
+
+DROP TABLE IF EXISTS `Loyalty_Ledger`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Loyalty_Ledger` (
+  `entry_id` binary(16) NOT NULL,
+  `customer_id` binary(16) NOT NULL,
+  `entry_type` enum('earn','redeem','reverse_earn','reverse_redeem','expire') NOT NULL,
+  `change_points` int NOT NULL,
+  `balance_points` int NOT NULL COMMENT 'running balance of the customer after this entry',
+  `transaction_id` binary(16) DEFAULT NULL,
+  `return_id` binary(16) DEFAULT NULL,
+  `expires_at` timestamp NULL DEFAULT NULL COMMENT 'credited points expire oldest first, NULL never expires',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`entry_id`),
+  KEY `idx_loyalty_ledger_customer` (`customer_id`,`entry_id`),
+  KEY `transaction_id` (`transaction_id`),
+  KEY `return_id` (`return_id`),
+  CONSTRAINT `Loyalty_Ledger_ibfk_1` FOREIGN KEY (`customer_id`) REFERENCES `Customers` (`customer_id`) ON DELETE CASCADE,
+  CONSTRAINT `Loyalty_Ledger_ibfk_2` FOREIGN KEY (`transaction_id`) REFERENCES `Transactions` (`transaction_id`) ON DELETE CASCADE,
+  CONSTRAINT `Loyalty_Ledger_ibfk_3` FOREIGN KEY (`return_id`) REFERENCES `Transaction_Returns` (`return_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Loyalty_Ledger`
+--
+
+LOCK TABLES `Loyalty_Ledger` WRITE;
+/*!40000 ALTER TABLE `Loyalty_Ledger` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Loyalty_Ledger` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Product_Barcodes`
+--
+
+DROP TABLE IF EXISTS `Product_Barcodes`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Product_Barcodes` (
+  `barcode` varchar(32) NOT NULL,
+  `product_id` binary(16) NOT NULL,
+  `barcode_type` enum('ean13','upca','internal') NOT NULL,
+  PRIMARY KEY (`barcode`),
+  KEY `product_id` (`product_id`),
+  CONSTRAINT `Product_Barcodes_ibfk_1` FOREIGN KEY (`product_id`) REFERENCES `Products` (`product_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Product_Barcodes`
+--
+
+LOCK TABLES `Product_Barcodes` WRITE;
+/*!40000 ALTER TABLE `Product_Barcodes` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Product_Barcodes` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Product_Bundle_Components`
+--
+
+DROP TABLE IF EXISTS `Product_Bundle_Components`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Product_Bundle_Components` (
+  `bundle_product_id` binary(16) NOT NULL,
+  `component_product_id` binary(16) NOT NULL,
+  `quantity` int NOT NULL COMMENT 'units of the component in one bundle',
+  PRIMARY KEY (`bundle_product_id`,`component_product_id`),
+  KEY `component_product_id` (`component_product_id`),
+  CONSTRAINT `Product_Bundle_Components_ibfk_1` FOREIGN KEY (`bundle_product_id`) REFERENCES `Products` (`product_id`) ON DELETE CASCADE,
+  CONSTRAINT `Product_Bundle_Components_ibfk_2` FOREIGN KEY (`component_product_id`) REFERENCES `Products` (`product_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Product_Bundle_Components`
+--
+
+LOCK TABLES `Product_Bundle_Components` WRITE;
+/*!40000 ALTER TABLE `Product_Bundle_Components` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Product_Bundle_Components` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Product_Reorder_Rules`
+--
+
+DROP TABLE IF EXISTS `Product_Reorder_Rules`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Product_Reorder_Rules` (
+  `product_id` binary(16) NOT NULL,
+  `min_quantity` int NOT NULL DEFAULT '0' COMMENT 'reorder when projected stock falls to this level',
+  `max_quantity` int NOT NULL COMMENT 'target stock level after replenishment',
+  `reorder_quantity` int NOT NULL DEFAULT '1' COMMENT 'suggested quantities are rounded up to a multiple of this',
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`product_id`),
+  CONSTRAINT `Product_Reorder_Rules_ibfk_1` FOREIGN KEY (`product_id`) REFERENCES `Products` (`product_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Product_Reorder_Rules`
+--
+
+LOCK TABLES `Product_Reorder_Rules` WRITE;
+/*!40000 ALTER TABLE `Product_Reorder_Rules` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Product_Reorder_Rules` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Product_Variant_Attributes`
+--
+
+DROP TABLE IF EXISTS `Product_Variant_Attributes`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Product_Variant_Attributes` (
+  `product_id` binary(16) NOT NULL,
+  `attribute_name` varchar(50) NOT NULL,
+  `attribute_value` varchar(100) NOT NULL,
+  PRIMARY KEY (`product_id`,`attribute_name`),
+  CONSTRAINT `Product_Variant_Attributes_ibfk_1` FOREIGN KEY (`product_id`) REFERENCES `Products` (`product_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Product_Variant_Attributes`
+--
+
+LOCK TABLES `Product_Variant_Attributes` WRITE;
+/*!40000 ALTER TABLE `Product_Variant_Attributes` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Product_Variant_Attributes` ENABLE KEYS */;
+UNLOCK TABLES;
+
 --
 -- Table structure for table `Products`
 --
@@ -86,11 +477,19 @@ CREATE TABLE `Products` (
   `stock_quantity` int NOT NULL DEFAULT '0',
   `category_id` binary(16) NOT NULL,
   `supplier_id` binary(16) NOT NULL,
+  `tax_rate_id` binary(16) DEFAULT NULL COMMENT 'overrides the tax of the category',
+  `sku` varchar(64) DEFAULT NULL,
+  `parent_product_id` binary(16) DEFAULT NULL COMMENT 'set on variants, the parent is the template they are grouped under',
   PRIMARY KEY (`product_id`),
+  UNIQUE KEY `sku` (`sku`),
   KEY `category_id` (`category_id`),
   KEY `supplier_id` (`supplier_id`),
+  KEY `tax_rate_id` (`tax_rate_id`),
+  KEY `parent_product_id` (`parent_product_id`),
   CONSTRAINT `Products_ibfk_1` FOREIGN KEY (`category_id`) REFERENCES `Categories` (`category_id`) ON DELETE RESTRICT,
-  CONSTRAINT `Products_ibfk_2` FOREIGN KEY (`supplier_id`) REFERENCES `Suppliers` (`supplier_id`) ON DELETE RESTRICT
+  CONSTRAINT `Products_ibfk_2` FOREIGN KEY (`supplier_id`) REFERENCES `Suppliers` (`supplier_id`) ON DELETE RESTRICT,
+  CONSTRAINT `Products_ibfk_3` FOREIGN KEY (`tax_rate_id`) REFERENCES `Tax_Rates` (`tax_rate_id`) ON DELETE SET NULL,
+  CONSTRAINT `Products_ibfk_4` FOREIGN KEY (`parent_product_id`) REFERENCES `Products` (`product_id`) ON DELETE RESTRICT
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
 /*!40101 SET character_set_client = @saved_cs_client */;
 
@@ -100,10 +499,144 @@ CREATE TABLE `Products` (
 
 LOCK TABLES `Products` WRITE;
 /*!40000 ALTER TABLE `Products` DISABLE KEYS */;
-INSERT INTO `Products` VALUES (_binary 'i\Q3r$e\쓏','Wedang Uwuh - Original',8250.75,12000.00,200,_binary 'eք\\>\{ȝC\\',_binary 'hH/݃-\'),(_binary 'k%\\\Q0EQ3Pq','Wedang Uwuh - Jahe Merah',10750.75,15000.00,250,_binary 'eք\\>\{ȝC\\',_binary 'hH/݃-\'),(_binary 'kq^V-70','Wedang Uwuh - Bunga Telang',9250.25,12000.00,225,_binary 'eք\\>\{ȝC\\',_binary 'hH/݃-\'),(_binary 'l)VS\{r','Wedang Uwuh - Lemon',14500.50,18000.00,175,_binary 'eք\\>\{ȝC\\',_binary 'hH/݃-\');
+INSERT INTO `Products` VALUES (_binary 'i\Q3r$e\쓏','Wedang Uwuh - Original',8250.75,12000.00,200,_binary 'eք\\>\{ȝC\\',_binary 'hH/݃-\',NULL,NULL,NULL),(_binary 'k%\\\Q0EQ3Pq','Wedang Uwuh - Jahe Merah',10750.75,15000.00,250,_binary 'eք\\>\{ȝC\\',_binary 'hH/݃-\',NULL,NULL,NULL),(_binary 'kq^V-70','Wedang Uwuh - Bunga Telang',9250.25,12000.00,225,_binary 'eք\\>\{ȝC\\',_binary 'hH/݃-\',NULL,NULL,NULL),(_binary 'l)VS\{r','Wedang Uwuh - Lemon',14500.50,18000.00,175,_binary 'eք\\>\{ȝC\\',_binary 'hH/݃-\',NULL,NULL,NULL);
 /*!40000 ALTER TABLE `Products` ENABLE KEYS */;
 UNLOCK TABLES;
 
+--
+-- Table structure for table `Promotions`
+--
+
+DROP TABLE IF EXISTS `Promotions`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Promotions` (
+  `promotion_id` binary(16) NOT NULL,
+  `promotion_name` varchar(100) NOT NULL,
+  `promotion_type` enum('percentage','fixed_amount','buy_x_get_y','bundle_price') NOT NULL,
+  `product_id` binary(16) DEFAULT NULL COMMENT 'limits the promotion to one product',
+  `category_id` binary(16) DEFAULT NULL COMMENT 'limits the promotion to one category, neither means the whole basket',
+  `value` decimal(12,2) NOT NULL DEFAULT '0.00' COMMENT 'percent off, amount off or the bundle price',
+  `buy_quantity` int NOT NULL DEFAULT '0',
+  `get_quantity` int NOT NULL DEFAULT '0',
+  `min_spend` decimal(14,2) DEFAULT NULL,
+  `coupon_code` varchar(50) DEFAULT NULL COMMENT 'only applies when the code is presented at the sale',
+  `starts_at` timestamp NULL DEFAULT NULL,
+  `ends_at` timestamp NULL DEFAULT NULL,
+  `usage_limit` int DEFAULT NULL,
+  `usage_count` int NOT NULL DEFAULT '0' COMMENT 'sales that used the promotion, released again when the sale fails',
+  `active` tinyint(1) NOT NULL DEFAULT '1',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`promotion_id`),
+  UNIQUE KEY `uq_promotions_coupon_code` (`coupon_code`),
+  KEY `product_id` (`product_id`),
+  KEY `category_id` (`category_id`),
+  KEY `idx_promotions_active` (`active`,`starts_at`,`ends_at`),
+  CONSTRAINT `Promotions_ibfk_1` FOREIGN KEY (`product_id`) REFERENCES `Products` (`product_id`) ON DELETE CASCADE,
+  CONSTRAINT `Promotions_ibfk_2` FOREIGN KEY (`category_id`) REFERENCES `Categories` (`category_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Promotions`
+--
+
+LOCK TABLES `Promotions` WRITE;
+/*!40000 ALTER TABLE `Promotions` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Promotions` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Purchase_Order_Items`
+--
+
+DROP TABLE IF EXISTS `Purchase_Order_Items`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Purchase_Order_Items` (
+  `purchase_order_id` binary(16) NOT NULL,
+  `product_id` binary(16) NOT NULL,
+  `quantity` int NOT NULL,
+  `quantity_received` int NOT NULL DEFAULT '0',
+  `purchase_price` decimal(10,2) NOT NULL COMMENT 'price agreed with the supplier',
+  PRIMARY KEY (`purchase_order_id`,`product_id`),
+  KEY `product_id` (`product_id`),
+  CONSTRAINT `Purchase_Order_Items_ibfk_1` FOREIGN KEY (`purchase_order_id`) REFERENCES `Purchase_Orders` (`purchase_order_id`) ON DELETE CASCADE,
+  CONSTRAINT `Purchase_Order_Items_ibfk_2` FOREIGN KEY (`product_id`) REFERENCES `Products` (`product_id`) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Purchase_Order_Items`
+--
+
+LOCK TABLES `Purchase_Order_Items` WRITE;
+/*!40000 ALTER TABLE `Purchase_Order_Items` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Purchase_Order_Items` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Purchase_Orders`
+--
+
+DROP TABLE IF EXISTS `Purchase_Orders`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Purchase_Orders` (
+  `purchase_order_id` binary(16) NOT NULL,
+  `supplier_id` binary(16) NOT NULL,
+  `location_id` binary(16) NOT NULL COMMENT 'location_id in retail_inventory.Locations the goods are received at',
+  `status` varchar(20) NOT NULL DEFAULT 'draft' COMMENT 'draft, sent, partially_received, received, closed',
+  `note` varchar(255) NOT NULL DEFAULT '',
+  `created_by` binary(16) NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `sent_at` timestamp NULL DEFAULT NULL,
+  `closed_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`purchase_order_id`),
+  KEY `supplier_id` (`supplier_id`),
+  KEY `created_by` (`created_by`),
+  KEY `status` (`status`),
+  CONSTRAINT `Purchase_Orders_ibfk_1` FOREIGN KEY (`supplier_id`) REFERENCES `Suppliers` (`supplier_id`) ON DELETE RESTRICT,
+  CONSTRAINT `Purchase_Orders_ibfk_2` FOREIGN KEY (`created_by`) REFERENCES `Users` (`user_id`) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Purchase_Orders`
+--
+
+LOCK TABLES `Purchase_Orders` WRITE;
+/*!40000 ALTER TABLE `Purchase_Orders` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Purchase_Orders` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Registers`
+--
+
+DROP TABLE IF EXISTS `Registers`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Registers` (
+  `register_id` binary(16) NOT NULL,
+  `register_name` varchar(100) NOT NULL,
+  `location_id` binary(16) NOT NULL COMMENT 'location_id in retail_inventory.Locations the register sells from',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`register_id`),
+  UNIQUE KEY `register_name` (`register_name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Registers`
+--
+
+LOCK TABLES `Registers` WRITE;
+/*!40000 ALTER TABLE `Registers` DISABLE KEYS */;
+INSERT INTO `Registers` VALUES (0x019AA315B460D1DB94618CE77989A260,'Main Store - Register 1',0x019AA31120804055595EE6D24ACBD2BC,'2025-11-20 21:05:00');
+/*!40000 ALTER TABLE `Registers` ENABLE KEYS */;
+UNLOCK TABLES;
+
 --
 -- Table structure for table `Roles`
 --
@@ -129,6 +662,146 @@ INSERT INTO `Roles` VALUES (1,'admin'),(2,'cashier');
 /*!40000 ALTER TABLE `Roles` ENABLE KEYS */;
 UNLOCK TABLES;
 
+--
+-- Table structure for table `Shifts`
+--
+
+DROP TABLE IF EXISTS `Shifts`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Shifts` (
+  `shift_id` binary(16) NOT NULL,
+  `user_id` binary(16) NOT NULL,
+  `location_id` binary(16) NOT NULL COMMENT 'location_id in retail_inventory.Locations',
+  `register_id` binary(16) DEFAULT NULL,
+  `status` enum('open','closed') NOT NULL DEFAULT 'open',
+  `opening_float` decimal(12,2) NOT NULL DEFAULT '0.00',
+  `note` varchar(255) NOT NULL DEFAULT '',
+  `opened_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `closed_at` timestamp NULL DEFAULT NULL,
+  `sales_count` int NOT NULL DEFAULT '0' COMMENT 'Z report totals, frozen when the shift is closed',
+  `sales_amount` decimal(14,2) NOT NULL DEFAULT '0.00',
+  `returns_count` int NOT NULL DEFAULT '0',
+  `returns_amount` decimal(14,2) NOT NULL DEFAULT '0.00',
+  `voids_count` int NOT NULL DEFAULT '0',
+  `voids_amount` decimal(14,2) NOT NULL DEFAULT '0.00',
+  `cash_amount` decimal(14,2) NOT NULL DEFAULT '0.00' COMMENT 'cash tender kept after voids',
+  `cash_refunds` decimal(14,2) NOT NULL DEFAULT '0.00' COMMENT 'returns refunded in cash out of the drawer',
+  `expected_cash` decimal(14,2) DEFAULT NULL,
+  `counted_cash` decimal(14,2) DEFAULT NULL,
+  `over_short` decimal(14,2) DEFAULT NULL COMMENT 'counted minus expected cash',
+  `open_user_id` binary(16) GENERATED ALWAYS AS (if((`status` = _utf8mb4'open'),`user_id`,NULL)) STORED COMMENT 'allows a single open shift per user',
+  PRIMARY KEY (`shift_id`),
+  UNIQUE KEY `uq_shifts_open_user` (`open_user_id`),
+  KEY `user_id` (`user_id`),
+  KEY `register_id` (`register_id`),
+  CONSTRAINT `Shifts_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `Users` (`user_id`) ON DELETE RESTRICT,
+  CONSTRAINT `Shifts_ibfk_2` FOREIGN KEY (`register_id`) REFERENCES `Registers` (`register_id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Shifts`
+--
+
+LOCK TABLES `Shifts` WRITE;
+/*!40000 ALTER TABLE `Shifts` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Shifts` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Stocktake_Counts`
+--
+
+DROP TABLE IF EXISTS `Stocktake_Counts`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Stocktake_Counts` (
+  `session_id` binary(16) NOT NULL,
+  `product_id` binary(16) NOT NULL,
+  `user_id` binary(16) NOT NULL,
+  `counted_quantity` int NOT NULL COMMENT 'latest count by this user, counts of all users are summed',
+  `counted_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`session_id`,`product_id`,`user_id`),
+  KEY `user_id` (`user_id`),
+  CONSTRAINT `Stocktake_Counts_ibfk_1` FOREIGN KEY (`session_id`, `product_id`) REFERENCES `Stocktake_Items` (`session_id`, `product_id`) ON DELETE CASCADE,
+  CONSTRAINT `Stocktake_Counts_ibfk_2` FOREIGN KEY (`user_id`) REFERENCES `Users` (`user_id`) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Stocktake_Counts`
+--
+
+LOCK TABLES `Stocktake_Counts` WRITE;
+/*!40000 ALTER TABLE `Stocktake_Counts` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Stocktake_Counts` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Stocktake_Items`
+--
+
+DROP TABLE IF EXISTS `Stocktake_Items`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Stocktake_Items` (
+  `session_id` binary(16) NOT NULL,
+  `product_id` binary(16) NOT NULL,
+  `expected_quantity` int NOT NULL COMMENT 'on-hand quantity frozen when the session was opened',
+  PRIMARY KEY (`session_id`,`product_id`),
+  KEY `product_id` (`product_id`),
+  CONSTRAINT `Stocktake_Items_ibfk_1` FOREIGN KEY (`session_id`) REFERENCES `Stocktake_Sessions` (`session_id`) ON DELETE CASCADE,
+  CONSTRAINT `Stocktake_Items_ibfk_2` FOREIGN KEY (`product_id`) REFERENCES `Products` (`product_id`) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Stocktake_Items`
+--
+
+LOCK TABLES `Stocktake_Items` WRITE;
+/*!40000 ALTER TABLE `Stocktake_Items` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Stocktake_Items` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Stocktake_Sessions`
+--
+
+DROP TABLE IF EXISTS `Stocktake_Sessions`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Stocktake_Sessions` (
+  `session_id` binary(16) NOT NULL,
+  `location_id` binary(16) NOT NULL COMMENT 'location_id in retail_inventory.Locations being counted',
+  `category_id` binary(16) DEFAULT NULL COMMENT 'NULL counts every product at the location',
+  `blind` tinyint(1) NOT NULL DEFAULT '0' COMMENT 'hide expected quantities from counters',
+  `status` varchar(20) NOT NULL DEFAULT 'open' COMMENT 'open, approved, cancelled',
+  `note` varchar(255) NOT NULL DEFAULT '',
+  `created_by` binary(16) NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `approved_by` binary(16) DEFAULT NULL,
+  `approved_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`session_id`),
+  KEY `category_id` (`category_id`),
+  KEY `created_by` (`created_by`),
+  KEY `approved_by` (`approved_by`),
+  CONSTRAINT `Stocktake_Sessions_ibfk_1` FOREIGN KEY (`category_id`) REFERENCES `Categories` (`category_id`) ON DELETE RESTRICT,
+  CONSTRAINT `Stocktake_Sessions_ibfk_2` FOREIGN KEY (`created_by`) REFERENCES `Users` (`user_id`) ON DELETE RESTRICT,
+  CONSTRAINT `Stocktake_Sessions_ibfk_3` FOREIGN KEY (`approved_by`) REFERENCES `Users` (`user_id`) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Stocktake_Sessions`
+--
+
+LOCK TABLES `Stocktake_Sessions` WRITE;
+/*!40000 ALTER TABLE `Stocktake_Sessions` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Stocktake_Sessions` ENABLE KEYS */;
+UNLOCK TABLES;
+
 --
 -- Table structure for table `Suppliers`
 --
@@ -155,6 +828,94 @@ INSERT INTO `Suppliers` VALUES (_binary '
 /*!40000 ALTER TABLE `Suppliers` ENABLE KEYS */;
 UNLOCK TABLES;
 
+--
+-- Table structure for table `Tax_Rates`
+--
+
+DROP TABLE IF EXISTS `Tax_Rates`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Tax_Rates` (
+  `tax_rate_id` binary(16) NOT NULL,
+  `tax_name` varchar(50) NOT NULL,
+  `rate` decimal(5,2) NOT NULL DEFAULT '0.00' COMMENT 'percent, e.g. 11.00 for PPN',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`tax_rate_id`),
+  UNIQUE KEY `tax_name` (`tax_name`),
+  CONSTRAINT `Tax_Rates_chk_1` CHECK (((`rate` >= 0) and (`rate` <= 100)))
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Tax_Rates`
+--
+
+LOCK TABLES `Tax_Rates` WRITE;
+/*!40000 ALTER TABLE `Tax_Rates` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Tax_Rates` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Transaction_Detail_Components`
+--
+
+DROP TABLE IF EXISTS `Transaction_Detail_Components`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Transaction_Detail_Components` (
+  `detail_id` binary(16) NOT NULL,
+  `product_id` binary(16) NOT NULL,
+  `quantity` int NOT NULL COMMENT 'units of the component in one sold bundle',
+  `unit_cost` decimal(12,4) DEFAULT NULL COMMENT 'set when the cost of goods sold is recorded',
+  PRIMARY KEY (`detail_id`,`product_id`),
+  KEY `product_id` (`product_id`),
+  CONSTRAINT `Transaction_Detail_Components_ibfk_1` FOREIGN KEY (`detail_id`) REFERENCES `Transaction_Details` (`detail_id`) ON DELETE CASCADE,
+  CONSTRAINT `Transaction_Detail_Components_ibfk_2` FOREIGN KEY (`product_id`) REFERENCES `Products` (`product_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Transaction_Detail_Components`
+--
+
+LOCK TABLES `Transaction_Detail_Components` WRITE;
+/*!40000 ALTER TABLE `Transaction_Detail_Components` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Transaction_Detail_Components` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Transaction_Detail_Costs`
+--
+
+DROP TABLE IF EXISTS `Transaction_Detail_Costs`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Transaction_Detail_Costs` (
+  `detail_id` binary(16) NOT NULL,
+  `transaction_id` binary(16) NOT NULL,
+  `product_id` binary(16) NOT NULL,
+  `quantity` int NOT NULL,
+  `unit_cost` decimal(12,4) NOT NULL,
+  `cost_amount` decimal(14,2) NOT NULL COMMENT 'cost of goods sold for the sale line',
+  `costing_method` enum('fifo','wac') NOT NULL,
+  PRIMARY KEY (`detail_id`),
+  KEY `transaction_id` (`transaction_id`),
+  KEY `product_id` (`product_id`),
+  CONSTRAINT `Transaction_Detail_Costs_ibfk_1` FOREIGN KEY (`detail_id`) REFERENCES `Transaction_Details` (`detail_id`) ON DELETE CASCADE,
+  CONSTRAINT `Transaction_Detail_Costs_ibfk_2` FOREIGN KEY (`transaction_id`) REFERENCES `Transactions` (`transaction_id`) ON DELETE CASCADE,
+  CONSTRAINT `Transaction_Detail_Costs_ibfk_3` FOREIGN KEY (`product_id`) REFERENCES `Products` (`product_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Transaction_Detail_Costs`
+--
+
+LOCK TABLES `Transaction_Detail_Costs` WRITE;
+/*!40000 ALTER TABLE `Transaction_Detail_Costs` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Transaction_Detail_Costs` ENABLE KEYS */;
+UNLOCK TABLES;
+
 --
 -- Table structure for table `Transaction_Details`
 --
@@ -168,11 +929,19 @@ CREATE TABLE `Transaction_Details` (
   `product_id` binary(16) NOT NULL,
   `quantity` int NOT NULL,
   `price` decimal(10,2) NOT NULL,
+  `discount_amount` decimal(14,2) NOT NULL DEFAULT '0.00' COMMENT 'promotions on the line plus its share of basket discounts',
+  `tax_rate_id` binary(16) DEFAULT NULL,
+  `tax_rate` decimal(5,2) NOT NULL DEFAULT '0.00' COMMENT 'rate at the time of sale',
+  `tax_amount` decimal(14,2) NOT NULL DEFAULT '0.00',
+  `tax_inclusive` tinyint(1) NOT NULL DEFAULT '1' COMMENT 'whether the price already included the tax',
+  `line_total` decimal(14,2) GENERATED ALWAYS AS ((((`quantity` * `price`) - `discount_amount`) + if(`tax_inclusive`,0,`tax_amount`))) STORED COMMENT 'what the customer paid for the line',
   PRIMARY KEY (`detail_id`),
   KEY `transaction_id` (`transaction_id`),
   KEY `product_id` (`product_id`),
+  KEY `tax_rate_id` (`tax_rate_id`),
   CONSTRAINT `Transaction_Details_ibfk_1` FOREIGN KEY (`transaction_id`) REFERENCES `Transactions` (`transaction_id`) ON DELETE CASCADE,
   CONSTRAINT `Transaction_Details_ibfk_2` FOREIGN KEY (`product_id`) REFERENCES `Products` (`product_id`) ON DELETE RESTRICT,
+  CONSTRAINT `Transaction_Details_ibfk_3` FOREIGN KEY (`tax_rate_id`) REFERENCES `Tax_Rates` (`tax_rate_id`) ON DELETE RESTRICT,
   CONSTRAINT `Transaction_Details_chk_1` CHECK ((`quantity` > 0))
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
 /*!40101 SET character_set_client = @saved_cs_client */;
@@ -183,10 +952,170 @@ CREATE TABLE `Transaction_Details` (
 
 LOCK TABLES `Transaction_Details` WRITE;
 /*!40000 ALTER TABLE `Transaction_Details` DISABLE KEYS */;
-INSERT INTO `Transaction_Details` VALUES (_binary 'pp]*Td7',_binary 'pp]*T]\',_binary 'i\Q3r$e\쓏',3,12000.00),(_binary 'pp]*T\\l',_binary 'pp]*T]\',_binary 'k%\\\Q0EQ3Pq',5,15000.00),(_binary 'pp]*T\\\',_binary 'pp]*T]\',_binary 'kq^V-70',2,12000.00);
+INSERT INTO `Transaction_Details` (`detail_id`, `transaction_id`, `product_id`, `quantity`, `price`, `discount_amount`, `tax_rate_id`, `tax_rate`, `tax_amount`, `tax_inclusive`) VALUES (_binary 'pp]*Td7',_binary 'pp]*T]\',_binary 'i\Q3r$e\쓏',3,12000.00,0.00,NULL,0.00,0.00,1),(_binary 'pp]*T\\l',_binary 'pp]*T]\',_binary 'k%\\\Q0EQ3Pq',5,15000.00,0.00,NULL,0.00,0.00,1),(_binary 'pp]*T\\\',_binary 'pp]*T]\',_binary 'kq^V-70',2,12000.00,0.00,NULL,0.00,0.00,1);
 /*!40000 ALTER TABLE `Transaction_Details` ENABLE KEYS */;
 UNLOCK TABLES;
 
+--
+-- Table structure for table `Transaction_Discounts`
+--
+
+DROP TABLE IF EXISTS `Transaction_Discounts`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Transaction_Discounts` (
+  `discount_id` binary(16) NOT NULL,
+  `transaction_id` binary(16) NOT NULL,
+  `detail_id` binary(16) DEFAULT NULL COMMENT 'NULL for a basket discount spread over the lines',
+  `promotion_id` binary(16) NOT NULL,
+  `amount` decimal(14,2) NOT NULL,
+  PRIMARY KEY (`discount_id`),
+  KEY `transaction_id` (`transaction_id`),
+  KEY `detail_id` (`detail_id`),
+  KEY `promotion_id` (`promotion_id`),
+  CONSTRAINT `Transaction_Discounts_ibfk_1` FOREIGN KEY (`transaction_id`) REFERENCES `Transactions` (`transaction_id`) ON DELETE CASCADE,
+  CONSTRAINT `Transaction_Discounts_ibfk_2` FOREIGN KEY (`detail_id`) REFERENCES `Transaction_Details` (`detail_id`) ON DELETE CASCADE,
+  CONSTRAINT `Transaction_Discounts_ibfk_3` FOREIGN KEY (`promotion_id`) REFERENCES `Promotions` (`promotion_id`) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Transaction_Discounts`
+--
+
+LOCK TABLES `Transaction_Discounts` WRITE;
+/*!40000 ALTER TABLE `Transaction_Discounts` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Transaction_Discounts` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Transaction_Payments`
+--
+
+DROP TABLE IF EXISTS `Transaction_Payments`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Transaction_Payments` (
+  `payment_id` binary(16) NOT NULL,
+  `transaction_id` binary(16) NOT NULL,
+  `method` enum('cash','card','e_wallet','qris','gift_card','store_credit','loyalty_points') NOT NULL,
+  `amount` decimal(14,2) NOT NULL COMMENT 'part of the transaction total settled by this tender',
+  `amount_tendered` decimal(14,2) DEFAULT NULL COMMENT 'cash handed over, only for cash',
+  `change_given` decimal(14,2) NOT NULL DEFAULT '0.00',
+  `reference` varchar(100) DEFAULT NULL COMMENT 'card approval code or e-wallet reference',
+  `gift_card_id` binary(16) DEFAULT NULL COMMENT 'card charged by gift card and store credit tender',
+  PRIMARY KEY (`payment_id`),
+  KEY `transaction_id` (`transaction_id`),
+  KEY `method` (`method`),
+  KEY `gift_card_id` (`gift_card_id`),
+  CONSTRAINT `Transaction_Payments_ibfk_1` FOREIGN KEY (`transaction_id`) REFERENCES `Transactions` (`transaction_id`) ON DELETE CASCADE,
+  CONSTRAINT `Transaction_Payments_ibfk_2` FOREIGN KEY (`gift_card_id`) REFERENCES `Gift_Cards` (`gift_card_id`) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Transaction_Payments`
+--
+
+LOCK TABLES `Transaction_Payments` WRITE;
+/*!40000 ALTER TABLE `Transaction_Payments` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Transaction_Payments` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Transaction_Return_Details`
+--
+
+DROP TABLE IF EXISTS `Transaction_Return_Details`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Transaction_Return_Details` (
+  `return_detail_id` binary(16) NOT NULL,
+  `return_id` binary(16) NOT NULL,
+  `detail_id` binary(16) NOT NULL COMMENT 'the sold line being returned',
+  `quantity` int NOT NULL,
+  `price` decimal(10,2) NOT NULL COMMENT 'unit price paid at sale, net of the line discount',
+  PRIMARY KEY (`return_detail_id`),
+  KEY `return_id` (`return_id`),
+  KEY `detail_id` (`detail_id`),
+  CONSTRAINT `Transaction_Return_Details_ibfk_1` FOREIGN KEY (`return_id`) REFERENCES `Transaction_Returns` (`return_id`) ON DELETE CASCADE,
+  CONSTRAINT `Transaction_Return_Details_ibfk_2` FOREIGN KEY (`detail_id`) REFERENCES `Transaction_Details` (`detail_id`) ON DELETE RESTRICT,
+  CONSTRAINT `Transaction_Return_Details_chk_1` CHECK ((`quantity` > 0))
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Transaction_Return_Details`
+--
+
+LOCK TABLES `Transaction_Return_Details` WRITE;
+/*!40000 ALTER TABLE `Transaction_Return_Details` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Transaction_Return_Details` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Transaction_Returns`
+--
+
+DROP TABLE IF EXISTS `Transaction_Returns`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Transaction_Returns` (
+  `return_id` binary(16) NOT NULL,
+  `transaction_id` binary(16) NOT NULL,
+  `user_id` binary(16) NOT NULL COMMENT 'ID of the user who processed the return',
+  `reason` varchar(255) DEFAULT NULL,
+  `refund_method` enum('cash','store_credit') NOT NULL DEFAULT 'cash',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `shift_id` binary(16) DEFAULT NULL COMMENT 'shift of the user who processed the return, whose drawer paid a cash refund',
+  PRIMARY KEY (`return_id`),
+  KEY `transaction_id` (`transaction_id`),
+  KEY `user_id` (`user_id`),
+  KEY `shift_id` (`shift_id`),
+  CONSTRAINT `Transaction_Returns_ibfk_1` FOREIGN KEY (`transaction_id`) REFERENCES `Transactions` (`transaction_id`) ON DELETE CASCADE,
+  CONSTRAINT `Transaction_Returns_ibfk_2` FOREIGN KEY (`user_id`) REFERENCES `Users` (`user_id`) ON DELETE RESTRICT,
+  CONSTRAINT `Transaction_Returns_ibfk_3` FOREIGN KEY (`shift_id`) REFERENCES `Shifts` (`shift_id`) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Transaction_Returns`
+--
+
+LOCK TABLES `Transaction_Returns` WRITE;
+/*!40000 ALTER TABLE `Transaction_Returns` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Transaction_Returns` ENABLE KEYS */;
+UNLOCK TABLES;
+
+--
+-- Table structure for table `Transaction_Sagas`
+--
+
+DROP TABLE IF EXISTS `Transaction_Sagas`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!50503 SET character_set_client = utf8mb4 */;
+CREATE TABLE `Transaction_Sagas` (
+  `saga_id` binary(16) NOT NULL COMMENT 'same value as the transaction_id it drives',
+  `status` varchar(20) NOT NULL DEFAULT 'pending' COMMENT 'pending, completed, compensating, compensated, dead_letter',
+  `attempts` int NOT NULL DEFAULT '0',
+  `last_error` varchar(255) DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`saga_id`),
+  KEY `idx_sagas_status_updated_at` (`status`,`updated_at`),
+  CONSTRAINT `Transaction_Sagas_ibfk_1` FOREIGN KEY (`saga_id`) REFERENCES `Transactions` (`transaction_id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `Transaction_Sagas`
+--
+
+LOCK TABLES `Transaction_Sagas` WRITE;
+/*!40000 ALTER TABLE `Transaction_Sagas` DISABLE KEYS */;
+/*!40000 ALTER TABLE `Transaction_Sagas` ENABLE KEYS */;
+UNLOCK TABLES;
+
 --
 -- Table structure for table `Transactions`
 --
@@ -198,9 +1127,26 @@ CREATE TABLE `Transactions` (
   `transaction_id` binary(16) NOT NULL,
   `transaction_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
   `user_id` binary(16) NOT NULL,
+  `status` varchar(20) NOT NULL DEFAULT 'completed' COMMENT 'pending, completed, failed, voided',
+  `voided_at` timestamp NULL DEFAULT NULL,
+  `voided_by` binary(16) DEFAULT NULL COMMENT 'ID of the user who voided the transaction',
+  `void_reason` varchar(255) DEFAULT NULL,
+  `location_id` binary(16) NOT NULL COMMENT 'location_id in retail_inventory.Locations the stock was taken from',
+  `register_id` binary(16) DEFAULT NULL,
+  `shift_id` binary(16) DEFAULT NULL COMMENT 'cashier shift the sale was made in',
+  `customer_id` binary(16) DEFAULT NULL COMMENT 'NULL for anonymous sales',
   PRIMARY KEY (`transaction_id`),
   KEY `user_id` (`user_id`),
-  CONSTRAINT `Transactions_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `Users` (`user_id`) ON DELETE RESTRICT
+  KEY `voided_by` (`voided_by`),
+  KEY `register_id` (`register_id`),
+  KEY `idx_transactions_time` (`transaction_time`),
+  KEY `shift_id` (`shift_id`),
+  KEY `customer_id` (`customer_id`),
+  CONSTRAINT `Transactions_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `Users` (`user_id`) ON DELETE RESTRICT,
+  CONSTRAINT `Transactions_ibfk_2` FOREIGN KEY (`voided_by`) REFERENCES `Users` (`user_id`) ON DELETE RESTRICT,
+  CONSTRAINT `Transactions_ibfk_3` FOREIGN KEY (`register_id`) REFERENCES `Registers` (`register_id`) ON DELETE SET NULL,
+  CONSTRAINT `Transactions_ibfk_4` FOREIGN KEY (`shift_id`) REFERENCES `Shifts` (`shift_id`) ON DELETE RESTRICT,
+  CONSTRAINT `Transactions_ibfk_5` FOREIGN KEY (`customer_id`) REFERENCES `Customers` (`customer_id`) ON DELETE SET NULL
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
 /*!40101 SET character_set_client = @saved_cs_client */;
 
@@ -210,7 +1156,7 @@ CREATE TABLE `Transactions` (
 
 LOCK TABLES `Transactions` WRITE;
 /*!40000 ALTER TABLE `Transactions` DISABLE KEYS */;
-INSERT INTO `Transactions` VALUES (_binary 'pp]*T]\','2025-11-21 08:03:29',_binary '[*}tb8_\');
+INSERT INTO `Transactions` VALUES (_binary 'pp]*T]\','2025-11-21 08:03:29',_binary '[*}tb8_\','completed',NULL,NULL,NULL,0x019AA31120804055595EE6D24ACBD2BC,0x019AA315B460D1DB94618CE77989A260,NULL,NULL);
 /*!40000 ALTER TABLE `Transactions` ENABLE KEYS */;
 UNLOCK TABLES;
 
@@ -252,8 +1198,11 @@ CREATE TABLE `Users` (
   `user_id` binary(16) NOT NULL,
   `username` varchar(100) NOT NULL,
   `hashed_password` varchar(255) NOT NULL COMMENT 'password must be hashed',
+  `register_id` binary(16) DEFAULT NULL COMMENT 'register the user sells from',
   PRIMARY KEY (`user_id`),
-  UNIQUE KEY `username` (`username`)
+  UNIQUE KEY `username` (`username`),
+  KEY `register_id` (`register_id`),
+  CONSTRAINT `Users_ibfk_1` FOREIGN KEY (`register_id`) REFERENCES `Registers` (`register_id`) ON DELETE SET NULL
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
 /*!40101 SET character_set_client = @saved_cs_client */;
 
@@ -263,7 +1212,7 @@ CREATE TABLE `Users` (
 
 LOCK TABLES `Users` WRITE;
 /*!40000 ALTER TABLE `Users` DISABLE KEYS */;
-INSERT INTO `Users` VALUES (_binary '[*}tb8_\','admin_toko','$2a$10$eCN9a5MVh5S/s/HkKNc9eu8xmwUUgNxVGfg6Njz/mXABGuf2C8QEG'),(_binary 'b\yyZ0G','admin_pison','$2a$10$z1GsbvQeleIGkvK3scd.UuMdCTVv7Q2SDTtyfljeSKOfIRzBpAIz6'),(_binary 'cĕ\\\\','cashier_asa','$2a$10$rhBLZ2lk2zAFWY8TdybvM.x4TK/toaJimgv9ZujkwdFBnI2EQUFXC'),(_binary 'c5\i	~iC\'','cashier_beni','$2a$10$o3s5krS060QeaKSCTsuSy.jSCSzYOJFJgbQUtvrvhrLUW7iVpj06e'),(_binary 'cz\Ҍ\Z\]\','cashier_candra','$2a$10$u8QvbmIkCbejZUmllJoJQOtEBS5tOOB51sam.BQd/wa9HBXGxnBBC'),(_binary '-wYU\@','cashier_deni','$2a$10$ge83RB7XzhFVjO9L6bVfKuyh7lyE1SOCbiKaovjO9AoyXk1eGL35K'),(_binary 'EXM\"\\','cashier_eko','$2a$10$6XPJ5huXerVqwViE/ZiTC.1arNGB6P35mdsDYSwpyGusenyEsvhE.'),(_binary 'c\\<\ݙZD','cashier_ferry','$2a$10$o5cvYrMkMknSMa5UijClUudecLgrRzmjTqZ0f9UrHkzuN3mOMyeJW'),(_binary 'tPi\\Z4Нz','cashier_gerrard','$2a$10$KWEsX.AgKsaOtwoqckqXquNd.cpRMeEnLCMWzIg4orCp20mudY3rK');
+INSERT INTO `Users` VALUES (_binary '[*}tb8_\','admin_toko','$2a$10$eCN9a5MVh5S/s/HkKNc9eu8xmwUUgNxVGfg6Njz/mXABGuf2C8QEG',0x019AA315B460D1DB94618CE77989A260),(_binary 'b\yyZ0G','admin_pison','$2a$10$z1GsbvQeleIGkvK3scd.UuMdCTVv7Q2SDTtyfljeSKOfIRzBpAIz6',0x019AA315B460D1DB94618CE77989A260),(_binary 'cĕ\\\\','cashier_asa','$2a$10$rhBLZ2lk2zAFWY8TdybvM.x4TK/toaJimgv9ZujkwdFBnI2EQUFXC',0x019AA315B460D1DB94618CE77989A260),(_binary 'c5\i	~iC\'','cashier_beni','$2a$10$o3s5krS060QeaKSCTsuSy.jSCSzYOJFJgbQUtvrvhrLUW7iVpj06e',0x019AA315B460D1DB94618CE77989A260),(_binary 'cz\Ҍ\Z\]\','cashier_candra','$2a$10$u8QvbmIkCbejZUmllJoJQOtEBS5tOOB51sam.BQd/wa9HBXGxnBBC',0x019AA315B460D1DB94618CE77989A260),(_binary '-wYU\@','cashier_deni','$2a$10$ge83RB7XzhFVjO9L6bVfKuyh7lyE1SOCbiKaovjO9AoyXk1eGL35K',0x019AA315B460D1DB94618CE77989A260),(_binary 'EXM\"\\','cashier_eko','$2a$10$6XPJ5huXerVqwViE/ZiTC.1arNGB6P35mdsDYSwpyGusenyEsvhE.',0x019AA315B460D1DB94618CE77989A260),(_binary 'c\\<\ݙZD','cashier_ferry','$2a$10$o5cvYrMkMknSMa5UijClUudecLgrRzmjTqZ0f9UrHkzuN3mOMyeJW',0x019AA315B460D1DB94618CE77989A260),(_binary 'tPi\\Z4Нz','cashier_gerrard','$2a$10$KWEsX.AgKsaOtwoqckqXquNd.cpRMeEnLCMWzIg4orCp20mudY3rK',0x019AA315B460D1DB94618CE77989A260);
 /*!40000 ALTER TABLE `Users` ENABLE KEYS */;
 UNLOCK TABLES;
 /*!40103 SET TIME_ZONE=@OLD_TIME_ZONE */;
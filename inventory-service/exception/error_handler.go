@@ -24,6 +24,15 @@ func GRPCErrorHandler(logger *logrus.Logger, err error, contextMessage string) e
 		return status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	if errors.Is(err, ErrReservationExpired) || errors.Is(err, ErrReservationClosed) || errors.Is(err, ErrInsufficientStock) ||
+		errors.Is(err, ErrTransferNotDispatched) || errors.Is(err, ErrTransferDispatched) || errors.Is(err, ErrTransferClosed) || errors.Is(err, ErrLotNotExpired) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	if errors.Is(err, ErrLocationExists) {
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+
 	return status.Error(codes.Internal, ErrInternalServer.Error())
 }
 
@@ -34,7 +43,8 @@ func FormatErrorMessage(logger *logrus.Logger, err error, contextMessage string)
 		return ErrNotFound.Error()
 	}
 
-	if errors.Is(err, ErrInsufficientStock) || errors.Is(err, ErrStockNegative) || errors.Is(err, ErrInvalidID) {
+	if errors.Is(err, ErrInsufficientStock) || errors.Is(err, ErrStockNegative) || errors.Is(err, ErrInvalidID) ||
+		errors.Is(err, ErrReservationExpired) || errors.Is(err, ErrReservationClosed) {
 		return err.Error()
 	}
 
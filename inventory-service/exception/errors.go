@@ -10,6 +10,19 @@ var (
 
 	ErrNotFound = errors.New("data not found")
 
+	ErrOperationApplied = errors.New("operation already applied")
+
+	ErrReservationExpired = errors.New("reservation has expired")
+	ErrReservationClosed  = errors.New("reservation is no longer active")
+
+	ErrLocationExists = errors.New("location name already exists")
+
+	ErrTransferNotDispatched = errors.New("transfer has not been dispatched")
+	ErrTransferDispatched    = errors.New("transfer has already been dispatched")
+	ErrTransferClosed        = errors.New("transfer is already closed")
+
+	ErrLotNotExpired = errors.New("lot has not expired")
+
 	ErrInternalServer = errors.New("internal server error")
 	ErrDatabase       = errors.New("database operation failed")
 )
@@ -31,7 +31,11 @@ func main() {
 	logger.Info("connected to database retail_inventory")
 
 	inventoryRepo := repository.NewInventoryRepository(logger)
-	inventoryService := service.NewInventoryService(inventoryRepo, db, logger)
+	reservationRepo := repository.NewReservationRepository(logger)
+	locationRepo := repository.NewLocationRepository(logger)
+	transferRepo := repository.NewTransferRepository(logger)
+	lotRepo := repository.NewLotRepository(logger)
+	inventoryService := service.NewInventoryService(inventoryRepo, reservationRepo, locationRepo, transferRepo, lotRepo, db, logger)
 
 	grpcPort := os.Getenv("GRPC_PORT")
 	if grpcPort == "" {
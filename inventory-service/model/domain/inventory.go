@@ -7,15 +7,32 @@ import (
 )
 
 type ProductStock struct {
-	ProductID ulid.ULID
-	Quantity  int
+	LocationID ulid.ULID
+	ProductID  ulid.ULID
+	Quantity   int
 }
 
 type InventoryLog struct {
 	LogID          ulid.ULID
+	LocationID     ulid.ULID
 	ProductID      ulid.ULID
 	UserID         ulid.ULID
 	ChangeQuantity int
 	Reason         string
+	OperationID    string
 	CreatedAt      time.Time
 }
+
+type StockOperation struct {
+	OperationID   string
+	OperationType string
+	ResultMessage string
+	CreatedAt     time.Time
+}
+
+const (
+	OperationTypeDecrease  = "decrease"
+	OperationTypeRestore   = "restore"
+	OperationTypeAdjust    = "adjust"
+	OperationTypeCancelled = "cancelled"
+)
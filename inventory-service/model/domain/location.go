@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	LocationTypeStore     = "store"
+	LocationTypeWarehouse = "warehouse"
+)
+
+type Location struct {
+	LocationID   ulid.ULID
+	LocationName string
+	LocationType string
+	CreatedAt    time.Time
+}
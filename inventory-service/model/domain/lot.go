@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const ExpiryDateLayout = "2006-01-02"
+
+type StockLot struct {
+	LotID      ulid.ULID
+	LocationID ulid.ULID
+	ProductID  ulid.ULID
+	LotNumber  string
+	ReceivedAt time.Time
+	ExpiryDate *time.Time
+	Quantity   int
+}
+
+type StockLotMovement struct {
+	MovementID        ulid.ULID
+	LotID             ulid.ULID
+	OperationID       string
+	SourceOperationID string
+	SourceLotID       *ulid.ULID
+	ChangeQuantity    int
+	CreatedAt         time.Time
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	ReservationStatusActive    = "active"
+	ReservationStatusCommitted = "committed"
+	ReservationStatusReleased  = "released"
+)
+
+type StockReservation struct {
+	ReservationID ulid.ULID
+	Reference     string
+	LocationID    ulid.ULID
+	UserID        ulid.ULID
+	Status        string
+	ExpiresAt     time.Time
+	CreatedAt     time.Time
+}
+
+type StockReservationItem struct {
+	ReservationID ulid.ULID
+	ProductID     ulid.ULID
+	Quantity      int
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	TransferStatusDraft             = "draft"
+	TransferStatusInTransit         = "in_transit"
+	TransferStatusPartiallyReceived = "partially_received"
+	TransferStatusReceived          = "received"
+	TransferStatusCancelled         = "cancelled"
+)
+
+type StockTransfer struct {
+	TransferID            ulid.ULID
+	SourceLocationID      ulid.ULID
+	DestinationLocationID ulid.ULID
+	Status                string
+	Note                  string
+	CreatedBy             ulid.ULID
+	CreatedAt             time.Time
+	DispatchedAt          *time.Time
+	ReceivedAt            *time.Time
+}
+
+type StockTransferItem struct {
+	TransferID          ulid.ULID
+	ProductID           ulid.ULID
+	Quantity            int
+	QuantityReceived    int
+	QuantityDiscrepancy int
+	DiscrepancyNote     string
+}
+
+func (item StockTransferItem) InTransit() int {
+	return item.Quantity - item.QuantityReceived - item.QuantityDiscrepancy
+}
@@ -24,6 +24,7 @@ const (
 type GetStockRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	LocationId    string                 `protobuf:"bytes,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -65,9 +66,18 @@ func (x *GetStockRequest) GetProductId() string {
 	return ""
 }
 
+func (x *GetStockRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
 type GetStockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Quantity      int32                  `protobuf:"varint,1,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Reserved      int32                  `protobuf:"varint,2,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Available     int32                  `protobuf:"varint,3,opt,name=available,proto3" json:"available,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -109,10 +119,26 @@ func (x *GetStockResponse) GetQuantity() int32 {
 	return 0
 }
 
+func (x *GetStockResponse) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *GetStockResponse) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
 type Item struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
 	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	LotNumber     string                 `protobuf:"bytes,3,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	ExpiryDate    string                 `protobuf:"bytes,4,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -161,11 +187,26 @@ func (x *Item) GetQuantity() int32 {
 	return 0
 }
 
+func (x *Item) GetLotNumber() string {
+	if x != nil {
+		return x.LotNumber
+	}
+	return ""
+}
+
+func (x *Item) GetExpiryDate() string {
+	if x != nil {
+		return x.ExpiryDate
+	}
+	return ""
+}
+
 type DecreaseStockRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Items         []*Item                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
 	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	TransactionId string                 `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	LocationId    string                 `protobuf:"bytes,4,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -221,10 +262,18 @@ func (x *DecreaseStockRequest) GetTransactionId() string {
 	return ""
 }
 
+func (x *DecreaseStockRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
 type DecreaseStockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Replayed      bool                   `protobuf:"varint,3,opt,name=replayed,proto3" json:"replayed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -273,12 +322,22 @@ func (x *DecreaseStockResponse) GetMessage() string {
 	return ""
 }
 
+func (x *DecreaseStockResponse) GetReplayed() bool {
+	if x != nil {
+		return x.Replayed
+	}
+	return false
+}
+
 type AdjustStockRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	ProductId      string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
 	QuantityChange int32                  `protobuf:"varint,2,opt,name=quantity_change,json=quantityChange,proto3" json:"quantity_change,omitempty"`
 	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
 	UserId         string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	LocationId     string                 `protobuf:"bytes,5,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	LotNumber      string                 `protobuf:"bytes,6,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	ExpiryDate     string                 `protobuf:"bytes,7,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -341,6 +400,27 @@ func (x *AdjustStockRequest) GetUserId() string {
 	return ""
 }
 
+func (x *AdjustStockRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetLotNumber() string {
+	if x != nil {
+		return x.LotNumber
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetExpiryDate() string {
+	if x != nil {
+		return x.ExpiryDate
+	}
+	return ""
+}
+
 type AdjustStockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -409,27 +489,30 @@ func (x *AdjustStockResponse) GetLogId() string {
 	return ""
 }
 
-type GetBatchStockRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductIds    []string               `protobuf:"bytes,1,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type StockAdjustment struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ProductId      string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	QuantityChange int32                  `protobuf:"varint,2,opt,name=quantity_change,json=quantityChange,proto3" json:"quantity_change,omitempty"`
+	LotNumber      string                 `protobuf:"bytes,3,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	ExpiryDate     string                 `protobuf:"bytes,4,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *GetBatchStockRequest) Reset() {
-	*x = GetBatchStockRequest{}
+func (x *StockAdjustment) Reset() {
+	*x = StockAdjustment{}
 	mi := &file_inventory_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBatchStockRequest) String() string {
+func (x *StockAdjustment) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBatchStockRequest) ProtoMessage() {}
+func (*StockAdjustment) ProtoMessage() {}
 
-func (x *GetBatchStockRequest) ProtoReflect() protoreflect.Message {
+func (x *StockAdjustment) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -441,40 +524,64 @@ func (x *GetBatchStockRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBatchStockRequest.ProtoReflect.Descriptor instead.
-func (*GetBatchStockRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use StockAdjustment.ProtoReflect.Descriptor instead.
+func (*StockAdjustment) Descriptor() ([]byte, []int) {
 	return file_inventory_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetBatchStockRequest) GetProductIds() []string {
+func (x *StockAdjustment) GetProductId() string {
 	if x != nil {
-		return x.ProductIds
+		return x.ProductId
 	}
-	return nil
+	return ""
 }
 
-type BatchStockItem struct {
+func (x *StockAdjustment) GetQuantityChange() int32 {
+	if x != nil {
+		return x.QuantityChange
+	}
+	return 0
+}
+
+func (x *StockAdjustment) GetLotNumber() string {
+	if x != nil {
+		return x.LotNumber
+	}
+	return ""
+}
+
+func (x *StockAdjustment) GetExpiryDate() string {
+	if x != nil {
+		return x.ExpiryDate
+	}
+	return ""
+}
+
+type BatchAdjustStockRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Adjustments   []*StockAdjustment     `protobuf:"bytes,1,rep,name=adjustments,proto3" json:"adjustments,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	LocationId    string                 `protobuf:"bytes,3,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	OperationId   string                 `protobuf:"bytes,5,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BatchStockItem) Reset() {
-	*x = BatchStockItem{}
+func (x *BatchAdjustStockRequest) Reset() {
+	*x = BatchAdjustStockRequest{}
 	mi := &file_inventory_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchStockItem) String() string {
+func (x *BatchAdjustStockRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchStockItem) ProtoMessage() {}
+func (*BatchAdjustStockRequest) ProtoMessage() {}
 
-func (x *BatchStockItem) ProtoReflect() protoreflect.Message {
+func (x *BatchAdjustStockRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -486,46 +593,69 @@ func (x *BatchStockItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchStockItem.ProtoReflect.Descriptor instead.
-func (*BatchStockItem) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchAdjustStockRequest.ProtoReflect.Descriptor instead.
+func (*BatchAdjustStockRequest) Descriptor() ([]byte, []int) {
 	return file_inventory_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *BatchStockItem) GetProductId() string {
+func (x *BatchAdjustStockRequest) GetAdjustments() []*StockAdjustment {
 	if x != nil {
-		return x.ProductId
+		return x.Adjustments
+	}
+	return nil
+}
+
+func (x *BatchAdjustStockRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *BatchStockItem) GetQuantity() int32 {
+func (x *BatchAdjustStockRequest) GetLocationId() string {
 	if x != nil {
-		return x.Quantity
+		return x.LocationId
 	}
-	return 0
+	return ""
 }
 
-type GetBatchStockResponse struct {
+func (x *BatchAdjustStockRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BatchAdjustStockRequest) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+type AdjustedStock struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Items         []*BatchStockItem      `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	NewQuantity   int32                  `protobuf:"varint,2,opt,name=new_quantity,json=newQuantity,proto3" json:"new_quantity,omitempty"`
+	LogId         string                 `protobuf:"bytes,3,opt,name=log_id,json=logId,proto3" json:"log_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetBatchStockResponse) Reset() {
-	*x = GetBatchStockResponse{}
+func (x *AdjustedStock) Reset() {
+	*x = AdjustedStock{}
 	mi := &file_inventory_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBatchStockResponse) String() string {
+func (x *AdjustedStock) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBatchStockResponse) ProtoMessage() {}
+func (*AdjustedStock) ProtoMessage() {}
 
-func (x *GetBatchStockResponse) ProtoReflect() protoreflect.Message {
+func (x *AdjustedStock) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -537,64 +667,2366 @@ func (x *GetBatchStockResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBatchStockResponse.ProtoReflect.Descriptor instead.
-func (*GetBatchStockResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AdjustedStock.ProtoReflect.Descriptor instead.
+func (*AdjustedStock) Descriptor() ([]byte, []int) {
 	return file_inventory_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *GetBatchStockResponse) GetItems() []*BatchStockItem {
+func (x *AdjustedStock) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *AdjustedStock) GetNewQuantity() int32 {
+	if x != nil {
+		return x.NewQuantity
+	}
+	return 0
+}
+
+func (x *AdjustedStock) GetLogId() string {
+	if x != nil {
+		return x.LogId
+	}
+	return ""
+}
+
+type BatchAdjustStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Replayed      bool                   `protobuf:"varint,3,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	Items         []*AdjustedStock       `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchAdjustStockResponse) Reset() {
+	*x = BatchAdjustStockResponse{}
+	mi := &file_inventory_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchAdjustStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchAdjustStockResponse) ProtoMessage() {}
+
+func (x *BatchAdjustStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchAdjustStockResponse.ProtoReflect.Descriptor instead.
+func (*BatchAdjustStockResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BatchAdjustStockResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BatchAdjustStockResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BatchAdjustStockResponse) GetReplayed() bool {
+	if x != nil {
+		return x.Replayed
+	}
+	return false
+}
+
+func (x *BatchAdjustStockResponse) GetItems() []*AdjustedStock {
 	if x != nil {
 		return x.Items
 	}
 	return nil
 }
 
-var File_inventory_proto protoreflect.FileDescriptor
+type GetBatchStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductIds    []string               `protobuf:"bytes,1,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	LocationId    string                 `protobuf:"bytes,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_inventory_proto_rawDesc = "" +
-	"\n" +
-	"\x0finventory.proto\x12\tinventory\"0\n" +
-	"\x0fGetStockRequest\x12\x1d\n" +
-	"\n" +
-	"product_id\x18\x01 \x01(\tR\tproductId\".\n" +
-	"\x10GetStockResponse\x12\x1a\n" +
-	"\bquantity\x18\x01 \x01(\x05R\bquantity\"A\n" +
-	"\x04Item\x12\x1d\n" +
-	"\n" +
-	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"}\n" +
-	"\x14DecreaseStockRequest\x12%\n" +
-	"\x05items\x18\x01 \x03(\v2\x0f.inventory.ItemR\x05items\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12%\n" +
-	"\x0etransaction_id\x18\x03 \x01(\tR\rtransactionId\"K\n" +
-	"\x15DecreaseStockResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\x8d\x01\n" +
-	"\x12AdjustStockRequest\x12\x1d\n" +
-	"\n" +
-	"product_id\x18\x01 \x01(\tR\tproductId\x12'\n" +
-	"\x0fquantity_change\x18\x02 \x01(\x05R\x0equantityChange\x12\x16\n" +
-	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x17\n" +
-	"\auser_id\x18\x04 \x01(\tR\x06userId\"\x83\x01\n" +
-	"\x13AdjustStockResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12!\n" +
-	"\fnew_quantity\x18\x02 \x01(\x05R\vnewQuantity\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\x12\x15\n" +
-	"\x06log_id\x18\x04 \x01(\tR\x05logId\"7\n" +
-	"\x14GetBatchStockRequest\x12\x1f\n" +
-	"\vproduct_ids\x18\x01 \x03(\tR\n" +
-	"productIds\"K\n" +
-	"\x0eBatchStockItem\x12\x1d\n" +
-	"\n" +
-	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"H\n" +
-	"\x15GetBatchStockResponse\x12/\n" +
-	"\x05items\x18\x01 \x03(\v2\x19.inventory.BatchStockItemR\x05items2\xcd\x02\n" +
+func (x *GetBatchStockRequest) Reset() {
+	*x = GetBatchStockRequest{}
+	mi := &file_inventory_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBatchStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBatchStockRequest) ProtoMessage() {}
+
+func (x *GetBatchStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBatchStockRequest.ProtoReflect.Descriptor instead.
+func (*GetBatchStockRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetBatchStockRequest) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *GetBatchStockRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+type BatchStockItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Reserved      int32                  `protobuf:"varint,3,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Available     int32                  `protobuf:"varint,4,opt,name=available,proto3" json:"available,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchStockItem) Reset() {
+	*x = BatchStockItem{}
+	mi := &file_inventory_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchStockItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchStockItem) ProtoMessage() {}
+
+func (x *BatchStockItem) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchStockItem.ProtoReflect.Descriptor instead.
+func (*BatchStockItem) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BatchStockItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *BatchStockItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *BatchStockItem) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *BatchStockItem) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+type GetBatchStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*BatchStockItem      `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBatchStockResponse) Reset() {
+	*x = GetBatchStockResponse{}
+	mi := &file_inventory_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBatchStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBatchStockResponse) ProtoMessage() {}
+
+func (x *GetBatchStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBatchStockResponse.ProtoReflect.Descriptor instead.
+func (*GetBatchStockResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetBatchStockResponse) GetItems() []*BatchStockItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type RestoreStockRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Items               []*Item                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	UserId              string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OperationId         string                 `protobuf:"bytes,3,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	Reason              string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	LocationId          string                 `protobuf:"bytes,5,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	SourceTransactionId string                 `protobuf:"bytes,6,opt,name=source_transaction_id,json=sourceTransactionId,proto3" json:"source_transaction_id,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *RestoreStockRequest) Reset() {
+	*x = RestoreStockRequest{}
+	mi := &file_inventory_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreStockRequest) ProtoMessage() {}
+
+func (x *RestoreStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreStockRequest.ProtoReflect.Descriptor instead.
+func (*RestoreStockRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RestoreStockRequest) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *RestoreStockRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RestoreStockRequest) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+func (x *RestoreStockRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RestoreStockRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *RestoreStockRequest) GetSourceTransactionId() string {
+	if x != nil {
+		return x.SourceTransactionId
+	}
+	return ""
+}
+
+type RestoreStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Replayed      bool                   `protobuf:"varint,3,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreStockResponse) Reset() {
+	*x = RestoreStockResponse{}
+	mi := &file_inventory_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreStockResponse) ProtoMessage() {}
+
+func (x *RestoreStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreStockResponse.ProtoReflect.Descriptor instead.
+func (*RestoreStockResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RestoreStockResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RestoreStockResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RestoreStockResponse) GetReplayed() bool {
+	if x != nil {
+		return x.Replayed
+	}
+	return false
+}
+
+type CancelStockOperationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	OperationId   string                 `protobuf:"bytes,4,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelStockOperationRequest) Reset() {
+	*x = CancelStockOperationRequest{}
+	mi := &file_inventory_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelStockOperationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelStockOperationRequest) ProtoMessage() {}
+
+func (x *CancelStockOperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelStockOperationRequest.ProtoReflect.Descriptor instead.
+func (*CancelStockOperationRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CancelStockOperationRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *CancelStockOperationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CancelStockOperationRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CancelStockOperationRequest) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+type CancelStockOperationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Restored      bool                   `protobuf:"varint,3,opt,name=restored,proto3" json:"restored,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelStockOperationResponse) Reset() {
+	*x = CancelStockOperationResponse{}
+	mi := &file_inventory_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelStockOperationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelStockOperationResponse) ProtoMessage() {}
+
+func (x *CancelStockOperationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelStockOperationResponse.ProtoReflect.Descriptor instead.
+func (*CancelStockOperationResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CancelStockOperationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CancelStockOperationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CancelStockOperationResponse) GetRestored() bool {
+	if x != nil {
+		return x.Restored
+	}
+	return false
+}
+
+type ReserveStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Item                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reference     string                 `protobuf:"bytes,3,opt,name=reference,proto3" json:"reference,omitempty"`
+	TtlSeconds    int32                  `protobuf:"varint,4,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	LocationId    string                 `protobuf:"bytes,5,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockRequest) Reset() {
+	*x = ReserveStockRequest{}
+	mi := &file_inventory_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockRequest) ProtoMessage() {}
+
+func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockRequest.ProtoReflect.Descriptor instead.
+func (*ReserveStockRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReserveStockRequest) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReserveStockRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetTtlSeconds() int32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *ReserveStockRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+type ReserveStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ReservationId string                 `protobuf:"bytes,3,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockResponse) Reset() {
+	*x = ReserveStockResponse{}
+	mi := &file_inventory_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockResponse) ProtoMessage() {}
+
+func (x *ReserveStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockResponse.ProtoReflect.Descriptor instead.
+func (*ReserveStockResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReserveStockResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReserveStockResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReserveStockResponse) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *ReserveStockResponse) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type CommitReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TransactionId string                 `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitReservationRequest) Reset() {
+	*x = CommitReservationRequest{}
+	mi := &file_inventory_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationRequest) ProtoMessage() {}
+
+func (x *CommitReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationRequest.ProtoReflect.Descriptor instead.
+func (*CommitReservationRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CommitReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *CommitReservationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CommitReservationRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type CommitReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitReservationResponse) Reset() {
+	*x = CommitReservationResponse{}
+	mi := &file_inventory_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationResponse) ProtoMessage() {}
+
+func (x *CommitReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationResponse.ProtoReflect.Descriptor instead.
+func (*CommitReservationResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CommitReservationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CommitReservationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ReleaseReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationRequest) Reset() {
+	*x = ReleaseReservationRequest{}
+	mi := &file_inventory_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationRequest) ProtoMessage() {}
+
+func (x *ReleaseReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ReleaseReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *ReleaseReservationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ReleaseReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationResponse) Reset() {
+	*x = ReleaseReservationResponse{}
+	mi := &file_inventory_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationResponse) ProtoMessage() {}
+
+func (x *ReleaseReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReleaseReservationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReleaseReservationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type Location struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LocationId    string                 `protobuf:"bytes,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	LocationName  string                 `protobuf:"bytes,2,opt,name=location_name,json=locationName,proto3" json:"location_name,omitempty"`
+	LocationType  string                 `protobuf:"bytes,3,opt,name=location_type,json=locationType,proto3" json:"location_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_inventory_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Location) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *Location) GetLocationName() string {
+	if x != nil {
+		return x.LocationName
+	}
+	return ""
+}
+
+func (x *Location) GetLocationType() string {
+	if x != nil {
+		return x.LocationType
+	}
+	return ""
+}
+
+type CreateLocationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LocationName  string                 `protobuf:"bytes,1,opt,name=location_name,json=locationName,proto3" json:"location_name,omitempty"`
+	LocationType  string                 `protobuf:"bytes,2,opt,name=location_type,json=locationType,proto3" json:"location_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLocationRequest) Reset() {
+	*x = CreateLocationRequest{}
+	mi := &file_inventory_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLocationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLocationRequest) ProtoMessage() {}
+
+func (x *CreateLocationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLocationRequest.ProtoReflect.Descriptor instead.
+func (*CreateLocationRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreateLocationRequest) GetLocationName() string {
+	if x != nil {
+		return x.LocationName
+	}
+	return ""
+}
+
+func (x *CreateLocationRequest) GetLocationType() string {
+	if x != nil {
+		return x.LocationType
+	}
+	return ""
+}
+
+type GetLocationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LocationId    string                 `protobuf:"bytes,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLocationRequest) Reset() {
+	*x = GetLocationRequest{}
+	mi := &file_inventory_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLocationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLocationRequest) ProtoMessage() {}
+
+func (x *GetLocationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLocationRequest.ProtoReflect.Descriptor instead.
+func (*GetLocationRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetLocationRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+type ListLocationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLocationsRequest) Reset() {
+	*x = ListLocationsRequest{}
+	mi := &file_inventory_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLocationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLocationsRequest) ProtoMessage() {}
+
+func (x *ListLocationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLocationsRequest.ProtoReflect.Descriptor instead.
+func (*ListLocationsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{27}
+}
+
+type ListLocationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Locations     []*Location            `protobuf:"bytes,1,rep,name=locations,proto3" json:"locations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLocationsResponse) Reset() {
+	*x = ListLocationsResponse{}
+	mi := &file_inventory_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLocationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLocationsResponse) ProtoMessage() {}
+
+func (x *ListLocationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLocationsResponse.ProtoReflect.Descriptor instead.
+func (*ListLocationsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListLocationsResponse) GetLocations() []*Location {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+type TransferItem struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ProductId           string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity            int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	QuantityReceived    int32                  `protobuf:"varint,3,opt,name=quantity_received,json=quantityReceived,proto3" json:"quantity_received,omitempty"`
+	QuantityDiscrepancy int32                  `protobuf:"varint,4,opt,name=quantity_discrepancy,json=quantityDiscrepancy,proto3" json:"quantity_discrepancy,omitempty"`
+	InTransit           int32                  `protobuf:"varint,5,opt,name=in_transit,json=inTransit,proto3" json:"in_transit,omitempty"`
+	DiscrepancyNote     string                 `protobuf:"bytes,6,opt,name=discrepancy_note,json=discrepancyNote,proto3" json:"discrepancy_note,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *TransferItem) Reset() {
+	*x = TransferItem{}
+	mi := &file_inventory_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferItem) ProtoMessage() {}
+
+func (x *TransferItem) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferItem.ProtoReflect.Descriptor instead.
+func (*TransferItem) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TransferItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *TransferItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *TransferItem) GetQuantityReceived() int32 {
+	if x != nil {
+		return x.QuantityReceived
+	}
+	return 0
+}
+
+func (x *TransferItem) GetQuantityDiscrepancy() int32 {
+	if x != nil {
+		return x.QuantityDiscrepancy
+	}
+	return 0
+}
+
+func (x *TransferItem) GetInTransit() int32 {
+	if x != nil {
+		return x.InTransit
+	}
+	return 0
+}
+
+func (x *TransferItem) GetDiscrepancyNote() string {
+	if x != nil {
+		return x.DiscrepancyNote
+	}
+	return ""
+}
+
+type StockTransfer struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	TransferId            string                 `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	SourceLocationId      string                 `protobuf:"bytes,2,opt,name=source_location_id,json=sourceLocationId,proto3" json:"source_location_id,omitempty"`
+	DestinationLocationId string                 `protobuf:"bytes,3,opt,name=destination_location_id,json=destinationLocationId,proto3" json:"destination_location_id,omitempty"`
+	Status                string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Note                  string                 `protobuf:"bytes,5,opt,name=note,proto3" json:"note,omitempty"`
+	CreatedBy             string                 `protobuf:"bytes,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt             string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DispatchedAt          string                 `protobuf:"bytes,8,opt,name=dispatched_at,json=dispatchedAt,proto3" json:"dispatched_at,omitempty"`
+	ReceivedAt            string                 `protobuf:"bytes,9,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	Items                 []*TransferItem        `protobuf:"bytes,10,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *StockTransfer) Reset() {
+	*x = StockTransfer{}
+	mi := &file_inventory_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockTransfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockTransfer) ProtoMessage() {}
+
+func (x *StockTransfer) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockTransfer.ProtoReflect.Descriptor instead.
+func (*StockTransfer) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *StockTransfer) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *StockTransfer) GetSourceLocationId() string {
+	if x != nil {
+		return x.SourceLocationId
+	}
+	return ""
+}
+
+func (x *StockTransfer) GetDestinationLocationId() string {
+	if x != nil {
+		return x.DestinationLocationId
+	}
+	return ""
+}
+
+func (x *StockTransfer) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StockTransfer) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *StockTransfer) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *StockTransfer) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *StockTransfer) GetDispatchedAt() string {
+	if x != nil {
+		return x.DispatchedAt
+	}
+	return ""
+}
+
+func (x *StockTransfer) GetReceivedAt() string {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return ""
+}
+
+func (x *StockTransfer) GetItems() []*TransferItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type CreateTransferRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	SourceLocationId      string                 `protobuf:"bytes,1,opt,name=source_location_id,json=sourceLocationId,proto3" json:"source_location_id,omitempty"`
+	DestinationLocationId string                 `protobuf:"bytes,2,opt,name=destination_location_id,json=destinationLocationId,proto3" json:"destination_location_id,omitempty"`
+	Items                 []*Item                `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	UserId                string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Note                  string                 `protobuf:"bytes,5,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *CreateTransferRequest) Reset() {
+	*x = CreateTransferRequest{}
+	mi := &file_inventory_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTransferRequest) ProtoMessage() {}
+
+func (x *CreateTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTransferRequest.ProtoReflect.Descriptor instead.
+func (*CreateTransferRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CreateTransferRequest) GetSourceLocationId() string {
+	if x != nil {
+		return x.SourceLocationId
+	}
+	return ""
+}
+
+func (x *CreateTransferRequest) GetDestinationLocationId() string {
+	if x != nil {
+		return x.DestinationLocationId
+	}
+	return ""
+}
+
+func (x *CreateTransferRequest) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreateTransferRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateTransferRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type DispatchTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransferId    string                 `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DispatchTransferRequest) Reset() {
+	*x = DispatchTransferRequest{}
+	mi := &file_inventory_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DispatchTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DispatchTransferRequest) ProtoMessage() {}
+
+func (x *DispatchTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DispatchTransferRequest.ProtoReflect.Descriptor instead.
+func (*DispatchTransferRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DispatchTransferRequest) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *DispatchTransferRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type TransferReceiptItem struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ProductId           string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	QuantityReceived    int32                  `protobuf:"varint,2,opt,name=quantity_received,json=quantityReceived,proto3" json:"quantity_received,omitempty"`
+	QuantityDiscrepancy int32                  `protobuf:"varint,3,opt,name=quantity_discrepancy,json=quantityDiscrepancy,proto3" json:"quantity_discrepancy,omitempty"`
+	DiscrepancyNote     string                 `protobuf:"bytes,4,opt,name=discrepancy_note,json=discrepancyNote,proto3" json:"discrepancy_note,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *TransferReceiptItem) Reset() {
+	*x = TransferReceiptItem{}
+	mi := &file_inventory_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferReceiptItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferReceiptItem) ProtoMessage() {}
+
+func (x *TransferReceiptItem) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferReceiptItem.ProtoReflect.Descriptor instead.
+func (*TransferReceiptItem) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *TransferReceiptItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *TransferReceiptItem) GetQuantityReceived() int32 {
+	if x != nil {
+		return x.QuantityReceived
+	}
+	return 0
+}
+
+func (x *TransferReceiptItem) GetQuantityDiscrepancy() int32 {
+	if x != nil {
+		return x.QuantityDiscrepancy
+	}
+	return 0
+}
+
+func (x *TransferReceiptItem) GetDiscrepancyNote() string {
+	if x != nil {
+		return x.DiscrepancyNote
+	}
+	return ""
+}
+
+type ReceiveTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransferId    string                 `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items         []*TransferReceiptItem `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	ReceiptKey    string                 `protobuf:"bytes,4,opt,name=receipt_key,json=receiptKey,proto3" json:"receipt_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiveTransferRequest) Reset() {
+	*x = ReceiveTransferRequest{}
+	mi := &file_inventory_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveTransferRequest) ProtoMessage() {}
+
+func (x *ReceiveTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveTransferRequest.ProtoReflect.Descriptor instead.
+func (*ReceiveTransferRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ReceiveTransferRequest) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *ReceiveTransferRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReceiveTransferRequest) GetItems() []*TransferReceiptItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReceiveTransferRequest) GetReceiptKey() string {
+	if x != nil {
+		return x.ReceiptKey
+	}
+	return ""
+}
+
+type CancelTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransferId    string                 `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTransferRequest) Reset() {
+	*x = CancelTransferRequest{}
+	mi := &file_inventory_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTransferRequest) ProtoMessage() {}
+
+func (x *CancelTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTransferRequest.ProtoReflect.Descriptor instead.
+func (*CancelTransferRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CancelTransferRequest) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *CancelTransferRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransferId    string                 `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferRequest) Reset() {
+	*x = GetTransferRequest{}
+	mi := &file_inventory_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferRequest) ProtoMessage() {}
+
+func (x *GetTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferRequest.ProtoReflect.Descriptor instead.
+func (*GetTransferRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetTransferRequest) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+type ListTransfersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LocationId    string                 `protobuf:"bytes,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransfersRequest) Reset() {
+	*x = ListTransfersRequest{}
+	mi := &file_inventory_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransfersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransfersRequest) ProtoMessage() {}
+
+func (x *ListTransfersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransfersRequest.ProtoReflect.Descriptor instead.
+func (*ListTransfersRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListTransfersRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *ListTransfersRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListTransfersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfers     []*StockTransfer       `protobuf:"bytes,1,rep,name=transfers,proto3" json:"transfers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransfersResponse) Reset() {
+	*x = ListTransfersResponse{}
+	mi := &file_inventory_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransfersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransfersResponse) ProtoMessage() {}
+
+func (x *ListTransfersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransfersResponse.ProtoReflect.Descriptor instead.
+func (*ListTransfersResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListTransfersResponse) GetTransfers() []*StockTransfer {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+type StockLot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LotId         string                 `protobuf:"bytes,1,opt,name=lot_id,json=lotId,proto3" json:"lot_id,omitempty"`
+	LocationId    string                 `protobuf:"bytes,2,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	LotNumber     string                 `protobuf:"bytes,4,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	ReceivedAt    string                 `protobuf:"bytes,5,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	ExpiryDate    string                 `protobuf:"bytes,6,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
+	Quantity      int32                  `protobuf:"varint,7,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockLot) Reset() {
+	*x = StockLot{}
+	mi := &file_inventory_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockLot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockLot) ProtoMessage() {}
+
+func (x *StockLot) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockLot.ProtoReflect.Descriptor instead.
+func (*StockLot) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *StockLot) GetLotId() string {
+	if x != nil {
+		return x.LotId
+	}
+	return ""
+}
+
+func (x *StockLot) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *StockLot) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *StockLot) GetLotNumber() string {
+	if x != nil {
+		return x.LotNumber
+	}
+	return ""
+}
+
+func (x *StockLot) GetReceivedAt() string {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return ""
+}
+
+func (x *StockLot) GetExpiryDate() string {
+	if x != nil {
+		return x.ExpiryDate
+	}
+	return ""
+}
+
+func (x *StockLot) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type ListExpiringLotsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LocationId    string                 `protobuf:"bytes,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	WithinDays    int32                  `protobuf:"varint,2,opt,name=within_days,json=withinDays,proto3" json:"within_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExpiringLotsRequest) Reset() {
+	*x = ListExpiringLotsRequest{}
+	mi := &file_inventory_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExpiringLotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExpiringLotsRequest) ProtoMessage() {}
+
+func (x *ListExpiringLotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExpiringLotsRequest.ProtoReflect.Descriptor instead.
+func (*ListExpiringLotsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListExpiringLotsRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *ListExpiringLotsRequest) GetWithinDays() int32 {
+	if x != nil {
+		return x.WithinDays
+	}
+	return 0
+}
+
+type ListLotsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lots          []*StockLot            `protobuf:"bytes,1,rep,name=lots,proto3" json:"lots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLotsResponse) Reset() {
+	*x = ListLotsResponse{}
+	mi := &file_inventory_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLotsResponse) ProtoMessage() {}
+
+func (x *ListLotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLotsResponse.ProtoReflect.Descriptor instead.
+func (*ListLotsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListLotsResponse) GetLots() []*StockLot {
+	if x != nil {
+		return x.Lots
+	}
+	return nil
+}
+
+type WriteOffExpiredLotsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LocationId    string                 `protobuf:"bytes,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	LotIds        []string               `protobuf:"bytes,2,rep,name=lot_ids,json=lotIds,proto3" json:"lot_ids,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteOffExpiredLotsRequest) Reset() {
+	*x = WriteOffExpiredLotsRequest{}
+	mi := &file_inventory_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteOffExpiredLotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteOffExpiredLotsRequest) ProtoMessage() {}
+
+func (x *WriteOffExpiredLotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteOffExpiredLotsRequest.ProtoReflect.Descriptor instead.
+func (*WriteOffExpiredLotsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *WriteOffExpiredLotsRequest) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *WriteOffExpiredLotsRequest) GetLotIds() []string {
+	if x != nil {
+		return x.LotIds
+	}
+	return nil
+}
+
+func (x *WriteOffExpiredLotsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *WriteOffExpiredLotsRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type WriteOffExpiredLotsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Lots          []*StockLot            `protobuf:"bytes,3,rep,name=lots,proto3" json:"lots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteOffExpiredLotsResponse) Reset() {
+	*x = WriteOffExpiredLotsResponse{}
+	mi := &file_inventory_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteOffExpiredLotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteOffExpiredLotsResponse) ProtoMessage() {}
+
+func (x *WriteOffExpiredLotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteOffExpiredLotsResponse.ProtoReflect.Descriptor instead.
+func (*WriteOffExpiredLotsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *WriteOffExpiredLotsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *WriteOffExpiredLotsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *WriteOffExpiredLotsResponse) GetLots() []*StockLot {
+	if x != nil {
+		return x.Lots
+	}
+	return nil
+}
+
+var File_inventory_proto protoreflect.FileDescriptor
+
+const file_inventory_proto_rawDesc = "" +
+	"\n" +
+	"\x0finventory.proto\x12\tinventory\"Q\n" +
+	"\x0fGetStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\tR\n" +
+	"locationId\"h\n" +
+	"\x10GetStockResponse\x12\x1a\n" +
+	"\bquantity\x18\x01 \x01(\x05R\bquantity\x12\x1a\n" +
+	"\breserved\x18\x02 \x01(\x05R\breserved\x12\x1c\n" +
+	"\tavailable\x18\x03 \x01(\x05R\tavailable\"\x81\x01\n" +
+	"\x04Item\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"lot_number\x18\x03 \x01(\tR\tlotNumber\x12\x1f\n" +
+	"\vexpiry_date\x18\x04 \x01(\tR\n" +
+	"expiryDate\"\x9e\x01\n" +
+	"\x14DecreaseStockRequest\x12%\n" +
+	"\x05items\x18\x01 \x03(\v2\x0f.inventory.ItemR\x05items\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12%\n" +
+	"\x0etransaction_id\x18\x03 \x01(\tR\rtransactionId\x12\x1f\n" +
+	"\vlocation_id\x18\x04 \x01(\tR\n" +
+	"locationId\"g\n" +
+	"\x15DecreaseStockResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1a\n" +
+	"\breplayed\x18\x03 \x01(\bR\breplayed\"\xee\x01\n" +
+	"\x12AdjustStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12'\n" +
+	"\x0fquantity_change\x18\x02 \x01(\x05R\x0equantityChange\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vlocation_id\x18\x05 \x01(\tR\n" +
+	"locationId\x12\x1d\n" +
+	"\n" +
+	"lot_number\x18\x06 \x01(\tR\tlotNumber\x12\x1f\n" +
+	"\vexpiry_date\x18\a \x01(\tR\n" +
+	"expiryDate\"\x83\x01\n" +
+	"\x13AdjustStockResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12!\n" +
+	"\fnew_quantity\x18\x02 \x01(\x05R\vnewQuantity\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x15\n" +
+	"\x06log_id\x18\x04 \x01(\tR\x05logId\"\x99\x01\n" +
+	"\x0fStockAdjustment\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12'\n" +
+	"\x0fquantity_change\x18\x02 \x01(\x05R\x0equantityChange\x12\x1d\n" +
+	"\n" +
+	"lot_number\x18\x03 \x01(\tR\tlotNumber\x12\x1f\n" +
+	"\vexpiry_date\x18\x04 \x01(\tR\n" +
+	"expiryDate\"\xcc\x01\n" +
+	"\x17BatchAdjustStockRequest\x12<\n" +
+	"\vadjustments\x18\x01 \x03(\v2\x1a.inventory.StockAdjustmentR\vadjustments\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vlocation_id\x18\x03 \x01(\tR\n" +
+	"locationId\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12!\n" +
+	"\foperation_id\x18\x05 \x01(\tR\voperationId\"h\n" +
+	"\rAdjustedStock\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
+	"\fnew_quantity\x18\x02 \x01(\x05R\vnewQuantity\x12\x15\n" +
+	"\x06log_id\x18\x03 \x01(\tR\x05logId\"\x9a\x01\n" +
+	"\x18BatchAdjustStockResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1a\n" +
+	"\breplayed\x18\x03 \x01(\bR\breplayed\x12.\n" +
+	"\x05items\x18\x04 \x03(\v2\x18.inventory.AdjustedStockR\x05items\"X\n" +
+	"\x14GetBatchStockRequest\x12\x1f\n" +
+	"\vproduct_ids\x18\x01 \x03(\tR\n" +
+	"productIds\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\tR\n" +
+	"locationId\"\x85\x01\n" +
+	"\x0eBatchStockItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1a\n" +
+	"\breserved\x18\x03 \x01(\x05R\breserved\x12\x1c\n" +
+	"\tavailable\x18\x04 \x01(\x05R\tavailable\"H\n" +
+	"\x15GetBatchStockResponse\x12/\n" +
+	"\x05items\x18\x01 \x03(\v2\x19.inventory.BatchStockItemR\x05items\"\xe5\x01\n" +
+	"\x13RestoreStockRequest\x12%\n" +
+	"\x05items\x18\x01 \x03(\v2\x0f.inventory.ItemR\x05items\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12!\n" +
+	"\foperation_id\x18\x03 \x01(\tR\voperationId\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x1f\n" +
+	"\vlocation_id\x18\x05 \x01(\tR\n" +
+	"locationId\x122\n" +
+	"\x15source_transaction_id\x18\x06 \x01(\tR\x13sourceTransactionId\"f\n" +
+	"\x14RestoreStockResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1a\n" +
+	"\breplayed\x18\x03 \x01(\bR\breplayed\"\x98\x01\n" +
+	"\x1bCancelStockOperationRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12!\n" +
+	"\foperation_id\x18\x04 \x01(\tR\voperationId\"n\n" +
+	"\x1cCancelStockOperationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1a\n" +
+	"\brestored\x18\x03 \x01(\bR\brestored\"\xb5\x01\n" +
+	"\x13ReserveStockRequest\x12%\n" +
+	"\x05items\x18\x01 \x03(\v2\x0f.inventory.ItemR\x05items\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1c\n" +
+	"\treference\x18\x03 \x01(\tR\treference\x12\x1f\n" +
+	"\vttl_seconds\x18\x04 \x01(\x05R\n" +
+	"ttlSeconds\x12\x1f\n" +
+	"\vlocation_id\x18\x05 \x01(\tR\n" +
+	"locationId\"\x90\x01\n" +
+	"\x14ReserveStockResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12%\n" +
+	"\x0ereservation_id\x18\x03 \x01(\tR\rreservationId\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\tR\texpiresAt\"\x81\x01\n" +
+	"\x18CommitReservationRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12%\n" +
+	"\x0etransaction_id\x18\x03 \x01(\tR\rtransactionId\"O\n" +
+	"\x19CommitReservationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"[\n" +
+	"\x19ReleaseReservationRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"P\n" +
+	"\x1aReleaseReservationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"u\n" +
+	"\bLocation\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\tR\n" +
+	"locationId\x12#\n" +
+	"\rlocation_name\x18\x02 \x01(\tR\flocationName\x12#\n" +
+	"\rlocation_type\x18\x03 \x01(\tR\flocationType\"a\n" +
+	"\x15CreateLocationRequest\x12#\n" +
+	"\rlocation_name\x18\x01 \x01(\tR\flocationName\x12#\n" +
+	"\rlocation_type\x18\x02 \x01(\tR\flocationType\"5\n" +
+	"\x12GetLocationRequest\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\tR\n" +
+	"locationId\"\x16\n" +
+	"\x14ListLocationsRequest\"J\n" +
+	"\x15ListLocationsResponse\x121\n" +
+	"\tlocations\x18\x01 \x03(\v2\x13.inventory.LocationR\tlocations\"\xf3\x01\n" +
+	"\fTransferItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12+\n" +
+	"\x11quantity_received\x18\x03 \x01(\x05R\x10quantityReceived\x121\n" +
+	"\x14quantity_discrepancy\x18\x04 \x01(\x05R\x13quantityDiscrepancy\x12\x1d\n" +
+	"\n" +
+	"in_transit\x18\x05 \x01(\x05R\tinTransit\x12)\n" +
+	"\x10discrepancy_note\x18\x06 \x01(\tR\x0fdiscrepancyNote\"\xf5\x02\n" +
+	"\rStockTransfer\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\tR\n" +
+	"transferId\x12,\n" +
+	"\x12source_location_id\x18\x02 \x01(\tR\x10sourceLocationId\x126\n" +
+	"\x17destination_location_id\x18\x03 \x01(\tR\x15destinationLocationId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x12\n" +
+	"\x04note\x18\x05 \x01(\tR\x04note\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x06 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12#\n" +
+	"\rdispatched_at\x18\b \x01(\tR\fdispatchedAt\x12\x1f\n" +
+	"\vreceived_at\x18\t \x01(\tR\n" +
+	"receivedAt\x12-\n" +
+	"\x05items\x18\n" +
+	" \x03(\v2\x17.inventory.TransferItemR\x05items\"\xd1\x01\n" +
+	"\x15CreateTransferRequest\x12,\n" +
+	"\x12source_location_id\x18\x01 \x01(\tR\x10sourceLocationId\x126\n" +
+	"\x17destination_location_id\x18\x02 \x01(\tR\x15destinationLocationId\x12%\n" +
+	"\x05items\x18\x03 \x03(\v2\x0f.inventory.ItemR\x05items\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04note\x18\x05 \x01(\tR\x04note\"S\n" +
+	"\x17DispatchTransferRequest\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\tR\n" +
+	"transferId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xbf\x01\n" +
+	"\x13TransferReceiptItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12+\n" +
+	"\x11quantity_received\x18\x02 \x01(\x05R\x10quantityReceived\x121\n" +
+	"\x14quantity_discrepancy\x18\x03 \x01(\x05R\x13quantityDiscrepancy\x12)\n" +
+	"\x10discrepancy_note\x18\x04 \x01(\tR\x0fdiscrepancyNote\"\xa9\x01\n" +
+	"\x16ReceiveTransferRequest\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\tR\n" +
+	"transferId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x124\n" +
+	"\x05items\x18\x03 \x03(\v2\x1e.inventory.TransferReceiptItemR\x05items\x12\x1f\n" +
+	"\vreceipt_key\x18\x04 \x01(\tR\n" +
+	"receiptKey\"Q\n" +
+	"\x15CancelTransferRequest\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\tR\n" +
+	"transferId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"5\n" +
+	"\x12GetTransferRequest\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\tR\n" +
+	"transferId\"O\n" +
+	"\x14ListTransfersRequest\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\tR\n" +
+	"locationId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"O\n" +
+	"\x15ListTransfersResponse\x126\n" +
+	"\ttransfers\x18\x01 \x03(\v2\x18.inventory.StockTransferR\ttransfers\"\xde\x01\n" +
+	"\bStockLot\x12\x15\n" +
+	"\x06lot_id\x18\x01 \x01(\tR\x05lotId\x12\x1f\n" +
+	"\vlocation_id\x18\x02 \x01(\tR\n" +
+	"locationId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x12\x1d\n" +
+	"\n" +
+	"lot_number\x18\x04 \x01(\tR\tlotNumber\x12\x1f\n" +
+	"\vreceived_at\x18\x05 \x01(\tR\n" +
+	"receivedAt\x12\x1f\n" +
+	"\vexpiry_date\x18\x06 \x01(\tR\n" +
+	"expiryDate\x12\x1a\n" +
+	"\bquantity\x18\a \x01(\x05R\bquantity\"[\n" +
+	"\x17ListExpiringLotsRequest\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\tR\n" +
+	"locationId\x12\x1f\n" +
+	"\vwithin_days\x18\x02 \x01(\x05R\n" +
+	"withinDays\";\n" +
+	"\x10ListLotsResponse\x12'\n" +
+	"\x04lots\x18\x01 \x03(\v2\x13.inventory.StockLotR\x04lots\"\x87\x01\n" +
+	"\x1aWriteOffExpiredLotsRequest\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\tR\n" +
+	"locationId\x12\x17\n" +
+	"\alot_ids\x18\x02 \x03(\tR\x06lotIds\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"z\n" +
+	"\x1bWriteOffExpiredLotsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12'\n" +
+	"\x04lots\x18\x03 \x03(\v2\x13.inventory.StockLotR\x04lots2\xed\r\n" +
 	"\x10InventoryService\x12C\n" +
 	"\bGetStock\x12\x1a.inventory.GetStockRequest\x1a\x1b.inventory.GetStockResponse\x12R\n" +
 	"\rDecreaseStock\x12\x1f.inventory.DecreaseStockRequest\x1a .inventory.DecreaseStockResponse\x12L\n" +
-	"\vAdjustStock\x12\x1d.inventory.AdjustStockRequest\x1a\x1e.inventory.AdjustStockResponse\x12R\n" +
-	"\rGetBatchStock\x12\x1f.inventory.GetBatchStockRequest\x1a .inventory.GetBatchStockResponseB\x06Z\x04./pbb\x06proto3"
+	"\vAdjustStock\x12\x1d.inventory.AdjustStockRequest\x1a\x1e.inventory.AdjustStockResponse\x12[\n" +
+	"\x10BatchAdjustStock\x12\".inventory.BatchAdjustStockRequest\x1a#.inventory.BatchAdjustStockResponse\x12R\n" +
+	"\rGetBatchStock\x12\x1f.inventory.GetBatchStockRequest\x1a .inventory.GetBatchStockResponse\x12O\n" +
+	"\fRestoreStock\x12\x1e.inventory.RestoreStockRequest\x1a\x1f.inventory.RestoreStockResponse\x12g\n" +
+	"\x14CancelStockOperation\x12&.inventory.CancelStockOperationRequest\x1a'.inventory.CancelStockOperationResponse\x12O\n" +
+	"\fReserveStock\x12\x1e.inventory.ReserveStockRequest\x1a\x1f.inventory.ReserveStockResponse\x12^\n" +
+	"\x11CommitReservation\x12#.inventory.CommitReservationRequest\x1a$.inventory.CommitReservationResponse\x12a\n" +
+	"\x12ReleaseReservation\x12$.inventory.ReleaseReservationRequest\x1a%.inventory.ReleaseReservationResponse\x12G\n" +
+	"\x0eCreateLocation\x12 .inventory.CreateLocationRequest\x1a\x13.inventory.Location\x12A\n" +
+	"\vGetLocation\x12\x1d.inventory.GetLocationRequest\x1a\x13.inventory.Location\x12R\n" +
+	"\rListLocations\x12\x1f.inventory.ListLocationsRequest\x1a .inventory.ListLocationsResponse\x12L\n" +
+	"\x0eCreateTransfer\x12 .inventory.CreateTransferRequest\x1a\x18.inventory.StockTransfer\x12P\n" +
+	"\x10DispatchTransfer\x12\".inventory.DispatchTransferRequest\x1a\x18.inventory.StockTransfer\x12N\n" +
+	"\x0fReceiveTransfer\x12!.inventory.ReceiveTransferRequest\x1a\x18.inventory.StockTransfer\x12L\n" +
+	"\x0eCancelTransfer\x12 .inventory.CancelTransferRequest\x1a\x18.inventory.StockTransfer\x12F\n" +
+	"\vGetTransfer\x12\x1d.inventory.GetTransferRequest\x1a\x18.inventory.StockTransfer\x12R\n" +
+	"\rListTransfers\x12\x1f.inventory.ListTransfersRequest\x1a .inventory.ListTransfersResponse\x12S\n" +
+	"\x10ListExpiringLots\x12\".inventory.ListExpiringLotsRequest\x1a\x1b.inventory.ListLotsResponse\x12d\n" +
+	"\x13WriteOffExpiredLots\x12%.inventory.WriteOffExpiredLotsRequest\x1a&.inventory.WriteOffExpiredLotsResponseB\x06Z\x04./pbb\x06proto3"
 
 var (
 	file_inventory_proto_rawDescOnce sync.Once
@@ -608,35 +3040,114 @@ func file_inventory_proto_rawDescGZIP() []byte {
 	return file_inventory_proto_rawDescData
 }
 
-var file_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
 var file_inventory_proto_goTypes = []any{
-	(*GetStockRequest)(nil),       // 0: inventory.GetStockRequest
-	(*GetStockResponse)(nil),      // 1: inventory.GetStockResponse
-	(*Item)(nil),                  // 2: inventory.Item
-	(*DecreaseStockRequest)(nil),  // 3: inventory.DecreaseStockRequest
-	(*DecreaseStockResponse)(nil), // 4: inventory.DecreaseStockResponse
-	(*AdjustStockRequest)(nil),    // 5: inventory.AdjustStockRequest
-	(*AdjustStockResponse)(nil),   // 6: inventory.AdjustStockResponse
-	(*GetBatchStockRequest)(nil),  // 7: inventory.GetBatchStockRequest
-	(*BatchStockItem)(nil),        // 8: inventory.BatchStockItem
-	(*GetBatchStockResponse)(nil), // 9: inventory.GetBatchStockResponse
+	(*GetStockRequest)(nil),              // 0: inventory.GetStockRequest
+	(*GetStockResponse)(nil),             // 1: inventory.GetStockResponse
+	(*Item)(nil),                         // 2: inventory.Item
+	(*DecreaseStockRequest)(nil),         // 3: inventory.DecreaseStockRequest
+	(*DecreaseStockResponse)(nil),        // 4: inventory.DecreaseStockResponse
+	(*AdjustStockRequest)(nil),           // 5: inventory.AdjustStockRequest
+	(*AdjustStockResponse)(nil),          // 6: inventory.AdjustStockResponse
+	(*StockAdjustment)(nil),              // 7: inventory.StockAdjustment
+	(*BatchAdjustStockRequest)(nil),      // 8: inventory.BatchAdjustStockRequest
+	(*AdjustedStock)(nil),                // 9: inventory.AdjustedStock
+	(*BatchAdjustStockResponse)(nil),     // 10: inventory.BatchAdjustStockResponse
+	(*GetBatchStockRequest)(nil),         // 11: inventory.GetBatchStockRequest
+	(*BatchStockItem)(nil),               // 12: inventory.BatchStockItem
+	(*GetBatchStockResponse)(nil),        // 13: inventory.GetBatchStockResponse
+	(*RestoreStockRequest)(nil),          // 14: inventory.RestoreStockRequest
+	(*RestoreStockResponse)(nil),         // 15: inventory.RestoreStockResponse
+	(*CancelStockOperationRequest)(nil),  // 16: inventory.CancelStockOperationRequest
+	(*CancelStockOperationResponse)(nil), // 17: inventory.CancelStockOperationResponse
+	(*ReserveStockRequest)(nil),          // 18: inventory.ReserveStockRequest
+	(*ReserveStockResponse)(nil),         // 19: inventory.ReserveStockResponse
+	(*CommitReservationRequest)(nil),     // 20: inventory.CommitReservationRequest
+	(*CommitReservationResponse)(nil),    // 21: inventory.CommitReservationResponse
+	(*ReleaseReservationRequest)(nil),    // 22: inventory.ReleaseReservationRequest
+	(*ReleaseReservationResponse)(nil),   // 23: inventory.ReleaseReservationResponse
+	(*Location)(nil),                     // 24: inventory.Location
+	(*CreateLocationRequest)(nil),        // 25: inventory.CreateLocationRequest
+	(*GetLocationRequest)(nil),           // 26: inventory.GetLocationRequest
+	(*ListLocationsRequest)(nil),         // 27: inventory.ListLocationsRequest
+	(*ListLocationsResponse)(nil),        // 28: inventory.ListLocationsResponse
+	(*TransferItem)(nil),                 // 29: inventory.TransferItem
+	(*StockTransfer)(nil),                // 30: inventory.StockTransfer
+	(*CreateTransferRequest)(nil),        // 31: inventory.CreateTransferRequest
+	(*DispatchTransferRequest)(nil),      // 32: inventory.DispatchTransferRequest
+	(*TransferReceiptItem)(nil),          // 33: inventory.TransferReceiptItem
+	(*ReceiveTransferRequest)(nil),       // 34: inventory.ReceiveTransferRequest
+	(*CancelTransferRequest)(nil),        // 35: inventory.CancelTransferRequest
+	(*GetTransferRequest)(nil),           // 36: inventory.GetTransferRequest
+	(*ListTransfersRequest)(nil),         // 37: inventory.ListTransfersRequest
+	(*ListTransfersResponse)(nil),        // 38: inventory.ListTransfersResponse
+	(*StockLot)(nil),                     // 39: inventory.StockLot
+	(*ListExpiringLotsRequest)(nil),      // 40: inventory.ListExpiringLotsRequest
+	(*ListLotsResponse)(nil),             // 41: inventory.ListLotsResponse
+	(*WriteOffExpiredLotsRequest)(nil),   // 42: inventory.WriteOffExpiredLotsRequest
+	(*WriteOffExpiredLotsResponse)(nil),  // 43: inventory.WriteOffExpiredLotsResponse
 }
 var file_inventory_proto_depIdxs = []int32{
-	2, // 0: inventory.DecreaseStockRequest.items:type_name -> inventory.Item
-	8, // 1: inventory.GetBatchStockResponse.items:type_name -> inventory.BatchStockItem
-	0, // 2: inventory.InventoryService.GetStock:input_type -> inventory.GetStockRequest
-	3, // 3: inventory.InventoryService.DecreaseStock:input_type -> inventory.DecreaseStockRequest
-	5, // 4: inventory.InventoryService.AdjustStock:input_type -> inventory.AdjustStockRequest
-	7, // 5: inventory.InventoryService.GetBatchStock:input_type -> inventory.GetBatchStockRequest
-	1, // 6: inventory.InventoryService.GetStock:output_type -> inventory.GetStockResponse
-	4, // 7: inventory.InventoryService.DecreaseStock:output_type -> inventory.DecreaseStockResponse
-	6, // 8: inventory.InventoryService.AdjustStock:output_type -> inventory.AdjustStockResponse
-	9, // 9: inventory.InventoryService.GetBatchStock:output_type -> inventory.GetBatchStockResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	2,  // 0: inventory.DecreaseStockRequest.items:type_name -> inventory.Item
+	7,  // 1: inventory.BatchAdjustStockRequest.adjustments:type_name -> inventory.StockAdjustment
+	9,  // 2: inventory.BatchAdjustStockResponse.items:type_name -> inventory.AdjustedStock
+	12, // 3: inventory.GetBatchStockResponse.items:type_name -> inventory.BatchStockItem
+	2,  // 4: inventory.RestoreStockRequest.items:type_name -> inventory.Item
+	2,  // 5: inventory.ReserveStockRequest.items:type_name -> inventory.Item
+	24, // 6: inventory.ListLocationsResponse.locations:type_name -> inventory.Location
+	29, // 7: inventory.StockTransfer.items:type_name -> inventory.TransferItem
+	2,  // 8: inventory.CreateTransferRequest.items:type_name -> inventory.Item
+	33, // 9: inventory.ReceiveTransferRequest.items:type_name -> inventory.TransferReceiptItem
+	30, // 10: inventory.ListTransfersResponse.transfers:type_name -> inventory.StockTransfer
+	39, // 11: inventory.ListLotsResponse.lots:type_name -> inventory.StockLot
+	39, // 12: inventory.WriteOffExpiredLotsResponse.lots:type_name -> inventory.StockLot
+	0,  // 13: inventory.InventoryService.GetStock:input_type -> inventory.GetStockRequest
+	3,  // 14: inventory.InventoryService.DecreaseStock:input_type -> inventory.DecreaseStockRequest
+	5,  // 15: inventory.InventoryService.AdjustStock:input_type -> inventory.AdjustStockRequest
+	8,  // 16: inventory.InventoryService.BatchAdjustStock:input_type -> inventory.BatchAdjustStockRequest
+	11, // 17: inventory.InventoryService.GetBatchStock:input_type -> inventory.GetBatchStockRequest
+	14, // 18: inventory.InventoryService.RestoreStock:input_type -> inventory.RestoreStockRequest
+	16, // 19: inventory.InventoryService.CancelStockOperation:input_type -> inventory.CancelStockOperationRequest
+	18, // 20: inventory.InventoryService.ReserveStock:input_type -> inventory.ReserveStockRequest
+	20, // 21: inventory.InventoryService.CommitReservation:input_type -> inventory.CommitReservationRequest
+	22, // 22: inventory.InventoryService.ReleaseReservation:input_type -> inventory.ReleaseReservationRequest
+	25, // 23: inventory.InventoryService.CreateLocation:input_type -> inventory.CreateLocationRequest
+	26, // 24: inventory.InventoryService.GetLocation:input_type -> inventory.GetLocationRequest
+	27, // 25: inventory.InventoryService.ListLocations:input_type -> inventory.ListLocationsRequest
+	31, // 26: inventory.InventoryService.CreateTransfer:input_type -> inventory.CreateTransferRequest
+	32, // 27: inventory.InventoryService.DispatchTransfer:input_type -> inventory.DispatchTransferRequest
+	34, // 28: inventory.InventoryService.ReceiveTransfer:input_type -> inventory.ReceiveTransferRequest
+	35, // 29: inventory.InventoryService.CancelTransfer:input_type -> inventory.CancelTransferRequest
+	36, // 30: inventory.InventoryService.GetTransfer:input_type -> inventory.GetTransferRequest
+	37, // 31: inventory.InventoryService.ListTransfers:input_type -> inventory.ListTransfersRequest
+	40, // 32: inventory.InventoryService.ListExpiringLots:input_type -> inventory.ListExpiringLotsRequest
+	42, // 33: inventory.InventoryService.WriteOffExpiredLots:input_type -> inventory.WriteOffExpiredLotsRequest
+	1,  // 34: inventory.InventoryService.GetStock:output_type -> inventory.GetStockResponse
+	4,  // 35: inventory.InventoryService.DecreaseStock:output_type -> inventory.DecreaseStockResponse
+	6,  // 36: inventory.InventoryService.AdjustStock:output_type -> inventory.AdjustStockResponse
+	10, // 37: inventory.InventoryService.BatchAdjustStock:output_type -> inventory.BatchAdjustStockResponse
+	13, // 38: inventory.InventoryService.GetBatchStock:output_type -> inventory.GetBatchStockResponse
+	15, // 39: inventory.InventoryService.RestoreStock:output_type -> inventory.RestoreStockResponse
+	17, // 40: inventory.InventoryService.CancelStockOperation:output_type -> inventory.CancelStockOperationResponse
+	19, // 41: inventory.InventoryService.ReserveStock:output_type -> inventory.ReserveStockResponse
+	21, // 42: inventory.InventoryService.CommitReservation:output_type -> inventory.CommitReservationResponse
+	23, // 43: inventory.InventoryService.ReleaseReservation:output_type -> inventory.ReleaseReservationResponse
+	24, // 44: inventory.InventoryService.CreateLocation:output_type -> inventory.Location
+	24, // 45: inventory.InventoryService.GetLocation:output_type -> inventory.Location
+	28, // 46: inventory.InventoryService.ListLocations:output_type -> inventory.ListLocationsResponse
+	30, // 47: inventory.InventoryService.CreateTransfer:output_type -> inventory.StockTransfer
+	30, // 48: inventory.InventoryService.DispatchTransfer:output_type -> inventory.StockTransfer
+	30, // 49: inventory.InventoryService.ReceiveTransfer:output_type -> inventory.StockTransfer
+	30, // 50: inventory.InventoryService.CancelTransfer:output_type -> inventory.StockTransfer
+	30, // 51: inventory.InventoryService.GetTransfer:output_type -> inventory.StockTransfer
+	38, // 52: inventory.InventoryService.ListTransfers:output_type -> inventory.ListTransfersResponse
+	41, // 53: inventory.InventoryService.ListExpiringLots:output_type -> inventory.ListLotsResponse
+	43, // 54: inventory.InventoryService.WriteOffExpiredLots:output_type -> inventory.WriteOffExpiredLotsResponse
+	34, // [34:55] is the sub-list for method output_type
+	13, // [13:34] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_inventory_proto_init() }
@@ -650,7 +3161,7 @@ func file_inventory_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_inventory_proto_rawDesc), len(file_inventory_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   44,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -9,9 +9,14 @@ import (
 )
 
 type InventoryRepository interface {
-	GetStock(ctx context.Context, tx *sql.Tx, productID ulid.ULID) (int, error)
-	UpdateStock(ctx context.Context, tx *sql.Tx, productID ulid.ULID, quantity int) error
+	GetStock(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, productID ulid.ULID) (int, error)
+	GetStockForUpdate(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, productID ulid.ULID) (int, error)
+	UpdateStock(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, productID ulid.ULID, quantity int) error
 	CreateStock(ctx context.Context, tx *sql.Tx, stock domain.ProductStock) error
 	CreateLog(ctx context.Context, tx *sql.Tx, log domain.InventoryLog) error
-	GetStocksByIDs(ctx context.Context, tx *sql.Tx, productIDs []string) (map[string]int, error)
+	GetStocksByIDs(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, productIDs []string) (map[string]int, error)
+	CreateOperation(ctx context.Context, tx *sql.Tx, operation domain.StockOperation) error
+	FindOperation(ctx context.Context, tx *sql.Tx, operationID string) (domain.StockOperation, error)
+	UpdateOperation(ctx context.Context, tx *sql.Tx, operation domain.StockOperation) error
+	FindLogsByOperation(ctx context.Context, tx *sql.Tx, operationID string) ([]domain.InventoryLog, error)
 }
@@ -3,9 +3,12 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"retail-inventory/exception"
 	"retail-inventory/model/domain"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/oklog/ulid/v2"
 	"github.com/sirupsen/logrus"
 )
@@ -20,12 +23,12 @@ func NewInventoryRepository(logger *logrus.Logger) InventoryRepository {
 	}
 }
 
-func (repository *InventoryRepositoryImpl) GetStock(ctx context.Context, tx *sql.Tx, productID ulid.ULID) (int, error) {
-	SQL := "SELECT quantity FROM Product_Stocks WHERE product_id = ?"
+func (repository *InventoryRepositoryImpl) GetStock(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, productID ulid.ULID) (int, error) {
+	SQL := "SELECT quantity FROM Product_Stocks WHERE location_id = ? AND product_id = ?"
 	var quantity int
 
 	repository.Logger.Info("---executing sql get stock...")
-	err := tx.QueryRowContext(ctx, SQL, productID).Scan(&quantity)
+	err := tx.QueryRowContext(ctx, SQL, locationID, productID).Scan(&quantity)
 	if err != nil {
 		repository.Logger.Errorf("---failed to get stock: %v", err)
 		return 0, err
@@ -34,11 +37,25 @@ func (repository *InventoryRepositoryImpl) GetStock(ctx context.Context, tx *sql
 	return quantity, nil
 }
 
-func (repository *InventoryRepositoryImpl) UpdateStock(ctx context.Context, tx *sql.Tx, productID ulid.ULID, quantity int) error {
-	SQL := "UPDATE Product_Stocks SET quantity = ? WHERE product_id = ?"
+func (repository *InventoryRepositoryImpl) GetStockForUpdate(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, productID ulid.ULID) (int, error) {
+	SQL := "SELECT quantity FROM Product_Stocks WHERE location_id = ? AND product_id = ? FOR UPDATE"
+	var quantity int
+
+	repository.Logger.Info("---executing sql get stock for update...")
+	err := tx.QueryRowContext(ctx, SQL, locationID, productID).Scan(&quantity)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get stock for update: %v", err)
+		return 0, err
+	}
+
+	return quantity, nil
+}
+
+func (repository *InventoryRepositoryImpl) UpdateStock(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, productID ulid.ULID, quantity int) error {
+	SQL := "UPDATE Product_Stocks SET quantity = ? WHERE location_id = ? AND product_id = ?"
 
 	repository.Logger.Info("---executing sql update stock...")
-	_, err := tx.ExecContext(ctx, SQL, quantity, productID)
+	_, err := tx.ExecContext(ctx, SQL, quantity, locationID, productID)
 	if err != nil {
 		repository.Logger.Errorf("---failed to update stock: %v", err)
 		return err
@@ -48,10 +65,10 @@ func (repository *InventoryRepositoryImpl) UpdateStock(ctx context.Context, tx *
 }
 
 func (repository *InventoryRepositoryImpl) CreateStock(ctx context.Context, tx *sql.Tx, stock domain.ProductStock) error {
-	SQL := "INSERT INTO Product_Stocks(product_id, quantity) VALUES (?, ?)"
+	SQL := "INSERT INTO Product_Stocks(location_id, product_id, quantity) VALUES (?, ?, ?)"
 
 	repository.Logger.Info("---executing sql create stock...")
-	_, err := tx.ExecContext(ctx, SQL, stock.ProductID, stock.Quantity)
+	_, err := tx.ExecContext(ctx, SQL, stock.LocationID, stock.ProductID, stock.Quantity)
 	if err != nil {
 		repository.Logger.Errorf("---failed to create stock: %v", err)
 		return err
@@ -61,10 +78,10 @@ func (repository *InventoryRepositoryImpl) CreateStock(ctx context.Context, tx *
 }
 
 func (repository *InventoryRepositoryImpl) CreateLog(ctx context.Context, tx *sql.Tx, log domain.InventoryLog) error {
-	SQL := "INSERT INTO Inventory_Logs(log_id, product_id, user_id, change_quantity, reason, created_at) VALUES (?, ?, ?, ?, ?, ?)"
+	SQL := "INSERT INTO Inventory_Logs(log_id, location_id, product_id, user_id, change_quantity, reason, operation_id, created_at) VALUES (?, ?, ?, ?, ?, ?, NULLIF(?, ''), ?)"
 
 	repository.Logger.Info("---executing sql create log...")
-	_, err := tx.ExecContext(ctx, SQL, log.LogID, log.ProductID, log.UserID, log.ChangeQuantity, log.Reason, log.CreatedAt)
+	_, err := tx.ExecContext(ctx, SQL, log.LogID, log.LocationID, log.ProductID, log.UserID, log.ChangeQuantity, log.Reason, log.OperationID, log.CreatedAt)
 	if err != nil {
 		repository.Logger.Errorf("---failed to create log: %v", err)
 		return err
@@ -73,13 +90,13 @@ func (repository *InventoryRepositoryImpl) CreateLog(ctx context.Context, tx *sq
 	return nil
 }
 
-func (repository *InventoryRepositoryImpl) GetStocksByIDs(ctx context.Context, tx *sql.Tx, productIDs []string) (map[string]int, error) {
+func (repository *InventoryRepositoryImpl) GetStocksByIDs(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, productIDs []string) (map[string]int, error) {
 	if len(productIDs) == 0 {
 		return map[string]int{}, nil
 	}
 
 	placeholders := ""
-	args := []interface{}{}
+	args := []interface{}{locationID}
 	for i, id := range productIDs {
 		if i > 0 {
 			placeholders += ", "
@@ -89,7 +106,7 @@ func (repository *InventoryRepositoryImpl) GetStocksByIDs(ctx context.Context, t
 		args = append(args, pid)
 	}
 
-	query := fmt.Sprintf("SELECT product_id, quantity FROM Product_Stocks WHERE product_id IN (%s)", placeholders)
+	query := fmt.Sprintf("SELECT product_id, quantity FROM Product_Stocks WHERE location_id = ? AND product_id IN (%s)", placeholders)
 
 	repository.Logger.Info("---executing batch get stock...")
 	rows, err := tx.QueryContext(ctx, query, args...)
@@ -113,3 +130,78 @@ func (repository *InventoryRepositoryImpl) GetStocksByIDs(ctx context.Context, t
 
 	return result, nil
 }
+
+func (repository *InventoryRepositoryImpl) CreateOperation(ctx context.Context, tx *sql.Tx, operation domain.StockOperation) error {
+	SQL := "INSERT INTO Stock_Operations(operation_id, operation_type, result_message, created_at) VALUES (?, ?, ?, ?)"
+
+	repository.Logger.Info("---executing sql create operation...")
+	_, err := tx.ExecContext(ctx, SQL, operation.OperationID, operation.OperationType, operation.ResultMessage, operation.CreatedAt)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			repository.Logger.Warnf("---operation already applied: %s", operation.OperationID)
+			return exception.ErrOperationApplied
+		}
+		repository.Logger.Errorf("---failed to create operation: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *InventoryRepositoryImpl) FindOperation(ctx context.Context, tx *sql.Tx, operationID string) (domain.StockOperation, error) {
+	SQL := "SELECT operation_id, operation_type, result_message, created_at FROM Stock_Operations WHERE operation_id = ? FOR UPDATE"
+	var operation domain.StockOperation
+
+	repository.Logger.Info("---executing sql find operation...")
+	err := tx.QueryRowContext(ctx, SQL, operationID).Scan(
+		&operation.OperationID,
+		&operation.OperationType,
+		&operation.ResultMessage,
+		&operation.CreatedAt,
+	)
+	if err != nil {
+		repository.Logger.Errorf("---failed to find operation: %v", err)
+		return domain.StockOperation{}, err
+	}
+
+	return operation, nil
+}
+
+func (repository *InventoryRepositoryImpl) UpdateOperation(ctx context.Context, tx *sql.Tx, operation domain.StockOperation) error {
+	SQL := "UPDATE Stock_Operations SET operation_type = ?, result_message = ? WHERE operation_id = ?"
+
+	repository.Logger.Info("---executing sql update operation...")
+	_, err := tx.ExecContext(ctx, SQL, operation.OperationType, operation.ResultMessage, operation.OperationID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to update operation: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *InventoryRepositoryImpl) FindLogsByOperation(ctx context.Context, tx *sql.Tx, operationID string) ([]domain.InventoryLog, error) {
+	SQL := "SELECT log_id, location_id, product_id, user_id, change_quantity, reason, COALESCE(operation_id, ''), created_at FROM Inventory_Logs WHERE operation_id = ?"
+
+	repository.Logger.Info("---executing sql find logs by operation...")
+	rows, err := tx.QueryContext(ctx, SQL, operationID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to find logs by operation: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []domain.InventoryLog
+	for rows.Next() {
+		var log domain.InventoryLog
+		err := rows.Scan(&log.LogID, &log.LocationID, &log.ProductID, &log.UserID, &log.ChangeQuantity, &log.Reason, &log.OperationID, &log.CreatedAt)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan log: %v", err)
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+
+	return logs, rows.Err()
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-inventory/model/domain"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type LocationRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, location domain.Location) error
+	FindByID(ctx context.Context, tx *sql.Tx, locationID ulid.ULID) (domain.Location, error)
+	FindAll(ctx context.Context, tx *sql.Tx) ([]domain.Location, error)
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"retail-inventory/exception"
+	"retail-inventory/model/domain"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type LocationRepositoryImpl struct {
+	Logger *logrus.Logger
+}
+
+func NewLocationRepository(logger *logrus.Logger) LocationRepository {
+	return &LocationRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+func (repository *LocationRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, location domain.Location) error {
+	SQL := "INSERT INTO Locations(location_id, location_name, location_type, created_at) VALUES (?, ?, ?, ?)"
+
+	repository.Logger.Info("---executing sql create location...")
+	_, err := tx.ExecContext(ctx, SQL, location.LocationID, location.LocationName, location.LocationType, location.CreatedAt)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			repository.Logger.Warnf("---location name already exists: %s", location.LocationName)
+			return exception.ErrLocationExists
+		}
+		repository.Logger.Errorf("---failed to create location: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *LocationRepositoryImpl) FindByID(ctx context.Context, tx *sql.Tx, locationID ulid.ULID) (domain.Location, error) {
+	SQL := "SELECT location_id, location_name, location_type, created_at FROM Locations WHERE location_id = ?"
+	var location domain.Location
+
+	repository.Logger.Info("---executing sql find location...")
+	err := tx.QueryRowContext(ctx, SQL, locationID).Scan(
+		&location.LocationID,
+		&location.LocationName,
+		&location.LocationType,
+		&location.CreatedAt,
+	)
+	if err != nil {
+		repository.Logger.Errorf("---failed to find location: %v", err)
+		return domain.Location{}, err
+	}
+
+	return location, nil
+}
+
+func (repository *LocationRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) ([]domain.Location, error) {
+	SQL := "SELECT location_id, location_name, location_type, created_at FROM Locations ORDER BY location_name"
+
+	repository.Logger.Info("---executing sql find all locations...")
+	rows, err := tx.QueryContext(ctx, SQL)
+	if err != nil {
+		repository.Logger.Errorf("---failed to find locations: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	locations := make([]domain.Location, 0)
+	for rows.Next() {
+		var location domain.Location
+		err := rows.Scan(&location.LocationID, &location.LocationName, &location.LocationType, &location.CreatedAt)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan location: %v", err)
+			return nil, err
+		}
+		locations = append(locations, location)
+	}
+
+	return locations, rows.Err()
+}
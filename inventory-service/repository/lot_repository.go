@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-inventory/model/domain"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type LotRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, lot domain.StockLot) error
+	FindAvailableForUpdate(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, productID ulid.ULID, today time.Time) ([]domain.StockLot, error)
+	FindByIDForUpdate(ctx context.Context, tx *sql.Tx, lotID ulid.ULID) (domain.StockLot, error)
+	FindExpiring(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, before time.Time) ([]domain.StockLot, error)
+	FindExpiredForUpdate(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, today time.Time) ([]domain.StockLot, error)
+	UpdateQuantity(ctx context.Context, tx *sql.Tx, lotID ulid.ULID, quantity int) error
+	AddQuantity(ctx context.Context, tx *sql.Tx, lotID ulid.ULID, changeQuantity int) error
+	CreateMovement(ctx context.Context, tx *sql.Tx, movement domain.StockLotMovement) error
+	FindConsumedByOperation(ctx context.Context, tx *sql.Tx, operationID string, productID ulid.ULID) ([]domain.StockLot, error)
+	FindReceivedByOperation(ctx context.Context, tx *sql.Tx, operationID string, locationID ulid.ULID, productID ulid.ULID) ([]domain.StockLotMovement, error)
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-inventory/model/domain"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type LotRepositoryImpl struct {
+	Logger *logrus.Logger
+}
+
+func NewLotRepository(logger *logrus.Logger) LotRepository {
+	return &LotRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+const lotColumns = "SELECT lot_id, location_id, product_id, lot_number, received_at, expiry_date, quantity FROM Stock_Lots"
+
+func (repository *LotRepositoryImpl) Create(ctx context.Context, tx *sql.Tx, lot domain.StockLot) error {
+	SQL := "INSERT INTO Stock_Lots(lot_id, location_id, product_id, lot_number, received_at, expiry_date, quantity) VALUES (?, ?, ?, ?, ?, ?, ?)"
+
+	var expiryDate interface{}
+	if lot.ExpiryDate != nil {
+		expiryDate = lot.ExpiryDate.Format(domain.ExpiryDateLayout)
+	}
+
+	repository.Logger.Info("---executing sql create lot...")
+	_, err := tx.ExecContext(ctx, SQL, lot.LotID, lot.LocationID, lot.ProductID, lot.LotNumber, lot.ReceivedAt, expiryDate, lot.Quantity)
+	if err != nil {
+		repository.Logger.Errorf("---failed to create lot: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *LotRepositoryImpl) FindAvailableForUpdate(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, productID ulid.ULID, today time.Time) ([]domain.StockLot, error) {
+	SQL := lotColumns + " WHERE location_id = ? AND product_id = ? AND quantity > 0 AND (expiry_date IS NULL OR expiry_date >= ?) ORDER BY expiry_date IS NULL, expiry_date, received_at FOR UPDATE"
+
+	repository.Logger.Info("---executing sql find available lots...")
+	return repository.queryLots(ctx, tx, SQL, locationID, productID, today.Format(domain.ExpiryDateLayout))
+}
+
+func (repository *LotRepositoryImpl) FindByIDForUpdate(ctx context.Context, tx *sql.Tx, lotID ulid.ULID) (domain.StockLot, error) {
+	SQL := lotColumns + " WHERE lot_id = ? FOR UPDATE"
+
+	repository.Logger.Info("---executing sql find lot by id...")
+	lots, err := repository.queryLots(ctx, tx, SQL, lotID)
+	if err != nil {
+		return domain.StockLot{}, err
+	}
+	if len(lots) == 0 {
+		repository.Logger.Warn("---lot not found")
+		return domain.StockLot{}, sql.ErrNoRows
+	}
+
+	return lots[0], nil
+}
+
+func (repository *LotRepositoryImpl) FindExpiring(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, before time.Time) ([]domain.StockLot, error) {
+	SQL := lotColumns + " WHERE location_id = ? AND quantity > 0 AND expiry_date <= ? ORDER BY expiry_date, received_at"
+
+	repository.Logger.Info("---executing sql find expiring lots...")
+	return repository.queryLots(ctx, tx, SQL, locationID, before.Format(domain.ExpiryDateLayout))
+}
+
+func (repository *LotRepositoryImpl) FindExpiredForUpdate(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, today time.Time) ([]domain.StockLot, error) {
+	SQL := lotColumns + " WHERE location_id = ? AND quantity > 0 AND expiry_date < ? ORDER BY expiry_date, received_at FOR UPDATE"
+
+	repository.Logger.Info("---executing sql find expired lots...")
+	return repository.queryLots(ctx, tx, SQL, locationID, today.Format(domain.ExpiryDateLayout))
+}
+
+func (repository *LotRepositoryImpl) UpdateQuantity(ctx context.Context, tx *sql.Tx, lotID ulid.ULID, quantity int) error {
+	SQL := "UPDATE Stock_Lots SET quantity = ? WHERE lot_id = ?"
+
+	repository.Logger.Info("---executing sql update lot quantity...")
+	_, err := tx.ExecContext(ctx, SQL, quantity, lotID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to update lot quantity: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *LotRepositoryImpl) AddQuantity(ctx context.Context, tx *sql.Tx, lotID ulid.ULID, changeQuantity int) error {
+	SQL := "UPDATE Stock_Lots SET quantity = quantity + ? WHERE lot_id = ?"
+
+	repository.Logger.Info("---executing sql add lot quantity...")
+	_, err := tx.ExecContext(ctx, SQL, changeQuantity, lotID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to add lot quantity: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *LotRepositoryImpl) CreateMovement(ctx context.Context, tx *sql.Tx, movement domain.StockLotMovement) error {
+	SQL := "INSERT INTO Stock_Lot_Movements(movement_id, lot_id, operation_id, source_operation_id, source_lot_id, change_quantity, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)"
+
+	var operationID, sourceOperationID, sourceLotID interface{}
+	if movement.OperationID != "" {
+		operationID = movement.OperationID
+	}
+	if movement.SourceOperationID != "" {
+		sourceOperationID = movement.SourceOperationID
+	}
+	if movement.SourceLotID != nil {
+		sourceLotID = *movement.SourceLotID
+	}
+
+	repository.Logger.Info("---executing sql create lot movement...")
+	_, err := tx.ExecContext(ctx, SQL, movement.MovementID, movement.LotID, operationID, sourceOperationID, sourceLotID, movement.ChangeQuantity, movement.CreatedAt)
+	if err != nil {
+		repository.Logger.Errorf("---failed to create lot movement: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *LotRepositoryImpl) FindConsumedByOperation(ctx context.Context, tx *sql.Tx, operationID string, productID ulid.ULID) ([]domain.StockLot, error) {
+	SQL := `
+        SELECT l.lot_id, l.location_id, l.product_id, l.lot_number, l.received_at, l.expiry_date, -SUM(m.change_quantity) as quantity
+        FROM Stock_Lot_Movements m
+        JOIN Stock_Lots l ON COALESCE(m.source_lot_id, m.lot_id) = l.lot_id
+        WHERE l.product_id = ? AND (m.operation_id = ? OR (m.source_operation_id = ? AND (m.operation_id IS NULL OR m.operation_id <> ?)))
+        GROUP BY l.lot_id
+        HAVING quantity > 0
+        ORDER BY l.expiry_date IS NULL, l.expiry_date, l.received_at
+    `
+
+	repository.Logger.Info("---executing sql find lots consumed by operation...")
+	return repository.queryLots(ctx, tx, SQL, productID, operationID, operationID, operationID)
+}
+
+func (repository *LotRepositoryImpl) FindReceivedByOperation(ctx context.Context, tx *sql.Tx, operationID string, locationID ulid.ULID, productID ulid.ULID) ([]domain.StockLotMovement, error) {
+	SQL := `
+        SELECT l.lot_id, COALESCE(m.source_operation_id, ''), m.source_lot_id, LEAST(SUM(m.change_quantity), l.quantity) as quantity
+        FROM Stock_Lot_Movements m
+        JOIN Stock_Lots l ON m.lot_id = l.lot_id
+        WHERE l.location_id = ? AND l.product_id = ? AND m.operation_id = ?
+        GROUP BY l.lot_id, m.source_operation_id, m.source_lot_id
+        HAVING quantity > 0
+        ORDER BY l.received_at
+    `
+
+	repository.Logger.Info("---executing sql find lots received by operation...")
+	rows, err := tx.QueryContext(ctx, SQL, locationID, productID, operationID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to find lots received by operation: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var movements []domain.StockLotMovement
+	for rows.Next() {
+		var movement domain.StockLotMovement
+		var sourceLotBinary []byte
+		err := rows.Scan(&movement.LotID, &movement.SourceOperationID, &sourceLotBinary, &movement.ChangeQuantity)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan lot movement: %v", err)
+			return nil, err
+		}
+		if sourceLotBinary != nil {
+			var sourceLotID ulid.ULID
+			copy(sourceLotID[:], sourceLotBinary)
+			movement.SourceLotID = &sourceLotID
+		}
+		movement.OperationID = operationID
+		movements = append(movements, movement)
+	}
+
+	return movements, rows.Err()
+}
+
+func (repository *LotRepositoryImpl) queryLots(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]domain.StockLot, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to query lots: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lots []domain.StockLot
+	for rows.Next() {
+		var lot domain.StockLot
+		err := rows.Scan(&lot.LotID, &lot.LocationID, &lot.ProductID, &lot.LotNumber, &lot.ReceivedAt, &lot.ExpiryDate, &lot.Quantity)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan lot: %v", err)
+			return nil, err
+		}
+		lots = append(lots, lot)
+	}
+
+	return lots, rows.Err()
+}
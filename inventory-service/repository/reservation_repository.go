@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-inventory/model/domain"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type ReservationRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, reservation domain.StockReservation) error
+	CreateItems(ctx context.Context, tx *sql.Tx, items []domain.StockReservationItem) error
+	FindByIDForUpdate(ctx context.Context, tx *sql.Tx, reservationID ulid.ULID) (domain.StockReservation, error)
+	FindItems(ctx context.Context, tx *sql.Tx, reservationID ulid.ULID) ([]domain.StockReservationItem, error)
+	UpdateStatus(ctx context.Context, tx *sql.Tx, reservationID ulid.ULID, status string) error
+	GetReservedQuantity(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, productID ulid.ULID, at time.Time) (int, error)
+	GetReservedByIDs(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, productIDs []string, at time.Time) (map[string]int, error)
+}
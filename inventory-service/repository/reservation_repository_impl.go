@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"retail-inventory/model/domain"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type ReservationRepositoryImpl struct {
+	Logger *logrus.Logger
+}
+
+func NewReservationRepository(logger *logrus.Logger) ReservationRepository {
+	return &ReservationRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+func (repository *ReservationRepositoryImpl) Create(ctx context.Context, tx *sql.Tx, reservation domain.StockReservation) error {
+	SQL := "INSERT INTO Stock_Reservations(reservation_id, reference, location_id, user_id, status, expires_at, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)"
+
+	repository.Logger.Info("---executing sql create reservation...")
+	_, err := tx.ExecContext(ctx, SQL, reservation.ReservationID, reservation.Reference, reservation.LocationID, reservation.UserID, reservation.Status, reservation.ExpiresAt, reservation.CreatedAt)
+	if err != nil {
+		repository.Logger.Errorf("---failed to create reservation: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *ReservationRepositoryImpl) CreateItems(ctx context.Context, tx *sql.Tx, items []domain.StockReservationItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	SQL := "INSERT INTO Stock_Reservation_Items(reservation_id, product_id, quantity) VALUES "
+
+	var args []interface{}
+	for _, item := range items {
+		SQL += "(?, ?, ?),"
+		args = append(args, item.ReservationID, item.ProductID, item.Quantity)
+	}
+
+	SQL = SQL[0 : len(SQL)-1]
+
+	repository.Logger.Info("---executing sql create reservation items...")
+	_, err := tx.ExecContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to create reservation items: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *ReservationRepositoryImpl) FindByIDForUpdate(ctx context.Context, tx *sql.Tx, reservationID ulid.ULID) (domain.StockReservation, error) {
+	SQL := "SELECT reservation_id, reference, location_id, user_id, status, expires_at, created_at FROM Stock_Reservations WHERE reservation_id = ? FOR UPDATE"
+	var reservation domain.StockReservation
+
+	repository.Logger.Info("---executing sql find reservation...")
+	err := tx.QueryRowContext(ctx, SQL, reservationID).Scan(
+		&reservation.ReservationID,
+		&reservation.Reference,
+		&reservation.LocationID,
+		&reservation.UserID,
+		&reservation.Status,
+		&reservation.ExpiresAt,
+		&reservation.CreatedAt,
+	)
+	if err != nil {
+		repository.Logger.Errorf("---failed to find reservation: %v", err)
+		return domain.StockReservation{}, err
+	}
+
+	return reservation, nil
+}
+
+func (repository *ReservationRepositoryImpl) FindItems(ctx context.Context, tx *sql.Tx, reservationID ulid.ULID) ([]domain.StockReservationItem, error) {
+	SQL := "SELECT reservation_id, product_id, quantity FROM Stock_Reservation_Items WHERE reservation_id = ?"
+
+	repository.Logger.Info("---executing sql find reservation items...")
+	rows, err := tx.QueryContext(ctx, SQL, reservationID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to find reservation items: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []domain.StockReservationItem
+	for rows.Next() {
+		var item domain.StockReservationItem
+		if err := rows.Scan(&item.ReservationID, &item.ProductID, &item.Quantity); err != nil {
+			repository.Logger.Errorf("---failed to scan reservation item: %v", err)
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (repository *ReservationRepositoryImpl) UpdateStatus(ctx context.Context, tx *sql.Tx, reservationID ulid.ULID, status string) error {
+	SQL := "UPDATE Stock_Reservations SET status = ? WHERE reservation_id = ?"
+
+	repository.Logger.Info("---executing sql update reservation status...")
+	_, err := tx.ExecContext(ctx, SQL, status, reservationID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to update reservation status: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *ReservationRepositoryImpl) GetReservedQuantity(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, productID ulid.ULID, at time.Time) (int, error) {
+	SQL := `
+        SELECT COALESCE(SUM(i.quantity), 0)
+        FROM Stock_Reservation_Items i
+        JOIN Stock_Reservations r ON r.reservation_id = i.reservation_id
+        WHERE r.location_id = ? AND i.product_id = ? AND r.status = ? AND r.expires_at > ?
+    `
+	var reserved int
+
+	repository.Logger.Info("---executing sql get reserved quantity...")
+	err := tx.QueryRowContext(ctx, SQL, locationID, productID, domain.ReservationStatusActive, at).Scan(&reserved)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get reserved quantity: %v", err)
+		return 0, err
+	}
+
+	return reserved, nil
+}
+
+func (repository *ReservationRepositoryImpl) GetReservedByIDs(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, productIDs []string, at time.Time) (map[string]int, error) {
+	if len(productIDs) == 0 {
+		return map[string]int{}, nil
+	}
+
+	placeholders := ""
+	args := []interface{}{locationID, domain.ReservationStatusActive, at}
+	for i, id := range productIDs {
+		if i > 0 {
+			placeholders += ", "
+		}
+		placeholders += "?"
+		pid, _ := ulid.Parse(id)
+		args = append(args, pid)
+	}
+
+	query := fmt.Sprintf(`
+        SELECT i.product_id, SUM(i.quantity)
+        FROM Stock_Reservation_Items i
+        JOIN Stock_Reservations r ON r.reservation_id = i.reservation_id
+        WHERE r.location_id = ? AND r.status = ? AND r.expires_at > ? AND i.product_id IN (%s)
+        GROUP BY i.product_id
+    `, placeholders)
+
+	repository.Logger.Info("---executing batch get reserved quantity...")
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]int)
+	for rows.Next() {
+		var pid ulid.ULID
+		var reserved int
+		if err := rows.Scan(&pid, &reserved); err != nil {
+			return nil, err
+		}
+		result[pid.String()] = reserved
+	}
+
+	return result, nil
+}
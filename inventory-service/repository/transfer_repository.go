@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-inventory/model/domain"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type TransferRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, transfer domain.StockTransfer) error
+	CreateItems(ctx context.Context, tx *sql.Tx, items []domain.StockTransferItem) error
+	FindByID(ctx context.Context, tx *sql.Tx, transferID ulid.ULID) (domain.StockTransfer, error)
+	FindByIDForUpdate(ctx context.Context, tx *sql.Tx, transferID ulid.ULID) (domain.StockTransfer, error)
+	FindAll(ctx context.Context, tx *sql.Tx, locationID *ulid.ULID, status string) ([]domain.StockTransfer, error)
+	FindItems(ctx context.Context, tx *sql.Tx, transferID ulid.ULID) ([]domain.StockTransferItem, error)
+	UpdateStatus(ctx context.Context, tx *sql.Tx, transfer domain.StockTransfer) error
+	UpdateItem(ctx context.Context, tx *sql.Tx, item domain.StockTransferItem) error
+}
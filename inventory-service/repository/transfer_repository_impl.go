@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-inventory/model/domain"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type TransferRepositoryImpl struct {
+	Logger *logrus.Logger
+}
+
+func NewTransferRepository(logger *logrus.Logger) TransferRepository {
+	return &TransferRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+func (repository *TransferRepositoryImpl) Create(ctx context.Context, tx *sql.Tx, transfer domain.StockTransfer) error {
+	SQL := "INSERT INTO Stock_Transfers(transfer_id, source_location_id, destination_location_id, status, note, created_by, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)"
+
+	repository.Logger.Info("---executing sql create transfer...")
+	_, err := tx.ExecContext(ctx, SQL, transfer.TransferID, transfer.SourceLocationID, transfer.DestinationLocationID, transfer.Status, transfer.Note, transfer.CreatedBy, transfer.CreatedAt)
+	if err != nil {
+		repository.Logger.Errorf("---failed to create transfer: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *TransferRepositoryImpl) CreateItems(ctx context.Context, tx *sql.Tx, items []domain.StockTransferItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	SQL := "INSERT INTO Stock_Transfer_Items(transfer_id, product_id, quantity) VALUES "
+
+	var args []interface{}
+	for _, item := range items {
+		SQL += "(?, ?, ?),"
+		args = append(args, item.TransferID, item.ProductID, item.Quantity)
+	}
+
+	SQL = SQL[0 : len(SQL)-1]
+
+	repository.Logger.Info("---executing sql create transfer items...")
+	_, err := tx.ExecContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to create transfer items: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *TransferRepositoryImpl) FindByID(ctx context.Context, tx *sql.Tx, transferID ulid.ULID) (domain.StockTransfer, error) {
+	SQL := "SELECT transfer_id, source_location_id, destination_location_id, status, note, created_by, created_at, dispatched_at, received_at FROM Stock_Transfers WHERE transfer_id = ?"
+
+	repository.Logger.Info("---executing sql find transfer...")
+	return repository.scanTransfer(tx.QueryRowContext(ctx, SQL, transferID))
+}
+
+func (repository *TransferRepositoryImpl) FindByIDForUpdate(ctx context.Context, tx *sql.Tx, transferID ulid.ULID) (domain.StockTransfer, error) {
+	SQL := "SELECT transfer_id, source_location_id, destination_location_id, status, note, created_by, created_at, dispatched_at, received_at FROM Stock_Transfers WHERE transfer_id = ? FOR UPDATE"
+
+	repository.Logger.Info("---executing sql find transfer for update...")
+	return repository.scanTransfer(tx.QueryRowContext(ctx, SQL, transferID))
+}
+
+func (repository *TransferRepositoryImpl) scanTransfer(row *sql.Row) (domain.StockTransfer, error) {
+	var transfer domain.StockTransfer
+	err := row.Scan(
+		&transfer.TransferID,
+		&transfer.SourceLocationID,
+		&transfer.DestinationLocationID,
+		&transfer.Status,
+		&transfer.Note,
+		&transfer.CreatedBy,
+		&transfer.CreatedAt,
+		&transfer.DispatchedAt,
+		&transfer.ReceivedAt,
+	)
+	if err != nil {
+		repository.Logger.Errorf("---failed to find transfer: %v", err)
+		return domain.StockTransfer{}, err
+	}
+
+	return transfer, nil
+}
+
+func (repository *TransferRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, locationID *ulid.ULID, status string) ([]domain.StockTransfer, error) {
+	SQL := "SELECT transfer_id, source_location_id, destination_location_id, status, note, created_by, created_at, dispatched_at, received_at FROM Stock_Transfers WHERE 1 = 1"
+
+	var args []interface{}
+	if locationID != nil {
+		SQL += " AND (source_location_id = ? OR destination_location_id = ?)"
+		args = append(args, *locationID, *locationID)
+	}
+	if status != "" {
+		SQL += " AND status = ?"
+		args = append(args, status)
+	}
+	SQL += " ORDER BY created_at DESC"
+
+	repository.Logger.Info("---executing sql find all transfers...")
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to find transfers: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transfers []domain.StockTransfer
+	for rows.Next() {
+		var transfer domain.StockTransfer
+		err := rows.Scan(
+			&transfer.TransferID,
+			&transfer.SourceLocationID,
+			&transfer.DestinationLocationID,
+			&transfer.Status,
+			&transfer.Note,
+			&transfer.CreatedBy,
+			&transfer.CreatedAt,
+			&transfer.DispatchedAt,
+			&transfer.ReceivedAt,
+		)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan transfer: %v", err)
+			return nil, err
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	return transfers, rows.Err()
+}
+
+func (repository *TransferRepositoryImpl) FindItems(ctx context.Context, tx *sql.Tx, transferID ulid.ULID) ([]domain.StockTransferItem, error) {
+	SQL := "SELECT transfer_id, product_id, quantity, quantity_received, quantity_discrepancy, discrepancy_note FROM Stock_Transfer_Items WHERE transfer_id = ?"
+
+	repository.Logger.Info("---executing sql find transfer items...")
+	rows, err := tx.QueryContext(ctx, SQL, transferID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to find transfer items: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []domain.StockTransferItem
+	for rows.Next() {
+		var item domain.StockTransferItem
+		err := rows.Scan(&item.TransferID, &item.ProductID, &item.Quantity, &item.QuantityReceived, &item.QuantityDiscrepancy, &item.DiscrepancyNote)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan transfer item: %v", err)
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (repository *TransferRepositoryImpl) UpdateStatus(ctx context.Context, tx *sql.Tx, transfer domain.StockTransfer) error {
+	SQL := "UPDATE Stock_Transfers SET status = ?, dispatched_at = ?, received_at = ? WHERE transfer_id = ?"
+
+	repository.Logger.Info("---executing sql update transfer status...")
+	_, err := tx.ExecContext(ctx, SQL, transfer.Status, transfer.DispatchedAt, transfer.ReceivedAt, transfer.TransferID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to update transfer status: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *TransferRepositoryImpl) UpdateItem(ctx context.Context, tx *sql.Tx, item domain.StockTransferItem) error {
+	SQL := "UPDATE Stock_Transfer_Items SET quantity_received = ?, quantity_discrepancy = ?, discrepancy_note = ? WHERE transfer_id = ? AND product_id = ?"
+
+	repository.Logger.Info("---executing sql update transfer item...")
+	_, err := tx.ExecContext(ctx, SQL, item.QuantityReceived, item.QuantityDiscrepancy, item.DiscrepancyNote, item.TransferID, item.ProductID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to update transfer item: %v", err)
+		return err
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"database/sql"
+	"errors"
 	"fmt"
 	"retail-inventory/exception"
 	"retail-inventory/model/domain"
@@ -17,16 +18,24 @@ import (
 
 type InventoryServiceImpl struct {
 	pb.UnimplementedInventoryServiceServer
-	InventoryRepository repository.InventoryRepository
-	DB                  *sql.DB
-	Logger              *logrus.Logger
+	InventoryRepository   repository.InventoryRepository
+	ReservationRepository repository.ReservationRepository
+	LocationRepository    repository.LocationRepository
+	TransferRepository    repository.TransferRepository
+	LotRepository         repository.LotRepository
+	DB                    *sql.DB
+	Logger                *logrus.Logger
 }
 
-func NewInventoryService(inventoryRepository repository.InventoryRepository, db *sql.DB, logger *logrus.Logger) *InventoryServiceImpl {
+func NewInventoryService(inventoryRepository repository.InventoryRepository, reservationRepository repository.ReservationRepository, locationRepository repository.LocationRepository, transferRepository repository.TransferRepository, lotRepository repository.LotRepository, db *sql.DB, logger *logrus.Logger) *InventoryServiceImpl {
 	return &InventoryServiceImpl{
-		InventoryRepository: inventoryRepository,
-		DB:                  db,
-		Logger:              logger,
+		InventoryRepository:   inventoryRepository,
+		ReservationRepository: reservationRepository,
+		LocationRepository:    locationRepository,
+		TransferRepository:    transferRepository,
+		LotRepository:         lotRepository,
+		DB:                    db,
+		Logger:                logger,
 	}
 }
 
@@ -44,7 +53,12 @@ func (service *InventoryServiceImpl) GetStock(ctx context.Context, req *pb.GetSt
 	}
 	defer tx.Commit()
 
-	qty, err := service.InventoryRepository.GetStock(ctx, tx, productID)
+	locationID, err := service.findLocation(ctx, tx, req.LocationId)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed to find location")
+	}
+
+	qty, err := service.InventoryRepository.GetStock(ctx, tx, locationID, productID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return &pb.GetStockResponse{Quantity: 0}, nil
@@ -52,7 +66,12 @@ func (service *InventoryServiceImpl) GetStock(ctx context.Context, req *pb.GetSt
 		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed to fetch stock from repo")
 	}
 
-	return &pb.GetStockResponse{Quantity: int32(qty)}, nil
+	reserved, err := service.ReservationRepository.GetReservedQuantity(ctx, tx, locationID, productID, time.Now())
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed to fetch reserved stock from repo")
+	}
+
+	return &pb.GetStockResponse{Quantity: int32(qty), Reserved: int32(reserved), Available: int32(qty - reserved)}, nil
 }
 
 func (service *InventoryServiceImpl) DecreaseStock(ctx context.Context, req *pb.DecreaseStockRequest) (*pb.DecreaseStockResponse, error) {
@@ -65,10 +84,44 @@ func (service *InventoryServiceImpl) DecreaseStock(ctx context.Context, req *pb.
 	}
 	defer tx.Rollback()
 
+	locationID, err := service.findLocation(ctx, tx, req.LocationId)
+	if err != nil {
+		msg := exception.FormatErrorMessage(service.Logger, err, "failed to find location")
+		return &pb.DecreaseStockResponse{Success: false, Message: msg}, nil
+	}
+
 	userID, _ := ulid.Parse(req.UserId)
 	t := time.Now()
 	entropy := ulid.Monotonic(rand.Reader, 0)
 
+	operationID := ""
+	if req.TransactionId != "" {
+		operationID = transactionOperationID(req.TransactionId)
+		err = service.InventoryRepository.CreateOperation(ctx, tx, domain.StockOperation{
+			OperationID:   operationID,
+			OperationType: domain.OperationTypeDecrease,
+			ResultMessage: "stock decreased",
+			CreatedAt:     t,
+		})
+		if errors.Is(err, exception.ErrOperationApplied) {
+			operation, err := service.InventoryRepository.FindOperation(ctx, tx, operationID)
+			if err != nil {
+				msg := exception.FormatErrorMessage(service.Logger, err, "failed find operation")
+				return &pb.DecreaseStockResponse{Success: false, Message: msg}, err
+			}
+			service.Logger.Infof("-transaction %s already handled, replaying result", req.TransactionId)
+			return &pb.DecreaseStockResponse{
+				Success:  operation.OperationType != domain.OperationTypeCancelled,
+				Message:  operation.ResultMessage,
+				Replayed: true,
+			}, nil
+		}
+		if err != nil {
+			msg := exception.FormatErrorMessage(service.Logger, err, "failed create operation")
+			return &pb.DecreaseStockResponse{Success: false, Message: msg}, err
+		}
+	}
+
 	for _, item := range req.Items {
 		productID, err := ulid.Parse(item.ProductId)
 		if err != nil {
@@ -76,32 +129,46 @@ func (service *InventoryServiceImpl) DecreaseStock(ctx context.Context, req *pb.
 			return &pb.DecreaseStockResponse{Success: false, Message: fmt.Sprintf("%s: %s", exception.ErrInvalidID.Error(), item.ProductId)}, nil
 		}
 
-		currentQty, err := service.InventoryRepository.GetStock(ctx, tx, productID)
+		currentQty, err := service.InventoryRepository.GetStockForUpdate(ctx, tx, locationID, productID)
 		if err != nil {
 			service.Logger.Errorf("-product not found: %s", item.ProductId)
 			msg := exception.FormatErrorMessage(service.Logger, err, "failed to get stock for "+item.ProductId)
 			return &pb.DecreaseStockResponse{Success: false, Message: fmt.Sprintf("%s (product: %s)", msg, item.ProductId)}, nil
 		}
 
-		if currentQty < int(item.Quantity) {
+		reserved, err := service.ReservationRepository.GetReservedQuantity(ctx, tx, locationID, productID, t)
+		if err != nil {
+			msg := exception.FormatErrorMessage(service.Logger, err, "failed to get reserved stock for "+item.ProductId)
+			return &pb.DecreaseStockResponse{Success: false, Message: msg}, err
+		}
+
+		if currentQty-reserved < int(item.Quantity) {
 			service.Logger.Warn("-insufficient stock")
 			return &pb.DecreaseStockResponse{Success: false, Message: fmt.Sprintf("%s: %s", exception.ErrInsufficientStock.Error(), item.ProductId)}, nil
 		}
 
 		newQty := currentQty - int(item.Quantity)
-		err = service.InventoryRepository.UpdateStock(ctx, tx, productID, newQty)
+		err = service.InventoryRepository.UpdateStock(ctx, tx, locationID, productID, newQty)
 		if err != nil {
 			msg := exception.FormatErrorMessage(service.Logger, err, "failed update stock")
 			return &pb.DecreaseStockResponse{Success: false, Message: msg}, err
 		}
 
+		err = service.consumeLots(ctx, tx, locationID, productID, int(item.Quantity), operationID, t)
+		if err != nil {
+			msg := exception.FormatErrorMessage(service.Logger, err, "failed consume lots")
+			return &pb.DecreaseStockResponse{Success: false, Message: msg}, err
+		}
+
 		logID := ulid.MustNew(ulid.Timestamp(t), entropy)
 		log := domain.InventoryLog{
 			LogID:          logID,
+			LocationID:     locationID,
 			ProductID:      productID,
 			UserID:         userID,
 			ChangeQuantity: -int(item.Quantity),
 			Reason:         fmt.Sprintf("Transaction: %s", req.TransactionId),
+			OperationID:    operationID,
 			CreatedAt:      t,
 		}
 
@@ -136,13 +203,19 @@ func (service *InventoryServiceImpl) AdjustStock(ctx context.Context, req *pb.Ad
 	}
 	defer tx.Rollback()
 
-	currentQty, err := service.InventoryRepository.GetStock(ctx, tx, productID)
+	locationID, err := service.findLocation(ctx, tx, req.LocationId)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find location")
+	}
+
+	currentQty, err := service.InventoryRepository.GetStock(ctx, tx, locationID, productID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			if req.QuantityChange >= 0 {
 				stock := domain.ProductStock{
-					ProductID: productID,
-					Quantity:  0,
+					LocationID: locationID,
+					ProductID:  productID,
+					Quantity:   0,
 				}
 				err := service.InventoryRepository.CreateStock(ctx, tx, stock)
 				if err != nil {
@@ -162,17 +235,26 @@ func (service *InventoryServiceImpl) AdjustStock(ctx context.Context, req *pb.Ad
 		return &pb.AdjustStockResponse{Success: false, Message: exception.ErrStockNegative.Error()}, nil
 	}
 
-	err = service.InventoryRepository.UpdateStock(ctx, tx, productID, newQty)
+	err = service.InventoryRepository.UpdateStock(ctx, tx, locationID, productID, newQty)
 	if err != nil {
 		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed update stock")
 	}
 
 	t := time.Now()
+	if req.QuantityChange < 0 {
+		err = service.consumeLots(ctx, tx, locationID, productID, -int(req.QuantityChange), "", t)
+	} else {
+		err = service.receiveLot(ctx, tx, locationID, productID, int(req.QuantityChange), req.LotNumber, req.ExpiryDate, "", t)
+	}
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed update lots")
+	}
 	entropy := ulid.Monotonic(rand.Reader, 0)
 	logID := ulid.MustNew(ulid.Timestamp(t), entropy)
 
 	log := domain.InventoryLog{
 		LogID:          logID,
+		LocationID:     locationID,
 		ProductID:      productID,
 		UserID:         userID,
 		ChangeQuantity: int(req.QuantityChange),
@@ -197,6 +279,137 @@ func (service *InventoryServiceImpl) AdjustStock(ctx context.Context, req *pb.Ad
 	}, nil
 }
 
+func (service *InventoryServiceImpl) BatchAdjustStock(ctx context.Context, req *pb.BatchAdjustStockRequest) (*pb.BatchAdjustStockResponse, error) {
+	service.Logger.Info("grpc BatchAdjustStock called...")
+
+	if req.OperationId == "" || len(req.Adjustments) == 0 {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidInput, "missing operation id or adjustments")
+	}
+	userID, _ := ulid.Parse(req.UserId)
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed begin tx")
+	}
+	defer tx.Rollback()
+
+	locationID, err := service.findLocation(ctx, tx, req.LocationId)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find location")
+	}
+
+	t := time.Now()
+	err = service.InventoryRepository.CreateOperation(ctx, tx, domain.StockOperation{
+		OperationID:   req.OperationId,
+		OperationType: domain.OperationTypeAdjust,
+		ResultMessage: "stock adjusted",
+		CreatedAt:     t,
+	})
+	if errors.Is(err, exception.ErrOperationApplied) {
+		operation, err := service.InventoryRepository.FindOperation(ctx, tx, req.OperationId)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find operation")
+		}
+		logs, err := service.InventoryRepository.FindLogsByOperation(ctx, tx, req.OperationId)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find operation logs")
+		}
+
+		var items []*pb.AdjustedStock
+		for _, log := range logs {
+			currentQty, err := service.InventoryRepository.GetStock(ctx, tx, log.LocationID, log.ProductID)
+			if err != nil && err != sql.ErrNoRows {
+				return nil, exception.GRPCErrorHandler(service.Logger, err, "failed get stock")
+			}
+			items = append(items, &pb.AdjustedStock{
+				ProductId:   log.ProductID.String(),
+				NewQuantity: int32(currentQty),
+				LogId:       log.LogID.String(),
+			})
+		}
+
+		service.Logger.Infof("-operation %s already applied, replaying result", req.OperationId)
+		return &pb.BatchAdjustStockResponse{Success: true, Message: operation.ResultMessage, Replayed: true, Items: items}, nil
+	}
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed create operation")
+	}
+
+	entropy := ulid.Monotonic(rand.Reader, 0)
+
+	var items []*pb.AdjustedStock
+	for _, adjustment := range req.Adjustments {
+		productID, err := ulid.Parse(adjustment.ProductId)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidID, "invalid product id "+adjustment.ProductId)
+		}
+		if adjustment.QuantityChange == 0 {
+			continue
+		}
+
+		currentQty, err := service.InventoryRepository.GetStockForUpdate(ctx, tx, locationID, productID)
+		if err != nil {
+			if err != sql.ErrNoRows {
+				return nil, exception.GRPCErrorHandler(service.Logger, err, "failed get stock")
+			}
+			err = service.InventoryRepository.CreateStock(ctx, tx, domain.ProductStock{LocationID: locationID, ProductID: productID, Quantity: 0})
+			if err != nil {
+				return nil, exception.GRPCErrorHandler(service.Logger, err, "failed create initial stock")
+			}
+			currentQty = 0
+		}
+
+		newQty := currentQty + int(adjustment.QuantityChange)
+		if newQty < 0 {
+			return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrStockNegative, "stock would become negative for "+adjustment.ProductId)
+		}
+
+		err = service.InventoryRepository.UpdateStock(ctx, tx, locationID, productID, newQty)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed update stock")
+		}
+
+		if adjustment.QuantityChange < 0 {
+			err = service.consumeLots(ctx, tx, locationID, productID, -int(adjustment.QuantityChange), req.OperationId, t)
+		} else {
+			err = service.receiveLot(ctx, tx, locationID, productID, int(adjustment.QuantityChange), adjustment.LotNumber, adjustment.ExpiryDate, req.OperationId, t)
+		}
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed update lots")
+		}
+
+		logID := ulid.MustNew(ulid.Timestamp(t), entropy)
+		log := domain.InventoryLog{
+			LogID:          logID,
+			LocationID:     locationID,
+			ProductID:      productID,
+			UserID:         userID,
+			ChangeQuantity: int(adjustment.QuantityChange),
+			Reason:         req.Reason,
+			OperationID:    req.OperationId,
+			CreatedAt:      t,
+		}
+
+		err = service.InventoryRepository.CreateLog(ctx, tx, log)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed create log")
+		}
+
+		items = append(items, &pb.AdjustedStock{
+			ProductId:   adjustment.ProductId,
+			NewQuantity: int32(newQty),
+			LogId:       logID.String(),
+		})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed commit")
+	}
+
+	service.Logger.Info("grpc BatchAdjustStock success")
+	return &pb.BatchAdjustStockResponse{Success: true, Message: "stock adjusted", Items: items}, nil
+}
+
 func (service *InventoryServiceImpl) GetBatchStock(ctx context.Context, req *pb.GetBatchStockRequest) (*pb.GetBatchStockResponse, error) {
 	service.Logger.Info("grpc GetBatchStock called...")
 
@@ -206,20 +419,281 @@ func (service *InventoryServiceImpl) GetBatchStock(ctx context.Context, req *pb.
 	}
 	defer tx.Commit()
 
-	stockMap, err := service.InventoryRepository.GetStocksByIDs(ctx, tx, req.ProductIds)
+	locationID, err := service.findLocation(ctx, tx, req.LocationId)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find location")
+	}
+
+	stockMap, err := service.InventoryRepository.GetStocksByIDs(ctx, tx, locationID, req.ProductIds)
 	if err != nil {
 		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed batch fetch")
 	}
 
+	reservedMap, err := service.ReservationRepository.GetReservedByIDs(ctx, tx, locationID, req.ProductIds, time.Now())
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed batch fetch reserved")
+	}
+
 	var items []*pb.BatchStockItem
 
 	for _, reqID := range req.ProductIds {
 		qty := stockMap[reqID]
+		reserved := reservedMap[reqID]
 		items = append(items, &pb.BatchStockItem{
 			ProductId: reqID,
 			Quantity:  int32(qty),
+			Reserved:  int32(reserved),
+			Available: int32(qty - reserved),
 		})
 	}
 
 	return &pb.GetBatchStockResponse{Items: items}, nil
 }
+
+func (service *InventoryServiceImpl) RestoreStock(ctx context.Context, req *pb.RestoreStockRequest) (*pb.RestoreStockResponse, error) {
+	service.Logger.Info("grpc RestoreStock called...")
+
+	if req.OperationId == "" || len(req.Items) == 0 {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidInput, "missing operation id or items")
+	}
+	userID, _ := ulid.Parse(req.UserId)
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed begin tx")
+	}
+	defer tx.Rollback()
+
+	locationID, err := service.findLocation(ctx, tx, req.LocationId)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find location")
+	}
+
+	t := time.Now()
+	err = service.InventoryRepository.CreateOperation(ctx, tx, domain.StockOperation{
+		OperationID:   req.OperationId,
+		OperationType: domain.OperationTypeRestore,
+		ResultMessage: "stock restored",
+		CreatedAt:     t,
+	})
+	if errors.Is(err, exception.ErrOperationApplied) {
+		operation, err := service.InventoryRepository.FindOperation(ctx, tx, req.OperationId)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find operation")
+		}
+
+		// a restore is only cancelled when the caller failed to commit after it, so a retry puts the stock back again
+		if operation.OperationType != domain.OperationTypeCancelled {
+			service.Logger.Infof("-operation %s already applied, replaying result", req.OperationId)
+			return &pb.RestoreStockResponse{Success: true, Message: operation.ResultMessage, Replayed: true}, nil
+		}
+
+		service.Logger.Infof("-operation %s was cancelled, applying it again", req.OperationId)
+		operation.OperationType = domain.OperationTypeRestore
+		operation.ResultMessage = "stock restored"
+		err = service.InventoryRepository.UpdateOperation(ctx, tx, operation)
+	}
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed create operation")
+	}
+
+	sourceOperationID := ""
+	if req.SourceTransactionId != "" {
+		sourceOperationID = transactionOperationID(req.SourceTransactionId)
+	}
+	entropy := ulid.Monotonic(rand.Reader, 0)
+
+	for _, item := range req.Items {
+		productID, err := ulid.Parse(item.ProductId)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidID, "invalid product id "+item.ProductId)
+		}
+		if item.Quantity <= 0 {
+			return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidInput, "invalid quantity for "+item.ProductId)
+		}
+
+		currentQty, err := service.InventoryRepository.GetStockForUpdate(ctx, tx, locationID, productID)
+		if err != nil {
+			if err != sql.ErrNoRows {
+				return nil, exception.GRPCErrorHandler(service.Logger, err, "failed get stock")
+			}
+			err = service.InventoryRepository.CreateStock(ctx, tx, domain.ProductStock{LocationID: locationID, ProductID: productID, Quantity: 0})
+			if err != nil {
+				return nil, exception.GRPCErrorHandler(service.Logger, err, "failed create initial stock")
+			}
+			currentQty = 0
+		}
+
+		err = service.InventoryRepository.UpdateStock(ctx, tx, locationID, productID, currentQty+int(item.Quantity))
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed update stock")
+		}
+
+		err = service.giveBackLots(ctx, tx, locationID, productID, int(item.Quantity), sourceOperationID, req.OperationId, item.LotNumber, item.ExpiryDate, t)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed receive lot")
+		}
+
+		log := domain.InventoryLog{
+			LogID:          ulid.MustNew(ulid.Timestamp(t), entropy),
+			LocationID:     locationID,
+			ProductID:      productID,
+			UserID:         userID,
+			ChangeQuantity: int(item.Quantity),
+			Reason:         req.Reason,
+			OperationID:    req.OperationId,
+			CreatedAt:      t,
+		}
+
+		err = service.InventoryRepository.CreateLog(ctx, tx, log)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed create log")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed commit")
+	}
+
+	service.Logger.Info("grpc RestoreStock success")
+	return &pb.RestoreStockResponse{Success: true, Message: "stock restored"}, nil
+}
+
+func (service *InventoryServiceImpl) CancelStockOperation(ctx context.Context, req *pb.CancelStockOperationRequest) (*pb.CancelStockOperationResponse, error) {
+	service.Logger.Info("grpc CancelStockOperation called...")
+
+	if req.TransactionId == "" && req.OperationId == "" {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidInput, "missing transaction id or operation id")
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed begin tx")
+	}
+	defer tx.Rollback()
+
+	userID, _ := ulid.Parse(req.UserId)
+	operationID := req.OperationId
+	if operationID == "" {
+		operationID = transactionOperationID(req.TransactionId)
+	}
+	t := time.Now()
+
+	operation, err := service.InventoryRepository.FindOperation(ctx, tx, operationID)
+	if err == sql.ErrNoRows {
+		service.Logger.Infof("-operation %s was never applied, writing tombstone", operationID)
+		err = service.InventoryRepository.CreateOperation(ctx, tx, domain.StockOperation{
+			OperationID:   operationID,
+			OperationType: domain.OperationTypeCancelled,
+			ResultMessage: "operation cancelled",
+			CreatedAt:     t,
+		})
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed create cancel operation")
+		}
+
+		if err := tx.Commit(); err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed commit")
+		}
+		return &pb.CancelStockOperationResponse{Success: true, Message: "operation cancelled", Restored: false}, nil
+	}
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find operation")
+	}
+
+	if operation.OperationType == domain.OperationTypeCancelled {
+		service.Logger.Infof("-operation %s already cancelled", operationID)
+		return &pb.CancelStockOperationResponse{Success: true, Message: operation.ResultMessage, Restored: false}, nil
+	}
+
+	logs, err := service.InventoryRepository.FindLogsByOperation(ctx, tx, operationID)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find operation logs")
+	}
+
+	var netChanges []domain.InventoryLog
+	netIndex := make(map[[2]ulid.ULID]int)
+	for _, log := range logs {
+		key := [2]ulid.ULID{log.LocationID, log.ProductID}
+		if i, ok := netIndex[key]; ok {
+			netChanges[i].ChangeQuantity += log.ChangeQuantity
+			continue
+		}
+		netIndex[key] = len(netChanges)
+		netChanges = append(netChanges, log)
+	}
+
+	entropy := ulid.Monotonic(rand.Reader, 0)
+	for _, applied := range netChanges {
+		if applied.ChangeQuantity == 0 {
+			continue
+		}
+
+		currentQty, err := service.InventoryRepository.GetStockForUpdate(ctx, tx, applied.LocationID, applied.ProductID)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed get stock")
+		}
+
+		err = service.InventoryRepository.UpdateStock(ctx, tx, applied.LocationID, applied.ProductID, currentQty-applied.ChangeQuantity)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed update stock")
+		}
+
+		if applied.ChangeQuantity < 0 {
+			err = service.giveBackLots(ctx, tx, applied.LocationID, applied.ProductID, -applied.ChangeQuantity, operationID, operationID, "", "", t)
+		} else {
+			err = service.takeBackLots(ctx, tx, applied.LocationID, applied.ProductID, applied.ChangeQuantity, operationID, t)
+		}
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed update lots")
+		}
+
+		log := domain.InventoryLog{
+			LogID:          ulid.MustNew(ulid.Timestamp(t), entropy),
+			LocationID:     applied.LocationID,
+			ProductID:      applied.ProductID,
+			UserID:         userID,
+			ChangeQuantity: -applied.ChangeQuantity,
+			Reason:         req.Reason,
+			OperationID:    operationID,
+			CreatedAt:      t,
+		}
+
+		err = service.InventoryRepository.CreateLog(ctx, tx, log)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed create log")
+		}
+	}
+
+	operation.OperationType = domain.OperationTypeCancelled
+	operation.ResultMessage = "operation cancelled"
+	err = service.InventoryRepository.UpdateOperation(ctx, tx, operation)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed update operation")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed commit")
+	}
+
+	service.Logger.Info("grpc CancelStockOperation success")
+	return &pb.CancelStockOperationResponse{Success: true, Message: "operation cancelled, stock restored", Restored: true}, nil
+}
+
+func transactionOperationID(transactionID string) string {
+	return fmt.Sprintf("transaction:%s", transactionID)
+}
+
+func (service *InventoryServiceImpl) findLocation(ctx context.Context, tx *sql.Tx, locationID string) (ulid.ULID, error) {
+	parsedID, err := ulid.Parse(locationID)
+	if err != nil {
+		return ulid.ULID{}, exception.ErrInvalidID
+	}
+
+	location, err := service.LocationRepository.FindByID(ctx, tx, parsedID)
+	if err != nil {
+		return ulid.ULID{}, err
+	}
+
+	return location.LocationID, nil
+}
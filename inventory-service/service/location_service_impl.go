@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"retail-inventory/exception"
+	"retail-inventory/model/domain"
+	"retail-inventory/pb"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+func (service *InventoryServiceImpl) CreateLocation(ctx context.Context, req *pb.CreateLocationRequest) (*pb.Location, error) {
+	service.Logger.Info("grpc CreateLocation called...")
+
+	name := strings.TrimSpace(req.LocationName)
+	if name == "" {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidInput, "missing location name")
+	}
+
+	locationType := req.LocationType
+	if locationType == "" {
+		locationType = domain.LocationTypeStore
+	}
+	if locationType != domain.LocationTypeStore && locationType != domain.LocationTypeWarehouse {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidInput, "invalid location type")
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed begin tx")
+	}
+	defer tx.Rollback()
+
+	t := time.Now()
+	location := domain.Location{
+		LocationID:   ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(rand.Reader, 0)),
+		LocationName: name,
+		LocationType: locationType,
+		CreatedAt:    t,
+	}
+
+	err = service.LocationRepository.Save(ctx, tx, location)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed create location")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed commit")
+	}
+
+	service.Logger.Info("grpc CreateLocation success")
+	return toPbLocation(location), nil
+}
+
+func (service *InventoryServiceImpl) GetLocation(ctx context.Context, req *pb.GetLocationRequest) (*pb.Location, error) {
+	service.Logger.Info("grpc GetLocation called...")
+
+	locationID, err := ulid.Parse(req.LocationId)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidID, "invalid location id")
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed begin tx")
+	}
+	defer tx.Commit()
+
+	location, err := service.LocationRepository.FindByID(ctx, tx, locationID)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find location")
+	}
+
+	return toPbLocation(location), nil
+}
+
+func (service *InventoryServiceImpl) ListLocations(ctx context.Context, req *pb.ListLocationsRequest) (*pb.ListLocationsResponse, error) {
+	service.Logger.Info("grpc ListLocations called...")
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed begin tx")
+	}
+	defer tx.Commit()
+
+	locations, err := service.LocationRepository.FindAll(ctx, tx)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find locations")
+	}
+
+	var items []*pb.Location
+	for _, location := range locations {
+		items = append(items, toPbLocation(location))
+	}
+
+	return &pb.ListLocationsResponse{Locations: items}, nil
+}
+
+func toPbLocation(location domain.Location) *pb.Location {
+	return &pb.Location{
+		LocationId:   location.LocationID.String(),
+		LocationName: location.LocationName,
+		LocationType: location.LocationType,
+	}
+}
@@ -0,0 +1,337 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"retail-inventory/exception"
+	"retail-inventory/model/domain"
+	"retail-inventory/pb"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+func (service *InventoryServiceImpl) ListExpiringLots(ctx context.Context, req *pb.ListExpiringLotsRequest) (*pb.ListLotsResponse, error) {
+	service.Logger.Info("grpc ListExpiringLots called...")
+
+	if req.WithinDays < 0 {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidInput, "invalid within days")
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed begin tx")
+	}
+	defer tx.Commit()
+
+	locationID, err := service.findLocation(ctx, tx, req.LocationId)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find location")
+	}
+
+	lots, err := service.LotRepository.FindExpiring(ctx, tx, locationID, today().AddDate(0, 0, int(req.WithinDays)))
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find expiring lots")
+	}
+
+	resp := &pb.ListLotsResponse{}
+	for _, lot := range lots {
+		resp.Lots = append(resp.Lots, toPbLot(lot))
+	}
+	return resp, nil
+}
+
+func (service *InventoryServiceImpl) WriteOffExpiredLots(ctx context.Context, req *pb.WriteOffExpiredLotsRequest) (*pb.WriteOffExpiredLotsResponse, error) {
+	service.Logger.Info("grpc WriteOffExpiredLots called...")
+
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidInput, "missing write-off reason")
+	}
+	userID, _ := ulid.Parse(req.UserId)
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed begin tx")
+	}
+	defer tx.Rollback()
+
+	locationID, err := service.findLocation(ctx, tx, req.LocationId)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find location")
+	}
+
+	var lots []domain.StockLot
+	if len(req.LotIds) == 0 {
+		lots, err = service.LotRepository.FindExpiredForUpdate(ctx, tx, locationID, today())
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find expired lots")
+		}
+	} else {
+		for _, id := range req.LotIds {
+			lotID, err := ulid.Parse(id)
+			if err != nil {
+				return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidID, "invalid lot id "+id)
+			}
+
+			lot, err := service.LotRepository.FindByIDForUpdate(ctx, tx, lotID)
+			if err != nil {
+				return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find lot "+id)
+			}
+			if lot.LocationID != locationID {
+				return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrNotFound, "lot is not held at location "+id)
+			}
+			if lot.ExpiryDate == nil || !lot.ExpiryDate.Before(today()) {
+				return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrLotNotExpired, "lot "+id)
+			}
+			if lot.Quantity > 0 {
+				lots = append(lots, lot)
+			}
+		}
+	}
+
+	t := time.Now()
+	entropy := ulid.Monotonic(rand.Reader, 0)
+
+	resp := &pb.WriteOffExpiredLotsResponse{Success: true}
+	for _, lot := range lots {
+		currentQty, err := service.InventoryRepository.GetStockForUpdate(ctx, tx, lot.LocationID, lot.ProductID)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed get stock")
+		}
+
+		newQty := currentQty - lot.Quantity
+		if newQty < 0 {
+			newQty = 0
+		}
+
+		err = service.InventoryRepository.UpdateStock(ctx, tx, lot.LocationID, lot.ProductID, newQty)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed update stock")
+		}
+
+		err = service.LotRepository.UpdateQuantity(ctx, tx, lot.LotID, 0)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed update lot")
+		}
+
+		err = service.moveLot(ctx, tx, lot.LotID, "", "", -lot.Quantity, t)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed record lot movement")
+		}
+
+		log := domain.InventoryLog{
+			LogID:          ulid.MustNew(ulid.Timestamp(t), entropy),
+			LocationID:     lot.LocationID,
+			ProductID:      lot.ProductID,
+			UserID:         userID,
+			ChangeQuantity: newQty - currentQty,
+			Reason:         fmt.Sprintf("Expired lot write-off %s: %s", lot.LotNumber, req.Reason),
+			CreatedAt:      t,
+		}
+
+		err = service.InventoryRepository.CreateLog(ctx, tx, log)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed create log")
+		}
+
+		resp.Lots = append(resp.Lots, toPbLot(lot))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed commit")
+	}
+
+	resp.Message = fmt.Sprintf("%d lots written off", len(resp.Lots))
+	service.Logger.Info("grpc WriteOffExpiredLots success")
+	return resp, nil
+}
+
+func (service *InventoryServiceImpl) receiveLot(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, productID ulid.ULID, quantity int, lotNumber string, expiryDate string, operationID string, t time.Time) error {
+	if quantity <= 0 {
+		return nil
+	}
+
+	lot := domain.StockLot{
+		LotID:      ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(rand.Reader, 0)),
+		LocationID: locationID,
+		ProductID:  productID,
+		LotNumber:  lotNumber,
+		ReceivedAt: t,
+		Quantity:   quantity,
+	}
+
+	if expiryDate != "" {
+		expiry, err := time.Parse(domain.ExpiryDateLayout, expiryDate)
+		if err != nil {
+			return fmt.Errorf("%w: expiry date must be YYYY-MM-DD", exception.ErrInvalidInput)
+		}
+		lot.ExpiryDate = &expiry
+	}
+
+	err := service.LotRepository.Create(ctx, tx, lot)
+	if err != nil {
+		return err
+	}
+
+	return service.moveLot(ctx, tx, lot.LotID, operationID, "", quantity, t)
+}
+
+func (service *InventoryServiceImpl) consumeLots(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, productID ulid.ULID, quantity int, operationID string, t time.Time) error {
+	if quantity <= 0 {
+		return nil
+	}
+
+	lots, err := service.LotRepository.FindAvailableForUpdate(ctx, tx, locationID, productID, today())
+	if err != nil {
+		return err
+	}
+
+	for _, lot := range lots {
+		if quantity == 0 {
+			break
+		}
+
+		taken := lot.Quantity
+		if taken > quantity {
+			taken = quantity
+		}
+
+		err = service.LotRepository.UpdateQuantity(ctx, tx, lot.LotID, lot.Quantity-taken)
+		if err != nil {
+			return err
+		}
+
+		err = service.moveLot(ctx, tx, lot.LotID, operationID, "", -taken, t)
+		if err != nil {
+			return err
+		}
+		quantity -= taken
+	}
+
+	return nil
+}
+
+func (service *InventoryServiceImpl) giveBackLots(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, productID ulid.ULID, quantity int, sourceOperationID string, operationID string, lotNumber string, expiryDate string, t time.Time) error {
+	if sourceOperationID != "" {
+		lots, err := service.LotRepository.FindConsumedByOperation(ctx, tx, sourceOperationID, productID)
+		if err != nil {
+			return err
+		}
+
+		for _, lot := range lots {
+			if quantity == 0 {
+				break
+			}
+
+			given := lot.Quantity
+			if given > quantity {
+				given = quantity
+			}
+
+			lotID := lot.LotID
+			var sourceLotID *ulid.ULID
+			if lot.LocationID == locationID {
+				err = service.LotRepository.AddQuantity(ctx, tx, lot.LotID, given)
+			} else {
+				lotID = ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(rand.Reader, 0))
+				err = service.LotRepository.Create(ctx, tx, domain.StockLot{
+					LotID:      lotID,
+					LocationID: locationID,
+					ProductID:  productID,
+					LotNumber:  lot.LotNumber,
+					ReceivedAt: lot.ReceivedAt,
+					ExpiryDate: lot.ExpiryDate,
+					Quantity:   given,
+				})
+				sourceLotID = &lot.LotID
+			}
+			if err != nil {
+				return err
+			}
+
+			err = service.LotRepository.CreateMovement(ctx, tx, domain.StockLotMovement{
+				MovementID:        ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(rand.Reader, 0)),
+				LotID:             lotID,
+				OperationID:       operationID,
+				SourceOperationID: sourceOperationID,
+				SourceLotID:       sourceLotID,
+				ChangeQuantity:    given,
+				CreatedAt:         t,
+			})
+			if err != nil {
+				return err
+			}
+			quantity -= given
+		}
+	}
+
+	return service.receiveLot(ctx, tx, locationID, productID, quantity, lotNumber, expiryDate, operationID, t)
+}
+
+func (service *InventoryServiceImpl) takeBackLots(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, productID ulid.ULID, quantity int, operationID string, t time.Time) error {
+	received, err := service.LotRepository.FindReceivedByOperation(ctx, tx, operationID, locationID, productID)
+	if err != nil {
+		return err
+	}
+
+	for _, movement := range received {
+		if quantity == 0 {
+			break
+		}
+
+		taken := movement.ChangeQuantity
+		if taken > quantity {
+			taken = quantity
+		}
+
+		err = service.LotRepository.AddQuantity(ctx, tx, movement.LotID, -taken)
+		if err != nil {
+			return err
+		}
+
+		movement.MovementID = ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(rand.Reader, 0))
+		movement.ChangeQuantity = -taken
+		movement.CreatedAt = t
+		err = service.LotRepository.CreateMovement(ctx, tx, movement)
+		if err != nil {
+			return err
+		}
+		quantity -= taken
+	}
+
+	return service.consumeLots(ctx, tx, locationID, productID, quantity, operationID, t)
+}
+
+func (service *InventoryServiceImpl) moveLot(ctx context.Context, tx *sql.Tx, lotID ulid.ULID, operationID string, sourceOperationID string, changeQuantity int, t time.Time) error {
+	return service.LotRepository.CreateMovement(ctx, tx, domain.StockLotMovement{
+		MovementID:        ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(rand.Reader, 0)),
+		LotID:             lotID,
+		OperationID:       operationID,
+		SourceOperationID: sourceOperationID,
+		ChangeQuantity:    changeQuantity,
+		CreatedAt:         t,
+	})
+}
+
+func today() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func toPbLot(lot domain.StockLot) *pb.StockLot {
+	pbLot := &pb.StockLot{
+		LotId:      lot.LotID.String(),
+		LocationId: lot.LocationID.String(),
+		ProductId:  lot.ProductID.String(),
+		LotNumber:  lot.LotNumber,
+		ReceivedAt: lot.ReceivedAt.Format(time.RFC3339),
+		Quantity:   int32(lot.Quantity),
+	}
+	if lot.ExpiryDate != nil {
+		pbLot.ExpiryDate = lot.ExpiryDate.Format(domain.ExpiryDateLayout)
+	}
+	return pbLot
+}
@@ -0,0 +1,305 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"retail-inventory/exception"
+	"retail-inventory/model/domain"
+	"retail-inventory/pb"
+	"strconv"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+func (service *InventoryServiceImpl) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*pb.ReserveStockResponse, error) {
+	service.Logger.Info("grpc ReserveStock called...")
+
+	if len(req.Items) == 0 {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidInput, "missing items")
+	}
+	userID, _ := ulid.Parse(req.UserId)
+
+	var productIDs []ulid.ULID
+	quantities := make(map[ulid.ULID]int)
+	for _, item := range req.Items {
+		productID, err := ulid.Parse(item.ProductId)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidID, "invalid product id")
+		}
+		if item.Quantity <= 0 {
+			return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidInput, "reserved quantity must be positive")
+		}
+		if _, ok := quantities[productID]; !ok {
+			productIDs = append(productIDs, productID)
+		}
+		quantities[productID] += int(item.Quantity)
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed begin tx")
+	}
+	defer tx.Rollback()
+
+	locationID, err := service.findLocation(ctx, tx, req.LocationId)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find location")
+	}
+
+	t := time.Now()
+	ttl := reservationTTL()
+	if req.TtlSeconds > 0 {
+		ttl = time.Duration(req.TtlSeconds) * time.Second
+	}
+
+	reservationID := ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(rand.Reader, 0))
+	var items []domain.StockReservationItem
+
+	for _, productID := range productIDs {
+		currentQty, err := service.InventoryRepository.GetStockForUpdate(ctx, tx, locationID, productID)
+		if err == sql.ErrNoRows {
+			currentQty = 0
+		} else if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed get stock")
+		}
+
+		reserved, err := service.ReservationRepository.GetReservedQuantity(ctx, tx, locationID, productID, t)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed get reserved stock")
+		}
+
+		if currentQty-reserved < quantities[productID] {
+			service.Logger.Warn("-insufficient available stock")
+			return &pb.ReserveStockResponse{Success: false, Message: fmt.Sprintf("%s: %s", exception.ErrInsufficientStock.Error(), productID.String())}, nil
+		}
+
+		items = append(items, domain.StockReservationItem{
+			ReservationID: reservationID,
+			ProductID:     productID,
+			Quantity:      quantities[productID],
+		})
+	}
+
+	reservation := domain.StockReservation{
+		ReservationID: reservationID,
+		Reference:     req.Reference,
+		LocationID:    locationID,
+		UserID:        userID,
+		Status:        domain.ReservationStatusActive,
+		ExpiresAt:     t.Add(ttl),
+		CreatedAt:     t,
+	}
+
+	err = service.ReservationRepository.Create(ctx, tx, reservation)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed create reservation")
+	}
+
+	err = service.ReservationRepository.CreateItems(ctx, tx, items)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed create reservation items")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed commit")
+	}
+
+	service.Logger.Info("grpc ReserveStock success")
+	return &pb.ReserveStockResponse{
+		Success:       true,
+		Message:       "stock reserved",
+		ReservationId: reservationID.String(),
+		ExpiresAt:     reservation.ExpiresAt.UTC().Format(time.RFC3339),
+	}, nil
+}
+
+func (service *InventoryServiceImpl) CommitReservation(ctx context.Context, req *pb.CommitReservationRequest) (*pb.CommitReservationResponse, error) {
+	service.Logger.Info("grpc CommitReservation called...")
+
+	reservationID, err := ulid.Parse(req.ReservationId)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidID, "invalid reservation id")
+	}
+	userID, _ := ulid.Parse(req.UserId)
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed begin tx")
+	}
+	defer tx.Rollback()
+
+	reservation, err := service.ReservationRepository.FindByIDForUpdate(ctx, tx, reservationID)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find reservation")
+	}
+
+	if reservation.Status == domain.ReservationStatusCommitted {
+		service.Logger.Infof("-reservation %s already committed", req.ReservationId)
+		return &pb.CommitReservationResponse{Success: true, Message: "reservation already committed"}, nil
+	}
+	if reservation.Status != domain.ReservationStatusActive {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrReservationClosed, "failed commit reservation")
+	}
+
+	t := time.Now()
+	if !reservation.ExpiresAt.After(t) {
+		err = service.ReservationRepository.UpdateStatus(ctx, tx, reservationID, domain.ReservationStatusReleased)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed release expired reservation")
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed commit")
+		}
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrReservationExpired, "failed commit reservation")
+	}
+
+	operationID := fmt.Sprintf("reservation:%s", req.ReservationId)
+	reason := fmt.Sprintf("Reservation: %s", req.ReservationId)
+	if req.TransactionId != "" {
+		operationID = transactionOperationID(req.TransactionId)
+		reason = fmt.Sprintf("Transaction: %s", req.TransactionId)
+	}
+
+	err = service.InventoryRepository.CreateOperation(ctx, tx, domain.StockOperation{
+		OperationID:   operationID,
+		OperationType: domain.OperationTypeDecrease,
+		ResultMessage: "stock decreased",
+		CreatedAt:     t,
+	})
+	if errors.Is(err, exception.ErrOperationApplied) {
+		operation, err := service.InventoryRepository.FindOperation(ctx, tx, operationID)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find operation")
+		}
+		service.Logger.Infof("-operation %s already handled, not decreasing stock again", operationID)
+
+		status := domain.ReservationStatusCommitted
+		if operation.OperationType == domain.OperationTypeCancelled {
+			status = domain.ReservationStatusReleased
+		}
+		err = service.ReservationRepository.UpdateStatus(ctx, tx, reservationID, status)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed update reservation status")
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed commit")
+		}
+
+		return &pb.CommitReservationResponse{
+			Success: operation.OperationType != domain.OperationTypeCancelled,
+			Message: operation.ResultMessage,
+		}, nil
+	}
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed create operation")
+	}
+
+	err = service.ReservationRepository.UpdateStatus(ctx, tx, reservationID, domain.ReservationStatusCommitted)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed update reservation status")
+	}
+
+	items, err := service.ReservationRepository.FindItems(ctx, tx, reservationID)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find reservation items")
+	}
+
+	entropy := ulid.Monotonic(rand.Reader, 0)
+	for _, item := range items {
+		currentQty, err := service.InventoryRepository.GetStockForUpdate(ctx, tx, reservation.LocationID, item.ProductID)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed get stock")
+		}
+
+		if currentQty < item.Quantity {
+			service.Logger.Warn("-insufficient stock to commit reservation")
+			return &pb.CommitReservationResponse{Success: false, Message: fmt.Sprintf("%s: %s", exception.ErrInsufficientStock.Error(), item.ProductID.String())}, nil
+		}
+
+		err = service.InventoryRepository.UpdateStock(ctx, tx, reservation.LocationID, item.ProductID, currentQty-item.Quantity)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed update stock")
+		}
+
+		err = service.consumeLots(ctx, tx, reservation.LocationID, item.ProductID, item.Quantity, operationID, t)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed consume lots")
+		}
+
+		log := domain.InventoryLog{
+			LogID:          ulid.MustNew(ulid.Timestamp(t), entropy),
+			LocationID:     reservation.LocationID,
+			ProductID:      item.ProductID,
+			UserID:         userID,
+			ChangeQuantity: -item.Quantity,
+			Reason:         reason,
+			OperationID:    operationID,
+			CreatedAt:      t,
+		}
+
+		err = service.InventoryRepository.CreateLog(ctx, tx, log)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed create log")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed commit")
+	}
+
+	service.Logger.Info("grpc CommitReservation success")
+	return &pb.CommitReservationResponse{Success: true, Message: "reservation committed"}, nil
+}
+
+func (service *InventoryServiceImpl) ReleaseReservation(ctx context.Context, req *pb.ReleaseReservationRequest) (*pb.ReleaseReservationResponse, error) {
+	service.Logger.Info("grpc ReleaseReservation called...")
+
+	reservationID, err := ulid.Parse(req.ReservationId)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidID, "invalid reservation id")
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed begin tx")
+	}
+	defer tx.Rollback()
+
+	reservation, err := service.ReservationRepository.FindByIDForUpdate(ctx, tx, reservationID)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find reservation")
+	}
+
+	if reservation.Status == domain.ReservationStatusReleased {
+		service.Logger.Infof("-reservation %s already released", req.ReservationId)
+		return &pb.ReleaseReservationResponse{Success: true, Message: "reservation already released"}, nil
+	}
+	if reservation.Status != domain.ReservationStatusActive {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrReservationClosed, "failed release reservation")
+	}
+
+	err = service.ReservationRepository.UpdateStatus(ctx, tx, reservationID, domain.ReservationStatusReleased)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed update reservation status")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed commit")
+	}
+
+	service.Logger.Info("grpc ReleaseReservation success")
+	return &pb.ReleaseReservationResponse{Success: true, Message: "reservation released"}, nil
+}
+
+func reservationTTL() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("RESERVATION_TTL_SECONDS"))
+	if err != nil || seconds <= 0 {
+		seconds = 900
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,511 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"fmt"
+	"retail-inventory/exception"
+	"retail-inventory/model/domain"
+	"retail-inventory/pb"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+func (service *InventoryServiceImpl) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.StockTransfer, error) {
+	service.Logger.Info("grpc CreateTransfer called...")
+
+	if len(req.Items) == 0 {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidInput, "missing items")
+	}
+	if req.SourceLocationId == req.DestinationLocationId {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidInput, "source and destination must differ")
+	}
+	userID, _ := ulid.Parse(req.UserId)
+
+	var productIDs []ulid.ULID
+	quantities := make(map[ulid.ULID]int)
+	for _, item := range req.Items {
+		productID, err := ulid.Parse(item.ProductId)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidID, "invalid product id")
+		}
+		if item.Quantity <= 0 {
+			return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidInput, "transfer quantity must be positive")
+		}
+		if _, ok := quantities[productID]; !ok {
+			productIDs = append(productIDs, productID)
+		}
+		quantities[productID] += int(item.Quantity)
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed begin tx")
+	}
+	defer tx.Rollback()
+
+	sourceID, err := service.findLocation(ctx, tx, req.SourceLocationId)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find source location")
+	}
+	destinationID, err := service.findLocation(ctx, tx, req.DestinationLocationId)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find destination location")
+	}
+
+	t := time.Now()
+	transfer := domain.StockTransfer{
+		TransferID:            ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(rand.Reader, 0)),
+		SourceLocationID:      sourceID,
+		DestinationLocationID: destinationID,
+		Status:                domain.TransferStatusDraft,
+		Note:                  req.Note,
+		CreatedBy:             userID,
+		CreatedAt:             t,
+	}
+
+	var items []domain.StockTransferItem
+	for _, productID := range productIDs {
+		items = append(items, domain.StockTransferItem{
+			TransferID: transfer.TransferID,
+			ProductID:  productID,
+			Quantity:   quantities[productID],
+		})
+	}
+
+	err = service.TransferRepository.Create(ctx, tx, transfer)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed create transfer")
+	}
+
+	err = service.TransferRepository.CreateItems(ctx, tx, items)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed create transfer items")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed commit")
+	}
+
+	service.Logger.Info("grpc CreateTransfer success")
+	return toPbTransfer(transfer, items), nil
+}
+
+func (service *InventoryServiceImpl) DispatchTransfer(ctx context.Context, req *pb.DispatchTransferRequest) (*pb.StockTransfer, error) {
+	service.Logger.Info("grpc DispatchTransfer called...")
+
+	transferID, err := ulid.Parse(req.TransferId)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidID, "invalid transfer id")
+	}
+	userID, _ := ulid.Parse(req.UserId)
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed begin tx")
+	}
+	defer tx.Rollback()
+
+	transfer, err := service.TransferRepository.FindByIDForUpdate(ctx, tx, transferID)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find transfer")
+	}
+
+	items, err := service.TransferRepository.FindItems(ctx, tx, transferID)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find transfer items")
+	}
+
+	if transfer.Status == domain.TransferStatusCancelled {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrTransferClosed, "failed dispatch transfer")
+	}
+	if transfer.Status != domain.TransferStatusDraft {
+		service.Logger.Infof("-transfer %s already dispatched", req.TransferId)
+		return toPbTransfer(transfer, items), nil
+	}
+
+	t := time.Now()
+	operationID := transferOperationID(req.TransferId)
+	entropy := ulid.Monotonic(rand.Reader, 0)
+
+	for _, item := range items {
+		currentQty, err := service.InventoryRepository.GetStockForUpdate(ctx, tx, transfer.SourceLocationID, item.ProductID)
+		if err == sql.ErrNoRows {
+			currentQty = 0
+		} else if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed get stock")
+		}
+
+		reserved, err := service.ReservationRepository.GetReservedQuantity(ctx, tx, transfer.SourceLocationID, item.ProductID, t)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed get reserved stock")
+		}
+
+		if currentQty-reserved < item.Quantity {
+			err := fmt.Errorf("%w: %s", exception.ErrInsufficientStock, item.ProductID.String())
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed dispatch transfer")
+		}
+
+		err = service.InventoryRepository.UpdateStock(ctx, tx, transfer.SourceLocationID, item.ProductID, currentQty-item.Quantity)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed update stock")
+		}
+
+		err = service.consumeLots(ctx, tx, transfer.SourceLocationID, item.ProductID, item.Quantity, operationID, t)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed consume lots")
+		}
+
+		log := domain.InventoryLog{
+			LogID:          ulid.MustNew(ulid.Timestamp(t), entropy),
+			LocationID:     transfer.SourceLocationID,
+			ProductID:      item.ProductID,
+			UserID:         userID,
+			ChangeQuantity: -item.Quantity,
+			Reason:         fmt.Sprintf("Transfer dispatch: %s", req.TransferId),
+			OperationID:    operationID,
+			CreatedAt:      t,
+		}
+
+		err = service.InventoryRepository.CreateLog(ctx, tx, log)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed create log")
+		}
+	}
+
+	transfer.Status = domain.TransferStatusInTransit
+	transfer.DispatchedAt = &t
+
+	err = service.TransferRepository.UpdateStatus(ctx, tx, transfer)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed update transfer status")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed commit")
+	}
+
+	service.Logger.Info("grpc DispatchTransfer success")
+	return toPbTransfer(transfer, items), nil
+}
+
+func (service *InventoryServiceImpl) ReceiveTransfer(ctx context.Context, req *pb.ReceiveTransferRequest) (*pb.StockTransfer, error) {
+	service.Logger.Info("grpc ReceiveTransfer called...")
+
+	transferID, err := ulid.Parse(req.TransferId)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidID, "invalid transfer id")
+	}
+	if len(req.Items) == 0 {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidInput, "missing items")
+	}
+	userID, _ := ulid.Parse(req.UserId)
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed begin tx")
+	}
+	defer tx.Rollback()
+
+	transfer, err := service.TransferRepository.FindByIDForUpdate(ctx, tx, transferID)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find transfer")
+	}
+
+	t := time.Now()
+	if req.ReceiptKey != "" {
+		receiptOperationID := transferReceiptOperationID(req.TransferId, req.ReceiptKey)
+		err = service.InventoryRepository.CreateOperation(ctx, tx, domain.StockOperation{
+			OperationID:   receiptOperationID,
+			OperationType: domain.OperationTypeRestore,
+			ResultMessage: "transfer received",
+			CreatedAt:     t,
+		})
+		if errors.Is(err, exception.ErrOperationApplied) {
+			items, err := service.TransferRepository.FindItems(ctx, tx, transferID)
+			if err != nil {
+				return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find transfer items")
+			}
+			service.Logger.Infof("-receipt %s already applied, returning the transfer", receiptOperationID)
+			return toPbTransfer(transfer, items), nil
+		}
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed create operation")
+		}
+	}
+
+	switch transfer.Status {
+	case domain.TransferStatusDraft:
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrTransferNotDispatched, "failed receive transfer")
+	case domain.TransferStatusReceived, domain.TransferStatusCancelled:
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrTransferClosed, "failed receive transfer")
+	}
+
+	items, err := service.TransferRepository.FindItems(ctx, tx, transferID)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find transfer items")
+	}
+
+	itemIndex := make(map[ulid.ULID]int)
+	for i, item := range items {
+		itemIndex[item.ProductID] = i
+	}
+
+	operationID := transferOperationID(req.TransferId)
+	entropy := ulid.Monotonic(rand.Reader, 0)
+
+	for _, receipt := range req.Items {
+		productID, err := ulid.Parse(receipt.ProductId)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidID, "invalid product id")
+		}
+
+		i, ok := itemIndex[productID]
+		if !ok {
+			err := fmt.Errorf("%w: product %s is not part of this transfer", exception.ErrInvalidInput, receipt.ProductId)
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed receive transfer")
+		}
+		if receipt.QuantityReceived < 0 || receipt.QuantityDiscrepancy < 0 || receipt.QuantityReceived+receipt.QuantityDiscrepancy == 0 {
+			return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidInput, "received and discrepancy quantities must be non-negative and not both zero")
+		}
+		if int(receipt.QuantityReceived+receipt.QuantityDiscrepancy) > items[i].InTransit() {
+			err := fmt.Errorf("%w: only %d of product %s still in transit", exception.ErrInvalidInput, items[i].InTransit(), receipt.ProductId)
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed receive transfer")
+		}
+
+		items[i].QuantityReceived += int(receipt.QuantityReceived)
+		items[i].QuantityDiscrepancy += int(receipt.QuantityDiscrepancy)
+		if receipt.DiscrepancyNote != "" {
+			items[i].DiscrepancyNote = receipt.DiscrepancyNote
+		}
+
+		err = service.TransferRepository.UpdateItem(ctx, tx, items[i])
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed update transfer item")
+		}
+
+		if receipt.QuantityReceived == 0 {
+			continue
+		}
+
+		currentQty, err := service.InventoryRepository.GetStockForUpdate(ctx, tx, transfer.DestinationLocationID, productID)
+		if err == sql.ErrNoRows {
+			err = service.InventoryRepository.CreateStock(ctx, tx, domain.ProductStock{
+				LocationID: transfer.DestinationLocationID,
+				ProductID:  productID,
+				Quantity:   0,
+			})
+			if err != nil {
+				return nil, exception.GRPCErrorHandler(service.Logger, err, "failed create destination stock")
+			}
+			currentQty = 0
+		} else if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed get stock")
+		}
+
+		err = service.InventoryRepository.UpdateStock(ctx, tx, transfer.DestinationLocationID, productID, currentQty+int(receipt.QuantityReceived))
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed update stock")
+		}
+
+		err = service.giveBackLots(ctx, tx, transfer.DestinationLocationID, productID, int(receipt.QuantityReceived), operationID, operationID, "", "", t)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed receive lots")
+		}
+
+		log := domain.InventoryLog{
+			LogID:          ulid.MustNew(ulid.Timestamp(t), entropy),
+			LocationID:     transfer.DestinationLocationID,
+			ProductID:      productID,
+			UserID:         userID,
+			ChangeQuantity: int(receipt.QuantityReceived),
+			Reason:         fmt.Sprintf("Transfer receipt: %s", req.TransferId),
+			OperationID:    operationID,
+			CreatedAt:      t,
+		}
+
+		err = service.InventoryRepository.CreateLog(ctx, tx, log)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed create log")
+		}
+	}
+
+	transfer.Status = domain.TransferStatusReceived
+	for _, item := range items {
+		if item.InTransit() > 0 {
+			transfer.Status = domain.TransferStatusPartiallyReceived
+			break
+		}
+	}
+	if transfer.Status == domain.TransferStatusReceived {
+		transfer.ReceivedAt = &t
+	}
+
+	err = service.TransferRepository.UpdateStatus(ctx, tx, transfer)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed update transfer status")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed commit")
+	}
+
+	service.Logger.Info("grpc ReceiveTransfer success")
+	return toPbTransfer(transfer, items), nil
+}
+
+func (service *InventoryServiceImpl) CancelTransfer(ctx context.Context, req *pb.CancelTransferRequest) (*pb.StockTransfer, error) {
+	service.Logger.Info("grpc CancelTransfer called...")
+
+	transferID, err := ulid.Parse(req.TransferId)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidID, "invalid transfer id")
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed begin tx")
+	}
+	defer tx.Rollback()
+
+	transfer, err := service.TransferRepository.FindByIDForUpdate(ctx, tx, transferID)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find transfer")
+	}
+
+	items, err := service.TransferRepository.FindItems(ctx, tx, transferID)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find transfer items")
+	}
+
+	if transfer.Status == domain.TransferStatusCancelled {
+		service.Logger.Infof("-transfer %s already cancelled", req.TransferId)
+		return toPbTransfer(transfer, items), nil
+	}
+	if transfer.Status != domain.TransferStatusDraft {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrTransferDispatched, "failed cancel transfer")
+	}
+
+	transfer.Status = domain.TransferStatusCancelled
+	err = service.TransferRepository.UpdateStatus(ctx, tx, transfer)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed update transfer status")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed commit")
+	}
+
+	service.Logger.Info("grpc CancelTransfer success")
+	return toPbTransfer(transfer, items), nil
+}
+
+func (service *InventoryServiceImpl) GetTransfer(ctx context.Context, req *pb.GetTransferRequest) (*pb.StockTransfer, error) {
+	service.Logger.Info("grpc GetTransfer called...")
+
+	transferID, err := ulid.Parse(req.TransferId)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, exception.ErrInvalidID, "invalid transfer id")
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed begin tx")
+	}
+	defer tx.Commit()
+
+	transfer, err := service.TransferRepository.FindByID(ctx, tx, transferID)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find transfer")
+	}
+
+	items, err := service.TransferRepository.FindItems(ctx, tx, transferID)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find transfer items")
+	}
+
+	return toPbTransfer(transfer, items), nil
+}
+
+func (service *InventoryServiceImpl) ListTransfers(ctx context.Context, req *pb.ListTransfersRequest) (*pb.ListTransfersResponse, error) {
+	service.Logger.Info("grpc ListTransfers called...")
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed begin tx")
+	}
+	defer tx.Commit()
+
+	var locationID *ulid.ULID
+	if req.LocationId != "" {
+		foundID, err := service.findLocation(ctx, tx, req.LocationId)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find location")
+		}
+		locationID = &foundID
+	}
+
+	transfers, err := service.TransferRepository.FindAll(ctx, tx, locationID, req.Status)
+	if err != nil {
+		return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find transfers")
+	}
+
+	resp := &pb.ListTransfersResponse{}
+	for _, transfer := range transfers {
+		items, err := service.TransferRepository.FindItems(ctx, tx, transfer.TransferID)
+		if err != nil {
+			return nil, exception.GRPCErrorHandler(service.Logger, err, "failed find transfer items")
+		}
+		resp.Transfers = append(resp.Transfers, toPbTransfer(transfer, items))
+	}
+
+	return resp, nil
+}
+
+func transferOperationID(transferID string) string {
+	return fmt.Sprintf("transfer:%s", transferID)
+}
+
+func transferReceiptOperationID(transferID string, receiptKey string) string {
+	sum := sha256.Sum256([]byte(transferID + ":" + receiptKey))
+	return fmt.Sprintf("transfer-receipt:%x", sum[:16])
+}
+
+func toPbTransfer(transfer domain.StockTransfer, items []domain.StockTransferItem) *pb.StockTransfer {
+	pbTransfer := &pb.StockTransfer{
+		TransferId:            transfer.TransferID.String(),
+		SourceLocationId:      transfer.SourceLocationID.String(),
+		DestinationLocationId: transfer.DestinationLocationID.String(),
+		Status:                transfer.Status,
+		Note:                  transfer.Note,
+		CreatedBy:             transfer.CreatedBy.String(),
+		CreatedAt:             transfer.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if transfer.DispatchedAt != nil {
+		pbTransfer.DispatchedAt = transfer.DispatchedAt.UTC().Format(time.RFC3339)
+	}
+	if transfer.ReceivedAt != nil {
+		pbTransfer.ReceivedAt = transfer.ReceivedAt.UTC().Format(time.RFC3339)
+	}
+
+	for _, item := range items {
+		inTransit := 0
+		if transfer.Status == domain.TransferStatusInTransit || transfer.Status == domain.TransferStatusPartiallyReceived {
+			inTransit = item.InTransit()
+		}
+		pbTransfer.Items = append(pbTransfer.Items, &pb.TransferItem{
+			ProductId:           item.ProductID.String(),
+			Quantity:            int32(item.Quantity),
+			QuantityReceived:    int32(item.QuantityReceived),
+			QuantityDiscrepancy: int32(item.QuantityDiscrepancy),
+			InTransit:           int32(inTransit),
+			DiscrepancyNote:     item.DiscrepancyNote,
+		})
+	}
+
+	return pbTransfer
+}
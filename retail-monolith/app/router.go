@@ -8,14 +8,31 @@ import (
 )
 
 type RouteConfig struct {
-	App                    *fiber.App
-	UserController         controller.UserController
-	RoleController         controller.RoleController
-	CategoryController     controller.CategoryController
-	SupplierController     controller.SupplierController
-	ProductController      controller.ProductController
-	InventoryLogController controller.InventoryLogController
-	TransactionController  controller.TransactionController
+	App                     *fiber.App
+	UserController          controller.UserController
+	RoleController          controller.RoleController
+	CategoryController      controller.CategoryController
+	SupplierController      controller.SupplierController
+	ProductController       controller.ProductController
+	InventoryLogController  controller.InventoryLogController
+	TransactionController   controller.TransactionController
+	LocationController      controller.LocationController
+	RegisterController      controller.RegisterController
+	TransferController      controller.TransferController
+	PurchaseOrderController controller.PurchaseOrderController
+	ReplenishmentController controller.ReplenishmentController
+	StocktakeController     controller.StocktakeController
+	LotController           controller.LotController
+	ValuationController     controller.ValuationController
+	ReportController        controller.ReportController
+	ShiftController         controller.ShiftController
+	PromotionController     controller.PromotionController
+	TaxController           controller.TaxController
+	CustomerController      controller.CustomerController
+	LoyaltyController       controller.LoyaltyController
+	GiftCardController      controller.GiftCardController
+	ReceiptController       controller.ReceiptController
+	LabelController         controller.LabelController
 }
 
 func (c *RouteConfig) Setup() {
@@ -30,6 +47,7 @@ func (c *RouteConfig) Setup() {
 	userRoutes.Get("/:userID", c.UserController.FindByID)
 	userRoutes.Patch("/:userID", c.UserController.Update)
 	userRoutes.Delete("/:userID", c.UserController.Delete)
+	userRoutes.Put("/:userID/register", c.RegisterController.AssignUser)
 	c.App.Get("/roles", middleware.AuthMiddleware(), middleware.AdminMiddleware(), c.RoleController.FindAll)
 
 	// categories
@@ -38,6 +56,7 @@ func (c *RouteConfig) Setup() {
 	categoryRoutes.Get("", c.CategoryController.FindAll)
 	categoryRoutes.Put("/:categoryID", middleware.AdminMiddleware(), c.CategoryController.Update)
 	categoryRoutes.Delete("/:categoryID", middleware.AdminMiddleware(), c.CategoryController.Delete)
+	categoryRoutes.Put("/:categoryID/tax-rate", middleware.AdminMiddleware(), c.TaxController.AssignToCategory)
 
 	// suppliers
 	supplierRoutes := c.App.Group("/suppliers", middleware.AuthMiddleware())
@@ -50,17 +69,114 @@ func (c *RouteConfig) Setup() {
 	productRoutes := c.App.Group("/products", middleware.AuthMiddleware())
 	productRoutes.Post("", middleware.AdminMiddleware(), c.ProductController.Create)
 	productRoutes.Get("", c.ProductController.FindAll)
+	productRoutes.Get("/lookup", c.ProductController.Lookup)
+	productRoutes.Post("/labels", c.LabelController.Render)
 	productRoutes.Get("/:productID", c.ProductController.FindByID)
 	productRoutes.Patch("/:productID", middleware.AdminMiddleware(), c.ProductController.Update)
 	productRoutes.Put("/:productID", middleware.AdminMiddleware(), c.ProductController.UpdateStock)
 	productRoutes.Delete("/:productID", middleware.AdminMiddleware(), c.ProductController.Delete)
+	productRoutes.Get("/:productID/reorder-rule", middleware.AdminMiddleware(), c.ReplenishmentController.FindRule)
+	productRoutes.Put("/:productID/reorder-rule", middleware.AdminMiddleware(), c.ReplenishmentController.SaveRule)
+	productRoutes.Put("/:productID/tax-rate", middleware.AdminMiddleware(), c.TaxController.AssignToProduct)
+
+	// locations & registers
+	locationRoutes := c.App.Group("/locations", middleware.AuthMiddleware())
+	locationRoutes.Post("", middleware.AdminMiddleware(), c.LocationController.Create)
+	locationRoutes.Get("", c.LocationController.FindAll)
+
+	registerRoutes := c.App.Group("/registers", middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	registerRoutes.Post("", c.RegisterController.Create)
+	registerRoutes.Get("", c.RegisterController.FindAll)
 
 	// inventory
 	c.App.Post("/inventory/adjust", middleware.AuthMiddleware(), middleware.AdminMiddleware(), c.InventoryLogController.Adjust)
+	c.App.Get("/inventory/lots/expiring", middleware.AuthMiddleware(), c.LotController.FindExpiring)
+	c.App.Post("/inventory/lots/write-off", middleware.AuthMiddleware(), middleware.AdminMiddleware(), c.LotController.WriteOffExpired)
+	c.App.Get("/inventory/valuation", middleware.AuthMiddleware(), middleware.AdminMiddleware(), c.ValuationController.Valuate)
+
+	// stocktakes
+	stocktakeRoutes := c.App.Group("/stocktakes", middleware.AuthMiddleware())
+	stocktakeRoutes.Post("", middleware.AdminMiddleware(), c.StocktakeController.Create)
+	stocktakeRoutes.Get("", c.StocktakeController.FindAll)
+	stocktakeRoutes.Get("/:sessionID", c.StocktakeController.FindByID)
+	stocktakeRoutes.Post("/:sessionID/counts", c.StocktakeController.SubmitCounts)
+	stocktakeRoutes.Get("/:sessionID/variance", middleware.AdminMiddleware(), c.StocktakeController.Variance)
+	stocktakeRoutes.Post("/:sessionID/approve", middleware.AdminMiddleware(), c.StocktakeController.Approve)
+	stocktakeRoutes.Post("/:sessionID/cancel", middleware.AdminMiddleware(), c.StocktakeController.Cancel)
+
+	// purchase orders
+	purchaseOrderRoutes := c.App.Group("/purchase-orders", middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	purchaseOrderRoutes.Post("", c.PurchaseOrderController.Create)
+	purchaseOrderRoutes.Get("", c.PurchaseOrderController.FindAll)
+	purchaseOrderRoutes.Get("/:purchaseOrderID", c.PurchaseOrderController.FindByID)
+	purchaseOrderRoutes.Post("/:purchaseOrderID/send", c.PurchaseOrderController.Send)
+	purchaseOrderRoutes.Post("/:purchaseOrderID/receipts", c.PurchaseOrderController.Receive)
+	purchaseOrderRoutes.Post("/:purchaseOrderID/close", c.PurchaseOrderController.Close)
+
+	// replenishment
+	replenishmentRoutes := c.App.Group("/replenishment", middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	replenishmentRoutes.Get("", c.ReplenishmentController.Suggest)
+	replenishmentRoutes.Post("", c.ReplenishmentController.CreatePurchaseOrders)
+
+	// reports
+	reportRoutes := c.App.Group("/reports", middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	reportRoutes.Get("/sales", c.ReportController.Sales)
+	reportRoutes.Get("/payments", c.ReportController.Payments)
+	reportRoutes.Get("/tax", c.ReportController.Tax)
+
+	// transfers
+	transferRoutes := c.App.Group("/transfers", middleware.AuthMiddleware())
+	transferRoutes.Post("", middleware.AdminMiddleware(), c.TransferController.Create)
+	transferRoutes.Get("", c.TransferController.FindAll)
+	transferRoutes.Get("/:transferID", c.TransferController.FindByID)
+	transferRoutes.Post("/:transferID/dispatch", middleware.AdminMiddleware(), c.TransferController.Dispatch)
+	transferRoutes.Post("/:transferID/receive", c.TransferController.Receive)
+	transferRoutes.Post("/:transferID/cancel", middleware.AdminMiddleware(), c.TransferController.Cancel)
+
+	// shifts
+	shiftRoutes := c.App.Group("/shifts", middleware.AuthMiddleware())
+	shiftRoutes.Post("", c.ShiftController.Open)
+	shiftRoutes.Get("", c.ShiftController.FindAll)
+	shiftRoutes.Get("/current", c.ShiftController.FindCurrent)
+	shiftRoutes.Get("/:shiftID/report", c.ShiftController.Report)
+	shiftRoutes.Post("/:shiftID/close", c.ShiftController.Close)
+
+	// promotions
+	promotionRoutes := c.App.Group("/promotions", middleware.AuthMiddleware())
+	promotionRoutes.Post("", middleware.AdminMiddleware(), c.PromotionController.Create)
+	promotionRoutes.Get("", c.PromotionController.FindAll)
+	promotionRoutes.Get("/:promotionID", c.PromotionController.FindByID)
+	promotionRoutes.Put("/:promotionID", middleware.AdminMiddleware(), c.PromotionController.Update)
+	promotionRoutes.Delete("/:promotionID", middleware.AdminMiddleware(), c.PromotionController.Delete)
+
+	// tax rates
+	taxRoutes := c.App.Group("/tax-rates", middleware.AuthMiddleware())
+	taxRoutes.Post("", middleware.AdminMiddleware(), c.TaxController.Create)
+	taxRoutes.Get("", c.TaxController.FindAll)
+	taxRoutes.Put("/:taxRateID", middleware.AdminMiddleware(), c.TaxController.Update)
+	taxRoutes.Delete("/:taxRateID", middleware.AdminMiddleware(), c.TaxController.Delete)
+
+	// customers
+	customerRoutes := c.App.Group("/customers", middleware.AuthMiddleware())
+	customerRoutes.Post("", c.CustomerController.Create)
+	customerRoutes.Get("", c.CustomerController.FindAll)
+	customerRoutes.Get("/:customerID", c.CustomerController.FindByID)
+	customerRoutes.Put("/:customerID", c.CustomerController.Update)
+	customerRoutes.Delete("/:customerID", middleware.AdminMiddleware(), c.CustomerController.Delete)
+	customerRoutes.Get("/:customerID/transactions", c.CustomerController.History)
+	customerRoutes.Get("/:customerID/loyalty", c.LoyaltyController.Balance)
+
+	// gift cards and store credit
+	giftCardRoutes := c.App.Group("/gift-cards", middleware.AuthMiddleware())
+	giftCardRoutes.Post("", middleware.AdminMiddleware(), c.GiftCardController.Issue)
+	giftCardRoutes.Get("/:cardCode", c.GiftCardController.FindByCode)
 
 	// transactions
 	transactionRoutes := c.App.Group("/transactions", middleware.AuthMiddleware())
 	transactionRoutes.Post("", c.TransactionController.Create)
 	transactionRoutes.Get("", c.TransactionController.FindAll)
 	transactionRoutes.Get("/:transactionID", c.TransactionController.FindByID)
+	transactionRoutes.Get("/:transactionID/receipt", c.ReceiptController.Render)
+	transactionRoutes.Post("/:transactionID/returns", c.TransactionController.CreateReturn)
+	transactionRoutes.Post("/:transactionID/void", c.TransactionController.Void)
 }
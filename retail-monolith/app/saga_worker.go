@@ -0,0 +1,29 @@
+package app
+
+import (
+	"context"
+	"os"
+	"retail-management/service"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func RunSagaWorker(transactionService service.TransactionService, logger *logrus.Logger) {
+	seconds, err := strconv.Atoi(os.Getenv("SAGA_WORKER_INTERVAL_SECONDS"))
+	if err != nil || seconds <= 0 {
+		seconds = 30
+	}
+
+	logger.Infof("starting saga worker, running every %d seconds...", seconds)
+	ticker := time.NewTicker(time.Duration(seconds) * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		err := transactionService.RecoverSagas(context.Background())
+		if err != nil {
+			logger.Errorf("saga worker run failed: %v", err)
+		}
+	}
+}
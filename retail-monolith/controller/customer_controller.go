@@ -0,0 +1,14 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type CustomerController interface {
+	Create(ctx *fiber.Ctx) error
+	FindAll(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+	Update(ctx *fiber.Ctx) error
+	Delete(ctx *fiber.Ctx) error
+	History(ctx *fiber.Ctx) error
+}
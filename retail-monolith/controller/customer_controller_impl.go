@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"retail-management/model/web"
+	"retail-management/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type CustomerControllerImpl struct {
+	CustomerService service.CustomerService
+	Logger          *logrus.Logger
+}
+
+func NewCustomerController(customerService service.CustomerService, logger *logrus.Logger) CustomerController {
+	return &CustomerControllerImpl{
+		CustomerService: customerService,
+		Logger:          logger,
+	}
+}
+
+func (controller *CustomerControllerImpl) Create(ctx *fiber.Ctx) error {
+	customerRequest := web.CustomerRequest{}
+
+	controller.Logger.Info("trying to parse the request body...")
+	err := ctx.BodyParser(&customerRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+
+	controller.Logger.Info("executing CustomerService.Create()...")
+	customer, err := controller.CustomerService.Create(ctx.Context(), customerRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY CREATE CUSTOMER---------")
+	return ctx.Status(fiber.StatusCreated).JSON(customer)
+}
+
+func (controller *CustomerControllerImpl) FindAll(ctx *fiber.Ctx) error {
+	controller.Logger.Info("executing CustomerService.FindAll()...")
+	customers, err := controller.CustomerService.FindAll(ctx.Context(), ctx.Query("search"))
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET ALL CUSTOMERS---------")
+	return ctx.Status(fiber.StatusOK).JSON(customers)
+}
+
+func (controller *CustomerControllerImpl) FindByID(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the customerID...")
+	customerID, err := ulid.Parse(ctx.Params("customerID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse customerID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing CustomerService.FindByID()...")
+	customer, err := controller.CustomerService.FindByID(ctx.Context(), customerID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET CUSTOMER---------")
+	return ctx.Status(fiber.StatusOK).JSON(customer)
+}
+
+func (controller *CustomerControllerImpl) Update(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the customerID...")
+	customerID, err := ulid.Parse(ctx.Params("customerID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse customerID: %v", err)
+		return err
+	}
+
+	customerUpdateRequest := web.CustomerUpdateRequest{
+		CustomerID: customerID,
+	}
+
+	controller.Logger.Info("trying to parse the request body...")
+	err = ctx.BodyParser(&customerUpdateRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+
+	controller.Logger.Info("executing CustomerService.Update()...")
+	customer, err := controller.CustomerService.Update(ctx.Context(), customerUpdateRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY UPDATE CUSTOMER---------")
+	return ctx.Status(fiber.StatusOK).JSON(customer)
+}
+
+func (controller *CustomerControllerImpl) Delete(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the customerID...")
+	customerID, err := ulid.Parse(ctx.Params("customerID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse customerID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing CustomerService.Delete()...")
+	err = controller.CustomerService.Delete(ctx.Context(), customerID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY DELETE CUSTOMER---------")
+	return ctx.Status(fiber.StatusOK).JSON(nil)
+}
+
+func (controller *CustomerControllerImpl) History(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the customerID...")
+	customerID, err := ulid.Parse(ctx.Params("customerID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse customerID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing CustomerService.History()...")
+	history, err := controller.CustomerService.History(ctx.Context(), customerID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET CUSTOMER PURCHASE HISTORY---------")
+	return ctx.Status(fiber.StatusOK).JSON(history)
+}
@@ -0,0 +1,10 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type GiftCardController interface {
+	Issue(ctx *fiber.Ctx) error
+	FindByCode(ctx *fiber.Ctx) error
+}
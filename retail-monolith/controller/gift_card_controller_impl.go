@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"retail-management/model/web"
+	"retail-management/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type GiftCardControllerImpl struct {
+	GiftCardService service.GiftCardService
+	Logger          *logrus.Logger
+}
+
+func NewGiftCardController(giftCardService service.GiftCardService, logger *logrus.Logger) GiftCardController {
+	return &GiftCardControllerImpl{
+		GiftCardService: giftCardService,
+		Logger:          logger,
+	}
+}
+
+func (controller *GiftCardControllerImpl) Issue(ctx *fiber.Ctx) error {
+	giftCardRequest := web.GiftCardRequest{}
+
+	controller.Logger.Info("trying to parse the request body...")
+	err := ctx.BodyParser(&giftCardRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+
+	userID, err := ulid.Parse(ctx.Locals("userID").(string))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID: %v", err)
+		return err
+	}
+	giftCardRequest.UserID = userID
+
+	controller.Logger.Info("executing GiftCardService.Issue()...")
+	giftCard, err := controller.GiftCardService.Issue(ctx.Context(), giftCardRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY ISSUE GIFT CARD---------")
+	return ctx.Status(fiber.StatusCreated).JSON(giftCard)
+}
+
+func (controller *GiftCardControllerImpl) FindByCode(ctx *fiber.Ctx) error {
+	controller.Logger.Info("executing GiftCardService.FindByCode()...")
+	giftCard, err := controller.GiftCardService.FindByCode(ctx.Context(), ctx.Params("cardCode"))
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET GIFT CARD BALANCE---------")
+	return ctx.Status(fiber.StatusOK).JSON(giftCard)
+}
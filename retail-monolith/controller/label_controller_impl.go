@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"fmt"
+	"retail-management/model/web"
+	"retail-management/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type LabelControllerImpl struct {
+	LabelService service.LabelService
+	Logger       *logrus.Logger
+}
+
+func NewLabelController(labelService service.LabelService, logger *logrus.Logger) LabelController {
+	return &LabelControllerImpl{
+		LabelService: labelService,
+		Logger:       logger,
+	}
+}
+
+func (controller *LabelControllerImpl) Render(ctx *fiber.Ctx) error {
+	labelRequest := web.LabelRequest{}
+
+	controller.Logger.Info("trying to parse the request body...")
+	err := ctx.BodyParser(&labelRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing LabelService.Render()...")
+	labels, err := controller.LabelService.Render(ctx.Context(), labelRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY RENDER LABELS---------")
+	ctx.Set(fiber.HeaderContentType, labels.ContentType)
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", labels.FileName))
+	return ctx.Status(fiber.StatusOK).Send(labels.Body)
+}
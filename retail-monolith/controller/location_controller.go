@@ -0,0 +1,10 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type LocationController interface {
+	Create(ctx *fiber.Ctx) error
+	FindAll(ctx *fiber.Ctx) error
+}
@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"retail-management/model/web"
+	"retail-management/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type LocationControllerImpl struct {
+	LocationService service.LocationService
+	Logger          *logrus.Logger
+}
+
+func NewLocationController(locationService service.LocationService, logger *logrus.Logger) LocationController {
+	return &LocationControllerImpl{
+		LocationService: locationService,
+		Logger:          logger,
+	}
+}
+
+func (controller *LocationControllerImpl) Create(ctx *fiber.Ctx) error {
+	locationRequest := web.LocationRequest{}
+
+	controller.Logger.Info("trying to parse the request body...")
+	err := ctx.BodyParser(&locationRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+
+	controller.Logger.Info("executing LocationService.Create()...")
+	location, err := controller.LocationService.Create(ctx.Context(), locationRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY CREATE LOCATION---------")
+	return ctx.Status(fiber.StatusCreated).JSON(location)
+}
+
+func (controller *LocationControllerImpl) FindAll(ctx *fiber.Ctx) error {
+	controller.Logger.Info("executing LocationService.FindAll()...")
+	locations, err := controller.LocationService.FindAll(ctx.Context())
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET ALL LOCATIONS---------")
+	return ctx.Status(fiber.StatusOK).JSON(locations)
+}
+
+func parseLocationQuery(ctx *fiber.Ctx) (*ulid.ULID, error) {
+	locationIDStr := ctx.Query("location_id")
+	if locationIDStr == "" {
+		return nil, nil
+	}
+
+	locationID, err := ulid.Parse(locationIDStr)
+	if err != nil {
+		return nil, err
+	}
+	return &locationID, nil
+}
@@ -0,0 +1,10 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type LotController interface {
+	FindExpiring(ctx *fiber.Ctx) error
+	WriteOffExpired(ctx *fiber.Ctx) error
+}
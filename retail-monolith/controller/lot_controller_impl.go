@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"retail-management/model/web"
+	"retail-management/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type LotControllerImpl struct {
+	LotService service.LotService
+	Logger     *logrus.Logger
+}
+
+func NewLotController(lotService service.LotService, logger *logrus.Logger) LotController {
+	return &LotControllerImpl{
+		LotService: lotService,
+		Logger:     logger,
+	}
+}
+
+func (controller *LotControllerImpl) FindExpiring(ctx *fiber.Ctx) error {
+	controller.Logger.Info("trying to parse location_id from query...")
+	locationID, err := parseLocationQuery(ctx)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse location_id: %v", err)
+		return err
+	}
+
+	withinDays := ctx.QueryInt("days", 30)
+
+	controller.Logger.Info("executing LotService.FindExpiring()...")
+	lots, err := controller.LotService.FindExpiring(ctx.Context(), locationID, withinDays)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET EXPIRING LOTS---------")
+	return ctx.Status(fiber.StatusOK).JSON(lots)
+}
+
+func (controller *LotControllerImpl) WriteOffExpired(ctx *fiber.Ctx) error {
+	writeOffRequest := web.LotWriteOffRequest{}
+
+	userID, err := ulid.Parse(ctx.Locals("userID").(string))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("trying to parse the request body...")
+	err = ctx.BodyParser(&writeOffRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+	writeOffRequest.UserID = userID
+
+	controller.Logger.Info("executing LotService.WriteOffExpired()...")
+	writeOff, err := controller.LotService.WriteOffExpired(ctx.Context(), writeOffRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY WRITE OFF EXPIRED LOTS---------")
+	return ctx.Status(fiber.StatusOK).JSON(writeOff)
+}
@@ -0,0 +1,9 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type LoyaltyController interface {
+	Balance(ctx *fiber.Ctx) error
+}
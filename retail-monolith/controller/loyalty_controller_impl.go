@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"retail-management/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type LoyaltyControllerImpl struct {
+	LoyaltyService service.LoyaltyService
+	Logger         *logrus.Logger
+}
+
+func NewLoyaltyController(loyaltyService service.LoyaltyService, logger *logrus.Logger) LoyaltyController {
+	return &LoyaltyControllerImpl{
+		LoyaltyService: loyaltyService,
+		Logger:         logger,
+	}
+}
+
+func (controller *LoyaltyControllerImpl) Balance(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the customerID...")
+	customerID, err := ulid.Parse(ctx.Params("customerID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse customerID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing LoyaltyService.Balance()...")
+	loyalty, err := controller.LoyaltyService.Balance(ctx.Context(), customerID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET LOYALTY POINTS---------")
+	return ctx.Status(fiber.StatusOK).JSON(loyalty)
+}
@@ -6,6 +6,7 @@ type ProductController interface {
 	Create(ctx *fiber.Ctx) error
 	FindAll(ctx *fiber.Ctx) error
 	FindByID(ctx *fiber.Ctx) error
+	Lookup(ctx *fiber.Ctx) error
 	Update(ctx *fiber.Ctx) error
 	UpdateStock(ctx *fiber.Ctx) error
 	Delete(ctx *fiber.Ctx) error
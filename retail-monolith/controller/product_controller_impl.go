@@ -51,8 +51,15 @@ func (controller *ProductControllerImpl) Create(ctx *fiber.Ctx) error {
 }
 
 func (controller *ProductControllerImpl) FindAll(ctx *fiber.Ctx) error {
+	controller.Logger.Info("trying to parse location_id from query...")
+	locationID, err := parseLocationQuery(ctx)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse location_id: %v", err)
+		return err
+	}
+
 	controller.Logger.Info("executing ProductService.FindAll()...")
-	selectedProducts, err := controller.ProductService.FindAll(ctx.Context())
+	selectedProducts, err := controller.ProductService.FindAll(ctx.Context(), locationID)
 	if err != nil {
 		controller.Logger.Errorf("failed to execute it: %v", err)
 		return err
@@ -72,8 +79,15 @@ func (controller *ProductControllerImpl) FindByID(ctx *fiber.Ctx) error {
 		return err
 	}
 
+	controller.Logger.Info("trying to parse location_id from query...")
+	locationID, err := parseLocationQuery(ctx)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse location_id: %v", err)
+		return err
+	}
+
 	controller.Logger.Info("executing ProductService.FindByID...")
-	product, err := controller.ProductService.FindByID(ctx.Context(), productID)
+	product, err := controller.ProductService.FindByID(ctx.Context(), productID, locationID)
 	if err != nil {
 		controller.Logger.Errorf("failed to execute ProductService.FindByID: %v", err)
 		webResponse := web.WebResponse{
@@ -87,6 +101,26 @@ func (controller *ProductControllerImpl) FindByID(ctx *fiber.Ctx) error {
 	return ctx.Status(fiber.StatusOK).JSON(product)
 }
 
+func (controller *ProductControllerImpl) Lookup(ctx *fiber.Ctx) error {
+	controller.Logger.Info("trying to parse location_id from query...")
+	locationID, err := parseLocationQuery(ctx)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse location_id: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing ProductService.Lookup()...")
+	product, err := controller.ProductService.Lookup(ctx.Context(), ctx.Query("barcode"), ctx.Query("sku"), locationID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY LOOKUP PRODUCT---------")
+	return ctx.Status(fiber.StatusOK).JSON(product)
+}
+
 func (controller *ProductControllerImpl) Update(ctx *fiber.Ctx) error {
 	controller.Logger.Info("Update: get and parse the productID...")
 	productIDStr := ctx.Params("productID")
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"retail-management/model/web"
+	"retail-management/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type PromotionControllerImpl struct {
+	PromotionService service.PromotionService
+	Logger           *logrus.Logger
+}
+
+func NewPromotionController(promotionService service.PromotionService, logger *logrus.Logger) PromotionController {
+	return &PromotionControllerImpl{
+		PromotionService: promotionService,
+		Logger:           logger,
+	}
+}
+
+func (controller *PromotionControllerImpl) Create(ctx *fiber.Ctx) error {
+	promotionRequest := web.PromotionRequest{}
+
+	controller.Logger.Info("trying to parse the request body...")
+	err := ctx.BodyParser(&promotionRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+
+	controller.Logger.Info("executing PromotionService.Create()...")
+	promotion, err := controller.PromotionService.Create(ctx.Context(), promotionRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY CREATE PROMOTION---------")
+	return ctx.Status(fiber.StatusCreated).JSON(promotion)
+}
+
+func (controller *PromotionControllerImpl) FindAll(ctx *fiber.Ctx) error {
+	controller.Logger.Info("executing PromotionService.FindAll()...")
+	promotions, err := controller.PromotionService.FindAll(ctx.Context(), ctx.QueryBool("active"))
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET ALL PROMOTIONS---------")
+	return ctx.Status(fiber.StatusOK).JSON(promotions)
+}
+
+func (controller *PromotionControllerImpl) FindByID(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the promotionID...")
+	promotionID, err := ulid.Parse(ctx.Params("promotionID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse promotionID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing PromotionService.FindByID()...")
+	promotion, err := controller.PromotionService.FindByID(ctx.Context(), promotionID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET PROMOTION---------")
+	return ctx.Status(fiber.StatusOK).JSON(promotion)
+}
+
+func (controller *PromotionControllerImpl) Update(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the promotionID...")
+	promotionID, err := ulid.Parse(ctx.Params("promotionID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse promotionID: %v", err)
+		return err
+	}
+
+	promotionUpdateRequest := web.PromotionUpdateRequest{
+		PromotionID: promotionID,
+	}
+
+	controller.Logger.Info("trying to parse the request body...")
+	err = ctx.BodyParser(&promotionUpdateRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+
+	controller.Logger.Info("executing PromotionService.Update()...")
+	promotion, err := controller.PromotionService.Update(ctx.Context(), promotionUpdateRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY UPDATE PROMOTION---------")
+	return ctx.Status(fiber.StatusOK).JSON(promotion)
+}
+
+func (controller *PromotionControllerImpl) Delete(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the promotionID...")
+	promotionID, err := ulid.Parse(ctx.Params("promotionID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse promotionID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing PromotionService.Delete()...")
+	err = controller.PromotionService.Delete(ctx.Context(), promotionID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY DELETE PROMOTION---------")
+	return ctx.Status(fiber.StatusOK).JSON(nil)
+}
@@ -0,0 +1,14 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type PurchaseOrderController interface {
+	Create(ctx *fiber.Ctx) error
+	FindAll(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+	Send(ctx *fiber.Ctx) error
+	Receive(ctx *fiber.Ctx) error
+	Close(ctx *fiber.Ctx) error
+}
@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"retail-management/model/web"
+	"retail-management/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type PurchaseOrderControllerImpl struct {
+	PurchaseOrderService service.PurchaseOrderService
+	Logger               *logrus.Logger
+}
+
+func NewPurchaseOrderController(purchaseOrderService service.PurchaseOrderService, logger *logrus.Logger) PurchaseOrderController {
+	return &PurchaseOrderControllerImpl{
+		PurchaseOrderService: purchaseOrderService,
+		Logger:               logger,
+	}
+}
+
+func (controller *PurchaseOrderControllerImpl) Create(ctx *fiber.Ctx) error {
+	purchaseOrderRequest := web.PurchaseOrderRequest{}
+
+	userID, err := ulid.Parse(ctx.Locals("userID").(string))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID: %v", err)
+		return err
+	}
+	purchaseOrderRequest.UserID = userID
+
+	controller.Logger.Info("trying to parse the request body...")
+	err = ctx.BodyParser(&purchaseOrderRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+
+	controller.Logger.Info("executing PurchaseOrderService.Create()...")
+	purchaseOrder, err := controller.PurchaseOrderService.Create(ctx.Context(), purchaseOrderRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY CREATE PURCHASE ORDER---------")
+	return ctx.Status(fiber.StatusCreated).JSON(purchaseOrder)
+}
+
+func (controller *PurchaseOrderControllerImpl) FindAll(ctx *fiber.Ctx) error {
+	var supplierID *ulid.ULID
+	if supplierIDStr := ctx.Query("supplier_id"); supplierIDStr != "" {
+		controller.Logger.Info("trying to parse supplier_id from query...")
+		parsedID, err := ulid.Parse(supplierIDStr)
+		if err != nil {
+			controller.Logger.Errorf("failed to parse supplier_id: %v", err)
+			return err
+		}
+		supplierID = &parsedID
+	}
+
+	controller.Logger.Info("executing PurchaseOrderService.FindAll()...")
+	purchaseOrders, err := controller.PurchaseOrderService.FindAll(ctx.Context(), ctx.Query("status"), supplierID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET ALL PURCHASE ORDERS---------")
+	return ctx.Status(fiber.StatusOK).JSON(purchaseOrders)
+}
+
+func (controller *PurchaseOrderControllerImpl) FindByID(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the purchaseOrderID...")
+	purchaseOrderID, err := ulid.Parse(ctx.Params("purchaseOrderID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse purchaseOrderID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing PurchaseOrderService.FindByID()...")
+	purchaseOrder, err := controller.PurchaseOrderService.FindByID(ctx.Context(), purchaseOrderID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY FIND PURCHASE ORDER BY ID---------")
+	return ctx.Status(fiber.StatusOK).JSON(purchaseOrder)
+}
+
+func (controller *PurchaseOrderControllerImpl) Send(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the purchaseOrderID...")
+	purchaseOrderID, err := ulid.Parse(ctx.Params("purchaseOrderID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse purchaseOrderID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing PurchaseOrderService.Send()...")
+	purchaseOrder, err := controller.PurchaseOrderService.Send(ctx.Context(), purchaseOrderID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY SEND PURCHASE ORDER---------")
+	return ctx.Status(fiber.StatusOK).JSON(purchaseOrder)
+}
+
+func (controller *PurchaseOrderControllerImpl) Receive(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the purchaseOrderID...")
+	purchaseOrderID, err := ulid.Parse(ctx.Params("purchaseOrderID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse purchaseOrderID: %v", err)
+		return err
+	}
+
+	userID, err := ulid.Parse(ctx.Locals("userID").(string))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID: %v", err)
+		return err
+	}
+
+	receiptRequest := web.GoodsReceiptRequest{
+		PurchaseOrderID: purchaseOrderID,
+		UserID:          userID,
+	}
+
+	controller.Logger.Info("trying to parse the request body...")
+	err = ctx.BodyParser(&receiptRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+	receiptRequest.IdempotencyKey = ctx.Get("Idempotency-Key")
+
+	controller.Logger.Info("executing PurchaseOrderService.Receive()...")
+	purchaseOrder, err := controller.PurchaseOrderService.Receive(ctx.Context(), receiptRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY RECEIVE GOODS---------")
+	return ctx.Status(fiber.StatusCreated).JSON(purchaseOrder)
+}
+
+func (controller *PurchaseOrderControllerImpl) Close(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the purchaseOrderID...")
+	purchaseOrderID, err := ulid.Parse(ctx.Params("purchaseOrderID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse purchaseOrderID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing PurchaseOrderService.Close()...")
+	purchaseOrder, err := controller.PurchaseOrderService.Close(ctx.Context(), purchaseOrderID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY CLOSE PURCHASE ORDER---------")
+	return ctx.Status(fiber.StatusOK).JSON(purchaseOrder)
+}
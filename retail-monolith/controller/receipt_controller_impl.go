@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"fmt"
+	"retail-management/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type ReceiptControllerImpl struct {
+	ReceiptService service.ReceiptService
+	Logger         *logrus.Logger
+}
+
+func NewReceiptController(receiptService service.ReceiptService, logger *logrus.Logger) ReceiptController {
+	return &ReceiptControllerImpl{
+		ReceiptService: receiptService,
+		Logger:         logger,
+	}
+}
+
+func (controller *ReceiptControllerImpl) Render(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the transactionID...")
+	transactionID, err := ulid.Parse(ctx.Params("transactionID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse transactionID: %v", err)
+		return err
+	}
+
+	userID, err := ulid.Parse(ctx.Locals("userID").(string))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID: %v", err)
+		return err
+	}
+	role, _ := ctx.Locals("role").(string)
+
+	controller.Logger.Info("executing ReceiptService.Render()...")
+	receipt, err := controller.ReceiptService.Render(ctx.Context(), userID, role, transactionID, ctx.Query("format"))
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY RENDER RECEIPT---------")
+	ctx.Set(fiber.HeaderContentType, receipt.ContentType)
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", receipt.FileName))
+	return ctx.Status(fiber.StatusOK).Send(receipt.Body)
+}
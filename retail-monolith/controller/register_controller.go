@@ -0,0 +1,11 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type RegisterController interface {
+	Create(ctx *fiber.Ctx) error
+	FindAll(ctx *fiber.Ctx) error
+	AssignUser(ctx *fiber.Ctx) error
+}
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"retail-management/model/web"
+	"retail-management/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type RegisterControllerImpl struct {
+	RegisterService service.RegisterService
+	Logger          *logrus.Logger
+}
+
+func NewRegisterController(registerService service.RegisterService, logger *logrus.Logger) RegisterController {
+	return &RegisterControllerImpl{
+		RegisterService: registerService,
+		Logger:          logger,
+	}
+}
+
+func (controller *RegisterControllerImpl) Create(ctx *fiber.Ctx) error {
+	registerRequest := web.RegisterRequest{}
+
+	controller.Logger.Info("trying to parse the request body...")
+	err := ctx.BodyParser(&registerRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+
+	controller.Logger.Info("executing RegisterService.Create()...")
+	register, err := controller.RegisterService.Create(ctx.Context(), registerRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY CREATE REGISTER---------")
+	return ctx.Status(fiber.StatusCreated).JSON(register)
+}
+
+func (controller *RegisterControllerImpl) FindAll(ctx *fiber.Ctx) error {
+	controller.Logger.Info("executing RegisterService.FindAll()...")
+	registers, err := controller.RegisterService.FindAll(ctx.Context())
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET ALL REGISTERS---------")
+	return ctx.Status(fiber.StatusOK).JSON(registers)
+}
+
+func (controller *RegisterControllerImpl) AssignUser(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the userID...")
+	userID, err := ulid.Parse(ctx.Params("userID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID: %v", err)
+		return err
+	}
+
+	assignRequest := web.RegisterAssignRequest{
+		UserID: userID,
+	}
+
+	controller.Logger.Info("trying to parse the request body...")
+	err = ctx.BodyParser(&assignRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+
+	controller.Logger.Info("executing RegisterService.AssignUser()...")
+	err = controller.RegisterService.AssignUser(ctx.Context(), assignRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY ASSIGN REGISTER---------")
+	return ctx.Status(fiber.StatusOK).JSON(nil)
+}
@@ -0,0 +1,12 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReplenishmentController interface {
+	SaveRule(ctx *fiber.Ctx) error
+	FindRule(ctx *fiber.Ctx) error
+	Suggest(ctx *fiber.Ctx) error
+	CreatePurchaseOrders(ctx *fiber.Ctx) error
+}
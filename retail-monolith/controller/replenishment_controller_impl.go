@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"retail-management/model/web"
+	"retail-management/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type ReplenishmentControllerImpl struct {
+	ReplenishmentService service.ReplenishmentService
+	Logger               *logrus.Logger
+}
+
+func NewReplenishmentController(replenishmentService service.ReplenishmentService, logger *logrus.Logger) ReplenishmentController {
+	return &ReplenishmentControllerImpl{
+		ReplenishmentService: replenishmentService,
+		Logger:               logger,
+	}
+}
+
+func (controller *ReplenishmentControllerImpl) SaveRule(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the productID...")
+	productID, err := ulid.Parse(ctx.Params("productID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse productID: %v", err)
+		return err
+	}
+
+	ruleRequest := web.ReorderRuleRequest{}
+
+	controller.Logger.Info("trying to parse the request body...")
+	err = ctx.BodyParser(&ruleRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+	ruleRequest.ProductID = productID
+
+	controller.Logger.Info("executing ReplenishmentService.SaveRule()...")
+	rule, err := controller.ReplenishmentService.SaveRule(ctx.Context(), ruleRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY SAVE REORDER RULE---------")
+	return ctx.Status(fiber.StatusOK).JSON(rule)
+}
+
+func (controller *ReplenishmentControllerImpl) FindRule(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the productID...")
+	productID, err := ulid.Parse(ctx.Params("productID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse productID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing ReplenishmentService.FindRule()...")
+	rule, err := controller.ReplenishmentService.FindRule(ctx.Context(), productID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY FIND REORDER RULE---------")
+	return ctx.Status(fiber.StatusOK).JSON(rule)
+}
+
+func (controller *ReplenishmentControllerImpl) Suggest(ctx *fiber.Ctx) error {
+	controller.Logger.Info("trying to parse location_id from query...")
+	locationID, err := parseLocationQuery(ctx)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse location_id: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing ReplenishmentService.Suggest()...")
+	suggestion, err := controller.ReplenishmentService.Suggest(ctx.Context(), locationID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET REPLENISHMENT SUGGESTIONS---------")
+	return ctx.Status(fiber.StatusOK).JSON(suggestion)
+}
+
+func (controller *ReplenishmentControllerImpl) CreatePurchaseOrders(ctx *fiber.Ctx) error {
+	userID, err := ulid.Parse(ctx.Locals("userID").(string))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("trying to parse location_id from query...")
+	locationID, err := parseLocationQuery(ctx)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse location_id: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing ReplenishmentService.CreatePurchaseOrders()...")
+	purchaseOrders, err := controller.ReplenishmentService.CreatePurchaseOrders(ctx.Context(), web.ReplenishmentRequest{
+		UserID:     userID,
+		LocationID: locationID,
+	})
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY CREATE REPLENISHMENT PURCHASE ORDERS---------")
+	return ctx.Status(fiber.StatusCreated).JSON(purchaseOrders)
+}
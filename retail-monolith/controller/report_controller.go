@@ -0,0 +1,11 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReportController interface {
+	Sales(ctx *fiber.Ctx) error
+	Payments(ctx *fiber.Ctx) error
+	Tax(ctx *fiber.Ctx) error
+}
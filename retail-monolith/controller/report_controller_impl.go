@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"retail-management/model/web"
+	"retail-management/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type ReportControllerImpl struct {
+	ReportService service.ReportService
+	Logger        *logrus.Logger
+}
+
+func NewReportController(reportService service.ReportService, logger *logrus.Logger) ReportController {
+	return &ReportControllerImpl{
+		ReportService: reportService,
+		Logger:        logger,
+	}
+}
+
+func (controller *ReportControllerImpl) Sales(ctx *fiber.Ctx) error {
+	controller.Logger.Info("trying to parse location_id from query...")
+	locationID, err := parseLocationQuery(ctx)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse location_id: %v", err)
+		return err
+	}
+
+	reportRequest := web.SalesReportRequest{
+		GroupBy:    ctx.Query("group_by", "day"),
+		From:       ctx.Query("from"),
+		To:         ctx.Query("to"),
+		LocationID: locationID,
+	}
+
+	controller.Logger.Info("executing ReportService.Sales()...")
+	report, err := controller.ReportService.Sales(ctx.Context(), reportRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET SALES REPORT---------")
+	return ctx.Status(fiber.StatusOK).JSON(report)
+}
+
+func (controller *ReportControllerImpl) Payments(ctx *fiber.Ctx) error {
+	controller.Logger.Info("trying to parse location_id from query...")
+	locationID, err := parseLocationQuery(ctx)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse location_id: %v", err)
+		return err
+	}
+
+	reportRequest := web.PaymentReportRequest{
+		From:       ctx.Query("from"),
+		To:         ctx.Query("to"),
+		LocationID: locationID,
+	}
+
+	controller.Logger.Info("executing ReportService.Payments()...")
+	report, err := controller.ReportService.Payments(ctx.Context(), reportRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET PAYMENT REPORT---------")
+	return ctx.Status(fiber.StatusOK).JSON(report)
+}
+
+func (controller *ReportControllerImpl) Tax(ctx *fiber.Ctx) error {
+	controller.Logger.Info("trying to parse location_id from query...")
+	locationID, err := parseLocationQuery(ctx)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse location_id: %v", err)
+		return err
+	}
+
+	reportRequest := web.TaxReportRequest{
+		From:       ctx.Query("from"),
+		To:         ctx.Query("to"),
+		LocationID: locationID,
+	}
+
+	controller.Logger.Info("executing ReportService.Tax()...")
+	report, err := controller.ReportService.Tax(ctx.Context(), reportRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET TAX REPORT---------")
+	return ctx.Status(fiber.StatusOK).JSON(report)
+}
@@ -0,0 +1,13 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type ShiftController interface {
+	Open(ctx *fiber.Ctx) error
+	FindAll(ctx *fiber.Ctx) error
+	FindCurrent(ctx *fiber.Ctx) error
+	Report(ctx *fiber.Ctx) error
+	Close(ctx *fiber.Ctx) error
+}
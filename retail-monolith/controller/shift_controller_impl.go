@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"retail-management/model/web"
+	"retail-management/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type ShiftControllerImpl struct {
+	ShiftService service.ShiftService
+	Logger       *logrus.Logger
+}
+
+func NewShiftController(shiftService service.ShiftService, logger *logrus.Logger) ShiftController {
+	return &ShiftControllerImpl{
+		ShiftService: shiftService,
+		Logger:       logger,
+	}
+}
+
+func (controller *ShiftControllerImpl) Open(ctx *fiber.Ctx) error {
+	openRequest := web.ShiftOpenRequest{}
+
+	userID, err := ulid.Parse(ctx.Locals("userID").(string))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("trying to parse the request body...")
+	err = ctx.BodyParser(&openRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+	openRequest.UserID = userID
+
+	controller.Logger.Info("executing ShiftService.Open()...")
+	shift, err := controller.ShiftService.Open(ctx.Context(), openRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY OPEN SHIFT---------")
+	return ctx.Status(fiber.StatusCreated).JSON(shift)
+}
+
+func (controller *ShiftControllerImpl) FindAll(ctx *fiber.Ctx) error {
+	userID, err := ulid.Parse(ctx.Locals("userID").(string))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID: %v", err)
+		return err
+	}
+	role, _ := ctx.Locals("role").(string)
+
+	controller.Logger.Info("executing ShiftService.FindAll()...")
+	shifts, err := controller.ShiftService.FindAll(ctx.Context(), userID, role, ctx.Query("status"))
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET ALL SHIFTS---------")
+	return ctx.Status(fiber.StatusOK).JSON(shifts)
+}
+
+func (controller *ShiftControllerImpl) FindCurrent(ctx *fiber.Ctx) error {
+	userID, err := ulid.Parse(ctx.Locals("userID").(string))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing ShiftService.FindCurrent()...")
+	report, err := controller.ShiftService.FindCurrent(ctx.Context(), userID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET CURRENT SHIFT---------")
+	return ctx.Status(fiber.StatusOK).JSON(report)
+}
+
+func (controller *ShiftControllerImpl) Report(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the shiftID...")
+	shiftID, err := ulid.Parse(ctx.Params("shiftID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse shiftID: %v", err)
+		return err
+	}
+
+	userID, err := ulid.Parse(ctx.Locals("userID").(string))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID: %v", err)
+		return err
+	}
+	role, _ := ctx.Locals("role").(string)
+
+	controller.Logger.Info("executing ShiftService.Report()...")
+	report, err := controller.ShiftService.Report(ctx.Context(), userID, role, shiftID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET SHIFT REPORT---------")
+	return ctx.Status(fiber.StatusOK).JSON(report)
+}
+
+func (controller *ShiftControllerImpl) Close(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the shiftID...")
+	shiftID, err := ulid.Parse(ctx.Params("shiftID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse shiftID: %v", err)
+		return err
+	}
+
+	userID, err := ulid.Parse(ctx.Locals("userID").(string))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID: %v", err)
+		return err
+	}
+	role, _ := ctx.Locals("role").(string)
+
+	closeRequest := web.ShiftCloseRequest{}
+
+	controller.Logger.Info("trying to parse the request body...")
+	err = ctx.BodyParser(&closeRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+	closeRequest.ShiftID = shiftID
+	closeRequest.UserID = userID
+
+	controller.Logger.Info("executing ShiftService.Close()...")
+	report, err := controller.ShiftService.Close(ctx.Context(), role, closeRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY CLOSE SHIFT---------")
+	return ctx.Status(fiber.StatusOK).JSON(report)
+}
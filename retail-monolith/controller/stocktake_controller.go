@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type StocktakeController interface {
+	Create(ctx *fiber.Ctx) error
+	FindAll(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+	SubmitCounts(ctx *fiber.Ctx) error
+	Variance(ctx *fiber.Ctx) error
+	Approve(ctx *fiber.Ctx) error
+	Cancel(ctx *fiber.Ctx) error
+}
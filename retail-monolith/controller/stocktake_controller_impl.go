@@ -0,0 +1,216 @@
+package controller
+
+import (
+	"retail-management/model/web"
+	"retail-management/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type StocktakeControllerImpl struct {
+	StocktakeService service.StocktakeService
+	Logger           *logrus.Logger
+}
+
+func NewStocktakeController(stocktakeService service.StocktakeService, logger *logrus.Logger) StocktakeController {
+	return &StocktakeControllerImpl{
+		StocktakeService: stocktakeService,
+		Logger:           logger,
+	}
+}
+
+func (controller *StocktakeControllerImpl) Create(ctx *fiber.Ctx) error {
+	stocktakeRequest := web.StocktakeRequest{}
+
+	userID, err := ulid.Parse(ctx.Locals("userID").(string))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID: %v", err)
+		return err
+	}
+	stocktakeRequest.UserID = userID
+
+	controller.Logger.Info("trying to parse the request body...")
+	err = ctx.BodyParser(&stocktakeRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+
+	controller.Logger.Info("executing StocktakeService.Create()...")
+	stocktake, err := controller.StocktakeService.Create(ctx.Context(), stocktakeRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY CREATE STOCKTAKE---------")
+	return ctx.Status(fiber.StatusCreated).JSON(stocktake)
+}
+
+func (controller *StocktakeControllerImpl) FindAll(ctx *fiber.Ctx) error {
+	controller.Logger.Info("trying to parse location_id from query...")
+	locationID, err := parseLocationQuery(ctx)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse location_id: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing StocktakeService.FindAll()...")
+	stocktakes, err := controller.StocktakeService.FindAll(ctx.Context(), ctx.Query("status"), locationID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET ALL STOCKTAKES---------")
+	return ctx.Status(fiber.StatusOK).JSON(stocktakes)
+}
+
+func (controller *StocktakeControllerImpl) FindByID(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the sessionID...")
+	sessionID, err := ulid.Parse(ctx.Params("sessionID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse sessionID: %v", err)
+		return err
+	}
+
+	userID, err := ulid.Parse(ctx.Locals("userID").(string))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID: %v", err)
+		return err
+	}
+
+	role, _ := ctx.Locals("role").(string)
+
+	controller.Logger.Info("executing StocktakeService.FindByID()...")
+	stocktake, err := controller.StocktakeService.FindByID(ctx.Context(), sessionID, userID, role == "admin")
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY FIND STOCKTAKE BY ID---------")
+	return ctx.Status(fiber.StatusOK).JSON(stocktake)
+}
+
+func (controller *StocktakeControllerImpl) SubmitCounts(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the sessionID...")
+	sessionID, err := ulid.Parse(ctx.Params("sessionID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse sessionID: %v", err)
+		return err
+	}
+
+	userID, err := ulid.Parse(ctx.Locals("userID").(string))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID: %v", err)
+		return err
+	}
+
+	countRequest := web.StocktakeCountRequest{}
+
+	controller.Logger.Info("trying to parse the request body...")
+	err = ctx.BodyParser(&countRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+	countRequest.SessionID = sessionID
+	countRequest.UserID = userID
+
+	controller.Logger.Info("executing StocktakeService.SubmitCounts()...")
+	stocktake, err := controller.StocktakeService.SubmitCounts(ctx.Context(), countRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY SUBMIT STOCKTAKE COUNTS---------")
+	return ctx.Status(fiber.StatusOK).JSON(stocktake)
+}
+
+func (controller *StocktakeControllerImpl) Variance(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the sessionID...")
+	sessionID, err := ulid.Parse(ctx.Params("sessionID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse sessionID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing StocktakeService.Variance()...")
+	variance, err := controller.StocktakeService.Variance(ctx.Context(), sessionID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET STOCKTAKE VARIANCE---------")
+	return ctx.Status(fiber.StatusOK).JSON(variance)
+}
+
+func (controller *StocktakeControllerImpl) Approve(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the sessionID...")
+	sessionID, err := ulid.Parse(ctx.Params("sessionID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse sessionID: %v", err)
+		return err
+	}
+
+	userID, err := ulid.Parse(ctx.Locals("userID").(string))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing StocktakeService.Approve()...")
+	variance, err := controller.StocktakeService.Approve(ctx.Context(), sessionID, userID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY APPROVE STOCKTAKE---------")
+	return ctx.Status(fiber.StatusOK).JSON(variance)
+}
+
+func (controller *StocktakeControllerImpl) Cancel(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the sessionID...")
+	sessionID, err := ulid.Parse(ctx.Params("sessionID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse sessionID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing StocktakeService.Cancel()...")
+	stocktake, err := controller.StocktakeService.Cancel(ctx.Context(), sessionID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY CANCEL STOCKTAKE---------")
+	return ctx.Status(fiber.StatusOK).JSON(stocktake)
+}
@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"retail-management/model/web"
+	"retail-management/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type TaxControllerImpl struct {
+	TaxService service.TaxService
+	Logger     *logrus.Logger
+}
+
+func NewTaxController(taxService service.TaxService, logger *logrus.Logger) TaxController {
+	return &TaxControllerImpl{
+		TaxService: taxService,
+		Logger:     logger,
+	}
+}
+
+func (controller *TaxControllerImpl) Create(ctx *fiber.Ctx) error {
+	taxRateRequest := web.TaxRateRequest{}
+
+	controller.Logger.Info("trying to parse the request body...")
+	err := ctx.BodyParser(&taxRateRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+
+	controller.Logger.Info("executing TaxService.Create()...")
+	taxRate, err := controller.TaxService.Create(ctx.Context(), taxRateRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY CREATE TAX RATE---------")
+	return ctx.Status(fiber.StatusCreated).JSON(taxRate)
+}
+
+func (controller *TaxControllerImpl) FindAll(ctx *fiber.Ctx) error {
+	controller.Logger.Info("executing TaxService.FindAll()...")
+	taxRates, err := controller.TaxService.FindAll(ctx.Context())
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET ALL TAX RATES---------")
+	return ctx.Status(fiber.StatusOK).JSON(taxRates)
+}
+
+func (controller *TaxControllerImpl) Update(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the taxRateID...")
+	taxRateID, err := ulid.Parse(ctx.Params("taxRateID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse taxRateID: %v", err)
+		return err
+	}
+
+	taxRateUpdateRequest := web.TaxRateUpdateRequest{
+		TaxRateID: taxRateID,
+	}
+
+	controller.Logger.Info("trying to parse the request body...")
+	err = ctx.BodyParser(&taxRateUpdateRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+
+	controller.Logger.Info("executing TaxService.Update()...")
+	taxRate, err := controller.TaxService.Update(ctx.Context(), taxRateUpdateRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY UPDATE TAX RATE---------")
+	return ctx.Status(fiber.StatusOK).JSON(taxRate)
+}
+
+func (controller *TaxControllerImpl) Delete(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the taxRateID...")
+	taxRateID, err := ulid.Parse(ctx.Params("taxRateID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse taxRateID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing TaxService.Delete()...")
+	err = controller.TaxService.Delete(ctx.Context(), taxRateID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY DELETE TAX RATE---------")
+	return ctx.Status(fiber.StatusOK).JSON(nil)
+}
+
+func (controller *TaxControllerImpl) AssignToCategory(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the categoryID...")
+	categoryID, err := ulid.Parse(ctx.Params("categoryID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse categoryID: %v", err)
+		return err
+	}
+
+	assignRequest := web.TaxRateAssignRequest{
+		TargetID: categoryID,
+	}
+
+	controller.Logger.Info("trying to parse the request body...")
+	err = ctx.BodyParser(&assignRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+
+	controller.Logger.Info("executing TaxService.AssignToCategory()...")
+	err = controller.TaxService.AssignToCategory(ctx.Context(), assignRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY ASSIGN CATEGORY TAX RATE---------")
+	return ctx.Status(fiber.StatusOK).JSON(nil)
+}
+
+func (controller *TaxControllerImpl) AssignToProduct(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the productID...")
+	productID, err := ulid.Parse(ctx.Params("productID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse productID: %v", err)
+		return err
+	}
+
+	assignRequest := web.TaxRateAssignRequest{
+		TargetID: productID,
+	}
+
+	controller.Logger.Info("trying to parse the request body...")
+	err = ctx.BodyParser(&assignRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+
+	controller.Logger.Info("executing TaxService.AssignToProduct()...")
+	err = controller.TaxService.AssignToProduct(ctx.Context(), assignRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY ASSIGN PRODUCT TAX RATE---------")
+	return ctx.Status(fiber.StatusOK).JSON(nil)
+}
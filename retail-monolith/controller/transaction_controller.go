@@ -6,4 +6,6 @@ type TransactionController interface {
 	Create(ctx *fiber.Ctx) error
 	FindAll(ctx *fiber.Ctx) error
 	FindByID(ctx *fiber.Ctx) error
+	CreateReturn(ctx *fiber.Ctx) error
+	Void(ctx *fiber.Ctx) error
 }
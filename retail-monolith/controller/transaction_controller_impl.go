@@ -40,6 +40,7 @@ func (controller *TransactionControllerImpl) Create(ctx *fiber.Ctx) error {
 		controller.Logger.Errorf("failed parse req body: %v", err)
 		return err
 	}
+	transactionRequest.IdempotencyKey = ctx.Get("Idempotency-Key")
 
 	controller.Logger.Info("executing TransactionService.Create()...")
 	createdTransactions, err := controller.TransactionService.Create(ctx.Context(), transactionRequest)
@@ -148,3 +149,127 @@ func (controller *TransactionControllerImpl) FindByID(ctx *fiber.Ctx) error {
 		Data:   response,
 	})
 }
+
+func (controller *TransactionControllerImpl) CreateReturn(ctx *fiber.Ctx) error {
+	transactionIDStr := ctx.Params("transactionID")
+	controller.Logger.Infof("param transactionID: %s", transactionIDStr)
+
+	transactionID, err := ulid.Parse(transactionIDStr)
+	if err != nil {
+		return err
+	}
+
+	userIDRaw := ctx.Locals("userID")
+	roleRaw := ctx.Locals("role")
+
+	if userIDRaw == nil || roleRaw == nil {
+		controller.Logger.Error("missing user info in context")
+		return exception.ErrUnauthorized
+	}
+
+	userIDStr, ok := userIDRaw.(string)
+	if !ok || userIDStr == "" {
+		controller.Logger.Error("userID in context is not a valid string")
+		return errors.New("invalid user id type")
+	}
+
+	role, ok := roleRaw.(string)
+	if !ok {
+		controller.Logger.Error("role in context is not a valid string")
+		return errors.New("invalid role type")
+	}
+
+	userID, err := ulid.Parse(userIDStr)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID from context: %v", err)
+		return exception.ErrUnauthorized
+	}
+
+	returnRequest := web.TransactionReturnRequest{}
+
+	controller.Logger.Info("trying to parse the req body...")
+	err = ctx.BodyParser(&returnRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed parse req body: %v", err)
+		return err
+	}
+	returnRequest.TransactionID = transactionID
+	returnRequest.UserID = userID
+
+	controller.Logger.Info("executing TransactionService.CreateReturn()...")
+	createdReturn, err := controller.TransactionService.CreateReturn(ctx.Context(), role, returnRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY CREATE TRANSACTION RETURN---------")
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:   fiber.StatusCreated,
+		Status: "CREATED",
+		Data:   createdReturn,
+	})
+}
+
+func (controller *TransactionControllerImpl) Void(ctx *fiber.Ctx) error {
+	transactionIDStr := ctx.Params("transactionID")
+	controller.Logger.Infof("param transactionID: %s", transactionIDStr)
+
+	transactionID, err := ulid.Parse(transactionIDStr)
+	if err != nil {
+		return err
+	}
+
+	userIDRaw := ctx.Locals("userID")
+	roleRaw := ctx.Locals("role")
+
+	if userIDRaw == nil || roleRaw == nil {
+		controller.Logger.Error("missing user info in context")
+		return exception.ErrUnauthorized
+	}
+
+	userIDStr, ok := userIDRaw.(string)
+	if !ok || userIDStr == "" {
+		controller.Logger.Error("userID in context is not a valid string")
+		return errors.New("invalid user id type")
+	}
+
+	role, ok := roleRaw.(string)
+	if !ok {
+		controller.Logger.Error("role in context is not a valid string")
+		return errors.New("invalid role type")
+	}
+
+	userID, err := ulid.Parse(userIDStr)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID from context: %v", err)
+		return exception.ErrUnauthorized
+	}
+
+	voidRequest := web.TransactionVoidRequest{}
+
+	controller.Logger.Info("trying to parse the req body...")
+	err = ctx.BodyParser(&voidRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed parse req body: %v", err)
+		return err
+	}
+	voidRequest.TransactionID = transactionID
+	voidRequest.UserID = userID
+
+	controller.Logger.Info("executing TransactionService.Void()...")
+	voidedTransaction, err := controller.TransactionService.Void(ctx.Context(), role, voidRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY VOID TRANSACTION---------")
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   voidedTransaction,
+	})
+}
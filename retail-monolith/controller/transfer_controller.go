@@ -0,0 +1,14 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type TransferController interface {
+	Create(ctx *fiber.Ctx) error
+	FindAll(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+	Dispatch(ctx *fiber.Ctx) error
+	Receive(ctx *fiber.Ctx) error
+	Cancel(ctx *fiber.Ctx) error
+}
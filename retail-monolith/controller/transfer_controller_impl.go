@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"retail-management/model/web"
+	"retail-management/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type TransferControllerImpl struct {
+	TransferService service.TransferService
+	Logger          *logrus.Logger
+}
+
+func NewTransferController(transferService service.TransferService, logger *logrus.Logger) TransferController {
+	return &TransferControllerImpl{
+		TransferService: transferService,
+		Logger:          logger,
+	}
+}
+
+func (controller *TransferControllerImpl) Create(ctx *fiber.Ctx) error {
+	transferRequest := web.TransferRequest{}
+
+	userID, err := ulid.Parse(ctx.Locals("userID").(string))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID: %v", err)
+		return err
+	}
+	transferRequest.UserID = userID
+
+	controller.Logger.Info("trying to parse the request body...")
+	err = ctx.BodyParser(&transferRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+
+	controller.Logger.Info("executing TransferService.Create()...")
+	transfer, err := controller.TransferService.Create(ctx.Context(), transferRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY CREATE TRANSFER---------")
+	return ctx.Status(fiber.StatusCreated).JSON(transfer)
+}
+
+func (controller *TransferControllerImpl) FindAll(ctx *fiber.Ctx) error {
+	controller.Logger.Info("trying to parse location_id from query...")
+	locationID, err := parseLocationQuery(ctx)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse location_id: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing TransferService.FindAll()...")
+	transfers, err := controller.TransferService.FindAll(ctx.Context(), locationID, ctx.Query("status"))
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET ALL TRANSFERS---------")
+	return ctx.Status(fiber.StatusOK).JSON(transfers)
+}
+
+func (controller *TransferControllerImpl) FindByID(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the transferID...")
+	transferID, err := ulid.Parse(ctx.Params("transferID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse transferID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing TransferService.FindByID()...")
+	transfer, err := controller.TransferService.FindByID(ctx.Context(), transferID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY FIND TRANSFER BY ID---------")
+	return ctx.Status(fiber.StatusOK).JSON(transfer)
+}
+
+func (controller *TransferControllerImpl) Dispatch(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the transferID...")
+	transferID, err := ulid.Parse(ctx.Params("transferID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse transferID: %v", err)
+		return err
+	}
+
+	userID, err := ulid.Parse(ctx.Locals("userID").(string))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing TransferService.Dispatch()...")
+	transfer, err := controller.TransferService.Dispatch(ctx.Context(), transferID, userID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY DISPATCH TRANSFER---------")
+	return ctx.Status(fiber.StatusOK).JSON(transfer)
+}
+
+func (controller *TransferControllerImpl) Receive(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the transferID...")
+	transferID, err := ulid.Parse(ctx.Params("transferID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse transferID: %v", err)
+		return err
+	}
+
+	userID, err := ulid.Parse(ctx.Locals("userID").(string))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID: %v", err)
+		return err
+	}
+
+	receiveRequest := web.TransferReceiveRequest{
+		TransferID: transferID,
+		UserID:     userID,
+	}
+
+	controller.Logger.Info("trying to parse the request body...")
+	err = ctx.BodyParser(&receiveRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to parse the body request: %v", err)
+
+		webResponse := web.WebResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "BAD REQUEST",
+			Data:   err,
+		}
+
+		return ctx.Status(fiber.StatusBadRequest).JSON(webResponse)
+	}
+	receiveRequest.IdempotencyKey = ctx.Get("Idempotency-Key")
+
+	controller.Logger.Info("executing TransferService.Receive()...")
+	transfer, err := controller.TransferService.Receive(ctx.Context(), receiveRequest)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY RECEIVE TRANSFER---------")
+	return ctx.Status(fiber.StatusOK).JSON(transfer)
+}
+
+func (controller *TransferControllerImpl) Cancel(ctx *fiber.Ctx) error {
+	controller.Logger.Info("get and parse the transferID...")
+	transferID, err := ulid.Parse(ctx.Params("transferID"))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse transferID: %v", err)
+		return err
+	}
+
+	userID, err := ulid.Parse(ctx.Locals("userID").(string))
+	if err != nil {
+		controller.Logger.Errorf("failed to parse userID: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("executing TransferService.Cancel()...")
+	transfer, err := controller.TransferService.Cancel(ctx.Context(), transferID, userID)
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY CANCEL TRANSFER---------")
+	return ctx.Status(fiber.StatusOK).JSON(transfer)
+}
@@ -0,0 +1,9 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type ValuationController interface {
+	Valuate(ctx *fiber.Ctx) error
+}
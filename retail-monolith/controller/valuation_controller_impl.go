@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"retail-management/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type ValuationControllerImpl struct {
+	ValuationService service.ValuationService
+	Logger           *logrus.Logger
+}
+
+func NewValuationController(valuationService service.ValuationService, logger *logrus.Logger) ValuationController {
+	return &ValuationControllerImpl{
+		ValuationService: valuationService,
+		Logger:           logger,
+	}
+}
+
+func (controller *ValuationControllerImpl) Valuate(ctx *fiber.Ctx) error {
+	controller.Logger.Info("executing ValuationService.Valuate()...")
+	valuation, err := controller.ValuationService.Valuate(ctx.Context(), ctx.Query("date"))
+	if err != nil {
+		controller.Logger.Errorf("failed to execute it: %v", err)
+		return err
+	}
+
+	controller.Logger.Info("returning the http response...")
+	controller.Logger.Info("---------SUCCESFULLY GET INVENTORY VALUATION---------")
+	return ctx.Status(fiber.StatusOK).JSON(valuation)
+}
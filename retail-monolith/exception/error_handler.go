@@ -23,6 +23,34 @@ func ErrorHandler(ctx *fiber.Ctx, err error) error {
 		code = fiber.StatusBadRequest
 		status = "BAD REQUEST"
 	}
+	if errors.Is(err, ErrInvalidReturnItem) || errors.Is(err, ErrReturnExceedsSold) {
+		code = fiber.StatusBadRequest
+		status = "BAD REQUEST"
+	}
+	if errors.Is(err, ErrLocationNotResolved) || errors.Is(err, ErrInvalidTransfer) {
+		code = fiber.StatusBadRequest
+		status = "BAD REQUEST"
+	}
+	if errors.Is(err, ErrProductSupplierMismatch) || errors.Is(err, ErrInvalidReceiptItem) || errors.Is(err, ErrReceiptExceedsOrdered) {
+		code = fiber.StatusBadRequest
+		status = "BAD REQUEST"
+	}
+	if errors.Is(err, ErrEmptyStocktake) || errors.Is(err, ErrInvalidStocktakeItem) || errors.Is(err, ErrInvalidLotRequest) || errors.Is(err, ErrInvalidValuationDate) || errors.Is(err, ErrInvalidReportRange) || errors.Is(err, ErrInvalidCashAmount) {
+		code = fiber.StatusBadRequest
+		status = "BAD REQUEST"
+	}
+	if errors.Is(err, ErrInvalidPayment) || errors.Is(err, ErrPaymentMismatch) {
+		code = fiber.StatusBadRequest
+		status = "BAD REQUEST"
+	}
+	if errors.Is(err, ErrInvalidPromotion) || errors.Is(err, ErrInvalidCoupon) || errors.Is(err, ErrInvalidTaxRate) ||
+		errors.Is(err, ErrLoyaltyCustomerRequired) || errors.Is(err, ErrInvalidGiftCard) || errors.Is(err, ErrInvalidCardPayment) ||
+		errors.Is(err, ErrInvalidReceiptFormat) || errors.Is(err, ErrInvalidBarcode) || errors.Is(err, ErrInvalidProductLookup) ||
+		errors.Is(err, ErrInvalidTransactionItem) || errors.Is(err, ErrInvalidLabelRequest) || errors.Is(err, ErrInvalidVariant) ||
+		errors.Is(err, ErrProductHasVariants) || errors.Is(err, ErrInvalidBundle) {
+		code = fiber.StatusBadRequest
+		status = "BAD REQUEST"
+	}
 
 	// 401 Unauthorized
 	if errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrUnauthorizedLogin) {
@@ -31,7 +59,7 @@ func ErrorHandler(ctx *fiber.Ctx, err error) error {
 	}
 
 	// 403 Forbidden
-	if errors.Is(err, ErrForbidden) {
+	if errors.Is(err, ErrForbidden) || errors.Is(err, ErrVoidWindowExpired) {
 		code = fiber.StatusForbidden
 		status = "FORBIDDEN"
 	}
@@ -43,11 +71,24 @@ func ErrorHandler(ctx *fiber.Ctx, err error) error {
 	}
 
 	// 409 Conflict
-	if errors.Is(err, ErrConflict) {
+	if errors.Is(err, ErrConflict) || errors.Is(err, ErrRegisterExists) || errors.Is(err, ErrLocationExists) || errors.Is(err, ErrTransactionVoided) || errors.Is(err, ErrIdempotencyKeyInProgress) ||
+		errors.Is(err, ErrTransactionNotCompleted) || errors.Is(err, ErrTransactionStateChanged) || errors.Is(err, ErrTransferRejected) || errors.Is(err, ErrPurchaseOrderState) ||
+		errors.Is(err, ErrStocktakeState) || errors.Is(err, ErrStocktakeRejected) || errors.Is(err, ErrLotNotExpired) ||
+		errors.Is(err, ErrShiftAlreadyOpen) || errors.Is(err, ErrNoOpenShift) || errors.Is(err, ErrShiftClosed) ||
+		errors.Is(err, ErrCouponExists) || errors.Is(err, ErrPromotionExhausted) || errors.Is(err, ErrPromotionInUse) ||
+		errors.Is(err, ErrTaxRateExists) || errors.Is(err, ErrTaxRateInUse) || errors.Is(err, ErrCustomerExists) ||
+		errors.Is(err, ErrInsufficientPoints) || errors.Is(err, ErrGiftCardExists) || errors.Is(err, ErrGiftCardExpired) ||
+		errors.Is(err, ErrInsufficientGiftCardBalance) || errors.Is(err, ErrProductCodeExists) || errors.Is(err, ErrVariantExists) {
 		code = fiber.StatusConflict
 		status = "CONFLICT"
 	}
 
+	// 422 Unprocessable Entity
+	if errors.Is(err, ErrIdempotencyKeyMismatch) {
+		code = fiber.StatusUnprocessableEntity
+		status = "UNPROCESSABLE ENTITY"
+	}
+
 	webResponse := web.WebResponse{
 		Code:   code,
 		Status: status,
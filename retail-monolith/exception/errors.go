@@ -9,4 +9,82 @@ var (
 	ErrForbidden         = errors.New("you are not authorized to access this resource")
 	ErrNotFound          = errors.New("resource not found")
 	ErrInsufficientStock = errors.New("insufficient stock quantity")
+	ErrInvalidReturnItem = errors.New("return item does not belong to the transaction")
+	ErrReturnExceedsSold = errors.New("return quantity exceeds the remaining sold quantity")
+	ErrTransactionVoided = errors.New("transaction has already been voided")
+	ErrVoidWindowExpired = errors.New("void window for this transaction has expired")
+
+	ErrTransactionNotCompleted = errors.New("transaction has not been completed")
+	ErrTransactionStateChanged = errors.New("transaction state changed while it was being processed")
+
+	ErrRegisterExists      = errors.New("register name already exists")
+	ErrLocationExists      = errors.New("location name already exists")
+	ErrLocationNotResolved = errors.New("no location could be resolved, assign a register to the user or pass a location_id")
+
+	ErrInvalidTransfer  = errors.New("invalid transfer request")
+	ErrTransferRejected = errors.New("transfer cannot be processed in its current state")
+
+	ErrPurchaseOrderState      = errors.New("purchase order cannot be processed in its current status")
+	ErrProductSupplierMismatch = errors.New("product is not supplied by the purchase order's supplier")
+	ErrInvalidReceiptItem      = errors.New("receipt item is not on the purchase order")
+	ErrReceiptExceedsOrdered   = errors.New("received quantity exceeds the outstanding ordered quantity")
+
+	ErrStocktakeState       = errors.New("stocktake session cannot be processed in its current status")
+	ErrEmptyStocktake       = errors.New("no products match the stocktake scope")
+	ErrInvalidStocktakeItem = errors.New("product is not part of the stocktake session")
+	ErrStocktakeRejected    = errors.New("stocktake adjustments were rejected by the inventory")
+
+	ErrInvalidLotRequest = errors.New("invalid lot request")
+	ErrLotNotExpired     = errors.New("lot has not expired yet")
+
+	ErrInvalidValuationDate = errors.New("invalid valuation date, expected YYYY-MM-DD")
+	ErrInvalidReportRange   = errors.New("report start date must not be after its end date")
+
+	ErrShiftAlreadyOpen  = errors.New("user already has an open shift")
+	ErrNoOpenShift       = errors.New("no open shift, open a shift before selling")
+	ErrShiftClosed       = errors.New("shift has already been closed")
+	ErrInvalidCashAmount = errors.New("cash amounts must not be negative")
+
+	ErrInvalidPayment  = errors.New("payment amounts must be positive and only cash may be tendered, covering its amount")
+	ErrPaymentMismatch = errors.New("payments must add up to the transaction total")
+
+	ErrInvalidPromotion   = errors.New("invalid promotion rule")
+	ErrInvalidCoupon      = errors.New("coupon code is invalid, expired or does not apply to this basket")
+	ErrCouponExists       = errors.New("coupon code already exists")
+	ErrPromotionExhausted = errors.New("promotion has reached its usage limit")
+	ErrPromotionInUse     = errors.New("promotion has discounted sales, deactivate it instead")
+
+	ErrInvalidTaxRate = errors.New("tax rate must be between 0 and 100 percent")
+	ErrTaxRateExists  = errors.New("tax rate name already exists")
+	ErrTaxRateInUse   = errors.New("tax rate has taxed sales")
+
+	ErrCustomerExists = errors.New("phone number or email already belongs to another customer")
+
+	ErrLoyaltyCustomerRequired = errors.New("paying with loyalty points needs a customer on the sale")
+	ErrInsufficientPoints      = errors.New("customer does not have enough loyalty points")
+
+	ErrInvalidGiftCard             = errors.New("gift card amount must be positive and its expiry in the future")
+	ErrInvalidCardPayment          = errors.New("gift card and store credit payments need the code of a card of that type, other methods take none")
+	ErrGiftCardExists              = errors.New("gift card code already exists")
+	ErrGiftCardExpired             = errors.New("gift card has expired")
+	ErrInsufficientGiftCardBalance = errors.New("gift card balance does not cover the amount")
+
+	ErrInvalidReceiptFormat = errors.New("receipt format must be one of escpos, pdf, text or html")
+
+	ErrInvalidBarcode         = errors.New("barcode must be a valid EAN-13, UPC-A or internal code")
+	ErrInvalidProductLookup   = errors.New("look a product up by exactly one of barcode or sku")
+	ErrInvalidTransactionItem = errors.New("sale items need a product_id or a barcode, and both must name the same product")
+	ErrProductCodeExists      = errors.New("sku or barcode already belongs to another product")
+
+	ErrInvalidLabelRequest = errors.New("labels need either product_ids or a category_id")
+
+	ErrInvalidVariant     = errors.New("variants need attributes and a parent that is not a variant itself, only variants have attributes")
+	ErrVariantExists      = errors.New("parent product already has a variant with these attributes")
+	ErrProductHasVariants = errors.New("product has variants, sell one of its variants instead")
+
+	ErrInvalidBundle = errors.New("bundles start without stock, are not variants and need distinct components that are neither bundles nor variant parents")
+
+	ErrDuplicateIdempotencyKey  = errors.New("idempotency key already used")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still being processed")
+	ErrIdempotencyKeyMismatch   = errors.New("idempotency key already used for a different request")
 )
@@ -0,0 +1,98 @@
+package helper
+
+import (
+	"fmt"
+	"strings"
+)
+
+var code128Patterns = [...]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+const (
+	code128StartB = 104
+	code128Stop   = 106
+)
+
+var ean13LeftCodes = [...]string{
+	"0001101", "0011001", "0010011", "0111101", "0100011", "0110001", "0101111", "0111011", "0110111", "0001011",
+}
+
+var ean13Parities = [...]string{
+	"LLLLLL", "LLGLGG", "LLGGLG", "LLGGGL", "LGLLGG", "LGGLLG", "LGGGLL", "LGLGLG", "LGLGGL", "LGGLGL",
+}
+
+func EncodeCode128(text string) (string, error) {
+	values := []int{code128StartB}
+	checksum := code128StartB
+	for i, char := range text {
+		if char < 32 || char > 126 {
+			return "", fmt.Errorf("code128 set B cannot encode %q", char)
+		}
+		values = append(values, int(char)-32)
+		checksum += (i + 1) * (int(char) - 32)
+	}
+	values = append(values, checksum%103, code128Stop)
+
+	var modules strings.Builder
+	for _, value := range values {
+		for i, width := range code128Patterns[value] {
+			module := "1"
+			if i%2 == 1 {
+				module = "0"
+			}
+			modules.WriteString(strings.Repeat(module, int(width-'0')))
+		}
+	}
+	return modules.String(), nil
+}
+
+func EncodeEAN13(code string) (string, error) {
+	if len(code) != 13 || strings.Trim(code, "0123456789") != "" {
+		return "", fmt.Errorf("ean13 needs 13 digits, got %q", code)
+	}
+
+	var modules strings.Builder
+	modules.WriteString("101")
+	parity := ean13Parities[code[0]-'0']
+	for i := 1; i <= 6; i++ {
+		left := ean13LeftCodes[code[i]-'0']
+		if parity[i-1] == 'G' {
+			left = reverseModules(invertModules(left))
+		}
+		modules.WriteString(left)
+	}
+	modules.WriteString("01010")
+	for i := 7; i <= 12; i++ {
+		modules.WriteString(invertModules(ean13LeftCodes[code[i]-'0']))
+	}
+	modules.WriteString("101")
+	return modules.String(), nil
+}
+
+func invertModules(modules string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '1' {
+			return '0'
+		}
+		return '1'
+	}, modules)
+}
+
+func reverseModules(modules string) string {
+	reversed := []byte(modules)
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+	return string(reversed)
+}
@@ -0,0 +1,137 @@
+package helper
+
+import (
+	"bytes"
+	"fmt"
+	"retail-management/model/domain"
+	"strings"
+)
+
+const labelPageWidth, labelPageHeight, labelPageMargin = 595.28, 841.89, 20.0
+
+var zplFieldReplacer = strings.NewReplacer("_", "_5F", "^", "_5E", "~", "_7E")
+
+func RenderLabels(labels []domain.Label, format string, layout domain.LabelLayout) ([]byte, error) {
+	switch format {
+	case domain.LabelFormatPDF:
+		return labelsPDF(labels, layout)
+	case domain.LabelFormatZPL:
+		return labelsZPL(labels, layout), nil
+	}
+	return nil, fmt.Errorf("unknown label format %q", format)
+}
+
+func labelModules(label domain.Label) (string, error) {
+	if label.Symbology == domain.LabelSymbologyEAN13 {
+		return EncodeEAN13(label.Code)
+	}
+	return EncodeCode128(label.Code)
+}
+
+func labelsPDF(labels []domain.Label, layout domain.LabelLayout) ([]byte, error) {
+	cellWidth := (labelPageWidth - 2*labelPageMargin) / float64(layout.Columns)
+	cellHeight := (labelPageHeight - 2*labelPageMargin) / float64(layout.Rows)
+	perPage := layout.Columns * layout.Rows
+
+	var pages [][]byte
+	var content bytes.Buffer
+	cell := 0
+	for _, label := range labels {
+		modules, err := labelModules(label)
+		if err != nil {
+			return nil, err
+		}
+		for range label.Copies {
+			if cell == perPage {
+				pages = append(pages, content.Bytes())
+				content = bytes.Buffer{}
+				cell = 0
+			}
+			x := labelPageMargin + float64(cell%layout.Columns)*cellWidth
+			y := labelPageHeight - labelPageMargin - float64(cell/layout.Columns+1)*cellHeight
+			pdfLabel(&content, label, modules, x, y, cellWidth, cellHeight)
+			cell++
+		}
+	}
+	if cell > 0 {
+		pages = append(pages, content.Bytes())
+	}
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>",
+	}
+	var kids []string
+	for _, page := range pages {
+		pageObject := len(objects) + 1
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageObject))
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", labelPageWidth, labelPageHeight, pageObject+1),
+			pdfStream(page),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages))
+
+	return pdfDocument(objects), nil
+}
+
+func pdfLabel(content *bytes.Buffer, label domain.Label, modules string, x float64, y float64, width float64, height float64) {
+	const padding, nameSize, priceSize, codeSize = 6.0, 8.0, 14.0, 7.0
+
+	name := fitReceiptText(label.ProductName, int((width-2*padding)/(nameSize*0.6)))
+	fmt.Fprintf(content, "BT\n/F1 %.0f Tf\n%.2f %.2f Td\n(%s) Tj\nET\n", nameSize, x+padding, y+height-padding-nameSize, pdfText(name))
+	fmt.Fprintf(content, "BT\n/F2 %.0f Tf\n%.2f %.2f Td\n(%s) Tj\nET\n", priceSize, x+padding, y+height-padding-nameSize-priceSize-2, label.SellingPrice.StringFixed(2))
+
+	moduleWidth := min((width-2*padding)/float64(len(modules)+20), 1.0)
+	barsLeft := x + (width-moduleWidth*float64(len(modules)))/2
+	barsBottom := y + padding + codeSize + 2
+	barHeight := max(height-2*padding-nameSize-priceSize-codeSize-8, 8.0)
+	for i := 0; i < len(modules); {
+		if modules[i] == '0' {
+			i++
+			continue
+		}
+		start := i
+		for i < len(modules) && modules[i] == '1' {
+			i++
+		}
+		fmt.Fprintf(content, "%.3f %.3f %.3f %.3f re\n", barsLeft+float64(start)*moduleWidth, barsBottom, float64(i-start)*moduleWidth, barHeight)
+	}
+	content.WriteString("f\n")
+
+	code := fitReceiptText(label.Code, int((width-2*padding)/(codeSize*0.6)))
+	codeLeft := x + (width-float64(len(code))*codeSize*0.6)/2
+	fmt.Fprintf(content, "BT\n/F1 %.0f Tf\n%.2f %.2f Td\n(%s) Tj\nET\n", codeSize, codeLeft, y+padding, pdfText(code))
+}
+
+func labelsZPL(labels []domain.Label, layout domain.LabelLayout) []byte {
+	const left, top = 20, 15
+
+	var out bytes.Buffer
+	for _, label := range labels {
+		moduleCount := 95
+		if label.Symbology == domain.LabelSymbologyCode128 {
+			moduleCount = 11*(len(label.Code)+3) + 2
+		}
+		moduleWidth := min(max((layout.ZPLWidth-2*left)/moduleCount, 1), 3)
+		barHeight := max(layout.ZPLHeight-top-80-35, 20)
+
+		out.WriteString("^XA\n^CI28\n")
+		fmt.Fprintf(&out, "^PW%d\n^LL%d\n", layout.ZPLWidth, layout.ZPLHeight)
+		name := fitReceiptText(label.ProductName, (layout.ZPLWidth-2*left)/13)
+		fmt.Fprintf(&out, "^FO%d,%d^A0N,24,24^FB%d,1,0,L^FH^FD%s^FS\n", left, top, layout.ZPLWidth-2*left, zplFieldReplacer.Replace(name))
+		fmt.Fprintf(&out, "^FO%d,%d^A0N,40,40^FD%s^FS\n", left, top+30, label.SellingPrice.StringFixed(2))
+		if label.Symbology == domain.LabelSymbologyEAN13 {
+			// the printer appends the check digit
+			fmt.Fprintf(&out, "^FO%d,%d^BY%d^BEN,%d,Y,N^FD%s^FS\n", left, top+80, moduleWidth, barHeight, label.Code[:12])
+		} else {
+			// > starts an invocation code in ^BC data, >< is a literal >
+			code := strings.ReplaceAll(zplFieldReplacer.Replace(label.Code), ">", "><")
+			fmt.Fprintf(&out, "^FO%d,%d^BY%d^BCN,%d,Y,N,N^FH^FD%s^FS\n", left, top+80, moduleWidth, barHeight, code)
+		}
+		fmt.Fprintf(&out, "^PQ%d\n^XZ\n", label.Copies)
+	}
+	return out.Bytes()
+}
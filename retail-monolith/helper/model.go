@@ -3,6 +3,8 @@ package helper
 import (
 	"retail-management/model/domain"
 	"retail-management/model/web"
+
+	"github.com/shopspring/decimal"
 )
 
 func ToUserRegisterResponse(user domain.User) web.UserRegisterResponse {
@@ -79,14 +81,27 @@ func ToSupplierResponses(suppliers []domain.Supplier) []web.SupplierResponse {
 }
 
 func ToProductResponse(product domain.Product) web.ProductResponse {
+	var variants []web.ProductResponse
+	for _, variant := range product.Variants {
+		variants = append(variants, ToProductResponse(variant))
+	}
+
 	return web.ProductResponse{
-		ProductID:     product.ProductID,
-		ProductName:   product.ProductName,
-		PurchasePrice: product.PurchasePrice,
-		SellingPrice:  product.SellingPrice,
-		StockQuantity: product.StockQuantity,
-		CategoryID:    product.CategoryID,
-		SupplierID:    product.SupplierID,
+		ProductID:         product.ProductID,
+		ProductName:       product.ProductName,
+		SKU:               product.SKU,
+		Barcodes:          ToProductBarcodeResponses(product.Barcodes),
+		PurchasePrice:     product.PurchasePrice,
+		SellingPrice:      product.SellingPrice,
+		StockQuantity:     product.StockQuantity,
+		ReservedQuantity:  product.ReservedQuantity,
+		AvailableQuantity: product.AvailableQuantity,
+		CategoryID:        product.CategoryID,
+		SupplierID:        product.SupplierID,
+		ParentProductID:   product.ParentProductID,
+		Attributes:        ToProductAttributeResponse(product.Attributes),
+		Variants:          variants,
+		Components:        ToProductComponentResponses(product.Components),
 	}
 }
 
@@ -101,14 +116,53 @@ func ToProductResponses(products []domain.Product) []web.ProductResponse {
 
 func ToProductUpdateResponse(product domain.ProductUpdate) web.ProductUpdateResponse {
 	return web.ProductUpdateResponse{
-		ProductID:     product.ProductID,
-		ProductName:   product.ProductName,
-		PurchasePrice: product.PurchasePrice,
-		SellingPrice:  product.SellingPrice,
-		StockQuantity: product.StockQuantity,
-		CategoryID:    product.CategoryID,
-		SupplierID:    product.SupplierID,
+		ProductID:       product.ProductID,
+		ProductName:     product.ProductName,
+		SKU:             product.SKU,
+		Barcodes:        ToProductBarcodeResponses(product.Barcodes),
+		PurchasePrice:   product.PurchasePrice,
+		SellingPrice:    product.SellingPrice,
+		StockQuantity:   product.StockQuantity,
+		CategoryID:      product.CategoryID,
+		SupplierID:      product.SupplierID,
+		ParentProductID: product.ParentProductID,
+		Attributes:      ToProductAttributeResponse(product.Attributes),
+		Components:      ToProductComponentResponses(product.Components),
+	}
+}
+
+func ToProductAttributeResponse(attributes []domain.ProductAttribute) map[string]string {
+	if len(attributes) == 0 {
+		return nil
 	}
+	attributeResponse := make(map[string]string)
+	for _, attribute := range attributes {
+		attributeResponse[attribute.AttributeName] = attribute.AttributeValue
+	}
+	return attributeResponse
+}
+
+func ToProductComponentResponses(components []domain.ProductComponent) []web.ProductComponentResponse {
+	var componentResponses []web.ProductComponentResponse
+	for _, component := range components {
+		componentResponses = append(componentResponses, web.ProductComponentResponse{
+			ProductID:   component.ProductID,
+			ProductName: component.ProductName,
+			Quantity:    component.Quantity,
+		})
+	}
+	return componentResponses
+}
+
+func ToProductBarcodeResponses(barcodes []domain.ProductBarcode) []web.ProductBarcodeResponse {
+	barcodeResponses := make([]web.ProductBarcodeResponse, 0)
+	for _, barcode := range barcodes {
+		barcodeResponses = append(barcodeResponses, web.ProductBarcodeResponse{
+			Barcode:     barcode.Barcode,
+			BarcodeType: barcode.BarcodeType,
+		})
+	}
+	return barcodeResponses
 }
 
 func ToProductUpdateResponses(products []domain.ProductUpdate) []web.ProductUpdateResponse {
@@ -132,13 +186,24 @@ func ToInventoryLogResponse(inventoryLog domain.InventoryLog) web.InventoryLogRe
 }
 
 func ToTransactionItemResponse(detail domain.TransactionDetailWithProduct) web.TransactionItemResp {
-	return web.TransactionItemResp{
-		ProductID:   detail.ProductID,
-		ProductName: detail.ProductName,
-		Quantity:    detail.Quantity,
-		Price:       detail.PriceAtSale,
-		SubTotal:    detail.SubTotal,
+	response := web.TransactionItemResp{
+		DetailID:         detail.DetailID,
+		ProductID:        detail.ProductID,
+		ProductName:      detail.ProductName,
+		Quantity:         detail.Quantity,
+		ReturnedQuantity: detail.ReturnedQuantity,
+		Price:            detail.PriceAtSale,
+		SubTotal:         detail.SubTotal,
+		DiscountAmount:   detail.DiscountAmount,
+		TaxRate:          detail.TaxRate,
+		TaxAmount:        detail.TaxAmount,
+		TaxInclusive:     detail.TaxInclusive,
+		LineTotal:        detail.LineTotal,
+	}
+	if detail.CostOfGoodsSold.Valid {
+		response.CostOfGoodsSold = &detail.CostOfGoodsSold.Decimal
 	}
+	return response
 }
 
 func ToTransactionItemResponses(details []domain.TransactionDetailWithProduct) []web.TransactionItemResp {
@@ -150,12 +215,29 @@ func ToTransactionItemResponses(details []domain.TransactionDetailWithProduct) [
 }
 
 func ToTransactionResponse(transaction domain.TransactionWithTotal, items []web.TransactionItemResp) web.TransactionResponse {
+	netAmount := transaction.TotalAmount.Sub(transaction.ReturnedAmount)
+	if transaction.Status == domain.TransactionStatusVoided {
+		netAmount = decimal.Zero
+	}
+
 	return web.TransactionResponse{
-		TransactionID: transaction.TransactionID,
-		UserID:        transaction.UserID,
-		TotalAmount:   transaction.TotalAmount,
-		CreatedAt:     transaction.CreatedAt,
-		Items:         items,
+		TransactionID:  transaction.TransactionID,
+		UserID:         transaction.UserID,
+		Status:         transaction.Status,
+		LocationID:     transaction.LocationID,
+		RegisterID:     transaction.RegisterID,
+		CustomerID:     transaction.CustomerID,
+		GrossAmount:    transaction.GrossAmount,
+		DiscountAmount: transaction.DiscountAmount,
+		TaxAmount:      transaction.TaxAmount,
+		TotalAmount:    transaction.TotalAmount,
+		ReturnedAmount: transaction.ReturnedAmount,
+		NetAmount:      netAmount,
+		CreatedAt:      transaction.CreatedAt,
+		VoidedAt:       transaction.VoidedAt,
+		VoidedBy:       transaction.VoidedBy,
+		VoidReason:     transaction.VoidReason,
+		Items:          items,
 	}
 }
 
@@ -166,3 +248,361 @@ func ToTransactionResponses(transactions []domain.TransactionWithTotal) []web.Tr
 	}
 	return responses
 }
+
+func ToTransactionDiscountResponse(discount domain.TransactionDiscount) web.TransactionDiscountResp {
+	return web.TransactionDiscountResp{
+		DiscountID:    discount.DiscountID,
+		DetailID:      discount.DetailID,
+		PromotionID:   discount.PromotionID,
+		PromotionName: discount.PromotionName,
+		Amount:        discount.Amount,
+	}
+}
+
+func ToTransactionDiscountResponses(discounts []domain.TransactionDiscount) []web.TransactionDiscountResp {
+	responses := make([]web.TransactionDiscountResp, 0)
+	for _, discount := range discounts {
+		responses = append(responses, ToTransactionDiscountResponse(discount))
+	}
+	return responses
+}
+
+func ToTransactionPaymentResponse(payment domain.TransactionPayment) web.TransactionPaymentResp {
+	response := web.TransactionPaymentResp{
+		PaymentID:   payment.PaymentID,
+		Method:      payment.Method,
+		Amount:      payment.Amount,
+		ChangeGiven: payment.ChangeGiven,
+		Reference:   payment.Reference,
+		GiftCardID:  payment.GiftCardID,
+	}
+	if payment.AmountTendered.Valid {
+		response.AmountTendered = &payment.AmountTendered.Decimal
+	}
+	return response
+}
+
+func ToTransactionPaymentResponses(payments []domain.TransactionPayment) []web.TransactionPaymentResp {
+	responses := make([]web.TransactionPaymentResp, 0)
+	for _, payment := range payments {
+		responses = append(responses, ToTransactionPaymentResponse(payment))
+	}
+	return responses
+}
+
+func ToRegisterResponse(register domain.Register) web.RegisterResponse {
+	return web.RegisterResponse{
+		RegisterID:   register.RegisterID,
+		RegisterName: register.RegisterName,
+		LocationID:   register.LocationID,
+		CreatedAt:    register.CreatedAt,
+	}
+}
+
+func ToRegisterResponses(registers []domain.Register) []web.RegisterResponse {
+	registerResponses := make([]web.RegisterResponse, 0)
+
+	for _, register := range registers {
+		registerResponses = append(registerResponses, ToRegisterResponse(register))
+	}
+	return registerResponses
+}
+
+func ToPurchaseOrderResponse(purchaseOrder domain.PurchaseOrder) web.PurchaseOrderResponse {
+	return web.PurchaseOrderResponse{
+		PurchaseOrderID: purchaseOrder.PurchaseOrderID,
+		SupplierID:      purchaseOrder.SupplierID,
+		LocationID:      purchaseOrder.LocationID,
+		Status:          purchaseOrder.Status,
+		Note:            purchaseOrder.Note,
+		CreatedBy:       purchaseOrder.CreatedBy,
+		CreatedAt:       purchaseOrder.CreatedAt,
+		SentAt:          purchaseOrder.SentAt,
+		ClosedAt:        purchaseOrder.ClosedAt,
+		TotalAmount:     purchaseOrder.TotalAmount,
+	}
+}
+
+func ToPurchaseOrderResponses(purchaseOrders []domain.PurchaseOrder) []web.PurchaseOrderResponse {
+	purchaseOrderResponses := make([]web.PurchaseOrderResponse, 0)
+
+	for _, purchaseOrder := range purchaseOrders {
+		purchaseOrderResponses = append(purchaseOrderResponses, ToPurchaseOrderResponse(purchaseOrder))
+	}
+	return purchaseOrderResponses
+}
+
+func ToPurchaseOrderItemResponses(items []domain.PurchaseOrderItem) []web.PurchaseOrderItemResponse {
+	itemResponses := make([]web.PurchaseOrderItemResponse, 0)
+
+	for _, item := range items {
+		itemResponses = append(itemResponses, web.PurchaseOrderItemResponse{
+			ProductID:        item.ProductID,
+			ProductName:      item.ProductName,
+			Quantity:         item.Quantity,
+			QuantityReceived: item.QuantityReceived,
+			PurchasePrice:    item.PurchasePrice,
+			SubTotal:         item.PurchasePrice.Mul(decimal.NewFromInt(int64(item.Quantity))),
+		})
+	}
+	return itemResponses
+}
+
+func ToGoodsReceiptResponses(receipts []domain.GoodsReceipt, items []domain.GoodsReceiptItem) []web.GoodsReceiptResponse {
+	itemsByReceipt := make(map[string][]web.GoodsReceiptItemResponse)
+	for _, item := range items {
+		itemsByReceipt[item.ReceiptID.String()] = append(itemsByReceipt[item.ReceiptID.String()], web.GoodsReceiptItemResponse{
+			ProductID:     item.ProductID,
+			Quantity:      item.Quantity,
+			PurchasePrice: item.PurchasePrice,
+		})
+	}
+
+	receiptResponses := make([]web.GoodsReceiptResponse, 0)
+	for _, receipt := range receipts {
+		receiptItems := itemsByReceipt[receipt.ReceiptID.String()]
+		if receiptItems == nil {
+			receiptItems = make([]web.GoodsReceiptItemResponse, 0)
+		}
+		receiptResponses = append(receiptResponses, web.GoodsReceiptResponse{
+			ReceiptID:  receipt.ReceiptID,
+			ReceivedBy: receipt.ReceivedBy,
+			Note:       receipt.Note,
+			ReceivedAt: receipt.ReceivedAt,
+			Items:      receiptItems,
+		})
+	}
+	return receiptResponses
+}
+
+func ToReorderRuleResponse(rule domain.ReorderRule) web.ReorderRuleResponse {
+	return web.ReorderRuleResponse{
+		ProductID:       rule.ProductID,
+		MinQuantity:     rule.MinQuantity,
+		MaxQuantity:     rule.MaxQuantity,
+		ReorderQuantity: rule.ReorderQuantity,
+		UpdatedAt:       rule.UpdatedAt,
+	}
+}
+
+func ToStocktakeResponse(session domain.StocktakeSession) web.StocktakeResponse {
+	return web.StocktakeResponse{
+		SessionID:  session.SessionID,
+		LocationID: session.LocationID,
+		CategoryID: session.CategoryID,
+		Blind:      session.Blind,
+		Status:     session.Status,
+		Note:       session.Note,
+		CreatedBy:  session.CreatedBy,
+		CreatedAt:  session.CreatedAt,
+		ApprovedBy: session.ApprovedBy,
+		ApprovedAt: session.ApprovedAt,
+	}
+}
+
+func ToStocktakeResponses(sessions []domain.StocktakeSession) []web.StocktakeResponse {
+	stocktakeResponses := make([]web.StocktakeResponse, 0)
+
+	for _, session := range sessions {
+		stocktakeResponses = append(stocktakeResponses, ToStocktakeResponse(session))
+	}
+	return stocktakeResponses
+}
+
+func ToValuationItemResponse(valuation domain.ProductValuation) web.ValuationItemResponse {
+	averageCost := decimal.Zero
+	if valuation.Quantity > 0 {
+		averageCost = valuation.Value.Div(decimal.NewFromInt(int64(valuation.Quantity))).Round(4)
+	}
+	return web.ValuationItemResponse{
+		ProductID:   valuation.ProductID,
+		ProductName: valuation.ProductName,
+		Quantity:    valuation.Quantity,
+		AverageCost: averageCost,
+		Value:       valuation.Value,
+	}
+}
+
+func ToSalesReportRowResponse(row domain.SalesReportRow) web.SalesReportRowResponse {
+	grossMargin := row.Revenue.Sub(row.Cost)
+	marginPercent := decimal.Zero
+	if row.Revenue.IsPositive() {
+		marginPercent = grossMargin.Div(row.Revenue).Mul(decimal.NewFromInt(100)).Round(2)
+	}
+	return web.SalesReportRowResponse{
+		ID:            row.GroupID,
+		Label:         row.Label,
+		Transactions:  row.Transactions,
+		Units:         row.Units,
+		Revenue:       row.Revenue,
+		Cost:          row.Cost.Round(2),
+		GrossMargin:   grossMargin.Round(2),
+		MarginPercent: marginPercent,
+	}
+}
+
+func ToPaymentReportRowResponse(row domain.PaymentReportRow) web.PaymentReportRowResponse {
+	return web.PaymentReportRowResponse{
+		Method:       row.Method,
+		Transactions: row.Transactions,
+		Amount:       row.Amount,
+		ChangeGiven:  row.ChangeGiven,
+	}
+}
+
+func ToShiftResponse(shift domain.Shift) web.ShiftResponse {
+	return web.ShiftResponse{
+		ShiftID:      shift.ShiftID,
+		UserID:       shift.UserID,
+		LocationID:   shift.LocationID,
+		RegisterID:   shift.RegisterID,
+		Status:       shift.Status,
+		OpeningFloat: shift.OpeningFloat,
+		Note:         shift.Note,
+		OpenedAt:     shift.OpenedAt,
+		ClosedAt:     shift.ClosedAt,
+	}
+}
+
+func ToShiftResponses(shifts []domain.Shift) []web.ShiftResponse {
+	shiftResponses := make([]web.ShiftResponse, 0)
+
+	for _, shift := range shifts {
+		shiftResponses = append(shiftResponses, ToShiftResponse(shift))
+	}
+	return shiftResponses
+}
+
+func ToPromotionResponse(promotion domain.Promotion) web.PromotionResponse {
+	response := web.PromotionResponse{
+		PromotionID:   promotion.PromotionID,
+		PromotionName: promotion.PromotionName,
+		PromotionType: promotion.PromotionType,
+		ProductID:     promotion.ProductID,
+		CategoryID:    promotion.CategoryID,
+		Value:         promotion.Value,
+		BuyQuantity:   promotion.BuyQuantity,
+		GetQuantity:   promotion.GetQuantity,
+		CouponCode:    promotion.CouponCode,
+		StartsAt:      promotion.StartsAt,
+		EndsAt:        promotion.EndsAt,
+		UsageLimit:    promotion.UsageLimit,
+		UsageCount:    promotion.UsageCount,
+		Active:        promotion.Active,
+		CreatedAt:     promotion.CreatedAt,
+	}
+	if promotion.MinSpend.Valid {
+		response.MinSpend = &promotion.MinSpend.Decimal
+	}
+	return response
+}
+
+func ToPromotionResponses(promotions []domain.Promotion) []web.PromotionResponse {
+	promotionResponses := make([]web.PromotionResponse, 0)
+	for _, promotion := range promotions {
+		promotionResponses = append(promotionResponses, ToPromotionResponse(promotion))
+	}
+	return promotionResponses
+}
+
+func ToTaxRateResponse(taxRate domain.TaxRate) web.TaxRateResponse {
+	return web.TaxRateResponse{
+		TaxRateID: taxRate.TaxRateID,
+		TaxName:   taxRate.TaxName,
+		Rate:      taxRate.Rate,
+		CreatedAt: taxRate.CreatedAt,
+	}
+}
+
+func ToTaxRateResponses(taxRates []domain.TaxRate) []web.TaxRateResponse {
+	taxRateResponses := make([]web.TaxRateResponse, 0)
+	for _, taxRate := range taxRates {
+		taxRateResponses = append(taxRateResponses, ToTaxRateResponse(taxRate))
+	}
+	return taxRateResponses
+}
+
+func ToTaxReportRowResponse(row domain.TaxReportRow) web.TaxReportRowResponse {
+	return web.TaxReportRowResponse{
+		Period:        row.Period,
+		TaxRateID:     row.TaxRateID,
+		TaxName:       row.TaxName,
+		Rate:          row.Rate,
+		TaxableAmount: row.TaxableAmount,
+		TaxAmount:     row.TaxAmount,
+		TotalAmount:   row.TaxableAmount.Add(row.TaxAmount),
+	}
+}
+
+func ToCustomerResponse(customer domain.Customer) web.CustomerResponse {
+	return web.CustomerResponse{
+		CustomerID:   customer.CustomerID,
+		CustomerName: customer.CustomerName,
+		PhoneNumber:  customer.PhoneNumber,
+		Email:        customer.Email,
+		Notes:        customer.Notes,
+		CreatedAt:    customer.CreatedAt,
+	}
+}
+
+func ToCustomerResponses(customers []domain.Customer) []web.CustomerResponse {
+	customerResponses := make([]web.CustomerResponse, 0)
+	for _, customer := range customers {
+		customerResponses = append(customerResponses, ToCustomerResponse(customer))
+	}
+	return customerResponses
+}
+
+func ToLoyaltyEntryResponse(entry domain.LoyaltyEntry) web.LoyaltyEntryResponse {
+	return web.LoyaltyEntryResponse{
+		EntryID:       entry.EntryID,
+		EntryType:     entry.EntryType,
+		ChangePoints:  entry.ChangePoints,
+		BalancePoints: entry.BalancePoints,
+		TransactionID: entry.TransactionID,
+		ReturnID:      entry.ReturnID,
+		ExpiresAt:     entry.ExpiresAt,
+		CreatedAt:     entry.CreatedAt,
+	}
+}
+
+func ToLoyaltyEntryResponses(entries []domain.LoyaltyEntry) []web.LoyaltyEntryResponse {
+	entryResponses := make([]web.LoyaltyEntryResponse, 0)
+	for _, entry := range entries {
+		entryResponses = append(entryResponses, ToLoyaltyEntryResponse(entry))
+	}
+	return entryResponses
+}
+
+func ToGiftCardResponse(giftCard domain.GiftCard, balance decimal.Decimal, entries []domain.GiftCardEntry) web.GiftCardResponse {
+	return web.GiftCardResponse{
+		GiftCardID: giftCard.GiftCardID,
+		CardCode:   giftCard.CardCode,
+		CardType:   giftCard.CardType,
+		CustomerID: giftCard.CustomerID,
+		Balance:    balance,
+		ExpiresAt:  giftCard.ExpiresAt,
+		CreatedAt:  giftCard.CreatedAt,
+		Entries:    ToGiftCardEntryResponses(entries),
+	}
+}
+
+func ToGiftCardEntryResponse(entry domain.GiftCardEntry) web.GiftCardEntryResponse {
+	return web.GiftCardEntryResponse{
+		EntryID:       entry.EntryID,
+		EntryType:     entry.EntryType,
+		ChangeAmount:  entry.ChangeAmount,
+		BalanceAmount: entry.BalanceAmount,
+		TransactionID: entry.TransactionID,
+		ReturnID:      entry.ReturnID,
+		CreatedAt:     entry.CreatedAt,
+	}
+}
+
+func ToGiftCardEntryResponses(entries []domain.GiftCardEntry) []web.GiftCardEntryResponse {
+	entryResponses := make([]web.GiftCardEntryResponse, 0)
+	for _, entry := range entries {
+		entryResponses = append(entryResponses, ToGiftCardEntryResponse(entry))
+	}
+	return entryResponses
+}
@@ -0,0 +1,36 @@
+package helper
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+var pdfTextReplacer = strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`)
+
+func pdfDocument(objects []string) []byte {
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return out.Bytes()
+}
+
+func pdfStream(content []byte) string {
+	return fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content)
+}
+
+func pdfText(text string) string {
+	return pdfTextReplacer.Replace(asciiReceiptLine(text))
+}
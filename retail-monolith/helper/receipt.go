@@ -0,0 +1,209 @@
+package helper
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"retail-management/model/domain"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+)
+
+//go:embed templates/receipt.txt.tmpl
+var defaultReceiptTextTemplate string
+
+//go:embed templates/receipt.html.tmpl
+var defaultReceiptHTMLTemplate string
+
+func RenderReceipt(receipt domain.Receipt, format string, width int) ([]byte, error) {
+	switch format {
+	case domain.ReceiptFormatText:
+		text, err := renderReceiptText(receipt, width, false)
+		return []byte(text), err
+	case domain.ReceiptFormatESCPOS:
+		text, err := renderReceiptText(receipt, width, true)
+		if err != nil {
+			return nil, err
+		}
+		return receiptESCPOS(text), nil
+	case domain.ReceiptFormatPDF:
+		text, err := renderReceiptText(receipt, width, false)
+		if err != nil {
+			return nil, err
+		}
+		return receiptPDF(text, width), nil
+	case domain.ReceiptFormatHTML:
+		return renderReceiptHTML(receipt)
+	}
+	return nil, fmt.Errorf("unknown receipt format %q", format)
+}
+
+func receiptTemplate(envName string, fallback string) (string, error) {
+	path := os.Getenv(envName)
+	if path == "" {
+		return fallback, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+func renderReceiptText(receipt domain.Receipt, width int, escpos bool) (string, error) {
+	source, err := receiptTemplate("RECEIPT_TEXT_TEMPLATE", defaultReceiptTextTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	funcs := template.FuncMap{
+		"money": func(amount decimal.Decimal) string { return amount.StringFixed(2) },
+		"rule":  func() string { return strings.Repeat("-", width) },
+		"center": func(text string) string {
+			text = fitReceiptText(text, width)
+			return strings.Repeat(" ", (width-utf8.RuneCountInString(text))/2) + text
+		},
+		"columns": func(left string, right string) string {
+			right = fitReceiptText(right, width)
+			left = fitReceiptText(left, max(width-utf8.RuneCountInString(right)-1, 0))
+			return left + strings.Repeat(" ", max(width-utf8.RuneCountInString(left)-utf8.RuneCountInString(right), 1)) + right
+		},
+		"wrap": func(text string) string {
+			return strings.Join(wrapReceiptText(text, width), "\n")
+		},
+		"bold": func(text string) string {
+			if escpos {
+				return "\x1bE\x01" + text + "\x1bE\x00"
+			}
+			return text
+		},
+	}
+
+	tmpl, err := template.New("receipt").Funcs(funcs).Parse(source)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	err = tmpl.Execute(&out, receipt)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(out.String(), "\n") + "\n", nil
+}
+
+func renderReceiptHTML(receipt domain.Receipt) ([]byte, error) {
+	source, err := receiptTemplate("RECEIPT_HTML_TEMPLATE", defaultReceiptHTMLTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	funcs := htmltemplate.FuncMap{
+		"money": func(amount decimal.Decimal) string { return amount.StringFixed(2) },
+	}
+
+	tmpl, err := htmltemplate.New("receipt").Funcs(funcs).Parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	err = tmpl.Execute(&out, receipt)
+	if err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func fitReceiptText(text string, width int) string {
+	runes := []rune(strings.Map(func(r rune) rune {
+		if r < 32 {
+			return ' '
+		}
+		return r
+	}, text))
+	if len(runes) > width {
+		runes = runes[:width]
+	}
+	return string(runes)
+}
+
+func wrapReceiptText(text string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		for utf8.RuneCountInString(word) > width {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			runes := []rune(word)
+			lines = append(lines, string(runes[:width]))
+			word = string(runes[width:])
+		}
+		switch {
+		case line == "":
+			line = word
+		case utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func receiptESCPOS(text string) []byte {
+	var out bytes.Buffer
+	out.WriteString("\x1b@")
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		out.WriteString(asciiReceiptLine(line))
+		out.WriteByte('\n')
+	}
+	out.WriteString("\x1bd\x04")
+	out.WriteString("\x1dV\x01")
+	return out.Bytes()
+}
+
+func asciiReceiptLine(line string) string {
+	return strings.Map(func(r rune) rune {
+		if r > 126 {
+			return '?'
+		}
+		return r
+	}, line)
+}
+
+func receiptPDF(text string, width int) []byte {
+	const fontSize, leading, margin = 9.0, 11.0, 14.0
+
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	pageWidth := float64(width)*fontSize*0.6 + 2*margin
+	pageHeight := float64(len(lines))*leading + 2*margin
+
+	var content bytes.Buffer
+	fmt.Fprintf(&content, "BT\n/F1 %.0f Tf\n%.0f TL\n%.2f %.2f Td\n", fontSize, leading, margin, pageHeight-margin-fontSize)
+	for _, line := range lines {
+		fmt.Fprintf(&content, "(%s) Tj T*\n", pdfText(line))
+	}
+	content.WriteString("ET\n")
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>", pageWidth, pageHeight),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+		pdfStream(content.Bytes()),
+	}
+
+	return pdfDocument(objects)
+}
@@ -49,18 +49,82 @@ func main() {
 	supplierService := service.NewSupplierService(supplierRepository, db, validate, logger)
 	supplierController := controller.NewSupplierController(supplierService, logger)
 
+	locationService := service.NewLocationService(inventoryClient, validate, logger)
+	locationController := controller.NewLocationController(locationService, logger)
+
+	registerRepository := repository.NewRegisterRepository(logger)
+	registerService := service.NewRegisterService(registerRepository, inventoryClient, db, validate, logger)
+	registerController := controller.NewRegisterController(registerService, logger)
+
+	transferService := service.NewTransferService(inventoryClient, validate, logger)
+	transferController := controller.NewTransferController(transferService, logger)
+
+	costRepository := repository.NewCostRepository(logger)
+	valuationService := service.NewValuationService(costRepository, db, logger)
+	valuationController := controller.NewValuationController(valuationService, logger)
+
 	productRepository := repository.NewProductRepository(logger)
-	productService := service.NewProductService(productRepository, inventoryClient, db, validate, logger)
+	productService := service.NewProductService(productRepository, costRepository, inventoryClient, db, validate, logger)
 	productController := controller.NewProductController(productService, logger)
 
 	// inventoryLogRepository := repository.NewInventoryLogRepository(logger)
-	inventoryLogService := service.NewInventoryLogService(inventoryClient, validate, logger)
+	inventoryLogService := service.NewInventoryLogService(productRepository, costRepository, inventoryClient, db, validate, logger)
 	inventoryLogController := controller.NewInventoryLogController(inventoryLogService, logger)
 
+	lotService := service.NewLotService(productRepository, costRepository, inventoryClient, db, validate, logger)
+	lotController := controller.NewLotController(lotService, logger)
+
+	purchaseOrderRepository := repository.NewPurchaseOrderRepository(logger)
+	purchaseOrderService := service.NewPurchaseOrderService(purchaseOrderRepository, productRepository, supplierRepository, costRepository, inventoryClient, db, validate, logger)
+	purchaseOrderController := controller.NewPurchaseOrderController(purchaseOrderService, logger)
+
+	stocktakeRepository := repository.NewStocktakeRepository(logger)
+	stocktakeService := service.NewStocktakeService(stocktakeRepository, productRepository, costRepository, inventoryClient, db, validate, logger)
+	stocktakeController := controller.NewStocktakeController(stocktakeService, logger)
+
+	shiftRepository := repository.NewShiftRepository(logger)
+	shiftService := service.NewShiftService(shiftRepository, registerRepository, db, validate, logger)
+	shiftController := controller.NewShiftController(shiftService, logger)
+
+	promotionRepository := repository.NewPromotionRepository(logger)
+	promotionService := service.NewPromotionService(promotionRepository, db, validate, logger)
+	promotionController := controller.NewPromotionController(promotionService, logger)
+
+	customerRepository := repository.NewCustomerRepository(logger)
+	loyaltyRepository := repository.NewLoyaltyRepository(logger)
+	loyaltyService := service.NewLoyaltyService(loyaltyRepository, db, logger)
+	loyaltyController := controller.NewLoyaltyController(loyaltyService, logger)
+
+	giftCardRepository := repository.NewGiftCardRepository(logger)
+	giftCardService := service.NewGiftCardService(giftCardRepository, db, validate, logger)
+	giftCardController := controller.NewGiftCardController(giftCardService, logger)
+
+	taxRepository := repository.NewTaxRepository(logger)
+	taxService := service.NewTaxService(taxRepository, db, validate, logger)
+	taxController := controller.NewTaxController(taxService, logger)
+
 	transactionRepository := repository.NewTransactionRepository(logger)
-	transactionService := service.NewTransactionService(transactionRepository, productRepository, inventoryClient, db, validate, logger)
+	transactionSagaRepository := repository.NewTransactionSagaRepository(logger)
+	transactionService := service.NewTransactionService(transactionRepository, transactionSagaRepository, productRepository, promotionRepository, taxRepository, customerRepository, loyaltyRepository, giftCardRepository, shiftRepository, costRepository, inventoryClient, db, validate, logger)
 	transactionController := controller.NewTransactionController(transactionService, logger)
 
+	customerService := service.NewCustomerService(customerRepository, transactionRepository, db, validate, logger)
+	customerController := controller.NewCustomerController(customerService, logger)
+
+	receiptService := service.NewReceiptService(transactionRepository, userRepository, customerRepository, registerRepository, db, logger)
+	receiptController := controller.NewReceiptController(receiptService, logger)
+
+	labelService := service.NewLabelService(productRepository, db, validate, logger)
+	labelController := controller.NewLabelController(labelService, logger)
+
+	reportRepository := repository.NewReportRepository(logger)
+	reportService := service.NewReportService(reportRepository, db, validate, logger)
+	reportController := controller.NewReportController(reportService, logger)
+
+	reorderRuleRepository := repository.NewReorderRuleRepository(logger)
+	replenishmentService := service.NewReplenishmentService(reorderRuleRepository, productRepository, purchaseOrderRepository, transactionRepository, purchaseOrderService, inventoryClient, db, validate, logger)
+	replenishmentController := controller.NewReplenishmentController(replenishmentService, logger)
+
 	server := fiber.New(fiber.Config{
 		ErrorHandler: exception.ErrorHandler,
 	})
@@ -68,23 +132,42 @@ func main() {
 	server.Use(cors.New(cors.Config{
 		// AllowOrigins: allowedOrigin,
 		AllowOrigins: "*",
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization, x-api-key",
+		AllowHeaders: "Origin, Content-Type, Accept, Authorization, x-api-key, Idempotency-Key",
 		AllowMethods: "GET, POST, PUT, PATCH, DELETE",
 		// AllowCredentials: true,
 	}))
 
 	routeConfig := app.RouteConfig{
-		App:                    server,
-		UserController:         userController,
-		RoleController:         roleController,
-		CategoryController:     categoryController,
-		SupplierController:     supplierController,
-		ProductController:      productController,
-		InventoryLogController: inventoryLogController,
-		TransactionController:  transactionController,
+		App:                     server,
+		UserController:          userController,
+		RoleController:          roleController,
+		CategoryController:      categoryController,
+		SupplierController:      supplierController,
+		ProductController:       productController,
+		InventoryLogController:  inventoryLogController,
+		TransactionController:   transactionController,
+		LocationController:      locationController,
+		RegisterController:      registerController,
+		TransferController:      transferController,
+		PurchaseOrderController: purchaseOrderController,
+		ReplenishmentController: replenishmentController,
+		StocktakeController:     stocktakeController,
+		LotController:           lotController,
+		ValuationController:     valuationController,
+		ReportController:        reportController,
+		ShiftController:         shiftController,
+		PromotionController:     promotionController,
+		TaxController:           taxController,
+		CustomerController:      customerController,
+		LoyaltyController:       loyaltyController,
+		GiftCardController:      giftCardController,
+		ReceiptController:       receiptController,
+		LabelController:         labelController,
 	}
 	routeConfig.Setup()
 
+	go app.RunSagaWorker(transactionService, logger)
+
 	err = server.Listen(serverPort)
 	if err != nil {
 		panic(err)
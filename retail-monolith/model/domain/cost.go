@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	CostingMethodFIFO            = "fifo"
+	CostingMethodWeightedAverage = "wac"
+)
+
+type CostLayer struct {
+	LayerID           ulid.ULID
+	ProductID         ulid.ULID
+	UnitCost          decimal.Decimal
+	QuantityReceived  int
+	QuantityRemaining int
+	Reference         string
+	ReceivedAt        time.Time
+}
+
+type CostLedgerEntry struct {
+	EntryID         ulid.ULID
+	ProductID       ulid.ULID
+	ChangeQuantity  int
+	ChangeValue     decimal.Decimal
+	BalanceQuantity int
+	BalanceValue    decimal.Decimal
+	Reference       string
+	CreatedAt       time.Time
+}
+
+type TransactionDetailCost struct {
+	DetailID      ulid.ULID
+	TransactionID ulid.ULID
+	ProductID     ulid.ULID
+	Quantity      int
+	UnitCost      decimal.Decimal
+	CostAmount    decimal.Decimal
+	CostingMethod string
+}
+
+type ProductValuation struct {
+	ProductID   ulid.ULID
+	ProductName string
+	Quantity    int
+	Value       decimal.Decimal
+}
@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type Customer struct {
+	CustomerID   ulid.ULID
+	CustomerName string
+	PhoneNumber  *string
+	Email        *string
+	Notes        *string
+	CreatedAt    time.Time
+}
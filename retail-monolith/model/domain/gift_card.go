@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	GiftCardTypeGiftCard    = "gift_card"
+	GiftCardTypeStoreCredit = "store_credit"
+)
+
+const (
+	GiftCardEntryIssue  = "issue"
+	GiftCardEntryRedeem = "redeem"
+	GiftCardEntryRefund = "refund"
+	GiftCardEntryExpire = "expire"
+)
+
+type GiftCard struct {
+	GiftCardID ulid.ULID
+	CardCode   string
+	CardType   string
+	CustomerID *ulid.ULID
+	IssuedBy   ulid.ULID
+	ExpiresAt  *time.Time
+	CreatedAt  time.Time
+}
+
+type GiftCardEntry struct {
+	EntryID       ulid.ULID
+	GiftCardID    ulid.ULID
+	EntryType     string
+	ChangeAmount  decimal.Decimal
+	BalanceAmount decimal.Decimal
+	TransactionID *ulid.ULID
+	ReturnID      *ulid.ULID
+	CreatedAt     time.Time
+}
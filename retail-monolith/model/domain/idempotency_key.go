@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type IdempotencyKey struct {
+	IdempotencyKey string
+	UserID         ulid.ULID
+	TransactionID  ulid.ULID
+	RequestHash    string
+	CreatedAt      time.Time
+}
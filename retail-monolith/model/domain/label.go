@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	LabelFormatPDF = "pdf"
+	LabelFormatZPL = "zpl"
+)
+
+const (
+	LabelSymbologyEAN13   = "ean13"
+	LabelSymbologyCode128 = "code128"
+)
+
+type Label struct {
+	ProductID    ulid.ULID
+	ProductName  string
+	SellingPrice decimal.Decimal
+	Code         string
+	Symbology    string
+	Copies       int
+}
+
+type LabelLayout struct {
+	Columns   int
+	Rows      int
+	ZPLWidth  int
+	ZPLHeight int
+}
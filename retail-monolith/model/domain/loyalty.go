@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	LoyaltyEntryEarn          = "earn"
+	LoyaltyEntryRedeem        = "redeem"
+	LoyaltyEntryReverseEarn   = "reverse_earn"
+	LoyaltyEntryReverseRedeem = "reverse_redeem"
+	LoyaltyEntryExpire        = "expire"
+)
+
+type LoyaltyEntry struct {
+	EntryID       ulid.ULID
+	CustomerID    ulid.ULID
+	EntryType     string
+	ChangePoints  int
+	BalancePoints int
+	TransactionID *ulid.ULID
+	ReturnID      *ulid.ULID
+	ExpiresAt     *time.Time
+	CreatedAt     time.Time
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	PaymentMethodCash        = "cash"
+	PaymentMethodCard        = "card"
+	PaymentMethodEWallet     = "e_wallet"
+	PaymentMethodQRIS        = "qris"
+	PaymentMethodGiftCard    = "gift_card"
+	PaymentMethodStoreCredit = "store_credit"
+	PaymentMethodLoyalty     = "loyalty_points"
+)
+
+type TransactionPayment struct {
+	PaymentID      ulid.ULID
+	TransactionID  ulid.ULID
+	Method         string
+	Amount         decimal.Decimal
+	AmountTendered decimal.NullDecimal
+	ChangeGiven    decimal.Decimal
+	Reference      *string
+	GiftCardID     *ulid.ULID
+}
+
+type PaymentReportRow struct {
+	Method       string
+	Transactions int
+	Amount       decimal.Decimal
+	ChangeGiven  decimal.Decimal
+}
@@ -5,22 +5,60 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+const (
+	BarcodeTypeEAN13    = "ean13"
+	BarcodeTypeUPCA     = "upca"
+	BarcodeTypeInternal = "internal"
+)
+
 type Product struct {
-	ProductID     ulid.ULID
-	ProductName   string
-	PurchasePrice decimal.Decimal
-	SellingPrice  decimal.Decimal
-	StockQuantity int
-	CategoryID    ulid.ULID
-	SupplierID    ulid.ULID
+	ProductID         ulid.ULID
+	ProductName       string
+	SKU               *string
+	PurchasePrice     decimal.Decimal
+	SellingPrice      decimal.Decimal
+	StockQuantity     int
+	ReservedQuantity  int
+	AvailableQuantity int
+	CategoryID        ulid.ULID
+	SupplierID        ulid.ULID
+	ParentProductID   *ulid.ULID
+	Barcodes          []ProductBarcode
+	Attributes        []ProductAttribute
+	Variants          []Product
+	Components        []ProductComponent
+}
+
+type ProductBarcode struct {
+	Barcode     string
+	ProductID   ulid.ULID
+	BarcodeType string
+}
+
+type ProductAttribute struct {
+	ProductID      ulid.ULID
+	AttributeName  string
+	AttributeValue string
+}
+
+type ProductComponent struct {
+	BundleProductID ulid.ULID
+	ProductID       ulid.ULID
+	ProductName     string
+	Quantity        int
 }
 
 type ProductUpdate struct {
-	ProductID     ulid.ULID
-	ProductName   *string
-	PurchasePrice *decimal.Decimal
-	SellingPrice  *decimal.Decimal
-	StockQuantity *int
-	CategoryID    *ulid.ULID
-	SupplierID    *ulid.ULID
+	ProductID       ulid.ULID
+	ProductName     *string
+	SKU             *string
+	PurchasePrice   *decimal.Decimal
+	SellingPrice    *decimal.Decimal
+	StockQuantity   *int
+	CategoryID      *ulid.ULID
+	SupplierID      *ulid.ULID
+	ParentProductID *ulid.ULID
+	Barcodes        []ProductBarcode
+	Attributes      []ProductAttribute
+	Components      []ProductComponent
 }
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	PromotionTypePercentage  = "percentage"
+	PromotionTypeFixedAmount = "fixed_amount"
+	PromotionTypeBuyXGetY    = "buy_x_get_y"
+	PromotionTypeBundlePrice = "bundle_price"
+)
+
+type Promotion struct {
+	PromotionID   ulid.ULID
+	PromotionName string
+	PromotionType string
+	ProductID     *ulid.ULID
+	CategoryID    *ulid.ULID
+	Value         decimal.Decimal
+	BuyQuantity   int
+	GetQuantity   int
+	MinSpend      decimal.NullDecimal
+	CouponCode    *string
+	StartsAt      *time.Time
+	EndsAt        *time.Time
+	UsageLimit    *int
+	UsageCount    int
+	Active        bool
+	CreatedAt     time.Time
+}
+
+type TransactionDiscount struct {
+	DiscountID    ulid.ULID
+	TransactionID ulid.ULID
+	DetailID      *ulid.ULID
+	PromotionID   ulid.ULID
+	PromotionName string
+	Amount        decimal.Decimal
+}
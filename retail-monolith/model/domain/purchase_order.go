@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	PurchaseOrderStatusDraft             = "draft"
+	PurchaseOrderStatusSent              = "sent"
+	PurchaseOrderStatusPartiallyReceived = "partially_received"
+	PurchaseOrderStatusReceived          = "received"
+	PurchaseOrderStatusClosed            = "closed"
+)
+
+type PurchaseOrder struct {
+	PurchaseOrderID ulid.ULID
+	SupplierID      ulid.ULID
+	LocationID      ulid.ULID
+	Status          string
+	Note            string
+	CreatedBy       ulid.ULID
+	CreatedAt       time.Time
+	SentAt          *time.Time
+	ClosedAt        *time.Time
+	TotalAmount     decimal.Decimal
+}
+
+type PurchaseOrderItem struct {
+	PurchaseOrderID  ulid.ULID
+	ProductID        ulid.ULID
+	ProductName      string
+	Quantity         int
+	QuantityReceived int
+	PurchasePrice    decimal.Decimal
+}
+
+type GoodsReceipt struct {
+	ReceiptID       ulid.ULID
+	PurchaseOrderID ulid.ULID
+	ReceivedBy      ulid.ULID
+	Note            string
+	ReceivedAt      time.Time
+	IdempotencyKey  string
+}
+
+type GoodsReceiptItem struct {
+	ReceiptID     ulid.ULID
+	ProductID     ulid.ULID
+	Quantity      int
+	PurchasePrice decimal.Decimal
+}
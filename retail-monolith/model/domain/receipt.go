@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	ReceiptFormatText   = "text"
+	ReceiptFormatHTML   = "html"
+	ReceiptFormatESCPOS = "escpos"
+	ReceiptFormatPDF    = "pdf"
+)
+
+type Receipt struct {
+	StoreName      string
+	HeaderLines    []string
+	FooterLines    []string
+	TransactionID  ulid.ULID
+	Status         string
+	CreatedAt      time.Time
+	Cashier        string
+	Register       string
+	Customer       string
+	Lines          []ReceiptLine
+	GrossAmount    decimal.Decimal
+	DiscountAmount decimal.Decimal
+	TaxAmount      decimal.Decimal
+	TaxInclusive   bool
+	TotalAmount    decimal.Decimal
+	ReturnedAmount decimal.Decimal
+	Payments       []ReceiptPayment
+}
+
+type ReceiptLine struct {
+	ProductName      string
+	Quantity         int
+	ReturnedQuantity int
+	Price            decimal.Decimal
+	SubTotal         decimal.Decimal
+	Discount         decimal.Decimal
+	LineTotal        decimal.Decimal
+}
+
+type ReceiptPayment struct {
+	Method         string
+	Amount         decimal.Decimal
+	AmountTendered decimal.NullDecimal
+	ChangeGiven    decimal.Decimal
+}
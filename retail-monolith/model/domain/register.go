@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type Register struct {
+	RegisterID   ulid.ULID
+	RegisterName string
+	LocationID   ulid.ULID
+	CreatedAt    time.Time
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+type ReorderRule struct {
+	ProductID       ulid.ULID
+	MinQuantity     int
+	MaxQuantity     int
+	ReorderQuantity int
+	UpdatedAt       time.Time
+	ProductName     string
+	PurchasePrice   decimal.Decimal
+	SupplierID      ulid.ULID
+	SupplierName    string
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	ReportGroupDay      = "day"
+	ReportGroupWeek     = "week"
+	ReportGroupMonth    = "month"
+	ReportGroupCategory = "category"
+	ReportGroupSupplier = "supplier"
+	ReportGroupProduct  = "product"
+	ReportGroupCashier  = "cashier"
+)
+
+type ReportFilter struct {
+	GroupBy    string
+	From       time.Time
+	To         time.Time
+	LocationID *ulid.ULID
+}
+
+type SalesReportRow struct {
+	GroupID      *ulid.ULID
+	Label        string
+	Transactions int
+	Units        int
+	Revenue      decimal.Decimal
+	Cost         decimal.Decimal
+}
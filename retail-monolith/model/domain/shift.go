@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	ShiftStatusOpen   = "open"
+	ShiftStatusClosed = "closed"
+)
+
+type Shift struct {
+	ShiftID      ulid.ULID
+	UserID       ulid.ULID
+	LocationID   ulid.ULID
+	RegisterID   *ulid.ULID
+	Status       string
+	OpeningFloat decimal.Decimal
+	Note         string
+	OpenedAt     time.Time
+	ClosedAt     *time.Time
+	Summary      ShiftSummary
+	ExpectedCash decimal.NullDecimal
+	CountedCash  decimal.NullDecimal
+	OverShort    decimal.NullDecimal
+}
+
+type ShiftSummary struct {
+	SalesCount    int
+	SalesAmount   decimal.Decimal
+	ReturnsCount  int
+	ReturnsAmount decimal.Decimal
+	VoidsCount    int
+	VoidsAmount   decimal.Decimal
+	CashAmount    decimal.Decimal
+	CashRefunds   decimal.Decimal
+}
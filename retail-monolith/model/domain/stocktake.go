@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	StocktakeStatusOpen      = "open"
+	StocktakeStatusApproved  = "approved"
+	StocktakeStatusCancelled = "cancelled"
+)
+
+type StocktakeSession struct {
+	SessionID  ulid.ULID
+	LocationID ulid.ULID
+	CategoryID *ulid.ULID
+	Blind      bool
+	Status     string
+	Note       string
+	CreatedBy  ulid.ULID
+	CreatedAt  time.Time
+	ApprovedBy *ulid.ULID
+	ApprovedAt *time.Time
+}
+
+type StocktakeItem struct {
+	SessionID        ulid.ULID
+	ProductID        ulid.ULID
+	ProductName      string
+	PurchasePrice    decimal.Decimal
+	ExpectedQuantity int
+}
+
+type StocktakeCount struct {
+	SessionID       ulid.ULID
+	ProductID       ulid.ULID
+	UserID          ulid.ULID
+	CountedQuantity int
+	CountedAt       time.Time
+}
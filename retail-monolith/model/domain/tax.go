@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+type TaxRate struct {
+	TaxRateID ulid.ULID
+	TaxName   string
+	Rate      decimal.Decimal
+	CreatedAt time.Time
+}
+
+type TaxReportRow struct {
+	Period        string
+	TaxRateID     *ulid.ULID
+	TaxName       string
+	Rate          decimal.Decimal
+	TaxableAmount decimal.Decimal
+	TaxAmount     decimal.Decimal
+}
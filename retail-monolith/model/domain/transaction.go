@@ -7,26 +7,64 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+const (
+	TransactionStatusPending   = "pending"
+	TransactionStatusCompleted = "completed"
+	TransactionStatusFailed    = "failed"
+	TransactionStatusVoided    = "voided"
+)
+
 type Transaction struct {
 	TransactionID   ulid.ULID
 	TransactionTime time.Time
 	UserID          ulid.ULID
+	Status          string
+	LocationID      ulid.ULID
+	RegisterID      *ulid.ULID
+	ShiftID         *ulid.ULID
+	CustomerID      *ulid.ULID
 	CreatedAt       time.Time
 }
 
 type TransactionWithTotal struct {
+	TransactionID  ulid.ULID
+	UserID         ulid.ULID
+	Status         string
+	LocationID     ulid.ULID
+	RegisterID     *ulid.ULID
+	CustomerID     *ulid.ULID
+	GrossAmount    decimal.Decimal
+	DiscountAmount decimal.Decimal
+	TaxAmount      decimal.Decimal
+	TotalAmount    decimal.Decimal
+	ReturnedAmount decimal.Decimal
+	CreatedAt      time.Time
+	VoidedAt       *time.Time
+	VoidedBy       *ulid.ULID
+	VoidReason     *string
+}
+
+type TransactionVoid struct {
 	TransactionID ulid.ULID
-	UserID        ulid.ULID
-	TotalAmount   decimal.Decimal
-	CreatedAt     time.Time
+	VoidedBy      ulid.ULID
+	VoidReason    string
+	VoidedAt      time.Time
 }
 
 type TransactionDetailWithProduct struct {
-	DetailID      ulid.ULID
-	TransactionID ulid.ULID
-	ProductID     ulid.ULID
-	ProductName   string
-	Quantity      int
-	PriceAtSale   decimal.Decimal
-	SubTotal      decimal.Decimal
+	DetailID         ulid.ULID
+	TransactionID    ulid.ULID
+	ProductID        ulid.ULID
+	ProductName      string
+	Quantity         int
+	ReturnedQuantity int
+	PriceAtSale      decimal.Decimal
+	SubTotal         decimal.Decimal
+	DiscountAmount   decimal.Decimal
+	TaxRate          decimal.Decimal
+	TaxAmount        decimal.Decimal
+	TaxInclusive     bool
+	LineTotal        decimal.Decimal
+	UnitCost         decimal.Decimal
+	CostOfGoodsSold  decimal.NullDecimal
 }
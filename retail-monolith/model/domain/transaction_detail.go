@@ -11,4 +11,16 @@ type TransactionDetail struct {
 	ProductID     ulid.ULID
 	Quantity      int
 	Price         decimal.Decimal
+	Discount      decimal.Decimal
+	TaxRateID     *ulid.ULID
+	TaxRate       decimal.Decimal
+	TaxAmount     decimal.Decimal
+	TaxInclusive  bool
+}
+
+type TransactionDetailComponent struct {
+	DetailID  ulid.ULID
+	ProductID ulid.ULID
+	Quantity  int
+	UnitCost  decimal.Decimal
 }
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	RefundMethodCash        = "cash"
+	RefundMethodStoreCredit = "store_credit"
+)
+
+type TransactionReturn struct {
+	ReturnID      ulid.ULID
+	TransactionID ulid.ULID
+	UserID        ulid.ULID
+	Reason        *string
+	RefundMethod  string
+	ShiftID       *ulid.ULID
+	CreatedAt     time.Time
+}
+
+type TransactionReturnDetail struct {
+	ReturnDetailID ulid.ULID
+	ReturnID       ulid.ULID
+	DetailID       ulid.ULID
+	Quantity       int
+	Price          decimal.Decimal
+}
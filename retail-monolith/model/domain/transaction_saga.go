@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	SagaStatusPending      = "pending"
+	SagaStatusCompleted    = "completed"
+	SagaStatusCompensating = "compensating"
+	SagaStatusCompensated  = "compensated"
+	SagaStatusDeadLetter   = "dead_letter"
+)
+
+type TransactionSaga struct {
+	SagaID    ulid.ULID
+	Status    string
+	Attempts  int
+	LastError *string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
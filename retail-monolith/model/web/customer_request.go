@@ -0,0 +1,18 @@
+package web
+
+import "github.com/oklog/ulid/v2"
+
+type CustomerRequest struct {
+	CustomerName string  `validate:"required,max=100" json:"customer_name"`
+	PhoneNumber  *string `validate:"omitempty,max=20" json:"phone_number"`
+	Email        *string `validate:"omitempty,email,max=100" json:"email"`
+	Notes        *string `validate:"omitempty,max=255" json:"notes"`
+}
+
+type CustomerUpdateRequest struct {
+	CustomerID   ulid.ULID
+	CustomerName string  `validate:"required,max=100" json:"customer_name"`
+	PhoneNumber  *string `validate:"omitempty,max=20" json:"phone_number"`
+	Email        *string `validate:"omitempty,email,max=100" json:"email"`
+	Notes        *string `validate:"omitempty,max=255" json:"notes"`
+}
@@ -0,0 +1,25 @@
+package web
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+type CustomerResponse struct {
+	CustomerID   ulid.ULID `json:"customer_id"`
+	CustomerName string    `json:"customer_name"`
+	PhoneNumber  *string   `json:"phone_number"`
+	Email        *string   `json:"email"`
+	Notes        *string   `json:"notes"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type CustomerHistoryResponse struct {
+	Customer       CustomerResponse      `json:"customer"`
+	PurchaseCount  int                   `json:"purchase_count"`
+	TotalSpent     decimal.Decimal       `json:"total_spent"`
+	LastPurchaseAt *time.Time            `json:"last_purchase_at"`
+	Transactions   []TransactionResponse `json:"transactions"`
+}
@@ -0,0 +1,17 @@
+package web
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+type GiftCardRequest struct {
+	UserID     ulid.ULID
+	CardType   string          `validate:"required,oneof=gift_card store_credit" json:"card_type"`
+	CardCode   *string         `validate:"omitempty,min=6,max=32" json:"card_code"`
+	Amount     decimal.Decimal `json:"amount"`
+	CustomerID *ulid.ULID      `json:"customer_id"`
+	ExpiresAt  *time.Time      `json:"expires_at"`
+}
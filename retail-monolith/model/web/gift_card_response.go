@@ -0,0 +1,29 @@
+package web
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+type GiftCardResponse struct {
+	GiftCardID ulid.ULID               `json:"gift_card_id"`
+	CardCode   string                  `json:"card_code"`
+	CardType   string                  `json:"card_type"`
+	CustomerID *ulid.ULID              `json:"customer_id"`
+	Balance    decimal.Decimal         `json:"balance"`
+	ExpiresAt  *time.Time              `json:"expires_at"`
+	CreatedAt  time.Time               `json:"created_at"`
+	Entries    []GiftCardEntryResponse `json:"entries,omitempty"`
+}
+
+type GiftCardEntryResponse struct {
+	EntryID       ulid.ULID       `json:"entry_id"`
+	EntryType     string          `json:"entry_type"`
+	ChangeAmount  decimal.Decimal `json:"change_amount"`
+	BalanceAmount decimal.Decimal `json:"balance_amount"`
+	TransactionID *ulid.ULID      `json:"transaction_id,omitempty"`
+	ReturnID      *ulid.ULID      `json:"return_id,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
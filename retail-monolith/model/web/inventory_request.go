@@ -3,8 +3,11 @@ package web
 import "github.com/oklog/ulid/v2"
 
 type InventoryLogRequest struct {
-	ProductID      ulid.ULID `validate:"required" json:"product_id"`
-	UserID         ulid.ULID `validate:"required" json:"user_id"`
-	ChangeQuantity int       `validate:"required" json:"change_quantity"`
-	Reason         *string   `validate:"required" json:"reason"`
+	ProductID      ulid.ULID  `validate:"required" json:"product_id"`
+	UserID         ulid.ULID  `validate:"required" json:"user_id"`
+	ChangeQuantity int        `validate:"required" json:"change_quantity"`
+	Reason         *string    `validate:"required" json:"reason"`
+	LocationID     *ulid.ULID `json:"location_id"`
+	LotNumber      string     `validate:"max=64" json:"lot_number"`
+	ExpiryDate     string     `validate:"omitempty,datetime=2006-01-02" json:"expiry_date"`
 }
@@ -9,6 +9,7 @@ import (
 type InventoryLogResponse struct {
 	LogID          ulid.ULID `json:"log_id"`
 	ProductID      ulid.ULID `json:"product_id"`
+	LocationID     ulid.ULID `json:"location_id"`
 	UserID         ulid.ULID `json:"user_id"`
 	ChangeQuantity int       `json:"change_quantity"`
 	Reason         *string   `json:"reason"`
@@ -0,0 +1,10 @@
+package web
+
+import "github.com/oklog/ulid/v2"
+
+type LabelRequest struct {
+	ProductIDs []ulid.ULID `json:"product_ids"`
+	CategoryID *ulid.ULID  `json:"category_id"`
+	Format     string      `validate:"omitempty,oneof=pdf zpl" json:"format"`
+	Copies     int         `validate:"omitempty,min=1,max=500" json:"copies"`
+}
@@ -0,0 +1,7 @@
+package web
+
+type LabelResponse struct {
+	ContentType string
+	FileName    string
+	Body        []byte
+}
@@ -0,0 +1,6 @@
+package web
+
+type LocationRequest struct {
+	LocationName string `validate:"required,max=100" json:"location_name"`
+	LocationType string `validate:"omitempty,oneof=store warehouse" json:"location_type"`
+}
@@ -0,0 +1,9 @@
+package web
+
+import "github.com/oklog/ulid/v2"
+
+type LocationResponse struct {
+	LocationID   ulid.ULID `json:"location_id"`
+	LocationName string    `json:"location_name"`
+	LocationType string    `json:"location_type"`
+}
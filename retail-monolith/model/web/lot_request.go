@@ -0,0 +1,10 @@
+package web
+
+import "github.com/oklog/ulid/v2"
+
+type LotWriteOffRequest struct {
+	UserID     ulid.ULID
+	LocationID *ulid.ULID  `json:"location_id"`
+	LotIDs     []ulid.ULID `json:"lot_ids"`
+	Reason     string      `validate:"required,max=200" json:"reason"`
+}
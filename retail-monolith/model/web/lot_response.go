@@ -0,0 +1,22 @@
+package web
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type LotResponse struct {
+	LotID      ulid.ULID `json:"lot_id"`
+	LocationID ulid.ULID `json:"location_id"`
+	ProductID  ulid.ULID `json:"product_id"`
+	LotNumber  string    `json:"lot_number"`
+	ReceivedAt time.Time `json:"received_at"`
+	ExpiryDate string    `json:"expiry_date"`
+	Quantity   int       `json:"quantity"`
+}
+
+type LotWriteOffResponse struct {
+	Message string        `json:"message"`
+	Lots    []LotResponse `json:"lots"`
+}
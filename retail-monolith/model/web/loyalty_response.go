@@ -0,0 +1,27 @@
+package web
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+type LoyaltyResponse struct {
+	CustomerID   ulid.ULID              `json:"customer_id"`
+	Balance      int                    `json:"balance"`
+	PointValue   decimal.Decimal        `json:"point_value"`
+	BalanceValue decimal.Decimal        `json:"balance_value"`
+	Entries      []LoyaltyEntryResponse `json:"entries"`
+}
+
+type LoyaltyEntryResponse struct {
+	EntryID       ulid.ULID  `json:"entry_id"`
+	EntryType     string     `json:"entry_type"`
+	ChangePoints  int        `json:"change_points"`
+	BalancePoints int        `json:"balance_points"`
+	TransactionID *ulid.ULID `json:"transaction_id,omitempty"`
+	ReturnID      *ulid.ULID `json:"return_id,omitempty"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
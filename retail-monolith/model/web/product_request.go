@@ -6,21 +6,36 @@ import (
 )
 
 type ProductRequest struct {
-	ProductName   string          `validate:"required" json:"product_name"`
-	PurchasePrice decimal.Decimal `validate:"required" json:"purchase_price"`
-	SellingPrice  decimal.Decimal `validate:"required" json:"selling_price"`
-	StockQuantity int             `validate:"required" json:"stock_quantity"`
-	CategoryID    ulid.ULID       `validate:"required" json:"category_id"`
-	SupplierID    ulid.ULID       `validate:"required" json:"supplier_id"`
+	ProductName     string                    `validate:"required_without=ParentProductID" json:"product_name"`
+	SKU             *string                   `validate:"omitempty,max=64" json:"sku"`
+	Barcodes        []string                  `validate:"omitempty,dive,required" json:"barcodes"`
+	PurchasePrice   decimal.Decimal           `validate:"required" json:"purchase_price"`
+	SellingPrice    decimal.Decimal           `validate:"required" json:"selling_price"`
+	StockQuantity   int                       `validate:"required_without=Components" json:"stock_quantity"`
+	CategoryID      ulid.ULID                 `validate:"required_without=ParentProductID" json:"category_id"`
+	SupplierID      ulid.ULID                 `validate:"required_without=ParentProductID" json:"supplier_id"`
+	LocationID      *ulid.ULID                `json:"location_id"`
+	ParentProductID *ulid.ULID                `json:"parent_product_id"`
+	Attributes      map[string]string         `validate:"required_with=ParentProductID,dive,keys,required,max=50,endkeys,required,max=100" json:"attributes"`
+	Components      []ProductComponentRequest `validate:"omitempty,dive" json:"components"`
+}
+
+type ProductComponentRequest struct {
+	ProductID ulid.ULID `validate:"required" json:"product_id"`
+	Quantity  int       `validate:"required,min=1" json:"quantity"`
 }
 
 type ProductUpdateRequest struct {
 	ProductID     ulid.ULID
-	ProductName   *string          `json:"product_name"`
-	PurchasePrice *decimal.Decimal `json:"purchase_price"`
-	SellingPrice  *decimal.Decimal `json:"selling_price"`
-	CategoryID    *ulid.ULID       `json:"category_id"`
-	SupplierID    *ulid.ULID       `json:"supplier_id"`
+	ProductName   *string                    `json:"product_name"`
+	SKU           *string                    `validate:"omitempty,max=64" json:"sku"`
+	Barcodes      *[]string                  `validate:"omitempty,dive,required" json:"barcodes"`
+	PurchasePrice *decimal.Decimal           `json:"purchase_price"`
+	SellingPrice  *decimal.Decimal           `json:"selling_price"`
+	CategoryID    *ulid.ULID                 `json:"category_id"`
+	SupplierID    *ulid.ULID                 `json:"supplier_id"`
+	Attributes    *map[string]string         `validate:"omitempty,dive,keys,required,max=50,endkeys,required,max=100" json:"attributes"`
+	Components    *[]ProductComponentRequest `validate:"omitempty,dive" json:"components"`
 }
 
 type ProductUpdateStockRequest struct {
@@ -6,21 +6,45 @@ import (
 )
 
 type ProductResponse struct {
-	ProductID     ulid.ULID       `json:"product_id"`
-	ProductName   string          `json:"product_name"`
-	PurchasePrice decimal.Decimal `json:"purchase_price"`
-	SellingPrice  decimal.Decimal `json:"selling_price"`
-	StockQuantity int             `json:"stock_quantity"`
-	CategoryID    ulid.ULID       `json:"category_id"`
-	SupplierID    ulid.ULID       `json:"supplier_id"`
+	ProductID         ulid.ULID                  `json:"product_id"`
+	ProductName       string                     `json:"product_name"`
+	SKU               *string                    `json:"sku"`
+	Barcodes          []ProductBarcodeResponse   `json:"barcodes"`
+	PurchasePrice     decimal.Decimal            `json:"purchase_price"`
+	SellingPrice      decimal.Decimal            `json:"selling_price"`
+	StockQuantity     int                        `json:"stock_quantity"`
+	ReservedQuantity  int                        `json:"reserved_quantity"`
+	AvailableQuantity int                        `json:"available_quantity"`
+	CategoryID        ulid.ULID                  `json:"category_id"`
+	SupplierID        ulid.ULID                  `json:"supplier_id"`
+	ParentProductID   *ulid.ULID                 `json:"parent_product_id"`
+	Attributes        map[string]string          `json:"attributes,omitempty"`
+	Variants          []ProductResponse          `json:"variants,omitempty"`
+	Components        []ProductComponentResponse `json:"components,omitempty"`
 }
 
 type ProductUpdateResponse struct {
-	ProductID     ulid.ULID        `json:"product_id"`
-	ProductName   *string          `json:"product_name"`
-	PurchasePrice *decimal.Decimal `json:"purchase_price"`
-	SellingPrice  *decimal.Decimal `json:"selling_price"`
-	StockQuantity *int             `json:"stock_quantity"`
-	CategoryID    *ulid.ULID       `json:"category_id"`
-	SupplierID    *ulid.ULID       `json:"supplier_id"`
+	ProductID       ulid.ULID                  `json:"product_id"`
+	ProductName     *string                    `json:"product_name"`
+	SKU             *string                    `json:"sku"`
+	Barcodes        []ProductBarcodeResponse   `json:"barcodes"`
+	PurchasePrice   *decimal.Decimal           `json:"purchase_price"`
+	SellingPrice    *decimal.Decimal           `json:"selling_price"`
+	StockQuantity   *int                       `json:"stock_quantity"`
+	CategoryID      *ulid.ULID                 `json:"category_id"`
+	SupplierID      *ulid.ULID                 `json:"supplier_id"`
+	ParentProductID *ulid.ULID                 `json:"parent_product_id"`
+	Attributes      map[string]string          `json:"attributes,omitempty"`
+	Components      []ProductComponentResponse `json:"components,omitempty"`
+}
+
+type ProductComponentResponse struct {
+	ProductID   ulid.ULID `json:"product_id"`
+	ProductName string    `json:"product_name"`
+	Quantity    int       `json:"quantity"`
+}
+
+type ProductBarcodeResponse struct {
+	Barcode     string `json:"barcode"`
+	BarcodeType string `json:"barcode_type"`
 }
@@ -0,0 +1,41 @@
+package web
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+type PromotionRequest struct {
+	PromotionName string           `validate:"required,max=100" json:"promotion_name"`
+	PromotionType string           `validate:"required,oneof=percentage fixed_amount buy_x_get_y bundle_price" json:"promotion_type"`
+	ProductID     *ulid.ULID       `json:"product_id"`
+	CategoryID    *ulid.ULID       `json:"category_id"`
+	Value         decimal.Decimal  `json:"value"`
+	BuyQuantity   int              `validate:"min=0" json:"buy_quantity"`
+	GetQuantity   int              `validate:"min=0" json:"get_quantity"`
+	MinSpend      *decimal.Decimal `json:"min_spend"`
+	CouponCode    *string          `validate:"omitempty,min=3,max=50" json:"coupon_code"`
+	StartsAt      *time.Time       `json:"starts_at"`
+	EndsAt        *time.Time       `json:"ends_at"`
+	UsageLimit    *int             `validate:"omitempty,min=1" json:"usage_limit"`
+	Active        *bool            `json:"active"`
+}
+
+type PromotionUpdateRequest struct {
+	PromotionID   ulid.ULID
+	PromotionName string           `validate:"required,max=100" json:"promotion_name"`
+	PromotionType string           `validate:"required,oneof=percentage fixed_amount buy_x_get_y bundle_price" json:"promotion_type"`
+	ProductID     *ulid.ULID       `json:"product_id"`
+	CategoryID    *ulid.ULID       `json:"category_id"`
+	Value         decimal.Decimal  `json:"value"`
+	BuyQuantity   int              `validate:"min=0" json:"buy_quantity"`
+	GetQuantity   int              `validate:"min=0" json:"get_quantity"`
+	MinSpend      *decimal.Decimal `json:"min_spend"`
+	CouponCode    *string          `validate:"omitempty,min=3,max=50" json:"coupon_code"`
+	StartsAt      *time.Time       `json:"starts_at"`
+	EndsAt        *time.Time       `json:"ends_at"`
+	UsageLimit    *int             `validate:"omitempty,min=1" json:"usage_limit"`
+	Active        *bool            `json:"active"`
+}
@@ -0,0 +1,27 @@
+package web
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+type PromotionResponse struct {
+	PromotionID   ulid.ULID        `json:"promotion_id"`
+	PromotionName string           `json:"promotion_name"`
+	PromotionType string           `json:"promotion_type"`
+	ProductID     *ulid.ULID       `json:"product_id"`
+	CategoryID    *ulid.ULID       `json:"category_id"`
+	Value         decimal.Decimal  `json:"value"`
+	BuyQuantity   int              `json:"buy_quantity"`
+	GetQuantity   int              `json:"get_quantity"`
+	MinSpend      *decimal.Decimal `json:"min_spend"`
+	CouponCode    *string          `json:"coupon_code"`
+	StartsAt      *time.Time       `json:"starts_at"`
+	EndsAt        *time.Time       `json:"ends_at"`
+	UsageLimit    *int             `json:"usage_limit"`
+	UsageCount    int              `json:"usage_count"`
+	Active        bool             `json:"active"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
@@ -0,0 +1,36 @@
+package web
+
+import (
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+type PurchaseOrderRequest struct {
+	UserID     ulid.ULID
+	SupplierID ulid.ULID                  `validate:"required" json:"supplier_id"`
+	LocationID *ulid.ULID                 `json:"location_id"`
+	Note       string                     `validate:"max=255" json:"note"`
+	Items      []PurchaseOrderItemRequest `validate:"required,min=1,dive" json:"items"`
+}
+
+type PurchaseOrderItemRequest struct {
+	ProductID     ulid.ULID       `validate:"required" json:"product_id"`
+	Quantity      int             `validate:"required,gt=0" json:"quantity"`
+	PurchasePrice decimal.Decimal `validate:"required" json:"purchase_price"`
+}
+
+type GoodsReceiptRequest struct {
+	PurchaseOrderID ulid.ULID
+	UserID          ulid.ULID
+	Note            string                    `validate:"max=255" json:"note"`
+	Items           []GoodsReceiptItemRequest `validate:"required,min=1,dive" json:"items"`
+	IdempotencyKey  string                    `json:"-" validate:"max=64"`
+}
+
+type GoodsReceiptItemRequest struct {
+	ProductID     ulid.ULID        `validate:"required" json:"product_id"`
+	Quantity      int              `validate:"required,gt=0" json:"quantity"`
+	PurchasePrice *decimal.Decimal `json:"purchase_price"`
+	LotNumber     string           `validate:"max=64" json:"lot_number"`
+	ExpiryDate    string           `validate:"omitempty,datetime=2006-01-02" json:"expiry_date"`
+}
@@ -0,0 +1,46 @@
+package web
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+type PurchaseOrderResponse struct {
+	PurchaseOrderID ulid.ULID                   `json:"purchase_order_id"`
+	SupplierID      ulid.ULID                   `json:"supplier_id"`
+	LocationID      ulid.ULID                   `json:"location_id"`
+	Status          string                      `json:"status"`
+	Note            string                      `json:"note"`
+	CreatedBy       ulid.ULID                   `json:"created_by"`
+	CreatedAt       time.Time                   `json:"created_at"`
+	SentAt          *time.Time                  `json:"sent_at"`
+	ClosedAt        *time.Time                  `json:"closed_at"`
+	TotalAmount     decimal.Decimal             `json:"total_amount"`
+	Items           []PurchaseOrderItemResponse `json:"items,omitempty"`
+	Receipts        []GoodsReceiptResponse      `json:"receipts,omitempty"`
+}
+
+type PurchaseOrderItemResponse struct {
+	ProductID        ulid.ULID       `json:"product_id"`
+	ProductName      string          `json:"product_name"`
+	Quantity         int             `json:"quantity"`
+	QuantityReceived int             `json:"quantity_received"`
+	PurchasePrice    decimal.Decimal `json:"purchase_price"`
+	SubTotal         decimal.Decimal `json:"sub_total"`
+}
+
+type GoodsReceiptResponse struct {
+	ReceiptID  ulid.ULID                  `json:"receipt_id"`
+	ReceivedBy ulid.ULID                  `json:"received_by"`
+	Note       string                     `json:"note"`
+	ReceivedAt time.Time                  `json:"received_at"`
+	Items      []GoodsReceiptItemResponse `json:"items"`
+}
+
+type GoodsReceiptItemResponse struct {
+	ProductID     ulid.ULID       `json:"product_id"`
+	Quantity      int             `json:"quantity"`
+	PurchasePrice decimal.Decimal `json:"purchase_price"`
+}
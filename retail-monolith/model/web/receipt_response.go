@@ -0,0 +1,7 @@
+package web
+
+type ReceiptResponse struct {
+	ContentType string
+	FileName    string
+	Body        []byte
+}
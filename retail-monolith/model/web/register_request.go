@@ -0,0 +1,13 @@
+package web
+
+import "github.com/oklog/ulid/v2"
+
+type RegisterRequest struct {
+	RegisterName string    `validate:"required,max=100" json:"register_name"`
+	LocationID   ulid.ULID `validate:"required" json:"location_id"`
+}
+
+type RegisterAssignRequest struct {
+	UserID     ulid.ULID
+	RegisterID *ulid.ULID `json:"register_id"`
+}
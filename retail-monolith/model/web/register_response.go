@@ -0,0 +1,14 @@
+package web
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type RegisterResponse struct {
+	RegisterID   ulid.ULID `json:"register_id"`
+	RegisterName string    `json:"register_name"`
+	LocationID   ulid.ULID `json:"location_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
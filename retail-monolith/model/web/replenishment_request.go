@@ -0,0 +1,15 @@
+package web
+
+import "github.com/oklog/ulid/v2"
+
+type ReorderRuleRequest struct {
+	ProductID       ulid.ULID
+	MinQuantity     int `validate:"gte=0" json:"min_quantity"`
+	MaxQuantity     int `validate:"gtfield=MinQuantity" json:"max_quantity"`
+	ReorderQuantity int `validate:"gte=1" json:"reorder_quantity"`
+}
+
+type ReplenishmentRequest struct {
+	UserID     ulid.ULID
+	LocationID *ulid.ULID
+}
@@ -0,0 +1,45 @@
+package web
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+type ReorderRuleResponse struct {
+	ProductID       ulid.ULID `json:"product_id"`
+	MinQuantity     int       `json:"min_quantity"`
+	MaxQuantity     int       `json:"max_quantity"`
+	ReorderQuantity int       `json:"reorder_quantity"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type ReplenishmentResponse struct {
+	LocationID      ulid.ULID                       `json:"location_id"`
+	SalesWindowDays int                             `json:"sales_window_days"`
+	LeadTimeDays    int                             `json:"lead_time_days"`
+	Suppliers       []ReplenishmentSupplierResponse `json:"suppliers"`
+}
+
+type ReplenishmentSupplierResponse struct {
+	SupplierID     ulid.ULID                   `json:"supplier_id"`
+	SupplierName   string                      `json:"supplier_name"`
+	EstimatedTotal decimal.Decimal             `json:"estimated_total"`
+	Items          []ReplenishmentItemResponse `json:"items"`
+}
+
+type ReplenishmentItemResponse struct {
+	ProductID         ulid.ULID       `json:"product_id"`
+	ProductName       string          `json:"product_name"`
+	AvailableQuantity int             `json:"available_quantity"`
+	OnOrderQuantity   int             `json:"on_order_quantity"`
+	SoldQuantity      int             `json:"sold_quantity"`
+	DailyVelocity     float64         `json:"daily_velocity"`
+	LeadTimeDemand    int             `json:"lead_time_demand"`
+	MinQuantity       int             `json:"min_quantity"`
+	MaxQuantity       int             `json:"max_quantity"`
+	ReorderQuantity   int             `json:"reorder_quantity"`
+	SuggestedQuantity int             `json:"suggested_quantity"`
+	PurchasePrice     decimal.Decimal `json:"purchase_price"`
+}
@@ -0,0 +1,22 @@
+package web
+
+import "github.com/oklog/ulid/v2"
+
+type SalesReportRequest struct {
+	GroupBy    string     `validate:"required,oneof=day week month category supplier product cashier" json:"group_by"`
+	From       string     `validate:"omitempty,datetime=2006-01-02" json:"from"`
+	To         string     `validate:"omitempty,datetime=2006-01-02" json:"to"`
+	LocationID *ulid.ULID `json:"location_id"`
+}
+
+type PaymentReportRequest struct {
+	From       string     `validate:"omitempty,datetime=2006-01-02" json:"from"`
+	To         string     `validate:"omitempty,datetime=2006-01-02" json:"to"`
+	LocationID *ulid.ULID `json:"location_id"`
+}
+
+type TaxReportRequest struct {
+	From       string     `validate:"omitempty,datetime=2006-01-02" json:"from"`
+	To         string     `validate:"omitempty,datetime=2006-01-02" json:"to"`
+	LocationID *ulid.ULID `json:"location_id"`
+}
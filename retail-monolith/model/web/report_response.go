@@ -0,0 +1,59 @@
+package web
+
+import (
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+type SalesReportResponse struct {
+	GroupBy    string                   `json:"group_by"`
+	From       string                   `json:"from"`
+	To         string                   `json:"to"`
+	LocationID *ulid.ULID               `json:"location_id,omitempty"`
+	Total      SalesReportRowResponse   `json:"total"`
+	Rows       []SalesReportRowResponse `json:"rows"`
+}
+
+type SalesReportRowResponse struct {
+	ID            *ulid.ULID      `json:"id,omitempty"`
+	Label         string          `json:"label"`
+	Transactions  int             `json:"transactions"`
+	Units         int             `json:"units"`
+	Revenue       decimal.Decimal `json:"revenue"`
+	Cost          decimal.Decimal `json:"cost"`
+	GrossMargin   decimal.Decimal `json:"gross_margin"`
+	MarginPercent decimal.Decimal `json:"margin_percent"`
+}
+
+type PaymentReportResponse struct {
+	From       string                     `json:"from"`
+	To         string                     `json:"to"`
+	LocationID *ulid.ULID                 `json:"location_id,omitempty"`
+	Total      PaymentReportRowResponse   `json:"total"`
+	Rows       []PaymentReportRowResponse `json:"rows"`
+}
+
+type PaymentReportRowResponse struct {
+	Method       string          `json:"method"`
+	Transactions int             `json:"transactions"`
+	Amount       decimal.Decimal `json:"amount"`
+	ChangeGiven  decimal.Decimal `json:"change_given"`
+}
+
+type TaxReportResponse struct {
+	From       string                 `json:"from"`
+	To         string                 `json:"to"`
+	LocationID *ulid.ULID             `json:"location_id,omitempty"`
+	Total      TaxReportRowResponse   `json:"total"`
+	Rows       []TaxReportRowResponse `json:"rows"`
+}
+
+type TaxReportRowResponse struct {
+	Period        string          `json:"period"`
+	TaxRateID     *ulid.ULID      `json:"tax_rate_id,omitempty"`
+	TaxName       string          `json:"tax_name"`
+	Rate          decimal.Decimal `json:"rate"`
+	TaxableAmount decimal.Decimal `json:"taxable_amount"`
+	TaxAmount     decimal.Decimal `json:"tax_amount"`
+	TotalAmount   decimal.Decimal `json:"total_amount"`
+}
@@ -0,0 +1,20 @@
+package web
+
+import (
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+type ShiftOpenRequest struct {
+	UserID       ulid.ULID       `validate:"required" json:"user_id"`
+	LocationID   *ulid.ULID      `json:"location_id"`
+	OpeningFloat decimal.Decimal `json:"opening_float"`
+	Note         string          `validate:"max=255" json:"note"`
+}
+
+type ShiftCloseRequest struct {
+	ShiftID     ulid.ULID       `validate:"required" json:"shift_id"`
+	UserID      ulid.ULID       `validate:"required" json:"user_id"`
+	CountedCash decimal.Decimal `json:"counted_cash"`
+	Note        string          `validate:"max=255" json:"note"`
+}
@@ -0,0 +1,37 @@
+package web
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+type ShiftResponse struct {
+	ShiftID      ulid.ULID       `json:"shift_id"`
+	UserID       ulid.ULID       `json:"user_id"`
+	LocationID   ulid.ULID       `json:"location_id"`
+	RegisterID   *ulid.ULID      `json:"register_id"`
+	Status       string          `json:"status"`
+	OpeningFloat decimal.Decimal `json:"opening_float"`
+	Note         string          `json:"note"`
+	OpenedAt     time.Time       `json:"opened_at"`
+	ClosedAt     *time.Time      `json:"closed_at"`
+}
+
+type ShiftReportResponse struct {
+	ReportType    string           `json:"report_type"`
+	Shift         ShiftResponse    `json:"shift"`
+	SalesCount    int              `json:"sales_count"`
+	SalesAmount   decimal.Decimal  `json:"sales_amount"`
+	ReturnsCount  int              `json:"returns_count"`
+	ReturnsAmount decimal.Decimal  `json:"returns_amount"`
+	VoidsCount    int              `json:"voids_count"`
+	VoidsAmount   decimal.Decimal  `json:"voids_amount"`
+	NetSales      decimal.Decimal  `json:"net_sales"`
+	CashSales     decimal.Decimal  `json:"cash_sales"`
+	CashRefunds   decimal.Decimal  `json:"cash_refunds"`
+	ExpectedCash  decimal.Decimal  `json:"expected_cash"`
+	CountedCash   *decimal.Decimal `json:"counted_cash"`
+	OverShort     *decimal.Decimal `json:"over_short"`
+}
@@ -0,0 +1,22 @@
+package web
+
+import "github.com/oklog/ulid/v2"
+
+type StocktakeRequest struct {
+	UserID     ulid.ULID
+	LocationID *ulid.ULID `json:"location_id"`
+	CategoryID *ulid.ULID `json:"category_id"`
+	Blind      bool       `json:"blind"`
+	Note       string     `validate:"max=255" json:"note"`
+}
+
+type StocktakeCountRequest struct {
+	SessionID ulid.ULID
+	UserID    ulid.ULID
+	Items     []StocktakeCountItemRequest `validate:"required,min=1,dive" json:"items"`
+}
+
+type StocktakeCountItemRequest struct {
+	ProductID       ulid.ULID `validate:"required" json:"product_id"`
+	CountedQuantity int       `validate:"gte=0" json:"counted_quantity"`
+}
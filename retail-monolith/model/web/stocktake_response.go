@@ -0,0 +1,55 @@
+package web
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+type StocktakeResponse struct {
+	SessionID  ulid.ULID               `json:"session_id"`
+	LocationID ulid.ULID               `json:"location_id"`
+	CategoryID *ulid.ULID              `json:"category_id"`
+	Blind      bool                    `json:"blind"`
+	Status     string                  `json:"status"`
+	Note       string                  `json:"note"`
+	CreatedBy  ulid.ULID               `json:"created_by"`
+	CreatedAt  time.Time               `json:"created_at"`
+	ApprovedBy *ulid.ULID              `json:"approved_by"`
+	ApprovedAt *time.Time              `json:"approved_at"`
+	Items      []StocktakeItemResponse `json:"items,omitempty"`
+}
+
+type StocktakeItemResponse struct {
+	ProductID         ulid.ULID `json:"product_id"`
+	ProductName       string    `json:"product_name"`
+	ExpectedQuantity  *int      `json:"expected_quantity,omitempty"`
+	MyCountedQuantity *int      `json:"my_counted_quantity"`
+}
+
+type StocktakeVarianceResponse struct {
+	SessionID          ulid.ULID                   `json:"session_id"`
+	LocationID         ulid.ULID                   `json:"location_id"`
+	Status             string                      `json:"status"`
+	CountedProducts    int                         `json:"counted_products"`
+	UncountedProducts  int                         `json:"uncounted_products"`
+	TotalVarianceValue decimal.Decimal             `json:"total_variance_value"`
+	Items              []StocktakeVarianceItemResp `json:"items"`
+}
+
+type StocktakeVarianceItemResp struct {
+	ProductID        ulid.ULID                `json:"product_id"`
+	ProductName      string                   `json:"product_name"`
+	ExpectedQuantity int                      `json:"expected_quantity"`
+	CountedQuantity  *int                     `json:"counted_quantity"`
+	Variance         int                      `json:"variance"`
+	VarianceValue    decimal.Decimal          `json:"variance_value"`
+	Counts           []StocktakeCountResponse `json:"counts"`
+}
+
+type StocktakeCountResponse struct {
+	UserID          ulid.ULID `json:"user_id"`
+	CountedQuantity int       `json:"counted_quantity"`
+	CountedAt       time.Time `json:"counted_at"`
+}
@@ -0,0 +1,22 @@
+package web
+
+import (
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+type TaxRateRequest struct {
+	TaxName string          `validate:"required,max=50" json:"tax_name"`
+	Rate    decimal.Decimal `json:"rate"`
+}
+
+type TaxRateUpdateRequest struct {
+	TaxRateID ulid.ULID
+	TaxName   string          `validate:"required,max=50" json:"tax_name"`
+	Rate      decimal.Decimal `json:"rate"`
+}
+
+type TaxRateAssignRequest struct {
+	TargetID  ulid.ULID
+	TaxRateID *ulid.ULID `json:"tax_rate_id"`
+}
@@ -0,0 +1,15 @@
+package web
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+type TaxRateResponse struct {
+	TaxRateID ulid.ULID       `json:"tax_rate_id"`
+	TaxName   string          `json:"tax_name"`
+	Rate      decimal.Decimal `json:"rate"`
+	CreatedAt time.Time       `json:"created_at"`
+}
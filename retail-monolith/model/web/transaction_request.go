@@ -2,14 +2,47 @@ package web
 
 import (
 	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
 )
 
 type TransactionRequest struct {
-	UserID ulid.ULID            `json:"user_id"`
-	Items  []TransactionItemReq `json:"items" validate:"required,min=1"`
+	UserID         ulid.ULID               `json:"user_id"`
+	CustomerID     *ulid.ULID              `json:"customer_id"`
+	IdempotencyKey string                  `json:"-" validate:"max=64"`
+	Items          []TransactionItemReq    `json:"items" validate:"required,min=1"`
+	CouponCode     *string                 `json:"coupon_code" validate:"omitempty,max=50"`
+	Payments       []TransactionPaymentReq `json:"payments" validate:"required,min=1,dive"`
 }
 
 type TransactionItemReq struct {
-	ProductID ulid.ULID `json:"product_id" validate:"required"`
+	ProductID ulid.ULID `json:"product_id"`
+	Barcode   string    `json:"barcode" validate:"omitempty,max=32"`
 	Quantity  int       `json:"quantity" validate:"required,min=1"`
 }
+
+type TransactionPaymentReq struct {
+	Method         string           `json:"method" validate:"required,oneof=cash card e_wallet qris gift_card store_credit loyalty_points"`
+	Amount         decimal.Decimal  `json:"amount"`
+	AmountTendered *decimal.Decimal `json:"amount_tendered"`
+	Reference      *string          `json:"reference" validate:"omitempty,max=100"`
+	CardCode       *string          `json:"card_code" validate:"omitempty,max=32"`
+}
+
+type TransactionReturnRequest struct {
+	TransactionID ulid.ULID                  `json:"transaction_id"`
+	UserID        ulid.ULID                  `json:"user_id"`
+	Reason        *string                    `json:"reason"`
+	RefundMethod  string                     `json:"refund_method" validate:"omitempty,oneof=cash store_credit"`
+	Items         []TransactionReturnItemReq `json:"items" validate:"required,min=1,dive"`
+}
+
+type TransactionReturnItemReq struct {
+	DetailID ulid.ULID `json:"detail_id" validate:"required"`
+	Quantity int       `json:"quantity" validate:"required,min=1"`
+}
+
+type TransactionVoidRequest struct {
+	TransactionID ulid.ULID `json:"transaction_id"`
+	UserID        ulid.ULID `json:"user_id"`
+	Reason        string    `json:"reason" validate:"required"`
+}
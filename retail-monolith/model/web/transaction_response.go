@@ -8,14 +8,75 @@ import (
 )
 
 type TransactionResponse struct {
-	TransactionID ulid.ULID             `json:"transaction_id"`
-	UserID        ulid.ULID             `json:"user_id"`
-	TotalAmount   decimal.Decimal       `json:"total_amount"`
-	CreatedAt     time.Time             `json:"created_at"`
-	Items         []TransactionItemResp `json:"items"`
+	TransactionID  ulid.ULID                 `json:"transaction_id"`
+	UserID         ulid.ULID                 `json:"user_id"`
+	Status         string                    `json:"status"`
+	LocationID     ulid.ULID                 `json:"location_id"`
+	RegisterID     *ulid.ULID                `json:"register_id"`
+	CustomerID     *ulid.ULID                `json:"customer_id"`
+	GrossAmount    decimal.Decimal           `json:"gross_amount"`
+	DiscountAmount decimal.Decimal           `json:"discount_amount"`
+	TaxAmount      decimal.Decimal           `json:"tax_amount"`
+	TotalAmount    decimal.Decimal           `json:"total_amount"`
+	ReturnedAmount decimal.Decimal           `json:"returned_amount"`
+	NetAmount      decimal.Decimal           `json:"net_amount"`
+	CreatedAt      time.Time                 `json:"created_at"`
+	VoidedAt       *time.Time                `json:"voided_at"`
+	VoidedBy       *ulid.ULID                `json:"voided_by"`
+	VoidReason     *string                   `json:"void_reason"`
+	Items          []TransactionItemResp     `json:"items"`
+	Discounts      []TransactionDiscountResp `json:"discounts,omitempty"`
+	Payments       []TransactionPaymentResp  `json:"payments,omitempty"`
 }
 
 type TransactionItemResp struct {
+	DetailID         ulid.ULID        `json:"detail_id"`
+	ProductID        ulid.ULID        `json:"product_id"`
+	ProductName      string           `json:"product_name"`
+	Quantity         int              `json:"quantity"`
+	ReturnedQuantity int              `json:"returned_quantity"`
+	Price            decimal.Decimal  `json:"price"`
+	SubTotal         decimal.Decimal  `json:"sub_total"`
+	DiscountAmount   decimal.Decimal  `json:"discount_amount"`
+	TaxRate          decimal.Decimal  `json:"tax_rate"`
+	TaxAmount        decimal.Decimal  `json:"tax_amount"`
+	TaxInclusive     bool             `json:"tax_inclusive"`
+	LineTotal        decimal.Decimal  `json:"line_total"`
+	CostOfGoodsSold  *decimal.Decimal `json:"cost_of_goods_sold,omitempty"`
+}
+
+type TransactionDiscountResp struct {
+	DiscountID    ulid.ULID       `json:"discount_id"`
+	DetailID      *ulid.ULID      `json:"detail_id"`
+	PromotionID   ulid.ULID       `json:"promotion_id"`
+	PromotionName string          `json:"promotion_name"`
+	Amount        decimal.Decimal `json:"amount"`
+}
+
+type TransactionPaymentResp struct {
+	PaymentID      ulid.ULID        `json:"payment_id"`
+	Method         string           `json:"method"`
+	Amount         decimal.Decimal  `json:"amount"`
+	AmountTendered *decimal.Decimal `json:"amount_tendered,omitempty"`
+	ChangeGiven    decimal.Decimal  `json:"change_given"`
+	Reference      *string          `json:"reference"`
+	GiftCardID     *ulid.ULID       `json:"gift_card_id,omitempty"`
+}
+
+type TransactionReturnResponse struct {
+	ReturnID      ulid.ULID                   `json:"return_id"`
+	TransactionID ulid.ULID                   `json:"transaction_id"`
+	UserID        ulid.ULID                   `json:"user_id"`
+	Reason        *string                     `json:"reason"`
+	RefundAmount  decimal.Decimal             `json:"refund_amount"`
+	RefundMethod  string                      `json:"refund_method"`
+	StoreCredit   *GiftCardResponse           `json:"store_credit,omitempty"`
+	CreatedAt     time.Time                   `json:"created_at"`
+	Items         []TransactionReturnItemResp `json:"items"`
+}
+
+type TransactionReturnItemResp struct {
+	DetailID    ulid.ULID       `json:"detail_id"`
 	ProductID   ulid.ULID       `json:"product_id"`
 	ProductName string          `json:"product_name"`
 	Quantity    int             `json:"quantity"`
@@ -0,0 +1,30 @@
+package web
+
+import "github.com/oklog/ulid/v2"
+
+type TransferRequest struct {
+	UserID                ulid.ULID
+	SourceLocationID      ulid.ULID             `validate:"required" json:"source_location_id"`
+	DestinationLocationID ulid.ULID             `validate:"required" json:"destination_location_id"`
+	Note                  string                `validate:"max=255" json:"note"`
+	Items                 []TransferItemRequest `validate:"required,min=1,dive" json:"items"`
+}
+
+type TransferItemRequest struct {
+	ProductID ulid.ULID `validate:"required" json:"product_id"`
+	Quantity  int       `validate:"required,gt=0" json:"quantity"`
+}
+
+type TransferReceiveRequest struct {
+	TransferID     ulid.ULID
+	UserID         ulid.ULID
+	Items          []TransferReceiveItemRequest `validate:"required,min=1,dive" json:"items"`
+	IdempotencyKey string                       `json:"-" validate:"max=64"`
+}
+
+type TransferReceiveItemRequest struct {
+	ProductID           ulid.ULID `validate:"required" json:"product_id"`
+	QuantityReceived    int       `validate:"gte=0" json:"quantity_received"`
+	QuantityDiscrepancy int       `validate:"gte=0" json:"quantity_discrepancy"`
+	DiscrepancyNote     string    `validate:"max=255" json:"discrepancy_note"`
+}
@@ -0,0 +1,29 @@
+package web
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type TransferResponse struct {
+	TransferID            ulid.ULID              `json:"transfer_id"`
+	SourceLocationID      ulid.ULID              `json:"source_location_id"`
+	DestinationLocationID ulid.ULID              `json:"destination_location_id"`
+	Status                string                 `json:"status"`
+	Note                  string                 `json:"note"`
+	CreatedBy             ulid.ULID              `json:"created_by"`
+	CreatedAt             time.Time              `json:"created_at"`
+	DispatchedAt          *time.Time             `json:"dispatched_at"`
+	ReceivedAt            *time.Time             `json:"received_at"`
+	Items                 []TransferItemResponse `json:"items"`
+}
+
+type TransferItemResponse struct {
+	ProductID           ulid.ULID `json:"product_id"`
+	Quantity            int       `json:"quantity"`
+	QuantityReceived    int       `json:"quantity_received"`
+	QuantityDiscrepancy int       `json:"quantity_discrepancy"`
+	InTransit           int       `json:"in_transit"`
+	DiscrepancyNote     string    `json:"discrepancy_note"`
+}
@@ -0,0 +1,22 @@
+package web
+
+import (
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+type ValuationResponse struct {
+	AsOf          string                  `json:"as_of"`
+	CostingMethod string                  `json:"costing_method"`
+	TotalQuantity int                     `json:"total_quantity"`
+	TotalValue    decimal.Decimal         `json:"total_value"`
+	Items         []ValuationItemResponse `json:"items"`
+}
+
+type ValuationItemResponse struct {
+	ProductID   ulid.ULID       `json:"product_id"`
+	ProductName string          `json:"product_name"`
+	Quantity    int             `json:"quantity"`
+	AverageCost decimal.Decimal `json:"average_cost"`
+	Value       decimal.Decimal `json:"value"`
+}
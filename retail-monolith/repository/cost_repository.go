@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/model/domain"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type CostRepository interface {
+	SaveLayer(ctx context.Context, tx *sql.Tx, layer domain.CostLayer) (domain.CostLayer, error)
+	FindOpenLayersForUpdate(ctx context.Context, tx *sql.Tx, productID ulid.ULID) ([]domain.CostLayer, error)
+	UpdateLayerRemaining(ctx context.Context, tx *sql.Tx, layerID ulid.ULID, quantityRemaining int) error
+	SaveEntry(ctx context.Context, tx *sql.Tx, entry domain.CostLedgerEntry) (domain.CostLedgerEntry, error)
+	FindLatestEntryForUpdate(ctx context.Context, tx *sql.Tx, productID ulid.ULID) (domain.CostLedgerEntry, error)
+	SaveDetailCosts(ctx context.Context, tx *sql.Tx, costs []domain.TransactionDetailCost) error
+	FindValuation(ctx context.Context, tx *sql.Tx, asOf time.Time) ([]domain.ProductValuation, error)
+}
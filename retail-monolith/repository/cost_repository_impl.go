@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/model/domain"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type CostRepositoryImpl struct {
+	Logger *logrus.Logger
+}
+
+func NewCostRepository(logger *logrus.Logger) CostRepository {
+	return &CostRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+func (repository *CostRepositoryImpl) SaveLayer(ctx context.Context, tx *sql.Tx, layer domain.CostLayer) (domain.CostLayer, error) {
+	SQL := "INSERT INTO Cost_Layers(layer_id, product_id, unit_cost, quantity_received, quantity_remaining, reference, received_at) VALUES (?, ?, ?, ?, ?, ?, ?)"
+
+	repository.Logger.Info("---executing sql (save cost layer)...")
+	_, err := tx.ExecContext(ctx, SQL, layer.LayerID, layer.ProductID, layer.UnitCost, layer.QuantityReceived, layer.QuantityRemaining, layer.Reference, layer.ReceivedAt)
+	if err != nil {
+		repository.Logger.Errorf("---failed to save cost layer: %v", err)
+		return domain.CostLayer{}, err
+	}
+
+	return layer, nil
+}
+
+func (repository *CostRepositoryImpl) FindOpenLayersForUpdate(ctx context.Context, tx *sql.Tx, productID ulid.ULID) ([]domain.CostLayer, error) {
+	SQL := `
+        SELECT layer_id, product_id, unit_cost, quantity_received, quantity_remaining, reference, received_at
+        FROM Cost_Layers
+        WHERE product_id = ? AND quantity_remaining > 0
+        ORDER BY received_at, layer_id
+        FOR UPDATE
+    `
+
+	repository.Logger.Info("---executing sql (lock open cost layers)...")
+	rows, err := tx.QueryContext(ctx, SQL, productID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get cost layers: %v", err)
+		return []domain.CostLayer{}, err
+	}
+	defer rows.Close()
+
+	layers := make([]domain.CostLayer, 0)
+	for rows.Next() {
+		layer := domain.CostLayer{}
+		err := rows.Scan(
+			&layer.LayerID,
+			&layer.ProductID,
+			&layer.UnitCost,
+			&layer.QuantityReceived,
+			&layer.QuantityRemaining,
+			&layer.Reference,
+			&layer.ReceivedAt,
+		)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.CostLayer{}, err
+		}
+		layers = append(layers, layer)
+	}
+
+	return layers, rows.Err()
+}
+
+func (repository *CostRepositoryImpl) UpdateLayerRemaining(ctx context.Context, tx *sql.Tx, layerID ulid.ULID, quantityRemaining int) error {
+	SQL := "UPDATE Cost_Layers SET quantity_remaining = ? WHERE layer_id = ?"
+
+	repository.Logger.Info("---executing sql (update cost layer)...")
+	_, err := tx.ExecContext(ctx, SQL, quantityRemaining, layerID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to update cost layer: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *CostRepositoryImpl) SaveEntry(ctx context.Context, tx *sql.Tx, entry domain.CostLedgerEntry) (domain.CostLedgerEntry, error) {
+	SQL := `
+        INSERT INTO Cost_Ledger(entry_id, product_id, change_quantity, change_value, balance_quantity, balance_value, reference, created_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+    `
+
+	repository.Logger.Info("---executing sql (save cost ledger entry)...")
+	_, err := tx.ExecContext(ctx, SQL, entry.EntryID, entry.ProductID, entry.ChangeQuantity, entry.ChangeValue, entry.BalanceQuantity, entry.BalanceValue, entry.Reference, entry.CreatedAt)
+	if err != nil {
+		repository.Logger.Errorf("---failed to save cost ledger entry: %v", err)
+		return domain.CostLedgerEntry{}, err
+	}
+
+	return entry, nil
+}
+
+func (repository *CostRepositoryImpl) FindLatestEntryForUpdate(ctx context.Context, tx *sql.Tx, productID ulid.ULID) (domain.CostLedgerEntry, error) {
+	SQL := `
+        SELECT entry_id, product_id, change_quantity, change_value, balance_quantity, balance_value, reference, created_at
+        FROM Cost_Ledger
+        WHERE product_id = ?
+        ORDER BY entry_id DESC
+        LIMIT 1
+        FOR UPDATE
+    `
+
+	entry := domain.CostLedgerEntry{}
+
+	repository.Logger.Info("---executing sql (lock latest cost ledger entry)...")
+	err := tx.QueryRowContext(ctx, SQL, productID).Scan(
+		&entry.EntryID,
+		&entry.ProductID,
+		&entry.ChangeQuantity,
+		&entry.ChangeValue,
+		&entry.BalanceQuantity,
+		&entry.BalanceValue,
+		&entry.Reference,
+		&entry.CreatedAt,
+	)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			repository.Logger.Errorf("---failed to get latest cost ledger entry: %v", err)
+		}
+		return domain.CostLedgerEntry{}, err
+	}
+
+	return entry, nil
+}
+
+func (repository *CostRepositoryImpl) SaveDetailCosts(ctx context.Context, tx *sql.Tx, costs []domain.TransactionDetailCost) error {
+	if len(costs) == 0 {
+		return nil
+	}
+
+	SQL := "INSERT INTO Transaction_Detail_Costs(detail_id, transaction_id, product_id, quantity, unit_cost, cost_amount, costing_method) VALUES "
+
+	var args []interface{}
+	for _, cost := range costs {
+		SQL += "(?, ?, ?, ?, ?, ?, ?),"
+		args = append(args, cost.DetailID, cost.TransactionID, cost.ProductID, cost.Quantity, cost.UnitCost, cost.CostAmount, cost.CostingMethod)
+	}
+
+	SQL = SQL[0 : len(SQL)-1]
+
+	repository.Logger.Info("---executing sql (save transaction detail costs)...")
+	_, err := tx.ExecContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to save transaction detail costs: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *CostRepositoryImpl) FindValuation(ctx context.Context, tx *sql.Tx, asOf time.Time) ([]domain.ProductValuation, error) {
+	SQL := `
+        SELECT l.product_id, p.product_name, l.balance_quantity, l.balance_value
+        FROM Cost_Ledger l
+        JOIN (
+            SELECT product_id, MAX(entry_id) AS entry_id
+            FROM Cost_Ledger
+            WHERE created_at < ?
+            GROUP BY product_id
+        ) latest ON l.entry_id = latest.entry_id
+        JOIN Products p ON l.product_id = p.product_id
+        WHERE l.balance_quantity > 0
+        ORDER BY p.product_name
+    `
+
+	repository.Logger.Info("---executing sql (get inventory valuation)...")
+	rows, err := tx.QueryContext(ctx, SQL, asOf)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get inventory valuation: %v", err)
+		return []domain.ProductValuation{}, err
+	}
+	defer rows.Close()
+
+	valuations := make([]domain.ProductValuation, 0)
+	for rows.Next() {
+		valuation := domain.ProductValuation{}
+		err := rows.Scan(
+			&valuation.ProductID,
+			&valuation.ProductName,
+			&valuation.Quantity,
+			&valuation.Value,
+		)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.ProductValuation{}, err
+		}
+		valuations = append(valuations, valuation)
+	}
+
+	return valuations, rows.Err()
+}
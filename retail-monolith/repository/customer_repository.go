@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/model/domain"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type CustomerRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, customer domain.Customer) (domain.Customer, error)
+	FindAll(ctx context.Context, tx *sql.Tx, search string) ([]domain.Customer, error)
+	FindByID(ctx context.Context, tx *sql.Tx, customerID ulid.ULID) (domain.Customer, error)
+	Update(ctx context.Context, tx *sql.Tx, customer domain.Customer) (domain.Customer, error)
+	Delete(ctx context.Context, tx *sql.Tx, customerID ulid.ULID) error
+}
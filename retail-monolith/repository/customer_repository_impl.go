@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"retail-management/exception"
+	"retail-management/model/domain"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type CustomerRepositoryImpl struct {
+	Logger *logrus.Logger
+}
+
+func NewCustomerRepository(logger *logrus.Logger) CustomerRepository {
+	return &CustomerRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+func (repository *CustomerRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, customer domain.Customer) (domain.Customer, error) {
+	SQL := "INSERT INTO Customers(customer_id, customer_name, phone_number, email, notes, created_at) VALUES (?, ?, ?, ?, ?, ?)"
+
+	repository.Logger.Info("---executing sql (insert new customer)...")
+	_, err := tx.ExecContext(ctx, SQL, customer.CustomerID, customer.CustomerName, customer.PhoneNumber, customer.Email, customer.Notes, customer.CreatedAt)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			repository.Logger.Warn("---phone number or email already belongs to a customer")
+			return domain.Customer{}, exception.ErrCustomerExists
+		}
+		repository.Logger.Errorf("---failed to insert new customer: %v", err)
+		return domain.Customer{}, err
+	}
+
+	repository.Logger.Info("---successfully insert new customer, returning back to service layer...")
+	return customer, nil
+}
+
+func (repository *CustomerRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, search string) ([]domain.Customer, error) {
+	SQL := "SELECT customer_id, customer_name, phone_number, email, notes, created_at FROM Customers"
+
+	var args []interface{}
+	if search != "" {
+		SQL += " WHERE customer_name LIKE ? OR phone_number LIKE ? OR email LIKE ?"
+		pattern := "%" + search + "%"
+		args = append(args, pattern, pattern, pattern)
+	}
+	SQL += " ORDER BY customer_name"
+
+	repository.Logger.Info("---executing sql (get all customers)...")
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get all customers: %v", err)
+		return []domain.Customer{}, err
+	}
+	defer rows.Close()
+
+	customers := make([]domain.Customer, 0)
+
+	repository.Logger.Info("---checking rows.Next()...")
+	for rows.Next() {
+		customer := domain.Customer{}
+		err := rows.Scan(
+			&customer.CustomerID,
+			&customer.CustomerName,
+			&customer.PhoneNumber,
+			&customer.Email,
+			&customer.Notes,
+			&customer.CreatedAt,
+		)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.Customer{}, err
+		}
+		customers = append(customers, customer)
+	}
+
+	repository.Logger.Info("---successfully get all customers, returning back to service layer...")
+	return customers, rows.Err()
+}
+
+func (repository *CustomerRepositoryImpl) FindByID(ctx context.Context, tx *sql.Tx, customerID ulid.ULID) (domain.Customer, error) {
+	SQL := "SELECT customer_id, customer_name, phone_number, email, notes, created_at FROM Customers WHERE customer_id = ?"
+
+	customer := domain.Customer{}
+
+	repository.Logger.Info("---executing sql (get customer by id)...")
+	err := tx.QueryRowContext(ctx, SQL, customerID).Scan(
+		&customer.CustomerID,
+		&customer.CustomerName,
+		&customer.PhoneNumber,
+		&customer.Email,
+		&customer.Notes,
+		&customer.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			repository.Logger.Warnf("---cannot found customer_id: %v", customerID)
+		} else {
+			repository.Logger.Errorf("---failed to get customer: %v", err)
+		}
+		return domain.Customer{}, err
+	}
+
+	return customer, nil
+}
+
+func (repository *CustomerRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, customer domain.Customer) (domain.Customer, error) {
+	SQL := "UPDATE Customers SET customer_name = ?, phone_number = ?, email = ?, notes = ? WHERE customer_id = ?"
+
+	repository.Logger.Info("---executing sql (update a customer)...")
+	_, err := tx.ExecContext(ctx, SQL, customer.CustomerName, customer.PhoneNumber, customer.Email, customer.Notes, customer.CustomerID)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			repository.Logger.Warn("---phone number or email already belongs to a customer")
+			return domain.Customer{}, exception.ErrCustomerExists
+		}
+		repository.Logger.Errorf("---failed to update a customer: %v", err)
+		return domain.Customer{}, err
+	}
+
+	repository.Logger.Info("---successfully update, returning back to service layer...")
+	return customer, nil
+}
+
+func (repository *CustomerRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, customerID ulid.ULID) error {
+	SQL := "DELETE FROM Customers WHERE customer_id = ?"
+
+	repository.Logger.Info("---executing sql (delete a customer)...")
+	result, err := tx.ExecContext(ctx, SQL, customerID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to delete a customer: %v", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		repository.Logger.Errorf("---failed to check rows affected: %v", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		repository.Logger.Warnf("---failed to delete, customer not found: %v", customerID)
+		return exception.ErrNotFound
+	}
+
+	return nil
+}
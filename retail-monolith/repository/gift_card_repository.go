@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/model/domain"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+)
+
+type GiftCardRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, giftCard domain.GiftCard) (domain.GiftCard, error)
+	FindByCodeForUpdate(ctx context.Context, tx *sql.Tx, cardCode string) (domain.GiftCard, error)
+	FindByIDForUpdate(ctx context.Context, tx *sql.Tx, giftCardID ulid.ULID) (domain.GiftCard, error)
+	SaveEntry(ctx context.Context, tx *sql.Tx, entry domain.GiftCardEntry) (domain.GiftCardEntry, error)
+	FindLatestEntry(ctx context.Context, tx *sql.Tx, giftCardID ulid.ULID) (domain.GiftCardEntry, error)
+	FindEntries(ctx context.Context, tx *sql.Tx, giftCardID ulid.ULID) ([]domain.GiftCardEntry, error)
+	FindTransactionAmounts(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID) (map[ulid.ULID]decimal.Decimal, error)
+}
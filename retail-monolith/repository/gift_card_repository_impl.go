@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"retail-management/exception"
+	"retail-management/model/domain"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+type GiftCardRepositoryImpl struct {
+	Logger *logrus.Logger
+}
+
+func NewGiftCardRepository(logger *logrus.Logger) GiftCardRepository {
+	return &GiftCardRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+func (repository *GiftCardRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, giftCard domain.GiftCard) (domain.GiftCard, error) {
+	SQL := "INSERT INTO Gift_Cards(gift_card_id, card_code, card_type, customer_id, issued_by, expires_at, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)"
+
+	repository.Logger.Info("---executing sql (save gift card)...")
+	_, err := tx.ExecContext(ctx, SQL,
+		giftCard.GiftCardID,
+		giftCard.CardCode,
+		giftCard.CardType,
+		giftCard.CustomerID,
+		giftCard.IssuedBy,
+		giftCard.ExpiresAt,
+		giftCard.CreatedAt,
+	)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			repository.Logger.Warn("---gift card code already exists")
+			return domain.GiftCard{}, exception.ErrGiftCardExists
+		}
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1452 {
+			repository.Logger.Warn("---gift card customer does not exist")
+			return domain.GiftCard{}, exception.ErrNotFound
+		}
+		repository.Logger.Errorf("---failed to save gift card: %v", err)
+		return domain.GiftCard{}, err
+	}
+
+	repository.Logger.Info("---success, returning back to service layer")
+	return giftCard, nil
+}
+
+const giftCardColumns = `
+        SELECT gift_card_id, card_code, card_type, customer_id, issued_by, expires_at, created_at
+        FROM Gift_Cards`
+
+func (repository *GiftCardRepositoryImpl) FindByCodeForUpdate(ctx context.Context, tx *sql.Tx, cardCode string) (domain.GiftCard, error) {
+	SQL := giftCardColumns + " WHERE card_code = ? FOR UPDATE"
+
+	repository.Logger.Info("---executing sql (lock gift card by code)...")
+	return repository.scanGiftCard(tx.QueryRowContext(ctx, SQL, cardCode).Scan)
+}
+
+func (repository *GiftCardRepositoryImpl) FindByIDForUpdate(ctx context.Context, tx *sql.Tx, giftCardID ulid.ULID) (domain.GiftCard, error) {
+	SQL := giftCardColumns + " WHERE gift_card_id = ? FOR UPDATE"
+
+	repository.Logger.Info("---executing sql (lock gift card)...")
+	return repository.scanGiftCard(tx.QueryRowContext(ctx, SQL, giftCardID).Scan)
+}
+
+func (repository *GiftCardRepositoryImpl) scanGiftCard(scan func(dest ...any) error) (domain.GiftCard, error) {
+	giftCard := domain.GiftCard{}
+	err := scan(
+		&giftCard.GiftCardID,
+		&giftCard.CardCode,
+		&giftCard.CardType,
+		&giftCard.CustomerID,
+		&giftCard.IssuedBy,
+		&giftCard.ExpiresAt,
+		&giftCard.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			repository.Logger.Warn("---cannot found gift card")
+		} else {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+		}
+		return domain.GiftCard{}, err
+	}
+
+	return giftCard, nil
+}
+
+func (repository *GiftCardRepositoryImpl) SaveEntry(ctx context.Context, tx *sql.Tx, entry domain.GiftCardEntry) (domain.GiftCardEntry, error) {
+	SQL := `
+        INSERT INTO Gift_Card_Ledger(entry_id, gift_card_id, entry_type, change_amount, balance_amount, transaction_id, return_id, created_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+    `
+
+	repository.Logger.Info("---executing sql (save gift card ledger entry)...")
+	_, err := tx.ExecContext(ctx, SQL,
+		entry.EntryID,
+		entry.GiftCardID,
+		entry.EntryType,
+		entry.ChangeAmount,
+		entry.BalanceAmount,
+		entry.TransactionID,
+		entry.ReturnID,
+		entry.CreatedAt,
+	)
+	if err != nil {
+		repository.Logger.Errorf("---failed to save gift card ledger entry: %v", err)
+		return domain.GiftCardEntry{}, err
+	}
+
+	return entry, nil
+}
+
+const giftCardEntryColumns = `
+        SELECT entry_id, gift_card_id, entry_type, change_amount, balance_amount, transaction_id, return_id, created_at
+        FROM Gift_Card_Ledger`
+
+func (repository *GiftCardRepositoryImpl) FindLatestEntry(ctx context.Context, tx *sql.Tx, giftCardID ulid.ULID) (domain.GiftCardEntry, error) {
+	SQL := giftCardEntryColumns + " WHERE gift_card_id = ? ORDER BY entry_id DESC LIMIT 1"
+
+	repository.Logger.Info("---executing sql (get latest gift card ledger entry)...")
+	entry, err := repository.scanEntry(tx.QueryRowContext(ctx, SQL, giftCardID).Scan)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			repository.Logger.Errorf("---failed to get latest gift card ledger entry: %v", err)
+		}
+		return domain.GiftCardEntry{}, err
+	}
+
+	return entry, nil
+}
+
+func (repository *GiftCardRepositoryImpl) FindEntries(ctx context.Context, tx *sql.Tx, giftCardID ulid.ULID) ([]domain.GiftCardEntry, error) {
+	SQL := giftCardEntryColumns + " WHERE gift_card_id = ? ORDER BY entry_id DESC"
+
+	repository.Logger.Info("---executing sql (get gift card ledger)...")
+	rows, err := tx.QueryContext(ctx, SQL, giftCardID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get gift card ledger: %v", err)
+		return []domain.GiftCardEntry{}, err
+	}
+	defer rows.Close()
+
+	entries := make([]domain.GiftCardEntry, 0)
+	for rows.Next() {
+		entry, err := repository.scanEntry(rows.Scan)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.GiftCardEntry{}, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (repository *GiftCardRepositoryImpl) FindTransactionAmounts(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID) (map[ulid.ULID]decimal.Decimal, error) {
+	SQL := `
+        SELECT gift_card_id, SUM(change_amount)
+        FROM Gift_Card_Ledger
+        WHERE transaction_id = ? AND entry_type IN (?, ?)
+        GROUP BY gift_card_id
+    `
+
+	repository.Logger.Info("---executing sql (get gift card amounts of transaction)...")
+	rows, err := tx.QueryContext(ctx, SQL, transactionID, domain.GiftCardEntryRedeem, domain.GiftCardEntryRefund)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get gift card amounts of transaction: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	amounts := make(map[ulid.ULID]decimal.Decimal)
+	for rows.Next() {
+		var giftCardID ulid.ULID
+		var amount decimal.Decimal
+		err := rows.Scan(&giftCardID, &amount)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return nil, err
+		}
+		amounts[giftCardID] = amount
+	}
+
+	return amounts, rows.Err()
+}
+
+func (repository *GiftCardRepositoryImpl) scanEntry(scan func(dest ...any) error) (domain.GiftCardEntry, error) {
+	entry := domain.GiftCardEntry{}
+	err := scan(
+		&entry.EntryID,
+		&entry.GiftCardID,
+		&entry.EntryType,
+		&entry.ChangeAmount,
+		&entry.BalanceAmount,
+		&entry.TransactionID,
+		&entry.ReturnID,
+		&entry.CreatedAt,
+	)
+	return entry, err
+}
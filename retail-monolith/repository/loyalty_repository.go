@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/model/domain"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type LoyaltyRepository interface {
+	LockCustomer(ctx context.Context, tx *sql.Tx, customerID ulid.ULID) error
+	SaveEntry(ctx context.Context, tx *sql.Tx, entry domain.LoyaltyEntry) (domain.LoyaltyEntry, error)
+	FindLatestEntry(ctx context.Context, tx *sql.Tx, customerID ulid.ULID) (domain.LoyaltyEntry, error)
+	FindEntries(ctx context.Context, tx *sql.Tx, customerID ulid.ULID) ([]domain.LoyaltyEntry, error)
+	FindExpiredPoints(ctx context.Context, tx *sql.Tx, customerID ulid.ULID, at time.Time) (int, error)
+	FindTransactionPoints(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID) (map[string]int, error)
+}
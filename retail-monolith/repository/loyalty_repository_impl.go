@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/model/domain"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type LoyaltyRepositoryImpl struct {
+	Logger *logrus.Logger
+}
+
+func NewLoyaltyRepository(logger *logrus.Logger) LoyaltyRepository {
+	return &LoyaltyRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+func (repository *LoyaltyRepositoryImpl) LockCustomer(ctx context.Context, tx *sql.Tx, customerID ulid.ULID) error {
+	SQL := "SELECT customer_id FROM Customers WHERE customer_id = ? FOR UPDATE"
+
+	var lockedID ulid.ULID
+
+	repository.Logger.Info("---executing sql (lock customer)...")
+	err := tx.QueryRowContext(ctx, SQL, customerID).Scan(&lockedID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			repository.Logger.Warnf("---cannot found customer_id: %v", customerID)
+		} else {
+			repository.Logger.Errorf("---failed to lock customer: %v", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (repository *LoyaltyRepositoryImpl) SaveEntry(ctx context.Context, tx *sql.Tx, entry domain.LoyaltyEntry) (domain.LoyaltyEntry, error) {
+	SQL := `
+        INSERT INTO Loyalty_Ledger(entry_id, customer_id, entry_type, change_points, balance_points, transaction_id, return_id, expires_at, created_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+    `
+
+	repository.Logger.Info("---executing sql (save loyalty ledger entry)...")
+	_, err := tx.ExecContext(ctx, SQL,
+		entry.EntryID,
+		entry.CustomerID,
+		entry.EntryType,
+		entry.ChangePoints,
+		entry.BalancePoints,
+		entry.TransactionID,
+		entry.ReturnID,
+		entry.ExpiresAt,
+		entry.CreatedAt,
+	)
+	if err != nil {
+		repository.Logger.Errorf("---failed to save loyalty ledger entry: %v", err)
+		return domain.LoyaltyEntry{}, err
+	}
+
+	return entry, nil
+}
+
+const loyaltyColumns = `
+        SELECT entry_id, customer_id, entry_type, change_points, balance_points, transaction_id, return_id, expires_at, created_at
+        FROM Loyalty_Ledger`
+
+func (repository *LoyaltyRepositoryImpl) FindLatestEntry(ctx context.Context, tx *sql.Tx, customerID ulid.ULID) (domain.LoyaltyEntry, error) {
+	SQL := loyaltyColumns + " WHERE customer_id = ? ORDER BY entry_id DESC LIMIT 1"
+
+	repository.Logger.Info("---executing sql (get latest loyalty ledger entry)...")
+	entry, err := repository.scanEntry(tx.QueryRowContext(ctx, SQL, customerID).Scan)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			repository.Logger.Errorf("---failed to get latest loyalty ledger entry: %v", err)
+		}
+		return domain.LoyaltyEntry{}, err
+	}
+
+	return entry, nil
+}
+
+func (repository *LoyaltyRepositoryImpl) FindEntries(ctx context.Context, tx *sql.Tx, customerID ulid.ULID) ([]domain.LoyaltyEntry, error) {
+	SQL := loyaltyColumns + " WHERE customer_id = ? ORDER BY entry_id DESC"
+
+	repository.Logger.Info("---executing sql (get loyalty ledger of customer)...")
+	rows, err := tx.QueryContext(ctx, SQL, customerID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get loyalty ledger: %v", err)
+		return []domain.LoyaltyEntry{}, err
+	}
+	defer rows.Close()
+
+	entries := make([]domain.LoyaltyEntry, 0)
+	for rows.Next() {
+		entry, err := repository.scanEntry(rows.Scan)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.LoyaltyEntry{}, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (repository *LoyaltyRepositoryImpl) FindExpiredPoints(ctx context.Context, tx *sql.Tx, customerID ulid.ULID, at time.Time) (int, error) {
+	SQL := `
+        SELECT
+            COALESCE(SUM(CASE WHEN change_points > 0 AND expires_at <= ? THEN change_points ELSE 0 END), 0) -
+            COALESCE(SUM(CASE WHEN change_points < 0 THEN -change_points ELSE 0 END), 0)
+        FROM Loyalty_Ledger
+        WHERE customer_id = ?
+    `
+
+	var points int
+
+	repository.Logger.Info("---executing sql (get expired loyalty points)...")
+	err := tx.QueryRowContext(ctx, SQL, at, customerID).Scan(&points)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get expired loyalty points: %v", err)
+		return 0, err
+	}
+
+	return max(points, 0), nil
+}
+
+func (repository *LoyaltyRepositoryImpl) FindTransactionPoints(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID) (map[string]int, error) {
+	SQL := "SELECT entry_type, SUM(change_points) FROM Loyalty_Ledger WHERE transaction_id = ? GROUP BY entry_type"
+
+	repository.Logger.Info("---executing sql (get loyalty points of transaction)...")
+	rows, err := tx.QueryContext(ctx, SQL, transactionID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get loyalty points of transaction: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	points := make(map[string]int)
+	for rows.Next() {
+		var entryType string
+		var changePoints int
+		err := rows.Scan(&entryType, &changePoints)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return nil, err
+		}
+		points[entryType] = changePoints
+	}
+
+	return points, rows.Err()
+}
+
+func (repository *LoyaltyRepositoryImpl) scanEntry(scan func(dest ...any) error) (domain.LoyaltyEntry, error) {
+	entry := domain.LoyaltyEntry{}
+	err := scan(
+		&entry.EntryID,
+		&entry.CustomerID,
+		&entry.EntryType,
+		&entry.ChangePoints,
+		&entry.BalancePoints,
+		&entry.TransactionID,
+		&entry.ReturnID,
+		&entry.ExpiresAt,
+		&entry.CreatedAt,
+	)
+	return entry, err
+}
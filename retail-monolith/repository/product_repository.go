@@ -6,13 +6,26 @@ import (
 	"retail-management/model/domain"
 
 	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
 )
 
 type ProductRepository interface {
 	Save(ctx context.Context, tx *sql.Tx, product domain.Product) (domain.Product, error)
 	FindAll(ctx context.Context, tx *sql.Tx) ([]domain.Product, error)
+	FindByCategoryID(ctx context.Context, tx *sql.Tx, categoryID ulid.ULID) ([]domain.Product, error)
 	FindByID(ctx context.Context, tx *sql.Tx, productID ulid.ULID) (domain.Product, error)
+	FindByParentID(ctx context.Context, tx *sql.Tx, parentProductID ulid.ULID) ([]domain.Product, error)
+	CountVariants(ctx context.Context, tx *sql.Tx, productID ulid.ULID) (int, error)
+	FindAttributes(ctx context.Context, tx *sql.Tx, productIDs []ulid.ULID) (map[ulid.ULID][]domain.ProductAttribute, error)
+	SaveAttributes(ctx context.Context, tx *sql.Tx, productID ulid.ULID, attributes []domain.ProductAttribute) error
+	FindComponents(ctx context.Context, tx *sql.Tx, bundleProductIDs []ulid.ULID) (map[ulid.ULID][]domain.ProductComponent, error)
+	SaveComponents(ctx context.Context, tx *sql.Tx, bundleProductID ulid.ULID, components []domain.ProductComponent) error
+	FindIDByBarcode(ctx context.Context, tx *sql.Tx, barcode string) (ulid.ULID, error)
+	FindIDBySKU(ctx context.Context, tx *sql.Tx, sku string) (ulid.ULID, error)
+	FindBarcodes(ctx context.Context, tx *sql.Tx, productIDs []ulid.ULID) (map[ulid.ULID][]domain.ProductBarcode, error)
+	SaveBarcodes(ctx context.Context, tx *sql.Tx, productID ulid.ULID, barcodes []domain.ProductBarcode) error
 	Update(ctx context.Context, tx *sql.Tx, product domain.ProductUpdate) (domain.ProductUpdate, error)
 	UpdateStock(ctx context.Context, tx *sql.Tx, productID ulid.ULID, changeQuantity int) (domain.ProductUpdate, error)
+	UpdatePurchasePrice(ctx context.Context, tx *sql.Tx, productID ulid.ULID, purchasePrice decimal.Decimal) error
 	Delete(ctx context.Context, tx *sql.Tx, productID ulid.ULID) error
 }
@@ -4,9 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"retail-management/exception"
 	"retail-management/model/domain"
+	"strings"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
 	"github.com/sirupsen/logrus"
 )
 
@@ -21,19 +25,26 @@ func NewProductRepository(logger *logrus.Logger) ProductRepository {
 }
 
 func (repository *ProductRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, product domain.Product) (domain.Product, error) {
-	SQL := "INSERT INTO Products(product_id, product_name, purchase_price, selling_price, stock_quantity, category_id, supplier_id) VALUES (?, ?, ?, ?, ?, ?, ?)"
+	SQL := "INSERT INTO Products(product_id, product_name, sku, purchase_price, selling_price, stock_quantity, category_id, supplier_id, parent_product_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"
 
 	repository.Logger.Info("---executing sql (insert new product)...")
 	_, err := tx.ExecContext(
 		ctx, SQL, product.ProductID,
 		product.ProductName,
+		product.SKU,
 		product.PurchasePrice,
 		product.SellingPrice,
 		product.StockQuantity,
 		product.CategoryID,
 		product.SupplierID,
+		product.ParentProductID,
 	)
 	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			repository.Logger.Warnf("---sku %v is already used by another product", *product.SKU)
+			return domain.Product{}, exception.ErrProductCodeExists
+		}
 		repository.Logger.Errorf("---failed to insert new product: %v", err)
 		return domain.Product{}, err
 	}
@@ -43,7 +54,7 @@ func (repository *ProductRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, p
 }
 
 func (repository *ProductRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) ([]domain.Product, error) {
-	SQL := "SELECT product_id, product_name, purchase_price, selling_price, stock_quantity, category_id, supplier_id FROM Products"
+	SQL := "SELECT product_id, product_name, sku, purchase_price, selling_price, stock_quantity, category_id, supplier_id, parent_product_id FROM Products"
 
 	repository.Logger.Info("---executing sql (get all products)...")
 	rows, err := tx.QueryContext(ctx, SQL)
@@ -61,11 +72,13 @@ func (repository *ProductRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx
 		err := rows.Scan(
 			&product.ProductID,
 			&product.ProductName,
+			&product.SKU,
 			&product.PurchasePrice,
 			&product.SellingPrice,
 			&product.StockQuantity,
 			&product.CategoryID,
 			&product.SupplierID,
+			&product.ParentProductID,
 		)
 		if err != nil {
 			repository.Logger.Errorf("---failed to scan row: %v", err)
@@ -78,8 +91,43 @@ func (repository *ProductRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx
 	return products, nil
 }
 
+func (repository *ProductRepositoryImpl) FindByCategoryID(ctx context.Context, tx *sql.Tx, categoryID ulid.ULID) ([]domain.Product, error) {
+	SQL := "SELECT product_id, product_name, sku, purchase_price, selling_price, stock_quantity, category_id, supplier_id, parent_product_id FROM Products WHERE category_id = ? ORDER BY product_name"
+
+	repository.Logger.Info("---executing sql (get products of category)...")
+	rows, err := tx.QueryContext(ctx, SQL, categoryID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get products of category: %v", err)
+		return []domain.Product{}, err
+	}
+	defer rows.Close()
+
+	products := make([]domain.Product, 0)
+	for rows.Next() {
+		product := domain.Product{}
+		err := rows.Scan(
+			&product.ProductID,
+			&product.ProductName,
+			&product.SKU,
+			&product.PurchasePrice,
+			&product.SellingPrice,
+			&product.StockQuantity,
+			&product.CategoryID,
+			&product.SupplierID,
+			&product.ParentProductID,
+		)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.Product{}, err
+		}
+		products = append(products, product)
+	}
+
+	return products, rows.Err()
+}
+
 func (repository *ProductRepositoryImpl) FindByID(ctx context.Context, tx *sql.Tx, ProductID ulid.ULID) (domain.Product, error) {
-	SQL := "SELECT product_id, product_name, purchase_price, selling_price, stock_quantity, category_id, supplier_id FROM Products WHERE product_id = ?"
+	SQL := "SELECT product_id, product_name, sku, purchase_price, selling_price, stock_quantity, category_id, supplier_id, parent_product_id FROM Products WHERE product_id = ?"
 
 	var product domain.Product
 
@@ -87,11 +135,13 @@ func (repository *ProductRepositoryImpl) FindByID(ctx context.Context, tx *sql.T
 	err := tx.QueryRowContext(ctx, SQL, ProductID).Scan(
 		&product.ProductID,
 		&product.ProductName,
+		&product.SKU,
 		&product.PurchasePrice,
 		&product.SellingPrice,
 		&product.StockQuantity,
 		&product.CategoryID,
 		&product.SupplierID,
+		&product.ParentProductID,
 	)
 	if err != nil {
 		if err != sql.ErrNoRows {
@@ -105,26 +155,294 @@ func (repository *ProductRepositoryImpl) FindByID(ctx context.Context, tx *sql.T
 	return product, nil
 }
 
+func (repository *ProductRepositoryImpl) FindByParentID(ctx context.Context, tx *sql.Tx, parentProductID ulid.ULID) ([]domain.Product, error) {
+	SQL := "SELECT product_id, product_name, sku, purchase_price, selling_price, stock_quantity, category_id, supplier_id, parent_product_id FROM Products WHERE parent_product_id = ? ORDER BY product_name"
+
+	repository.Logger.Info("---executing sql (get variants of product)...")
+	rows, err := tx.QueryContext(ctx, SQL, parentProductID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get variants of product: %v", err)
+		return []domain.Product{}, err
+	}
+	defer rows.Close()
+
+	products := make([]domain.Product, 0)
+	for rows.Next() {
+		product := domain.Product{}
+		err := rows.Scan(
+			&product.ProductID,
+			&product.ProductName,
+			&product.SKU,
+			&product.PurchasePrice,
+			&product.SellingPrice,
+			&product.StockQuantity,
+			&product.CategoryID,
+			&product.SupplierID,
+			&product.ParentProductID,
+		)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.Product{}, err
+		}
+		products = append(products, product)
+	}
+
+	return products, rows.Err()
+}
+
+func (repository *ProductRepositoryImpl) CountVariants(ctx context.Context, tx *sql.Tx, productID ulid.ULID) (int, error) {
+	SQL := "SELECT COUNT(*) FROM Products WHERE parent_product_id = ?"
+
+	var count int
+
+	repository.Logger.Info("---executing sql (count variants of product)...")
+	err := tx.QueryRowContext(ctx, SQL, productID).Scan(&count)
+	if err != nil {
+		repository.Logger.Errorf("---failed to count variants of product: %v", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (repository *ProductRepositoryImpl) FindAttributes(ctx context.Context, tx *sql.Tx, productIDs []ulid.ULID) (map[ulid.ULID][]domain.ProductAttribute, error) {
+	attributes := make(map[ulid.ULID][]domain.ProductAttribute)
+	if len(productIDs) == 0 {
+		return attributes, nil
+	}
+
+	SQL := "SELECT product_id, attribute_name, attribute_value FROM Product_Variant_Attributes WHERE product_id IN (?" + strings.Repeat(", ?", len(productIDs)-1) + ") ORDER BY attribute_name"
+	args := make([]interface{}, 0, len(productIDs))
+	for _, productID := range productIDs {
+		args = append(args, productID)
+	}
+
+	repository.Logger.Info("---executing sql (get variant attributes)...")
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get variant attributes: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		attribute := domain.ProductAttribute{}
+		err := rows.Scan(&attribute.ProductID, &attribute.AttributeName, &attribute.AttributeValue)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return nil, err
+		}
+		attributes[attribute.ProductID] = append(attributes[attribute.ProductID], attribute)
+	}
+
+	return attributes, rows.Err()
+}
+
+func (repository *ProductRepositoryImpl) SaveAttributes(ctx context.Context, tx *sql.Tx, productID ulid.ULID, attributes []domain.ProductAttribute) error {
+	repository.Logger.Info("---executing sql (delete variant attributes)...")
+	_, err := tx.ExecContext(ctx, "DELETE FROM Product_Variant_Attributes WHERE product_id = ?", productID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to delete variant attributes: %v", err)
+		return err
+	}
+
+	SQL := "INSERT INTO Product_Variant_Attributes(product_id, attribute_name, attribute_value) VALUES (?, ?, ?)"
+	for _, attribute := range attributes {
+		repository.Logger.Infof("---executing sql (save attribute %s)...", attribute.AttributeName)
+		_, err := tx.ExecContext(ctx, SQL, productID, attribute.AttributeName, attribute.AttributeValue)
+		if err != nil {
+			repository.Logger.Errorf("---failed to save attribute: %v", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (repository *ProductRepositoryImpl) FindComponents(ctx context.Context, tx *sql.Tx, bundleProductIDs []ulid.ULID) (map[ulid.ULID][]domain.ProductComponent, error) {
+	components := make(map[ulid.ULID][]domain.ProductComponent)
+	if len(bundleProductIDs) == 0 {
+		return components, nil
+	}
+
+	SQL := `
+        SELECT c.bundle_product_id, c.component_product_id, p.product_name, c.quantity
+        FROM Product_Bundle_Components c
+        JOIN Products p ON c.component_product_id = p.product_id
+        WHERE c.bundle_product_id IN (?` + strings.Repeat(", ?", len(bundleProductIDs)-1) + `)
+        ORDER BY p.product_name
+    `
+	args := make([]interface{}, 0, len(bundleProductIDs))
+	for _, bundleProductID := range bundleProductIDs {
+		args = append(args, bundleProductID)
+	}
+
+	repository.Logger.Info("---executing sql (get bundle components)...")
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get bundle components: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		component := domain.ProductComponent{}
+		err := rows.Scan(&component.BundleProductID, &component.ProductID, &component.ProductName, &component.Quantity)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return nil, err
+		}
+		components[component.BundleProductID] = append(components[component.BundleProductID], component)
+	}
+
+	return components, rows.Err()
+}
+
+func (repository *ProductRepositoryImpl) SaveComponents(ctx context.Context, tx *sql.Tx, bundleProductID ulid.ULID, components []domain.ProductComponent) error {
+	repository.Logger.Info("---executing sql (delete bundle components)...")
+	_, err := tx.ExecContext(ctx, "DELETE FROM Product_Bundle_Components WHERE bundle_product_id = ?", bundleProductID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to delete bundle components: %v", err)
+		return err
+	}
+
+	SQL := "INSERT INTO Product_Bundle_Components(bundle_product_id, component_product_id, quantity) VALUES (?, ?, ?)"
+	for _, component := range components {
+		repository.Logger.Infof("---executing sql (save component %s)...", component.ProductID)
+		_, err := tx.ExecContext(ctx, SQL, bundleProductID, component.ProductID, component.Quantity)
+		if err != nil {
+			repository.Logger.Errorf("---failed to save component: %v", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (repository *ProductRepositoryImpl) FindIDByBarcode(ctx context.Context, tx *sql.Tx, barcode string) (ulid.ULID, error) {
+	SQL := "SELECT product_id FROM Product_Barcodes WHERE barcode = ?"
+
+	var productID ulid.ULID
+
+	repository.Logger.Info("---executing sql (select by barcode)...")
+	err := tx.QueryRowContext(ctx, SQL, barcode).Scan(&productID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			repository.Logger.Warnf("---cannot found barcode %s", barcode)
+		} else {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+		}
+		return ulid.ULID{}, err
+	}
+
+	return productID, nil
+}
+
+func (repository *ProductRepositoryImpl) FindIDBySKU(ctx context.Context, tx *sql.Tx, sku string) (ulid.ULID, error) {
+	SQL := "SELECT product_id FROM Products WHERE sku = ?"
+
+	var productID ulid.ULID
+
+	repository.Logger.Info("---executing sql (select by sku)...")
+	err := tx.QueryRowContext(ctx, SQL, sku).Scan(&productID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			repository.Logger.Warnf("---cannot found sku %s", sku)
+		} else {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+		}
+		return ulid.ULID{}, err
+	}
+
+	return productID, nil
+}
+
+func (repository *ProductRepositoryImpl) FindBarcodes(ctx context.Context, tx *sql.Tx, productIDs []ulid.ULID) (map[ulid.ULID][]domain.ProductBarcode, error) {
+	barcodes := make(map[ulid.ULID][]domain.ProductBarcode)
+	if len(productIDs) == 0 {
+		return barcodes, nil
+	}
+
+	SQL := "SELECT barcode, product_id, barcode_type FROM Product_Barcodes WHERE product_id IN (?" + strings.Repeat(", ?", len(productIDs)-1) + ") ORDER BY barcode"
+	args := make([]interface{}, 0, len(productIDs))
+	for _, productID := range productIDs {
+		args = append(args, productID)
+	}
+
+	repository.Logger.Info("---executing sql (get product barcodes)...")
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get product barcodes: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		barcode := domain.ProductBarcode{}
+		err := rows.Scan(&barcode.Barcode, &barcode.ProductID, &barcode.BarcodeType)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return nil, err
+		}
+		barcodes[barcode.ProductID] = append(barcodes[barcode.ProductID], barcode)
+	}
+
+	return barcodes, rows.Err()
+}
+
+func (repository *ProductRepositoryImpl) SaveBarcodes(ctx context.Context, tx *sql.Tx, productID ulid.ULID, barcodes []domain.ProductBarcode) error {
+	repository.Logger.Info("---executing sql (delete product barcodes)...")
+	_, err := tx.ExecContext(ctx, "DELETE FROM Product_Barcodes WHERE product_id = ?", productID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to delete product barcodes: %v", err)
+		return err
+	}
+
+	SQL := "INSERT INTO Product_Barcodes(barcode, product_id, barcode_type) VALUES (?, ?, ?)"
+	for _, barcode := range barcodes {
+		repository.Logger.Infof("---executing sql (save barcode %s)...", barcode.Barcode)
+		_, err := tx.ExecContext(ctx, SQL, barcode.Barcode, productID, barcode.BarcodeType)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+				repository.Logger.Warnf("---barcode %s is already used by another product", barcode.Barcode)
+				return exception.ErrProductCodeExists
+			}
+			repository.Logger.Errorf("---failed to save barcode: %v", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (repository *ProductRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, product domain.ProductUpdate) (domain.ProductUpdate, error) {
-	SQL := "UPDATE Products SET product_name = ?, purchase_price = ?, selling_price = ? WHERE product_id = ?"
+	SQL := "UPDATE Products SET product_name = ?, sku = ?, purchase_price = ?, selling_price = ? WHERE product_id = ?"
 
 	repository.Logger.Info("---executing sql (update a product)...")
-	_, err := tx.ExecContext(ctx, SQL, product.ProductName, product.PurchasePrice, product.SellingPrice, product.ProductID)
+	_, err := tx.ExecContext(ctx, SQL, product.ProductName, product.SKU, product.PurchasePrice, product.SellingPrice, product.ProductID)
 	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			repository.Logger.Warnf("---sku %v is already used by another product", *product.SKU)
+			return domain.ProductUpdate{}, exception.ErrProductCodeExists
+		}
 		repository.Logger.Errorf("---failed to update a product: %v", err)
 		return domain.ProductUpdate{}, err
 	}
 
 	repository.Logger.Info("---get the updated product...")
-	SQLSelect := "SELECT product_id, product_name, purchase_price, selling_price, stock_quantity, category_id, supplier_id FROM Products WHERE product_id = ?"
+	SQLSelect := "SELECT product_id, product_name, sku, purchase_price, selling_price, stock_quantity, category_id, supplier_id, parent_product_id FROM Products WHERE product_id = ?"
 	err = tx.QueryRowContext(ctx, SQLSelect, product.ProductID).Scan(
 		&product.ProductID,
 		&product.ProductName,
+		&product.SKU,
 		&product.PurchasePrice,
 		&product.SellingPrice,
 		&product.StockQuantity,
 		&product.CategoryID,
 		&product.SupplierID,
+		&product.ParentProductID,
 	)
 	if err != nil {
 		repository.Logger.Errorf("---failed to get the updated product: %v", err)
@@ -148,15 +466,17 @@ func (repository *ProductRepositoryImpl) UpdateStock(ctx context.Context, tx *sq
 	repository.Logger.Info("---get the updated product...")
 
 	product := domain.ProductUpdate{}
-	SQLSelect := "SELECT product_id, product_name, purchase_price, selling_price, stock_quantity, category_id, supplier_id FROM Products WHERE product_id = ?"
+	SQLSelect := "SELECT product_id, product_name, sku, purchase_price, selling_price, stock_quantity, category_id, supplier_id, parent_product_id FROM Products WHERE product_id = ?"
 	err = tx.QueryRowContext(ctx, SQLSelect, productID).Scan(
 		&product.ProductID,
 		&product.ProductName,
+		&product.SKU,
 		&product.PurchasePrice,
 		&product.SellingPrice,
 		&product.StockQuantity,
 		&product.CategoryID,
 		&product.SupplierID,
+		&product.ParentProductID,
 	)
 	if err != nil {
 		repository.Logger.Errorf("---failed to get the updated product: %v", err)
@@ -167,6 +487,19 @@ func (repository *ProductRepositoryImpl) UpdateStock(ctx context.Context, tx *sq
 	return product, nil
 }
 
+func (repository *ProductRepositoryImpl) UpdatePurchasePrice(ctx context.Context, tx *sql.Tx, productID ulid.ULID, purchasePrice decimal.Decimal) error {
+	SQL := "UPDATE Products SET purchase_price = ? WHERE product_id = ?"
+
+	repository.Logger.Info("---executing sql (update purchase price)...")
+	_, err := tx.ExecContext(ctx, SQL, purchasePrice, productID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to update purchase price: %v", err)
+		return err
+	}
+
+	return nil
+}
+
 func (repository *ProductRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, productID ulid.ULID) error {
 	SQL := "DELETE FROM Products WHERE product_id = ?"
 	repository.Logger.Info("---executing sql (delete a product)...")
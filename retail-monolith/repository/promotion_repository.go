@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/model/domain"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type PromotionRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, promotion domain.Promotion) (domain.Promotion, error)
+	Update(ctx context.Context, tx *sql.Tx, promotion domain.Promotion) (domain.Promotion, error)
+	Delete(ctx context.Context, tx *sql.Tx, promotionID ulid.ULID) error
+	FindAll(ctx context.Context, tx *sql.Tx, activeOnly bool) ([]domain.Promotion, error)
+	FindByID(ctx context.Context, tx *sql.Tx, promotionID ulid.ULID) (domain.Promotion, error)
+	FindApplicable(ctx context.Context, tx *sql.Tx, at time.Time, couponCode *string) ([]domain.Promotion, error)
+	IncrementUsage(ctx context.Context, tx *sql.Tx, promotionID ulid.ULID) error
+	ReleaseUsage(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID) error
+}
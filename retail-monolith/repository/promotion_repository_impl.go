@@ -0,0 +1,284 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"retail-management/exception"
+	"retail-management/model/domain"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type PromotionRepositoryImpl struct {
+	Logger *logrus.Logger
+}
+
+func NewPromotionRepository(logger *logrus.Logger) PromotionRepository {
+	return &PromotionRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+const promotionColumns = `
+        SELECT promotion_id, promotion_name, promotion_type, product_id, category_id, value, buy_quantity, get_quantity,
+            min_spend, coupon_code, starts_at, ends_at, usage_limit, usage_count, active, created_at
+        FROM Promotions`
+
+func (repository *PromotionRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, promotion domain.Promotion) (domain.Promotion, error) {
+	SQL := `
+        INSERT INTO Promotions(promotion_id, promotion_name, promotion_type, product_id, category_id, value, buy_quantity, get_quantity,
+            min_spend, coupon_code, starts_at, ends_at, usage_limit, active, created_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    `
+
+	repository.Logger.Info("---executing sql (insert new promotion)...")
+	_, err := tx.ExecContext(ctx, SQL,
+		promotion.PromotionID,
+		promotion.PromotionName,
+		promotion.PromotionType,
+		promotion.ProductID,
+		promotion.CategoryID,
+		promotion.Value,
+		promotion.BuyQuantity,
+		promotion.GetQuantity,
+		promotion.MinSpend,
+		promotion.CouponCode,
+		promotion.StartsAt,
+		promotion.EndsAt,
+		promotion.UsageLimit,
+		promotion.Active,
+		promotion.CreatedAt,
+	)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			repository.Logger.Warnf("---coupon code already exists: %v", *promotion.CouponCode)
+			return domain.Promotion{}, exception.ErrCouponExists
+		}
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1452 {
+			repository.Logger.Warnf("---promotion targets an unknown product or category")
+			return domain.Promotion{}, exception.ErrInvalidPromotion
+		}
+		repository.Logger.Errorf("---failed to insert new promotion: %v", err)
+		return domain.Promotion{}, err
+	}
+
+	repository.Logger.Info("---successfully insert new promotion, returning back to service layer...")
+	return promotion, nil
+}
+
+func (repository *PromotionRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, promotion domain.Promotion) (domain.Promotion, error) {
+	SQL := `
+        UPDATE Promotions SET promotion_name = ?, promotion_type = ?, product_id = ?, category_id = ?, value = ?, buy_quantity = ?,
+            get_quantity = ?, min_spend = ?, coupon_code = ?, starts_at = ?, ends_at = ?, usage_limit = ?, active = ?
+        WHERE promotion_id = ?
+    `
+
+	repository.Logger.Info("---executing sql (update a promotion)...")
+	_, err := tx.ExecContext(ctx, SQL,
+		promotion.PromotionName,
+		promotion.PromotionType,
+		promotion.ProductID,
+		promotion.CategoryID,
+		promotion.Value,
+		promotion.BuyQuantity,
+		promotion.GetQuantity,
+		promotion.MinSpend,
+		promotion.CouponCode,
+		promotion.StartsAt,
+		promotion.EndsAt,
+		promotion.UsageLimit,
+		promotion.Active,
+		promotion.PromotionID,
+	)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			repository.Logger.Warnf("---coupon code already exists: %v", *promotion.CouponCode)
+			return domain.Promotion{}, exception.ErrCouponExists
+		}
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1452 {
+			repository.Logger.Warnf("---promotion targets an unknown product or category")
+			return domain.Promotion{}, exception.ErrInvalidPromotion
+		}
+		repository.Logger.Errorf("---failed to update promotion: %v", err)
+		return domain.Promotion{}, err
+	}
+
+	return promotion, nil
+}
+
+func (repository *PromotionRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, promotionID ulid.ULID) error {
+	SQL := "DELETE FROM Promotions WHERE promotion_id = ?"
+
+	repository.Logger.Info("---executing sql (delete a promotion)...")
+	result, err := tx.ExecContext(ctx, SQL, promotionID)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1451 {
+			repository.Logger.Warnf("---promotion %s has discounted sales", promotionID)
+			return exception.ErrPromotionInUse
+		}
+		repository.Logger.Errorf("---failed to delete a promotion: %v", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		repository.Logger.Errorf("---failed to check rows affected: %v", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		repository.Logger.Warnf("---failed to delete, promotion not found: %v", promotionID)
+		return exception.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repository *PromotionRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, activeOnly bool) ([]domain.Promotion, error) {
+	SQL := promotionColumns
+	if activeOnly {
+		SQL += " WHERE active = TRUE"
+	}
+	SQL += " ORDER BY created_at DESC"
+
+	repository.Logger.Info("---executing sql (get all promotions)...")
+	rows, err := tx.QueryContext(ctx, SQL)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get all promotions: %v", err)
+		return []domain.Promotion{}, err
+	}
+	defer rows.Close()
+
+	return repository.scanPromotions(rows)
+}
+
+func (repository *PromotionRepositoryImpl) FindByID(ctx context.Context, tx *sql.Tx, promotionID ulid.ULID) (domain.Promotion, error) {
+	SQL := promotionColumns + " WHERE promotion_id = ?"
+
+	repository.Logger.Info("---executing sql (get promotion by id)...")
+	promotion, err := repository.scanPromotion(tx.QueryRowContext(ctx, SQL, promotionID).Scan)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			repository.Logger.Warnf("---cannot found promotion_id: %v", promotionID)
+		} else {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+		}
+		return domain.Promotion{}, err
+	}
+
+	return promotion, nil
+}
+
+func (repository *PromotionRepositoryImpl) FindApplicable(ctx context.Context, tx *sql.Tx, at time.Time, couponCode *string) ([]domain.Promotion, error) {
+	SQL := promotionColumns + `
+        WHERE active = TRUE
+            AND (starts_at IS NULL OR starts_at <= ?)
+            AND (ends_at IS NULL OR ends_at > ?)
+            AND (usage_limit IS NULL OR usage_count < usage_limit)
+            AND (coupon_code IS NULL OR coupon_code = ?)
+        ORDER BY created_at`
+
+	repository.Logger.Info("---executing sql (get applicable promotions)...")
+	rows, err := tx.QueryContext(ctx, SQL, at, at, couponCode)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get applicable promotions: %v", err)
+		return []domain.Promotion{}, err
+	}
+	defer rows.Close()
+
+	return repository.scanPromotions(rows)
+}
+
+func (repository *PromotionRepositoryImpl) scanPromotions(rows *sql.Rows) ([]domain.Promotion, error) {
+	promotions := make([]domain.Promotion, 0)
+	for rows.Next() {
+		promotion, err := repository.scanPromotion(rows.Scan)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.Promotion{}, err
+		}
+		promotions = append(promotions, promotion)
+	}
+
+	return promotions, rows.Err()
+}
+
+func (repository *PromotionRepositoryImpl) scanPromotion(scan func(dest ...any) error) (domain.Promotion, error) {
+	promotion := domain.Promotion{}
+	err := scan(
+		&promotion.PromotionID,
+		&promotion.PromotionName,
+		&promotion.PromotionType,
+		&promotion.ProductID,
+		&promotion.CategoryID,
+		&promotion.Value,
+		&promotion.BuyQuantity,
+		&promotion.GetQuantity,
+		&promotion.MinSpend,
+		&promotion.CouponCode,
+		&promotion.StartsAt,
+		&promotion.EndsAt,
+		&promotion.UsageLimit,
+		&promotion.UsageCount,
+		&promotion.Active,
+		&promotion.CreatedAt,
+	)
+	if err != nil {
+		return domain.Promotion{}, err
+	}
+
+	return promotion, nil
+}
+
+func (repository *PromotionRepositoryImpl) IncrementUsage(ctx context.Context, tx *sql.Tx, promotionID ulid.ULID) error {
+	SQL := "UPDATE Promotions SET usage_count = usage_count + 1 WHERE promotion_id = ? AND (usage_limit IS NULL OR usage_count < usage_limit)"
+
+	repository.Logger.Info("---executing sql (increment promotion usage)...")
+	result, err := tx.ExecContext(ctx, SQL, promotionID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to increment promotion usage: %v", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		repository.Logger.Errorf("---failed to check rows affected: %v", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		repository.Logger.Warnf("---promotion %s reached its usage limit", promotionID)
+		return exception.ErrPromotionExhausted
+	}
+
+	return nil
+}
+
+func (repository *PromotionRepositoryImpl) ReleaseUsage(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID) error {
+	SQL := `
+        UPDATE Promotions p
+        JOIN (
+            SELECT DISTINCT promotion_id
+            FROM Transaction_Discounts
+            WHERE transaction_id = ?
+        ) d ON p.promotion_id = d.promotion_id
+        SET p.usage_count = p.usage_count - 1
+        WHERE p.usage_count > 0
+    `
+
+	repository.Logger.Info("---executing sql (release promotion usage)...")
+	_, err := tx.ExecContext(ctx, SQL, transactionID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to release promotion usage: %v", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/model/domain"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type PurchaseOrderRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, purchaseOrder domain.PurchaseOrder) (domain.PurchaseOrder, error)
+	SaveItems(ctx context.Context, tx *sql.Tx, items []domain.PurchaseOrderItem) ([]domain.PurchaseOrderItem, error)
+	FindAll(ctx context.Context, tx *sql.Tx, status string, supplierID *ulid.ULID) ([]domain.PurchaseOrder, error)
+	FindByID(ctx context.Context, tx *sql.Tx, purchaseOrderID ulid.ULID) (domain.PurchaseOrder, error)
+	FindByIDForUpdate(ctx context.Context, tx *sql.Tx, purchaseOrderID ulid.ULID) (domain.PurchaseOrder, error)
+	FindItems(ctx context.Context, tx *sql.Tx, purchaseOrderID ulid.ULID) ([]domain.PurchaseOrderItem, error)
+	UpdateStatus(ctx context.Context, tx *sql.Tx, purchaseOrder domain.PurchaseOrder) error
+	UpdateItemReceived(ctx context.Context, tx *sql.Tx, item domain.PurchaseOrderItem) error
+	SaveReceipt(ctx context.Context, tx *sql.Tx, receipt domain.GoodsReceipt) (domain.GoodsReceipt, error)
+	SaveReceiptItems(ctx context.Context, tx *sql.Tx, items []domain.GoodsReceiptItem) ([]domain.GoodsReceiptItem, error)
+	FindReceipts(ctx context.Context, tx *sql.Tx, purchaseOrderID ulid.ULID) ([]domain.GoodsReceipt, error)
+	FindReceiptByIdempotencyKey(ctx context.Context, tx *sql.Tx, purchaseOrderID ulid.ULID, idempotencyKey string) (domain.GoodsReceipt, error)
+	FindReceiptItems(ctx context.Context, tx *sql.Tx, purchaseOrderID ulid.ULID) ([]domain.GoodsReceiptItem, error)
+	FindOpenQuantities(ctx context.Context, tx *sql.Tx, locationID ulid.ULID) (map[ulid.ULID]int, error)
+}
@@ -0,0 +1,368 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/model/domain"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type PurchaseOrderRepositoryImpl struct {
+	Logger *logrus.Logger
+}
+
+func NewPurchaseOrderRepository(logger *logrus.Logger) PurchaseOrderRepository {
+	return &PurchaseOrderRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+const purchaseOrderColumns = `
+        SELECT
+            po.purchase_order_id,
+            po.supplier_id,
+            po.location_id,
+            po.status,
+            po.note,
+            po.created_by,
+            po.created_at,
+            po.sent_at,
+            po.closed_at,
+            (SELECT COALESCE(SUM(i.quantity * i.purchase_price), 0) FROM Purchase_Order_Items i WHERE i.purchase_order_id = po.purchase_order_id) as total_amount
+        FROM Purchase_Orders po
+`
+
+func (repository *PurchaseOrderRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, purchaseOrder domain.PurchaseOrder) (domain.PurchaseOrder, error) {
+	SQL := "INSERT INTO Purchase_Orders(purchase_order_id, supplier_id, location_id, status, note, created_by, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)"
+
+	repository.Logger.Info("---executing sql (save purchase order)...")
+	_, err := tx.ExecContext(ctx, SQL,
+		purchaseOrder.PurchaseOrderID,
+		purchaseOrder.SupplierID,
+		purchaseOrder.LocationID,
+		purchaseOrder.Status,
+		purchaseOrder.Note,
+		purchaseOrder.CreatedBy,
+		purchaseOrder.CreatedAt,
+	)
+	if err != nil {
+		repository.Logger.Errorf("---failed to save purchase order: %v", err)
+		return domain.PurchaseOrder{}, err
+	}
+
+	repository.Logger.Info("---success, returning back to service layer")
+	return purchaseOrder, nil
+}
+
+func (repository *PurchaseOrderRepositoryImpl) SaveItems(ctx context.Context, tx *sql.Tx, items []domain.PurchaseOrderItem) ([]domain.PurchaseOrderItem, error) {
+	if len(items) == 0 {
+		return []domain.PurchaseOrderItem{}, nil
+	}
+
+	SQL := "INSERT INTO Purchase_Order_Items(purchase_order_id, product_id, quantity, purchase_price) VALUES "
+
+	var args []interface{}
+	for _, item := range items {
+		SQL += "(?, ?, ?, ?),"
+		args = append(args, item.PurchaseOrderID, item.ProductID, item.Quantity, item.PurchasePrice)
+	}
+
+	SQL = SQL[0 : len(SQL)-1]
+
+	repository.Logger.Info("---executing sql (save purchase order items)...")
+	_, err := tx.ExecContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to save purchase order items: %v", err)
+		return []domain.PurchaseOrderItem{}, err
+	}
+
+	return items, nil
+}
+
+func (repository *PurchaseOrderRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, status string, supplierID *ulid.ULID) ([]domain.PurchaseOrder, error) {
+	SQL := purchaseOrderColumns + " WHERE 1 = 1"
+
+	var args []interface{}
+	if status != "" {
+		SQL += " AND po.status = ?"
+		args = append(args, status)
+	}
+	if supplierID != nil {
+		SQL += " AND po.supplier_id = ?"
+		args = append(args, *supplierID)
+	}
+	SQL += " ORDER BY po.created_at DESC"
+
+	repository.Logger.Info("---executing sql (get all purchase orders)...")
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get all purchase orders: %v", err)
+		return []domain.PurchaseOrder{}, err
+	}
+	defer rows.Close()
+
+	purchaseOrders := make([]domain.PurchaseOrder, 0)
+
+	repository.Logger.Info("---checking rows.Next()...")
+	for rows.Next() {
+		purchaseOrder := domain.PurchaseOrder{}
+		err := rows.Scan(
+			&purchaseOrder.PurchaseOrderID,
+			&purchaseOrder.SupplierID,
+			&purchaseOrder.LocationID,
+			&purchaseOrder.Status,
+			&purchaseOrder.Note,
+			&purchaseOrder.CreatedBy,
+			&purchaseOrder.CreatedAt,
+			&purchaseOrder.SentAt,
+			&purchaseOrder.ClosedAt,
+			&purchaseOrder.TotalAmount,
+		)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.PurchaseOrder{}, err
+		}
+		purchaseOrders = append(purchaseOrders, purchaseOrder)
+	}
+
+	return purchaseOrders, rows.Err()
+}
+
+func (repository *PurchaseOrderRepositoryImpl) FindByID(ctx context.Context, tx *sql.Tx, purchaseOrderID ulid.ULID) (domain.PurchaseOrder, error) {
+	SQL := purchaseOrderColumns + " WHERE po.purchase_order_id = ?"
+
+	repository.Logger.Info("---executing sql (get purchase order by id)...")
+	return repository.scanPurchaseOrder(tx.QueryRowContext(ctx, SQL, purchaseOrderID))
+}
+
+func (repository *PurchaseOrderRepositoryImpl) FindByIDForUpdate(ctx context.Context, tx *sql.Tx, purchaseOrderID ulid.ULID) (domain.PurchaseOrder, error) {
+	SQL := purchaseOrderColumns + " WHERE po.purchase_order_id = ? FOR UPDATE"
+
+	repository.Logger.Info("---executing sql (lock purchase order)...")
+	return repository.scanPurchaseOrder(tx.QueryRowContext(ctx, SQL, purchaseOrderID))
+}
+
+func (repository *PurchaseOrderRepositoryImpl) scanPurchaseOrder(row *sql.Row) (domain.PurchaseOrder, error) {
+	purchaseOrder := domain.PurchaseOrder{}
+	err := row.Scan(
+		&purchaseOrder.PurchaseOrderID,
+		&purchaseOrder.SupplierID,
+		&purchaseOrder.LocationID,
+		&purchaseOrder.Status,
+		&purchaseOrder.Note,
+		&purchaseOrder.CreatedBy,
+		&purchaseOrder.CreatedAt,
+		&purchaseOrder.SentAt,
+		&purchaseOrder.ClosedAt,
+		&purchaseOrder.TotalAmount,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			repository.Logger.Warn("---cannot found purchase_order_id")
+		} else {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+		}
+		return domain.PurchaseOrder{}, err
+	}
+
+	return purchaseOrder, nil
+}
+
+func (repository *PurchaseOrderRepositoryImpl) FindItems(ctx context.Context, tx *sql.Tx, purchaseOrderID ulid.ULID) ([]domain.PurchaseOrderItem, error) {
+	SQL := `
+        SELECT i.purchase_order_id, i.product_id, p.product_name, i.quantity, i.quantity_received, i.purchase_price
+        FROM Purchase_Order_Items i
+        JOIN Products p ON i.product_id = p.product_id
+        WHERE i.purchase_order_id = ?
+    `
+
+	repository.Logger.Info("---executing sql (get purchase order items)...")
+	rows, err := tx.QueryContext(ctx, SQL, purchaseOrderID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get purchase order items: %v", err)
+		return []domain.PurchaseOrderItem{}, err
+	}
+	defer rows.Close()
+
+	items := make([]domain.PurchaseOrderItem, 0)
+	for rows.Next() {
+		item := domain.PurchaseOrderItem{}
+		err := rows.Scan(&item.PurchaseOrderID, &item.ProductID, &item.ProductName, &item.Quantity, &item.QuantityReceived, &item.PurchasePrice)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.PurchaseOrderItem{}, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (repository *PurchaseOrderRepositoryImpl) UpdateStatus(ctx context.Context, tx *sql.Tx, purchaseOrder domain.PurchaseOrder) error {
+	SQL := "UPDATE Purchase_Orders SET status = ?, sent_at = ?, closed_at = ? WHERE purchase_order_id = ?"
+
+	repository.Logger.Info("---executing sql (update purchase order status)...")
+	_, err := tx.ExecContext(ctx, SQL, purchaseOrder.Status, purchaseOrder.SentAt, purchaseOrder.ClosedAt, purchaseOrder.PurchaseOrderID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to update purchase order status: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *PurchaseOrderRepositoryImpl) UpdateItemReceived(ctx context.Context, tx *sql.Tx, item domain.PurchaseOrderItem) error {
+	SQL := "UPDATE Purchase_Order_Items SET quantity_received = ? WHERE purchase_order_id = ? AND product_id = ?"
+
+	repository.Logger.Info("---executing sql (update received quantity)...")
+	_, err := tx.ExecContext(ctx, SQL, item.QuantityReceived, item.PurchaseOrderID, item.ProductID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to update received quantity: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *PurchaseOrderRepositoryImpl) SaveReceipt(ctx context.Context, tx *sql.Tx, receipt domain.GoodsReceipt) (domain.GoodsReceipt, error) {
+	SQL := "INSERT INTO Goods_Receipts(receipt_id, purchase_order_id, received_by, note, received_at, idempotency_key) VALUES (?, ?, ?, ?, ?, NULLIF(?, ''))"
+
+	repository.Logger.Info("---executing sql (save goods receipt)...")
+	_, err := tx.ExecContext(ctx, SQL, receipt.ReceiptID, receipt.PurchaseOrderID, receipt.ReceivedBy, receipt.Note, receipt.ReceivedAt, receipt.IdempotencyKey)
+	if err != nil {
+		repository.Logger.Errorf("---failed to save goods receipt: %v", err)
+		return domain.GoodsReceipt{}, err
+	}
+
+	return receipt, nil
+}
+
+func (repository *PurchaseOrderRepositoryImpl) SaveReceiptItems(ctx context.Context, tx *sql.Tx, items []domain.GoodsReceiptItem) ([]domain.GoodsReceiptItem, error) {
+	if len(items) == 0 {
+		return []domain.GoodsReceiptItem{}, nil
+	}
+
+	SQL := "INSERT INTO Goods_Receipt_Items(receipt_id, product_id, quantity, purchase_price) VALUES "
+
+	var args []interface{}
+	for _, item := range items {
+		SQL += "(?, ?, ?, ?),"
+		args = append(args, item.ReceiptID, item.ProductID, item.Quantity, item.PurchasePrice)
+	}
+
+	SQL = SQL[0 : len(SQL)-1]
+
+	repository.Logger.Info("---executing sql (save goods receipt items)...")
+	_, err := tx.ExecContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to save goods receipt items: %v", err)
+		return []domain.GoodsReceiptItem{}, err
+	}
+
+	return items, nil
+}
+
+func (repository *PurchaseOrderRepositoryImpl) FindReceipts(ctx context.Context, tx *sql.Tx, purchaseOrderID ulid.ULID) ([]domain.GoodsReceipt, error) {
+	SQL := "SELECT receipt_id, purchase_order_id, received_by, note, received_at, COALESCE(idempotency_key, '') FROM Goods_Receipts WHERE purchase_order_id = ? ORDER BY received_at"
+
+	repository.Logger.Info("---executing sql (get goods receipts)...")
+	rows, err := tx.QueryContext(ctx, SQL, purchaseOrderID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get goods receipts: %v", err)
+		return []domain.GoodsReceipt{}, err
+	}
+	defer rows.Close()
+
+	receipts := make([]domain.GoodsReceipt, 0)
+	for rows.Next() {
+		receipt := domain.GoodsReceipt{}
+		err := rows.Scan(&receipt.ReceiptID, &receipt.PurchaseOrderID, &receipt.ReceivedBy, &receipt.Note, &receipt.ReceivedAt, &receipt.IdempotencyKey)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.GoodsReceipt{}, err
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	return receipts, rows.Err()
+}
+
+func (repository *PurchaseOrderRepositoryImpl) FindReceiptByIdempotencyKey(ctx context.Context, tx *sql.Tx, purchaseOrderID ulid.ULID, idempotencyKey string) (domain.GoodsReceipt, error) {
+	SQL := "SELECT receipt_id, purchase_order_id, received_by, note, received_at, COALESCE(idempotency_key, '') FROM Goods_Receipts WHERE purchase_order_id = ? AND idempotency_key = ?"
+
+	receipt := domain.GoodsReceipt{}
+
+	repository.Logger.Info("---executing sql (get goods receipt by idempotency key)...")
+	err := tx.QueryRowContext(ctx, SQL, purchaseOrderID, idempotencyKey).Scan(&receipt.ReceiptID, &receipt.PurchaseOrderID, &receipt.ReceivedBy, &receipt.Note, &receipt.ReceivedAt, &receipt.IdempotencyKey)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			repository.Logger.Errorf("---failed to get goods receipt by idempotency key: %v", err)
+		}
+		return domain.GoodsReceipt{}, err
+	}
+
+	return receipt, nil
+}
+
+func (repository *PurchaseOrderRepositoryImpl) FindReceiptItems(ctx context.Context, tx *sql.Tx, purchaseOrderID ulid.ULID) ([]domain.GoodsReceiptItem, error) {
+	SQL := `
+        SELECT ri.receipt_id, ri.product_id, ri.quantity, ri.purchase_price
+        FROM Goods_Receipt_Items ri
+        JOIN Goods_Receipts r ON ri.receipt_id = r.receipt_id
+        WHERE r.purchase_order_id = ?
+    `
+
+	repository.Logger.Info("---executing sql (get goods receipt items)...")
+	rows, err := tx.QueryContext(ctx, SQL, purchaseOrderID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get goods receipt items: %v", err)
+		return []domain.GoodsReceiptItem{}, err
+	}
+	defer rows.Close()
+
+	items := make([]domain.GoodsReceiptItem, 0)
+	for rows.Next() {
+		item := domain.GoodsReceiptItem{}
+		err := rows.Scan(&item.ReceiptID, &item.ProductID, &item.Quantity, &item.PurchasePrice)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.GoodsReceiptItem{}, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (repository *PurchaseOrderRepositoryImpl) FindOpenQuantities(ctx context.Context, tx *sql.Tx, locationID ulid.ULID) (map[ulid.ULID]int, error) {
+	SQL := `
+        SELECT i.product_id, SUM(i.quantity - i.quantity_received)
+        FROM Purchase_Order_Items i
+        JOIN Purchase_Orders po ON i.purchase_order_id = po.purchase_order_id
+        WHERE po.location_id = ? AND po.status IN ('draft', 'sent', 'partially_received')
+        GROUP BY i.product_id
+    `
+
+	repository.Logger.Info("---executing sql (get open purchase order quantities)...")
+	rows, err := tx.QueryContext(ctx, SQL, locationID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get open purchase order quantities: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	quantities := make(map[ulid.ULID]int)
+	for rows.Next() {
+		var productID ulid.ULID
+		var quantity int
+		if err := rows.Scan(&productID, &quantity); err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return nil, err
+		}
+		quantities[productID] = quantity
+	}
+
+	return quantities, rows.Err()
+}
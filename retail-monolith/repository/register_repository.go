@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/model/domain"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type RegisterRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, register domain.Register) (domain.Register, error)
+	FindAll(ctx context.Context, tx *sql.Tx) ([]domain.Register, error)
+	FindByID(ctx context.Context, tx *sql.Tx, registerID ulid.ULID) (domain.Register, error)
+	FindByUserID(ctx context.Context, tx *sql.Tx, userID ulid.ULID) (domain.Register, error)
+	AssignUser(ctx context.Context, tx *sql.Tx, userID ulid.ULID, registerID *ulid.ULID) error
+}
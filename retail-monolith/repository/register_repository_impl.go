@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"retail-management/exception"
+	"retail-management/model/domain"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type RegisterRepositoryImpl struct {
+	Logger *logrus.Logger
+}
+
+func NewRegisterRepository(logger *logrus.Logger) RegisterRepository {
+	return &RegisterRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+func (repository *RegisterRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, register domain.Register) (domain.Register, error) {
+	SQL := "INSERT INTO Registers(register_id, register_name, location_id, created_at) VALUES (?, ?, ?, ?)"
+
+	repository.Logger.Info("---executing sql (insert new register)...")
+	_, err := tx.ExecContext(ctx, SQL, register.RegisterID, register.RegisterName, register.LocationID, register.CreatedAt)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			repository.Logger.Warnf("---register name already exists: %s", register.RegisterName)
+			return domain.Register{}, exception.ErrRegisterExists
+		}
+		repository.Logger.Errorf("---failed to insert new register: %v", err)
+		return domain.Register{}, err
+	}
+
+	repository.Logger.Info("---successfully insert new register, returning back to service layer...")
+	return register, nil
+}
+
+func (repository *RegisterRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) ([]domain.Register, error) {
+	SQL := "SELECT register_id, register_name, location_id, created_at FROM Registers ORDER BY register_name"
+
+	repository.Logger.Info("---executing sql (get all registers)...")
+	rows, err := tx.QueryContext(ctx, SQL)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get all registers: %v", err)
+		return []domain.Register{}, err
+	}
+	defer rows.Close()
+
+	registers := make([]domain.Register, 0)
+
+	repository.Logger.Info("---checking rows.Next()...")
+	for rows.Next() {
+		register := domain.Register{}
+		err := rows.Scan(
+			&register.RegisterID,
+			&register.RegisterName,
+			&register.LocationID,
+			&register.CreatedAt,
+		)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.Register{}, err
+		}
+		registers = append(registers, register)
+	}
+
+	repository.Logger.Info("---successfully get all registers, returning back to service layer...")
+	return registers, nil
+}
+
+func (repository *RegisterRepositoryImpl) FindByID(ctx context.Context, tx *sql.Tx, registerID ulid.ULID) (domain.Register, error) {
+	SQL := "SELECT register_id, register_name, location_id, created_at FROM Registers WHERE register_id = ?"
+
+	register := domain.Register{}
+
+	repository.Logger.Info("---executing sql (get register by id)...")
+	err := tx.QueryRowContext(ctx, SQL, registerID).Scan(
+		&register.RegisterID,
+		&register.RegisterName,
+		&register.LocationID,
+		&register.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			repository.Logger.Warnf("---cannot found register_id: %v", registerID)
+		} else {
+			repository.Logger.Errorf("---failed to get register: %v", err)
+		}
+		return domain.Register{}, err
+	}
+
+	return register, nil
+}
+
+func (repository *RegisterRepositoryImpl) FindByUserID(ctx context.Context, tx *sql.Tx, userID ulid.ULID) (domain.Register, error) {
+	SQL := `
+        SELECT r.register_id, r.register_name, r.location_id, r.created_at
+        FROM Registers r
+        JOIN Users u ON u.register_id = r.register_id
+        WHERE u.user_id = ?
+    `
+
+	register := domain.Register{}
+
+	repository.Logger.Info("---executing sql (get register by user id)...")
+	err := tx.QueryRowContext(ctx, SQL, userID).Scan(
+		&register.RegisterID,
+		&register.RegisterName,
+		&register.LocationID,
+		&register.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			repository.Logger.Warnf("---user %v has no register assigned", userID)
+		} else {
+			repository.Logger.Errorf("---failed to get register by user: %v", err)
+		}
+		return domain.Register{}, err
+	}
+
+	return register, nil
+}
+
+func (repository *RegisterRepositoryImpl) AssignUser(ctx context.Context, tx *sql.Tx, userID ulid.ULID, registerID *ulid.ULID) error {
+	SQL := "UPDATE Users SET register_id = ? WHERE user_id = ?"
+
+	repository.Logger.Info("---executing sql (assign register to user)...")
+	result, err := tx.ExecContext(ctx, SQL, registerID, userID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to assign register: %v", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		repository.Logger.Errorf("---failed to check rows affected: %v", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		var exists int
+		err := tx.QueryRowContext(ctx, "SELECT 1 FROM Users WHERE user_id = ?", userID).Scan(&exists)
+		if err != nil {
+			repository.Logger.Warnf("---cannot found user_id: %v", userID)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/model/domain"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type ReorderRuleRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, rule domain.ReorderRule) (domain.ReorderRule, error)
+	FindByProductID(ctx context.Context, tx *sql.Tx, productID ulid.ULID) (domain.ReorderRule, error)
+	FindAll(ctx context.Context, tx *sql.Tx) ([]domain.ReorderRule, error)
+}
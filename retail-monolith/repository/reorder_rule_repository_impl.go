@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/model/domain"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type ReorderRuleRepositoryImpl struct {
+	Logger *logrus.Logger
+}
+
+func NewReorderRuleRepository(logger *logrus.Logger) ReorderRuleRepository {
+	return &ReorderRuleRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+func (repository *ReorderRuleRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, rule domain.ReorderRule) (domain.ReorderRule, error) {
+	SQL := `
+        INSERT INTO Product_Reorder_Rules(product_id, min_quantity, max_quantity, reorder_quantity, updated_at) VALUES (?, ?, ?, ?, ?)
+        ON DUPLICATE KEY UPDATE min_quantity = VALUES(min_quantity), max_quantity = VALUES(max_quantity), reorder_quantity = VALUES(reorder_quantity), updated_at = VALUES(updated_at)
+    `
+
+	repository.Logger.Info("---executing sql (save reorder rule)...")
+	_, err := tx.ExecContext(ctx, SQL, rule.ProductID, rule.MinQuantity, rule.MaxQuantity, rule.ReorderQuantity, rule.UpdatedAt)
+	if err != nil {
+		repository.Logger.Errorf("---failed to save reorder rule: %v", err)
+		return domain.ReorderRule{}, err
+	}
+
+	return rule, nil
+}
+
+func (repository *ReorderRuleRepositoryImpl) FindByProductID(ctx context.Context, tx *sql.Tx, productID ulid.ULID) (domain.ReorderRule, error) {
+	SQL := "SELECT product_id, min_quantity, max_quantity, reorder_quantity, updated_at FROM Product_Reorder_Rules WHERE product_id = ?"
+
+	rule := domain.ReorderRule{}
+
+	repository.Logger.Info("---executing sql (get reorder rule)...")
+	err := tx.QueryRowContext(ctx, SQL, productID).Scan(
+		&rule.ProductID,
+		&rule.MinQuantity,
+		&rule.MaxQuantity,
+		&rule.ReorderQuantity,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get reorder rule: %v", err)
+		return domain.ReorderRule{}, err
+	}
+
+	return rule, nil
+}
+
+func (repository *ReorderRuleRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) ([]domain.ReorderRule, error) {
+	SQL := `
+        SELECT r.product_id, r.min_quantity, r.max_quantity, r.reorder_quantity, r.updated_at,
+            p.product_name, p.purchase_price, p.supplier_id, s.supplier_name
+        FROM Product_Reorder_Rules r
+        JOIN Products p ON r.product_id = p.product_id
+        JOIN Suppliers s ON p.supplier_id = s.supplier_id
+        ORDER BY s.supplier_name, p.product_name
+    `
+
+	repository.Logger.Info("---executing sql (get all reorder rules)...")
+	rows, err := tx.QueryContext(ctx, SQL)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get reorder rules: %v", err)
+		return []domain.ReorderRule{}, err
+	}
+	defer rows.Close()
+
+	rules := make([]domain.ReorderRule, 0)
+	for rows.Next() {
+		rule := domain.ReorderRule{}
+		err := rows.Scan(
+			&rule.ProductID,
+			&rule.MinQuantity,
+			&rule.MaxQuantity,
+			&rule.ReorderQuantity,
+			&rule.UpdatedAt,
+			&rule.ProductName,
+			&rule.PurchasePrice,
+			&rule.SupplierID,
+			&rule.SupplierName,
+		)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.ReorderRule{}, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
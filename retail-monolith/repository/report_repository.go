@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/model/domain"
+)
+
+type ReportRepository interface {
+	FindSales(ctx context.Context, tx *sql.Tx, filter domain.ReportFilter) ([]domain.SalesReportRow, error)
+	FindSalesTotal(ctx context.Context, tx *sql.Tx, filter domain.ReportFilter) (domain.SalesReportRow, error)
+	FindPayments(ctx context.Context, tx *sql.Tx, filter domain.ReportFilter) ([]domain.PaymentReportRow, error)
+	FindTax(ctx context.Context, tx *sql.Tx, filter domain.ReportFilter) ([]domain.TaxReportRow, error)
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/model/domain"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ReportRepositoryImpl struct {
+	Logger *logrus.Logger
+}
+
+func NewReportRepository(logger *logrus.Logger) ReportRepository {
+	return &ReportRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+var salesReportGroups = map[string]struct{ id, label, order string }{
+	domain.ReportGroupDay:      {"NULL", "DATE_FORMAT(t.transaction_time, '%Y-%m-%d')", "group_label"},
+	domain.ReportGroupWeek:     {"NULL", "DATE_FORMAT(t.transaction_time, '%x-W%v')", "group_label"},
+	domain.ReportGroupMonth:    {"NULL", "DATE_FORMAT(t.transaction_time, '%Y-%m')", "group_label"},
+	domain.ReportGroupCategory: {"cat.category_id", "cat.category_name", "revenue DESC"},
+	domain.ReportGroupSupplier: {"s.supplier_id", "s.supplier_name", "revenue DESC"},
+	domain.ReportGroupProduct:  {"p.product_id", "p.product_name", "revenue DESC"},
+	domain.ReportGroupCashier:  {"u.user_id", "u.username", "revenue DESC"},
+}
+
+const salesReportMeasures = `
+            COUNT(DISTINCT t.transaction_id) as transactions,
+            COALESCE(SUM(d.quantity - COALESCE(r.returned_quantity, 0)), 0) as units,
+            ROUND(COALESCE(SUM((d.quantity - COALESCE(r.returned_quantity, 0)) * (d.line_total - d.tax_amount) / d.quantity), 0), 2) as revenue,
+            COALESCE(SUM((d.quantity - COALESCE(r.returned_quantity, 0)) * COALESCE(c.unit_cost, p.purchase_price)), 0) as cost
+        FROM Transaction_Details d
+        JOIN Transactions t ON d.transaction_id = t.transaction_id
+        JOIN Products p ON d.product_id = p.product_id
+        JOIN Categories cat ON p.category_id = cat.category_id
+        JOIN Suppliers s ON p.supplier_id = s.supplier_id
+        JOIN Users u ON t.user_id = u.user_id
+        LEFT JOIN Transaction_Detail_Costs c ON d.detail_id = c.detail_id
+        LEFT JOIN (
+            SELECT detail_id, SUM(quantity) as returned_quantity
+            FROM Transaction_Return_Details
+            GROUP BY detail_id
+        ) r ON d.detail_id = r.detail_id
+        WHERE t.status = ? AND t.transaction_time >= ? AND t.transaction_time < ?`
+
+func salesReportArgs(filter domain.ReportFilter) (string, []interface{}) {
+	SQL := ""
+	args := []interface{}{domain.TransactionStatusCompleted, filter.From, filter.To}
+	if filter.LocationID != nil {
+		SQL += " AND t.location_id = ?"
+		args = append(args, *filter.LocationID)
+	}
+	return SQL, args
+}
+
+func (repository *ReportRepositoryImpl) FindSales(ctx context.Context, tx *sql.Tx, filter domain.ReportFilter) ([]domain.SalesReportRow, error) {
+	group := salesReportGroups[filter.GroupBy]
+	where, args := salesReportArgs(filter)
+
+	SQL := "SELECT " + group.id + " as group_id, " + group.label + " as group_label," +
+		salesReportMeasures + where +
+		" GROUP BY group_id, group_label ORDER BY " + group.order
+
+	repository.Logger.Infof("---executing sql (get sales report by %s)...", filter.GroupBy)
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get sales report: %v", err)
+		return []domain.SalesReportRow{}, err
+	}
+	defer rows.Close()
+
+	reportRows := make([]domain.SalesReportRow, 0)
+	for rows.Next() {
+		row := domain.SalesReportRow{}
+		err := rows.Scan(
+			&row.GroupID,
+			&row.Label,
+			&row.Transactions,
+			&row.Units,
+			&row.Revenue,
+			&row.Cost,
+		)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.SalesReportRow{}, err
+		}
+		reportRows = append(reportRows, row)
+	}
+
+	return reportRows, rows.Err()
+}
+
+func (repository *ReportRepositoryImpl) FindSalesTotal(ctx context.Context, tx *sql.Tx, filter domain.ReportFilter) (domain.SalesReportRow, error) {
+	where, args := salesReportArgs(filter)
+	SQL := "SELECT" + salesReportMeasures + where
+
+	total := domain.SalesReportRow{Label: "total"}
+
+	repository.Logger.Info("---executing sql (get sales report total)...")
+	err := tx.QueryRowContext(ctx, SQL, args...).Scan(
+		&total.Transactions,
+		&total.Units,
+		&total.Revenue,
+		&total.Cost,
+	)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get sales report total: %v", err)
+		return domain.SalesReportRow{}, err
+	}
+
+	return total, nil
+}
+
+func (repository *ReportRepositoryImpl) FindPayments(ctx context.Context, tx *sql.Tx, filter domain.ReportFilter) ([]domain.PaymentReportRow, error) {
+	where, args := salesReportArgs(filter)
+	SQL := `
+        SELECT
+            pay.method,
+            COUNT(DISTINCT t.transaction_id) as transactions,
+            COALESCE(SUM(pay.amount), 0) as amount,
+            COALESCE(SUM(pay.change_given), 0) as change_given
+        FROM Transaction_Payments pay
+        JOIN Transactions t ON pay.transaction_id = t.transaction_id
+        WHERE t.status = ? AND t.transaction_time >= ? AND t.transaction_time < ?` + where + `
+        GROUP BY pay.method
+        ORDER BY amount DESC`
+
+	repository.Logger.Info("---executing sql (get payment report)...")
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get payment report: %v", err)
+		return []domain.PaymentReportRow{}, err
+	}
+	defer rows.Close()
+
+	reportRows := make([]domain.PaymentReportRow, 0)
+	for rows.Next() {
+		row := domain.PaymentReportRow{}
+		err := rows.Scan(
+			&row.Method,
+			&row.Transactions,
+			&row.Amount,
+			&row.ChangeGiven,
+		)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.PaymentReportRow{}, err
+		}
+		reportRows = append(reportRows, row)
+	}
+
+	return reportRows, rows.Err()
+}
+
+func (repository *ReportRepositoryImpl) FindTax(ctx context.Context, tx *sql.Tx, filter domain.ReportFilter) ([]domain.TaxReportRow, error) {
+	where, args := salesReportArgs(filter)
+	SQL := `
+        SELECT
+            DATE_FORMAT(t.transaction_time, '%Y-%m') as period,
+            d.tax_rate_id,
+            COALESCE(tr.tax_name, 'no tax') as tax_name,
+            d.tax_rate,
+            ROUND(COALESCE(SUM((d.quantity - COALESCE(r.returned_quantity, 0)) * (d.line_total - d.tax_amount) / d.quantity), 0), 2) as taxable_amount,
+            ROUND(COALESCE(SUM((d.quantity - COALESCE(r.returned_quantity, 0)) * d.tax_amount / d.quantity), 0), 2) as tax_amount
+        FROM Transaction_Details d
+        JOIN Transactions t ON d.transaction_id = t.transaction_id
+        LEFT JOIN Tax_Rates tr ON d.tax_rate_id = tr.tax_rate_id
+        LEFT JOIN (
+            SELECT detail_id, SUM(quantity) as returned_quantity
+            FROM Transaction_Return_Details
+            GROUP BY detail_id
+        ) r ON d.detail_id = r.detail_id
+        WHERE t.status = ? AND t.transaction_time >= ? AND t.transaction_time < ?` + where + `
+        GROUP BY period, d.tax_rate_id, tax_name, d.tax_rate
+        ORDER BY period, d.tax_rate DESC`
+
+	repository.Logger.Info("---executing sql (get tax report)...")
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get tax report: %v", err)
+		return []domain.TaxReportRow{}, err
+	}
+	defer rows.Close()
+
+	reportRows := make([]domain.TaxReportRow, 0)
+	for rows.Next() {
+		row := domain.TaxReportRow{}
+		err := rows.Scan(
+			&row.Period,
+			&row.TaxRateID,
+			&row.TaxName,
+			&row.Rate,
+			&row.TaxableAmount,
+			&row.TaxAmount,
+		)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.TaxReportRow{}, err
+		}
+		reportRows = append(reportRows, row)
+	}
+
+	return reportRows, rows.Err()
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/model/domain"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type ShiftRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, shift domain.Shift) (domain.Shift, error)
+	FindAll(ctx context.Context, tx *sql.Tx, status string, userID *ulid.ULID) ([]domain.Shift, error)
+	FindByID(ctx context.Context, tx *sql.Tx, shiftID ulid.ULID) (domain.Shift, error)
+	FindByIDForUpdate(ctx context.Context, tx *sql.Tx, shiftID ulid.ULID) (domain.Shift, error)
+	FindOpenByUserID(ctx context.Context, tx *sql.Tx, userID ulid.ULID) (domain.Shift, error)
+	Close(ctx context.Context, tx *sql.Tx, shift domain.Shift) error
+	Summarize(ctx context.Context, tx *sql.Tx, shift domain.Shift, until time.Time) (domain.ShiftSummary, error)
+}
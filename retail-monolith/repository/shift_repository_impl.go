@@ -0,0 +1,284 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"retail-management/exception"
+	"retail-management/model/domain"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type ShiftRepositoryImpl struct {
+	Logger *logrus.Logger
+}
+
+func NewShiftRepository(logger *logrus.Logger) ShiftRepository {
+	return &ShiftRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+const shiftColumns = `
+        SELECT shift_id, user_id, location_id, register_id, status, opening_float, note, opened_at, closed_at,
+            sales_count, sales_amount, returns_count, returns_amount, voids_count, voids_amount, cash_amount, cash_refunds,
+            expected_cash, counted_cash, over_short
+        FROM Shifts`
+
+func (repository *ShiftRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, shift domain.Shift) (domain.Shift, error) {
+	SQL := "INSERT INTO Shifts(shift_id, user_id, location_id, register_id, status, opening_float, note, opened_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+
+	repository.Logger.Info("---executing sql (save shift)...")
+	_, err := tx.ExecContext(ctx, SQL,
+		shift.ShiftID,
+		shift.UserID,
+		shift.LocationID,
+		shift.RegisterID,
+		shift.Status,
+		shift.OpeningFloat,
+		shift.Note,
+		shift.OpenedAt,
+	)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			repository.Logger.Warnf("---user %s already has an open shift", shift.UserID)
+			return domain.Shift{}, exception.ErrShiftAlreadyOpen
+		}
+		repository.Logger.Errorf("---failed to save shift: %v", err)
+		return domain.Shift{}, err
+	}
+
+	repository.Logger.Info("---success, returning back to service layer")
+	return shift, nil
+}
+
+func (repository *ShiftRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, status string, userID *ulid.ULID) ([]domain.Shift, error) {
+	SQL := shiftColumns + " WHERE 1 = 1"
+
+	var args []interface{}
+	if status != "" {
+		SQL += " AND status = ?"
+		args = append(args, status)
+	}
+	if userID != nil {
+		SQL += " AND user_id = ?"
+		args = append(args, *userID)
+	}
+	SQL += " ORDER BY opened_at DESC"
+
+	repository.Logger.Info("---executing sql (get all shifts)...")
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get all shifts: %v", err)
+		return []domain.Shift{}, err
+	}
+	defer rows.Close()
+
+	shifts := make([]domain.Shift, 0)
+	for rows.Next() {
+		shift, err := repository.scanShift(rows.Scan)
+		if err != nil {
+			return []domain.Shift{}, err
+		}
+		shifts = append(shifts, shift)
+	}
+
+	return shifts, rows.Err()
+}
+
+func (repository *ShiftRepositoryImpl) FindByID(ctx context.Context, tx *sql.Tx, shiftID ulid.ULID) (domain.Shift, error) {
+	SQL := shiftColumns + " WHERE shift_id = ?"
+
+	repository.Logger.Info("---executing sql (get shift by id)...")
+	return repository.scanShift(tx.QueryRowContext(ctx, SQL, shiftID).Scan)
+}
+
+func (repository *ShiftRepositoryImpl) FindByIDForUpdate(ctx context.Context, tx *sql.Tx, shiftID ulid.ULID) (domain.Shift, error) {
+	SQL := shiftColumns + " WHERE shift_id = ? FOR UPDATE"
+
+	repository.Logger.Info("---executing sql (lock shift)...")
+	return repository.scanShift(tx.QueryRowContext(ctx, SQL, shiftID).Scan)
+}
+
+func (repository *ShiftRepositoryImpl) FindOpenByUserID(ctx context.Context, tx *sql.Tx, userID ulid.ULID) (domain.Shift, error) {
+	SQL := shiftColumns + " WHERE user_id = ? AND status = ? FOR SHARE"
+
+	repository.Logger.Info("---executing sql (get open shift of user)...")
+	return repository.scanShift(tx.QueryRowContext(ctx, SQL, userID, domain.ShiftStatusOpen).Scan)
+}
+
+func (repository *ShiftRepositoryImpl) scanShift(scan func(dest ...any) error) (domain.Shift, error) {
+	shift := domain.Shift{}
+	err := scan(
+		&shift.ShiftID,
+		&shift.UserID,
+		&shift.LocationID,
+		&shift.RegisterID,
+		&shift.Status,
+		&shift.OpeningFloat,
+		&shift.Note,
+		&shift.OpenedAt,
+		&shift.ClosedAt,
+		&shift.Summary.SalesCount,
+		&shift.Summary.SalesAmount,
+		&shift.Summary.ReturnsCount,
+		&shift.Summary.ReturnsAmount,
+		&shift.Summary.VoidsCount,
+		&shift.Summary.VoidsAmount,
+		&shift.Summary.CashAmount,
+		&shift.Summary.CashRefunds,
+		&shift.ExpectedCash,
+		&shift.CountedCash,
+		&shift.OverShort,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			repository.Logger.Warn("---cannot found shift")
+		} else {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+		}
+		return domain.Shift{}, err
+	}
+
+	return shift, nil
+}
+
+func (repository *ShiftRepositoryImpl) Close(ctx context.Context, tx *sql.Tx, shift domain.Shift) error {
+	SQL := `
+        UPDATE Shifts SET status = ?, note = ?, closed_at = ?,
+            sales_count = ?, sales_amount = ?, returns_count = ?, returns_amount = ?, voids_count = ?, voids_amount = ?, cash_amount = ?, cash_refunds = ?,
+            expected_cash = ?, counted_cash = ?, over_short = ?
+        WHERE shift_id = ?
+    `
+
+	repository.Logger.Info("---executing sql (close shift)...")
+	_, err := tx.ExecContext(ctx, SQL,
+		shift.Status,
+		shift.Note,
+		shift.ClosedAt,
+		shift.Summary.SalesCount,
+		shift.Summary.SalesAmount,
+		shift.Summary.ReturnsCount,
+		shift.Summary.ReturnsAmount,
+		shift.Summary.VoidsCount,
+		shift.Summary.VoidsAmount,
+		shift.Summary.CashAmount,
+		shift.Summary.CashRefunds,
+		shift.ExpectedCash,
+		shift.CountedCash,
+		shift.OverShort,
+		shift.ShiftID,
+	)
+	if err != nil {
+		repository.Logger.Errorf("---failed to close shift: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *ShiftRepositoryImpl) Summarize(ctx context.Context, tx *sql.Tx, shift domain.Shift, until time.Time) (domain.ShiftSummary, error) {
+	salesSQL := `
+        SELECT
+            COUNT(DISTINCT t.transaction_id),
+            COALESCE(SUM(d.line_total), 0),
+            COUNT(DISTINCT CASE WHEN t.status = ? THEN t.transaction_id END),
+            ROUND(COALESCE(SUM(CASE WHEN t.status = ? THEN (d.quantity - COALESCE(r.returned_quantity, 0)) * d.line_total / d.quantity ELSE 0 END), 0), 2)
+        FROM Transactions t
+        JOIN Transaction_Details d ON t.transaction_id = d.transaction_id
+        LEFT JOIN (
+            SELECT detail_id, SUM(quantity) as returned_quantity
+            FROM Transaction_Return_Details
+            GROUP BY detail_id
+        ) r ON d.detail_id = r.detail_id
+        WHERE t.shift_id = ? AND t.status IN (?, ?)
+    `
+
+	summary := domain.ShiftSummary{}
+
+	repository.Logger.Info("---executing sql (summarize shift sales)...")
+	err := tx.QueryRowContext(ctx, salesSQL,
+		domain.TransactionStatusVoided,
+		domain.TransactionStatusVoided,
+		shift.ShiftID,
+		domain.TransactionStatusCompleted,
+		domain.TransactionStatusVoided,
+	).Scan(
+		&summary.SalesCount,
+		&summary.SalesAmount,
+		&summary.VoidsCount,
+		&summary.VoidsAmount,
+	)
+	if err != nil {
+		repository.Logger.Errorf("---failed to summarize shift sales: %v", err)
+		return domain.ShiftSummary{}, err
+	}
+
+	cashSQL := `
+        SELECT COALESCE(SUM(
+            CASE WHEN t.status = ? THEN p.cash_amount - LEAST(p.cash_amount, v.remaining_amount) ELSE p.cash_amount END
+        ), 0)
+        FROM Transactions t
+        JOIN (
+            SELECT transaction_id, SUM(amount) as cash_amount
+            FROM Transaction_Payments
+            WHERE method = ?
+            GROUP BY transaction_id
+        ) p ON t.transaction_id = p.transaction_id
+        JOIN (
+            SELECT d.transaction_id, ROUND(SUM((d.quantity - COALESCE(r.returned_quantity, 0)) * d.line_total / d.quantity), 2) as remaining_amount
+            FROM Transaction_Details d
+            JOIN Transactions st ON d.transaction_id = st.transaction_id
+            LEFT JOIN (
+                SELECT detail_id, SUM(quantity) as returned_quantity
+                FROM Transaction_Return_Details
+                GROUP BY detail_id
+            ) r ON d.detail_id = r.detail_id
+            WHERE st.shift_id = ?
+            GROUP BY d.transaction_id
+        ) v ON t.transaction_id = v.transaction_id
+        WHERE t.shift_id = ? AND t.status IN (?, ?)
+    `
+
+	repository.Logger.Info("---executing sql (summarize shift cash)...")
+	err = tx.QueryRowContext(ctx, cashSQL,
+		domain.TransactionStatusVoided,
+		domain.PaymentMethodCash,
+		shift.ShiftID,
+		shift.ShiftID,
+		domain.TransactionStatusCompleted,
+		domain.TransactionStatusVoided,
+	).Scan(&summary.CashAmount)
+	if err != nil {
+		repository.Logger.Errorf("---failed to summarize shift cash: %v", err)
+		return domain.ShiftSummary{}, err
+	}
+
+	returnsSQL := `
+        SELECT
+            COUNT(DISTINCT tr.return_id),
+            COALESCE(SUM(rd.quantity * rd.price), 0),
+            COALESCE(SUM(CASE WHEN tr.refund_method = ? THEN rd.quantity * rd.price ELSE 0 END), 0)
+        FROM Transaction_Returns tr
+        JOIN Transaction_Return_Details rd ON tr.return_id = rd.return_id
+        WHERE tr.shift_id = ? AND tr.created_at <= ?
+    `
+
+	repository.Logger.Info("---executing sql (summarize shift returns)...")
+	err = tx.QueryRowContext(ctx, returnsSQL, domain.RefundMethodCash, shift.ShiftID, until).Scan(
+		&summary.ReturnsCount,
+		&summary.ReturnsAmount,
+		&summary.CashRefunds,
+	)
+	if err != nil {
+		repository.Logger.Errorf("---failed to summarize shift returns: %v", err)
+		return domain.ShiftSummary{}, err
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/model/domain"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type StocktakeRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, session domain.StocktakeSession) (domain.StocktakeSession, error)
+	SaveItems(ctx context.Context, tx *sql.Tx, items []domain.StocktakeItem) ([]domain.StocktakeItem, error)
+	FindAll(ctx context.Context, tx *sql.Tx, status string, locationID *ulid.ULID) ([]domain.StocktakeSession, error)
+	FindByID(ctx context.Context, tx *sql.Tx, sessionID ulid.ULID) (domain.StocktakeSession, error)
+	FindByIDForUpdate(ctx context.Context, tx *sql.Tx, sessionID ulid.ULID) (domain.StocktakeSession, error)
+	FindItems(ctx context.Context, tx *sql.Tx, sessionID ulid.ULID) ([]domain.StocktakeItem, error)
+	SaveCounts(ctx context.Context, tx *sql.Tx, counts []domain.StocktakeCount) error
+	FindCounts(ctx context.Context, tx *sql.Tx, sessionID ulid.ULID) ([]domain.StocktakeCount, error)
+	UpdateStatus(ctx context.Context, tx *sql.Tx, session domain.StocktakeSession) error
+}
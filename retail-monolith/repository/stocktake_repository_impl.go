@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/model/domain"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type StocktakeRepositoryImpl struct {
+	Logger *logrus.Logger
+}
+
+func NewStocktakeRepository(logger *logrus.Logger) StocktakeRepository {
+	return &StocktakeRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+const stocktakeColumns = "SELECT session_id, location_id, category_id, blind, status, note, created_by, created_at, approved_by, approved_at FROM Stocktake_Sessions"
+
+func (repository *StocktakeRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, session domain.StocktakeSession) (domain.StocktakeSession, error) {
+	SQL := "INSERT INTO Stocktake_Sessions(session_id, location_id, category_id, blind, status, note, created_by, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+
+	repository.Logger.Info("---executing sql (save stocktake session)...")
+	_, err := tx.ExecContext(ctx, SQL,
+		session.SessionID,
+		session.LocationID,
+		session.CategoryID,
+		session.Blind,
+		session.Status,
+		session.Note,
+		session.CreatedBy,
+		session.CreatedAt,
+	)
+	if err != nil {
+		repository.Logger.Errorf("---failed to save stocktake session: %v", err)
+		return domain.StocktakeSession{}, err
+	}
+
+	repository.Logger.Info("---success, returning back to service layer")
+	return session, nil
+}
+
+func (repository *StocktakeRepositoryImpl) SaveItems(ctx context.Context, tx *sql.Tx, items []domain.StocktakeItem) ([]domain.StocktakeItem, error) {
+	if len(items) == 0 {
+		return []domain.StocktakeItem{}, nil
+	}
+
+	SQL := "INSERT INTO Stocktake_Items(session_id, product_id, expected_quantity) VALUES "
+
+	var args []interface{}
+	for _, item := range items {
+		SQL += "(?, ?, ?),"
+		args = append(args, item.SessionID, item.ProductID, item.ExpectedQuantity)
+	}
+
+	SQL = SQL[0 : len(SQL)-1]
+
+	repository.Logger.Info("---executing sql (save stocktake items)...")
+	_, err := tx.ExecContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to save stocktake items: %v", err)
+		return []domain.StocktakeItem{}, err
+	}
+
+	return items, nil
+}
+
+func (repository *StocktakeRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, status string, locationID *ulid.ULID) ([]domain.StocktakeSession, error) {
+	SQL := stocktakeColumns + " WHERE 1 = 1"
+
+	var args []interface{}
+	if status != "" {
+		SQL += " AND status = ?"
+		args = append(args, status)
+	}
+	if locationID != nil {
+		SQL += " AND location_id = ?"
+		args = append(args, *locationID)
+	}
+	SQL += " ORDER BY created_at DESC"
+
+	repository.Logger.Info("---executing sql (get all stocktake sessions)...")
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get all stocktake sessions: %v", err)
+		return []domain.StocktakeSession{}, err
+	}
+	defer rows.Close()
+
+	sessions := make([]domain.StocktakeSession, 0)
+	for rows.Next() {
+		session := domain.StocktakeSession{}
+		err := rows.Scan(
+			&session.SessionID,
+			&session.LocationID,
+			&session.CategoryID,
+			&session.Blind,
+			&session.Status,
+			&session.Note,
+			&session.CreatedBy,
+			&session.CreatedAt,
+			&session.ApprovedBy,
+			&session.ApprovedAt,
+		)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.StocktakeSession{}, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, rows.Err()
+}
+
+func (repository *StocktakeRepositoryImpl) FindByID(ctx context.Context, tx *sql.Tx, sessionID ulid.ULID) (domain.StocktakeSession, error) {
+	SQL := stocktakeColumns + " WHERE session_id = ?"
+
+	repository.Logger.Info("---executing sql (get stocktake session by id)...")
+	return repository.scanSession(tx.QueryRowContext(ctx, SQL, sessionID))
+}
+
+func (repository *StocktakeRepositoryImpl) FindByIDForUpdate(ctx context.Context, tx *sql.Tx, sessionID ulid.ULID) (domain.StocktakeSession, error) {
+	SQL := stocktakeColumns + " WHERE session_id = ? FOR UPDATE"
+
+	repository.Logger.Info("---executing sql (lock stocktake session)...")
+	return repository.scanSession(tx.QueryRowContext(ctx, SQL, sessionID))
+}
+
+func (repository *StocktakeRepositoryImpl) scanSession(row *sql.Row) (domain.StocktakeSession, error) {
+	session := domain.StocktakeSession{}
+	err := row.Scan(
+		&session.SessionID,
+		&session.LocationID,
+		&session.CategoryID,
+		&session.Blind,
+		&session.Status,
+		&session.Note,
+		&session.CreatedBy,
+		&session.CreatedAt,
+		&session.ApprovedBy,
+		&session.ApprovedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			repository.Logger.Warn("---cannot found session_id")
+		} else {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+		}
+		return domain.StocktakeSession{}, err
+	}
+
+	return session, nil
+}
+
+func (repository *StocktakeRepositoryImpl) FindItems(ctx context.Context, tx *sql.Tx, sessionID ulid.ULID) ([]domain.StocktakeItem, error) {
+	SQL := `
+        SELECT i.session_id, i.product_id, p.product_name, p.purchase_price, i.expected_quantity
+        FROM Stocktake_Items i
+        JOIN Products p ON i.product_id = p.product_id
+        WHERE i.session_id = ?
+        ORDER BY p.product_name
+    `
+
+	repository.Logger.Info("---executing sql (get stocktake items)...")
+	rows, err := tx.QueryContext(ctx, SQL, sessionID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get stocktake items: %v", err)
+		return []domain.StocktakeItem{}, err
+	}
+	defer rows.Close()
+
+	items := make([]domain.StocktakeItem, 0)
+	for rows.Next() {
+		item := domain.StocktakeItem{}
+		err := rows.Scan(&item.SessionID, &item.ProductID, &item.ProductName, &item.PurchasePrice, &item.ExpectedQuantity)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.StocktakeItem{}, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (repository *StocktakeRepositoryImpl) SaveCounts(ctx context.Context, tx *sql.Tx, counts []domain.StocktakeCount) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	SQL := "INSERT INTO Stocktake_Counts(session_id, product_id, user_id, counted_quantity, counted_at) VALUES "
+
+	var args []interface{}
+	for _, count := range counts {
+		SQL += "(?, ?, ?, ?, ?),"
+		args = append(args, count.SessionID, count.ProductID, count.UserID, count.CountedQuantity, count.CountedAt)
+	}
+
+	SQL = SQL[0:len(SQL)-1] + " ON DUPLICATE KEY UPDATE counted_quantity = VALUES(counted_quantity), counted_at = VALUES(counted_at)"
+
+	repository.Logger.Info("---executing sql (save stocktake counts)...")
+	_, err := tx.ExecContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to save stocktake counts: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *StocktakeRepositoryImpl) FindCounts(ctx context.Context, tx *sql.Tx, sessionID ulid.ULID) ([]domain.StocktakeCount, error) {
+	SQL := "SELECT session_id, product_id, user_id, counted_quantity, counted_at FROM Stocktake_Counts WHERE session_id = ? ORDER BY counted_at"
+
+	repository.Logger.Info("---executing sql (get stocktake counts)...")
+	rows, err := tx.QueryContext(ctx, SQL, sessionID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get stocktake counts: %v", err)
+		return []domain.StocktakeCount{}, err
+	}
+	defer rows.Close()
+
+	counts := make([]domain.StocktakeCount, 0)
+	for rows.Next() {
+		count := domain.StocktakeCount{}
+		err := rows.Scan(&count.SessionID, &count.ProductID, &count.UserID, &count.CountedQuantity, &count.CountedAt)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.StocktakeCount{}, err
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, rows.Err()
+}
+
+func (repository *StocktakeRepositoryImpl) UpdateStatus(ctx context.Context, tx *sql.Tx, session domain.StocktakeSession) error {
+	SQL := "UPDATE Stocktake_Sessions SET status = ?, approved_by = ?, approved_at = ? WHERE session_id = ?"
+
+	repository.Logger.Info("---executing sql (update stocktake session status)...")
+	_, err := tx.ExecContext(ctx, SQL, session.Status, session.ApprovedBy, session.ApprovedAt, session.SessionID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to update stocktake session status: %v", err)
+		return err
+	}
+
+	return nil
+}
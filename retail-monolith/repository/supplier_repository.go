@@ -11,6 +11,7 @@ import (
 type SupplierRepository interface {
 	Save(ctx context.Context, tx *sql.Tx, supplier domain.Supplier) (domain.Supplier, error)
 	FindAll(ctx context.Context, tx *sql.Tx) ([]domain.Supplier, error)
+	FindByID(ctx context.Context, tx *sql.Tx, supplierID ulid.ULID) (domain.Supplier, error)
 	Update(ctx context.Context, tx *sql.Tx, supplier domain.Supplier) (domain.Supplier, error)
 	Delete(ctx context.Context, tx *sql.Tx, supplierID ulid.ULID) error
 }
@@ -67,6 +67,26 @@ func (repository *SupplierRepositoryImpl) FindAll(ctx context.Context, tx *sql.T
 	return suppliers, nil
 }
 
+func (repository *SupplierRepositoryImpl) FindByID(ctx context.Context, tx *sql.Tx, supplierID ulid.ULID) (domain.Supplier, error) {
+	SQL := "SELECT supplier_id, supplier_name, phone_number, email FROM Suppliers WHERE supplier_id = ?"
+
+	supplier := domain.Supplier{}
+
+	repository.Logger.Info("---executing sql (get supplier by id)...")
+	err := tx.QueryRowContext(ctx, SQL, supplierID).Scan(
+		&supplier.SupplierID,
+		&supplier.SupplierName,
+		&supplier.PhoneNumber,
+		&supplier.Email,
+	)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get supplier: %v", err)
+		return domain.Supplier{}, err
+	}
+
+	return supplier, nil
+}
+
 func (repository *SupplierRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, supplier domain.Supplier) (domain.Supplier, error) {
 	SQL := "UPDATE Suppliers SET supplier_name = ?, phone_number = ?, email = ? WHERE supplier_id = ?"
 
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/model/domain"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type TaxRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, taxRate domain.TaxRate) (domain.TaxRate, error)
+	Update(ctx context.Context, tx *sql.Tx, taxRate domain.TaxRate) (domain.TaxRate, error)
+	Delete(ctx context.Context, tx *sql.Tx, taxRateID ulid.ULID) error
+	FindAll(ctx context.Context, tx *sql.Tx) ([]domain.TaxRate, error)
+	FindByID(ctx context.Context, tx *sql.Tx, taxRateID ulid.ULID) (domain.TaxRate, error)
+	AssignToCategory(ctx context.Context, tx *sql.Tx, categoryID ulid.ULID, taxRateID *ulid.ULID) error
+	AssignToProduct(ctx context.Context, tx *sql.Tx, productID ulid.ULID, taxRateID *ulid.ULID) error
+	FindRatesByProductIDs(ctx context.Context, tx *sql.Tx, productIDs []ulid.ULID) (map[ulid.ULID]domain.TaxRate, error)
+}
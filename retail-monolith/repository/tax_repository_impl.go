@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"retail-management/exception"
+	"retail-management/model/domain"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type TaxRepositoryImpl struct {
+	Logger *logrus.Logger
+}
+
+func NewTaxRepository(logger *logrus.Logger) TaxRepository {
+	return &TaxRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+func (repository *TaxRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, taxRate domain.TaxRate) (domain.TaxRate, error) {
+	SQL := "INSERT INTO Tax_Rates(tax_rate_id, tax_name, rate, created_at) VALUES (?, ?, ?, ?)"
+
+	repository.Logger.Info("---executing sql (insert new tax rate)...")
+	_, err := tx.ExecContext(ctx, SQL, taxRate.TaxRateID, taxRate.TaxName, taxRate.Rate, taxRate.CreatedAt)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			repository.Logger.Warnf("---tax rate name already exists: %s", taxRate.TaxName)
+			return domain.TaxRate{}, exception.ErrTaxRateExists
+		}
+		repository.Logger.Errorf("---failed to insert new tax rate: %v", err)
+		return domain.TaxRate{}, err
+	}
+
+	repository.Logger.Info("---successfully insert new tax rate, returning back to service layer...")
+	return taxRate, nil
+}
+
+func (repository *TaxRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, taxRate domain.TaxRate) (domain.TaxRate, error) {
+	SQL := "UPDATE Tax_Rates SET tax_name = ?, rate = ? WHERE tax_rate_id = ?"
+
+	repository.Logger.Info("---executing sql (update a tax rate)...")
+	_, err := tx.ExecContext(ctx, SQL, taxRate.TaxName, taxRate.Rate, taxRate.TaxRateID)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			repository.Logger.Warnf("---tax rate name already exists: %s", taxRate.TaxName)
+			return domain.TaxRate{}, exception.ErrTaxRateExists
+		}
+		repository.Logger.Errorf("---failed to update tax rate: %v", err)
+		return domain.TaxRate{}, err
+	}
+
+	return taxRate, nil
+}
+
+func (repository *TaxRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, taxRateID ulid.ULID) error {
+	SQL := "DELETE FROM Tax_Rates WHERE tax_rate_id = ?"
+
+	repository.Logger.Info("---executing sql (delete a tax rate)...")
+	result, err := tx.ExecContext(ctx, SQL, taxRateID)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1451 {
+			repository.Logger.Warnf("---tax rate %s has taxed sales", taxRateID)
+			return exception.ErrTaxRateInUse
+		}
+		repository.Logger.Errorf("---failed to delete a tax rate: %v", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		repository.Logger.Errorf("---failed to check rows affected: %v", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		repository.Logger.Warnf("---failed to delete, tax rate not found: %v", taxRateID)
+		return exception.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repository *TaxRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) ([]domain.TaxRate, error) {
+	SQL := "SELECT tax_rate_id, tax_name, rate, created_at FROM Tax_Rates ORDER BY tax_name"
+
+	repository.Logger.Info("---executing sql (get all tax rates)...")
+	rows, err := tx.QueryContext(ctx, SQL)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get all tax rates: %v", err)
+		return []domain.TaxRate{}, err
+	}
+	defer rows.Close()
+
+	taxRates := make([]domain.TaxRate, 0)
+	for rows.Next() {
+		taxRate := domain.TaxRate{}
+		err := rows.Scan(&taxRate.TaxRateID, &taxRate.TaxName, &taxRate.Rate, &taxRate.CreatedAt)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.TaxRate{}, err
+		}
+		taxRates = append(taxRates, taxRate)
+	}
+
+	return taxRates, rows.Err()
+}
+
+func (repository *TaxRepositoryImpl) FindByID(ctx context.Context, tx *sql.Tx, taxRateID ulid.ULID) (domain.TaxRate, error) {
+	SQL := "SELECT tax_rate_id, tax_name, rate, created_at FROM Tax_Rates WHERE tax_rate_id = ?"
+
+	taxRate := domain.TaxRate{}
+
+	repository.Logger.Info("---executing sql (get tax rate by id)...")
+	err := tx.QueryRowContext(ctx, SQL, taxRateID).Scan(&taxRate.TaxRateID, &taxRate.TaxName, &taxRate.Rate, &taxRate.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			repository.Logger.Warnf("---cannot found tax_rate_id: %v", taxRateID)
+		} else {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+		}
+		return domain.TaxRate{}, err
+	}
+
+	return taxRate, nil
+}
+
+func (repository *TaxRepositoryImpl) AssignToCategory(ctx context.Context, tx *sql.Tx, categoryID ulid.ULID, taxRateID *ulid.ULID) error {
+	repository.Logger.Info("---executing sql (assign tax rate to category)...")
+	return repository.assign(ctx, tx, "Categories", "category_id", categoryID, taxRateID)
+}
+
+func (repository *TaxRepositoryImpl) AssignToProduct(ctx context.Context, tx *sql.Tx, productID ulid.ULID, taxRateID *ulid.ULID) error {
+	repository.Logger.Info("---executing sql (assign tax rate to product)...")
+	return repository.assign(ctx, tx, "Products", "product_id", productID, taxRateID)
+}
+
+func (repository *TaxRepositoryImpl) assign(ctx context.Context, tx *sql.Tx, table string, idColumn string, id ulid.ULID, taxRateID *ulid.ULID) error {
+	SQL := "UPDATE " + table + " SET tax_rate_id = ? WHERE " + idColumn + " = ?"
+
+	result, err := tx.ExecContext(ctx, SQL, taxRateID, id)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1452 {
+			repository.Logger.Warnf("---cannot found tax_rate_id: %v", *taxRateID)
+			return exception.ErrNotFound
+		}
+		repository.Logger.Errorf("---failed to assign tax rate: %v", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		repository.Logger.Errorf("---failed to check rows affected: %v", err)
+		return err
+	}
+	if rowsAffected > 0 {
+		return nil
+	}
+
+	// an unchanged row also reports zero affected rows
+	var exists bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM "+table+" WHERE "+idColumn+" = ?)", id).Scan(&exists)
+	if err != nil {
+		repository.Logger.Errorf("---failed to check %s: %v", idColumn, err)
+		return err
+	}
+	if !exists {
+		repository.Logger.Warnf("---cannot found %s: %v", idColumn, id)
+		return exception.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repository *TaxRepositoryImpl) FindRatesByProductIDs(ctx context.Context, tx *sql.Tx, productIDs []ulid.ULID) (map[ulid.ULID]domain.TaxRate, error) {
+	taxRates := make(map[ulid.ULID]domain.TaxRate)
+	if len(productIDs) == 0 {
+		return taxRates, nil
+	}
+
+	SQL := `
+        SELECT p.product_id, tr.tax_rate_id, tr.tax_name, tr.rate, tr.created_at
+        FROM Products p
+        JOIN Categories c ON p.category_id = c.category_id
+        LEFT JOIN Products parent ON p.parent_product_id = parent.product_id
+        JOIN Tax_Rates tr ON tr.tax_rate_id = COALESCE(p.tax_rate_id, parent.tax_rate_id, c.tax_rate_id)
+        WHERE p.product_id IN (?` + strings.Repeat(", ?", len(productIDs)-1) + `)`
+
+	var args []interface{}
+	for _, productID := range productIDs {
+		args = append(args, productID)
+	}
+
+	repository.Logger.Info("---executing sql (get tax rates of products)...")
+	rows, err := tx.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get tax rates of products: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var productID ulid.ULID
+		taxRate := domain.TaxRate{}
+		err := rows.Scan(&productID, &taxRate.TaxRateID, &taxRate.TaxName, &taxRate.Rate, &taxRate.CreatedAt)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return nil, err
+		}
+		taxRates[productID] = taxRate
+	}
+
+	return taxRates, rows.Err()
+}
@@ -4,15 +4,34 @@ import (
 	"context"
 	"database/sql"
 	"retail-management/model/domain"
+	"time"
 
 	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
 )
 
 type TransactionRepository interface {
 	Save(ctx context.Context, tx *sql.Tx, transaction domain.Transaction) (domain.Transaction, error)
+	LockByID(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID) error
 	SaveDetails(ctx context.Context, tx *sql.Tx, transactionDetail []domain.TransactionDetail) ([]domain.TransactionDetail, error)
 	FindAll(ctx context.Context, tx *sql.Tx) ([]domain.TransactionWithTotal, error)
 	FindAllByUserID(ctx context.Context, tx *sql.Tx, userID ulid.ULID) ([]domain.TransactionWithTotal, error)
+	FindAllByCustomerID(ctx context.Context, tx *sql.Tx, customerID ulid.ULID) ([]domain.TransactionWithTotal, error)
 	FindByID(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID) (domain.TransactionWithTotal, error)
 	FindDetailsByTransactionID(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID) ([]domain.TransactionDetailWithProduct, error)
+	SaveDetailComponents(ctx context.Context, tx *sql.Tx, components []domain.TransactionDetailComponent) error
+	FindDetailComponents(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID) (map[ulid.ULID][]domain.TransactionDetailComponent, error)
+	UpdateDetailComponentCost(ctx context.Context, tx *sql.Tx, detailID ulid.ULID, productID ulid.ULID, unitCost decimal.Decimal) error
+	SaveDiscounts(ctx context.Context, tx *sql.Tx, discounts []domain.TransactionDiscount) error
+	FindDiscountsByTransactionID(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID) ([]domain.TransactionDiscount, error)
+	SavePayments(ctx context.Context, tx *sql.Tx, payments []domain.TransactionPayment) error
+	FindPaymentsByTransactionID(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID) ([]domain.TransactionPayment, error)
+	SaveReturn(ctx context.Context, tx *sql.Tx, transactionReturn domain.TransactionReturn) (domain.TransactionReturn, error)
+	SaveReturnDetails(ctx context.Context, tx *sql.Tx, returnDetails []domain.TransactionReturnDetail) ([]domain.TransactionReturnDetail, error)
+	Void(ctx context.Context, tx *sql.Tx, transactionVoid domain.TransactionVoid) error
+	UpdateStatus(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID, fromStatus string, toStatus string) error
+	SaveIdempotencyKey(ctx context.Context, tx *sql.Tx, idempotencyKey domain.IdempotencyKey) error
+	FindIdempotencyKey(ctx context.Context, tx *sql.Tx, userID ulid.ULID, key string) (domain.IdempotencyKey, error)
+	DeleteFailedIdempotencyKey(ctx context.Context, tx *sql.Tx, userID ulid.ULID, key string) error
+	FindSoldQuantities(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, since time.Time) (map[ulid.ULID]int, error)
 }
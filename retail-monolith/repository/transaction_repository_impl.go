@@ -3,9 +3,14 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"retail-management/exception"
 	"retail-management/model/domain"
+	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
 	"github.com/sirupsen/logrus"
 )
 
@@ -19,10 +24,10 @@ func NewTransactionRepository(logger *logrus.Logger) TransactionRepository {
 	}
 }
 func (repository *TransactionRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, transaction domain.Transaction) (domain.Transaction, error) {
-	SQL := "INSERT INTO Transactions(transaction_id, user_id) VALUES (?, ?)"
+	SQL := "INSERT INTO Transactions(transaction_id, user_id, status, location_id, register_id, shift_id, customer_id) VALUES (?, ?, ?, ?, ?, ?, ?)"
 
 	repository.Logger.Info("---executing sql (save transaction)...")
-	_, err := tx.ExecContext(ctx, SQL, transaction.TransactionID, transaction.UserID)
+	_, err := tx.ExecContext(ctx, SQL, transaction.TransactionID, transaction.UserID, transaction.Status, transaction.LocationID, transaction.RegisterID, transaction.ShiftID, transaction.CustomerID)
 	if err != nil {
 		repository.Logger.Errorf("---failed to execcontext: %v", err)
 		return domain.Transaction{}, err
@@ -31,17 +36,36 @@ func (repository *TransactionRepositoryImpl) Save(ctx context.Context, tx *sql.T
 	return transaction, nil
 }
 
+func (repository *TransactionRepositoryImpl) LockByID(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID) error {
+	SQL := "SELECT transaction_id FROM Transactions WHERE transaction_id = ? FOR UPDATE"
+
+	var lockedID ulid.ULID
+
+	repository.Logger.Info("---executing sql (lock transaction header)...")
+	err := tx.QueryRowContext(ctx, SQL, transactionID).Scan(&lockedID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			repository.Logger.Warnf("---cannot found transaction_id: %v", transactionID)
+		} else {
+			repository.Logger.Errorf("---failed to lock transaction: %v", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (repository *TransactionRepositoryImpl) SaveDetails(ctx context.Context, tx *sql.Tx, transactionDetail []domain.TransactionDetail) ([]domain.TransactionDetail, error) {
 	if len(transactionDetail) == 0 {
 		return []domain.TransactionDetail{}, nil
 	}
 
-	SQL := "INSERT INTO Transaction_Details (detail_id, transaction_id, product_id, quantity, price) VALUES "
+	SQL := "INSERT INTO Transaction_Details (detail_id, transaction_id, product_id, quantity, price, discount_amount, tax_rate_id, tax_rate, tax_amount, tax_inclusive) VALUES "
 
 	var args []interface{}
 
 	for _, item := range transactionDetail {
-		SQL += "(?, ?, ?, ?, ?),"
+		SQL += "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?),"
 
 		args = append(args,
 			item.DetailID,
@@ -49,6 +73,11 @@ func (repository *TransactionRepositoryImpl) SaveDetails(ctx context.Context, tx
 			item.ProductID,
 			item.Quantity,
 			item.Price,
+			item.Discount,
+			item.TaxRateID,
+			item.TaxRate,
+			item.TaxAmount,
+			item.TaxInclusive,
 		)
 	}
 
@@ -70,11 +99,28 @@ func (repository *TransactionRepositoryImpl) FindAll(ctx context.Context, tx *sq
         SELECT 
             t.transaction_id, 
             t.user_id, 
+            t.status,
+            t.location_id,
+            t.register_id,
+            t.customer_id,
             t.transaction_time,
-            COALESCE(SUM(d.quantity * d.price), 0) as total_amount
+            COALESCE(SUM(d.quantity * d.price), 0) as gross_amount,
+            COALESCE(SUM(d.discount_amount), 0) as discount_amount,
+            COALESCE(SUM(d.tax_amount), 0) as tax_amount,
+            COALESCE(SUM(d.line_total), 0) as total_amount,
+            COALESCE(SUM(r.returned_amount), 0) as returned_amount,
+            t.voided_at,
+            t.voided_by,
+            t.void_reason
         FROM Transactions t
         LEFT JOIN Transaction_Details d ON t.transaction_id = d.transaction_id
-        GROUP BY t.transaction_id, t.user_id, t.transaction_time
+        LEFT JOIN (
+            SELECT detail_id, SUM(quantity * price) as returned_amount
+            FROM Transaction_Return_Details
+            GROUP BY detail_id
+        ) r ON d.detail_id = r.detail_id
+        WHERE t.status IN ('completed', 'voided')
+        GROUP BY t.transaction_id, t.user_id, t.status, t.location_id, t.register_id, t.customer_id, t.transaction_time, t.voided_at, t.voided_by, t.void_reason
         ORDER BY t.transaction_time DESC
     `
 
@@ -94,8 +140,19 @@ func (repository *TransactionRepositoryImpl) FindAll(ctx context.Context, tx *sq
 		err := rows.Scan(
 			&trx.TransactionID,
 			&trx.UserID,
+			&trx.Status,
+			&trx.LocationID,
+			&trx.RegisterID,
+			&trx.CustomerID,
 			&trx.CreatedAt,
+			&trx.GrossAmount,
+			&trx.DiscountAmount,
+			&trx.TaxAmount,
 			&trx.TotalAmount,
+			&trx.ReturnedAmount,
+			&trx.VoidedAt,
+			&trx.VoidedBy,
+			&trx.VoidReason,
 		)
 		if err != nil {
 			repository.Logger.Errorf("---failed to scan row: %v", err)
@@ -113,12 +170,28 @@ func (repository *TransactionRepositoryImpl) FindAllByUserID(ctx context.Context
         SELECT 
             t.transaction_id, 
             t.user_id, 
+            t.status,
+            t.location_id,
+            t.register_id,
+            t.customer_id,
             t.transaction_time,
-            COALESCE(SUM(d.quantity * d.price), 0) as total_amount
+            COALESCE(SUM(d.quantity * d.price), 0) as gross_amount,
+            COALESCE(SUM(d.discount_amount), 0) as discount_amount,
+            COALESCE(SUM(d.tax_amount), 0) as tax_amount,
+            COALESCE(SUM(d.line_total), 0) as total_amount,
+            COALESCE(SUM(r.returned_amount), 0) as returned_amount,
+            t.voided_at,
+            t.voided_by,
+            t.void_reason
         FROM Transactions t
         LEFT JOIN Transaction_Details d ON t.transaction_id = d.transaction_id
-        WHERE t.user_id = ?
-        GROUP BY t.transaction_id, t.user_id, t.transaction_time
+        LEFT JOIN (
+            SELECT detail_id, SUM(quantity * price) as returned_amount
+            FROM Transaction_Return_Details
+            GROUP BY detail_id
+        ) r ON d.detail_id = r.detail_id
+        WHERE t.user_id = ? AND t.status IN ('completed', 'voided')
+        GROUP BY t.transaction_id, t.user_id, t.status, t.location_id, t.register_id, t.customer_id, t.transaction_time, t.voided_at, t.voided_by, t.void_reason
         ORDER BY t.transaction_time DESC
     `
 
@@ -138,8 +211,19 @@ func (repository *TransactionRepositoryImpl) FindAllByUserID(ctx context.Context
 		err := rows.Scan(
 			&trx.TransactionID,
 			&trx.UserID,
+			&trx.Status,
+			&trx.LocationID,
+			&trx.RegisterID,
+			&trx.CustomerID,
 			&trx.CreatedAt,
+			&trx.GrossAmount,
+			&trx.DiscountAmount,
+			&trx.TaxAmount,
 			&trx.TotalAmount,
+			&trx.ReturnedAmount,
+			&trx.VoidedAt,
+			&trx.VoidedBy,
+			&trx.VoidReason,
 		)
 		if err != nil {
 			repository.Logger.Errorf("---failed to scan row: %v", err)
@@ -152,17 +236,104 @@ func (repository *TransactionRepositoryImpl) FindAllByUserID(ctx context.Context
 	return transactions, nil
 }
 
+func (repository *TransactionRepositoryImpl) FindAllByCustomerID(ctx context.Context, tx *sql.Tx, customerID ulid.ULID) ([]domain.TransactionWithTotal, error) {
+	SQL := `
+        SELECT 
+            t.transaction_id, 
+            t.user_id, 
+            t.status,
+            t.location_id,
+            t.register_id,
+            t.customer_id,
+            t.transaction_time,
+            COALESCE(SUM(d.quantity * d.price), 0) as gross_amount,
+            COALESCE(SUM(d.discount_amount), 0) as discount_amount,
+            COALESCE(SUM(d.tax_amount), 0) as tax_amount,
+            COALESCE(SUM(d.line_total), 0) as total_amount,
+            COALESCE(SUM(r.returned_amount), 0) as returned_amount,
+            t.voided_at,
+            t.voided_by,
+            t.void_reason
+        FROM Transactions t
+        LEFT JOIN Transaction_Details d ON t.transaction_id = d.transaction_id
+        LEFT JOIN (
+            SELECT detail_id, SUM(quantity * price) as returned_amount
+            FROM Transaction_Return_Details
+            GROUP BY detail_id
+        ) r ON d.detail_id = r.detail_id
+        WHERE t.customer_id = ? AND t.status IN ('completed', 'voided')
+        GROUP BY t.transaction_id, t.user_id, t.status, t.location_id, t.register_id, t.customer_id, t.transaction_time, t.voided_at, t.voided_by, t.void_reason
+        ORDER BY t.transaction_time DESC
+    `
+
+	repository.Logger.Info("---executing sql (get transactions by customer_id)...")
+	rows, err := tx.QueryContext(ctx, SQL, customerID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get transactions by customer: %v", err)
+		return []domain.TransactionWithTotal{}, err
+	}
+	defer rows.Close()
+
+	transactions := make([]domain.TransactionWithTotal, 0)
+
+	repository.Logger.Info("---checking rows.Next()...")
+	for rows.Next() {
+		trx := domain.TransactionWithTotal{}
+		err := rows.Scan(
+			&trx.TransactionID,
+			&trx.UserID,
+			&trx.Status,
+			&trx.LocationID,
+			&trx.RegisterID,
+			&trx.CustomerID,
+			&trx.CreatedAt,
+			&trx.GrossAmount,
+			&trx.DiscountAmount,
+			&trx.TaxAmount,
+			&trx.TotalAmount,
+			&trx.ReturnedAmount,
+			&trx.VoidedAt,
+			&trx.VoidedBy,
+			&trx.VoidReason,
+		)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.TransactionWithTotal{}, err
+		}
+		transactions = append(transactions, trx)
+	}
+
+	repository.Logger.Info("---successfully get transactions by customer, returning back to service layer...")
+	return transactions, nil
+}
+
 func (repository *TransactionRepositoryImpl) FindByID(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID) (domain.TransactionWithTotal, error) {
 	SQL := `
         SELECT 
             t.transaction_id, 
             t.user_id, 
+            t.status,
+            t.location_id,
+            t.register_id,
+            t.customer_id,
             t.transaction_time,
-            COALESCE(SUM(d.quantity * d.price), 0) as total_amount
+            COALESCE(SUM(d.quantity * d.price), 0) as gross_amount,
+            COALESCE(SUM(d.discount_amount), 0) as discount_amount,
+            COALESCE(SUM(d.tax_amount), 0) as tax_amount,
+            COALESCE(SUM(d.line_total), 0) as total_amount,
+            COALESCE(SUM(r.returned_amount), 0) as returned_amount,
+            t.voided_at,
+            t.voided_by,
+            t.void_reason
         FROM Transactions t
         LEFT JOIN Transaction_Details d ON t.transaction_id = d.transaction_id
+        LEFT JOIN (
+            SELECT detail_id, SUM(quantity * price) as returned_amount
+            FROM Transaction_Return_Details
+            GROUP BY detail_id
+        ) r ON d.detail_id = r.detail_id
         WHERE t.transaction_id = ?
-        GROUP BY t.transaction_id, t.user_id, t.transaction_time
+        GROUP BY t.transaction_id, t.user_id, t.status, t.location_id, t.register_id, t.customer_id, t.transaction_time, t.voided_at, t.voided_by, t.void_reason
     `
 
 	var trx domain.TransactionWithTotal
@@ -171,8 +342,19 @@ func (repository *TransactionRepositoryImpl) FindByID(ctx context.Context, tx *s
 	err := tx.QueryRowContext(ctx, SQL, transactionID).Scan(
 		&trx.TransactionID,
 		&trx.UserID,
+		&trx.Status,
+		&trx.LocationID,
+		&trx.RegisterID,
+		&trx.CustomerID,
 		&trx.CreatedAt,
+		&trx.GrossAmount,
+		&trx.DiscountAmount,
+		&trx.TaxAmount,
 		&trx.TotalAmount,
+		&trx.ReturnedAmount,
+		&trx.VoidedAt,
+		&trx.VoidedBy,
+		&trx.VoidReason,
 	)
 
 	if err != nil {
@@ -195,10 +377,24 @@ func (repository *TransactionRepositoryImpl) FindDetailsByTransactionID(ctx cont
             d.product_id,
             p.product_name,
             d.quantity,
+            COALESCE(r.returned_quantity, 0) as returned_quantity,
             d.price,
-            (d.quantity * d.price) as sub_total
+            (d.quantity * d.price) as sub_total,
+            d.discount_amount,
+            d.tax_rate,
+            d.tax_amount,
+            d.tax_inclusive,
+            d.line_total,
+            COALESCE(c.unit_cost, p.purchase_price) as unit_cost,
+            c.cost_amount
         FROM Transaction_Details d
         JOIN Products p ON d.product_id = p.product_id
+        LEFT JOIN Transaction_Detail_Costs c ON d.detail_id = c.detail_id
+        LEFT JOIN (
+            SELECT detail_id, SUM(quantity) as returned_quantity
+            FROM Transaction_Return_Details
+            GROUP BY detail_id
+        ) r ON d.detail_id = r.detail_id
         WHERE d.transaction_id = ?
     `
 
@@ -221,8 +417,16 @@ func (repository *TransactionRepositoryImpl) FindDetailsByTransactionID(ctx cont
 			&item.ProductID,
 			&item.ProductName,
 			&item.Quantity,
+			&item.ReturnedQuantity,
 			&item.PriceAtSale,
 			&item.SubTotal,
+			&item.DiscountAmount,
+			&item.TaxRate,
+			&item.TaxAmount,
+			&item.TaxInclusive,
+			&item.LineTotal,
+			&item.UnitCost,
+			&item.CostOfGoodsSold,
 		)
 		if err != nil {
 			repository.Logger.Errorf("---failed to scan row: %v", err)
@@ -234,3 +438,444 @@ func (repository *TransactionRepositoryImpl) FindDetailsByTransactionID(ctx cont
 	repository.Logger.Info("---successfully get details, returning back to service layer...")
 	return details, nil
 }
+
+func (repository *TransactionRepositoryImpl) SaveDetailComponents(ctx context.Context, tx *sql.Tx, components []domain.TransactionDetailComponent) error {
+	if len(components) == 0 {
+		return nil
+	}
+
+	SQL := "INSERT INTO Transaction_Detail_Components (detail_id, product_id, quantity) VALUES "
+
+	var args []interface{}
+
+	for _, component := range components {
+		SQL += "(?, ?, ?),"
+
+		args = append(args,
+			component.DetailID,
+			component.ProductID,
+			component.Quantity,
+		)
+	}
+
+	SQL = SQL[0 : len(SQL)-1]
+
+	repository.Logger.Info("---executing sql (save detail components)...")
+	_, err := tx.ExecContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to save detail components: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *TransactionRepositoryImpl) FindDetailComponents(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID) (map[ulid.ULID][]domain.TransactionDetailComponent, error) {
+	SQL := `
+        SELECT c.detail_id, c.product_id, c.quantity, COALESCE(c.unit_cost, p.purchase_price) as unit_cost
+        FROM Transaction_Detail_Components c
+        JOIN Transaction_Details d ON c.detail_id = d.detail_id
+        JOIN Products p ON c.product_id = p.product_id
+        WHERE d.transaction_id = ?
+        ORDER BY c.detail_id
+    `
+
+	repository.Logger.Info("---executing sql (get detail components)...")
+	rows, err := tx.QueryContext(ctx, SQL, transactionID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get detail components: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	components := make(map[ulid.ULID][]domain.TransactionDetailComponent)
+	for rows.Next() {
+		component := domain.TransactionDetailComponent{}
+		err := rows.Scan(&component.DetailID, &component.ProductID, &component.Quantity, &component.UnitCost)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return nil, err
+		}
+		components[component.DetailID] = append(components[component.DetailID], component)
+	}
+
+	return components, rows.Err()
+}
+
+func (repository *TransactionRepositoryImpl) UpdateDetailComponentCost(ctx context.Context, tx *sql.Tx, detailID ulid.ULID, productID ulid.ULID, unitCost decimal.Decimal) error {
+	SQL := "UPDATE Transaction_Detail_Components SET unit_cost = ? WHERE detail_id = ? AND product_id = ?"
+
+	repository.Logger.Info("---executing sql (update detail component cost)...")
+	_, err := tx.ExecContext(ctx, SQL, unitCost, detailID, productID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to update detail component cost: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *TransactionRepositoryImpl) SaveDiscounts(ctx context.Context, tx *sql.Tx, discounts []domain.TransactionDiscount) error {
+	if len(discounts) == 0 {
+		return nil
+	}
+
+	SQL := "INSERT INTO Transaction_Discounts (discount_id, transaction_id, detail_id, promotion_id, amount) VALUES "
+
+	var args []interface{}
+
+	for _, discount := range discounts {
+		SQL += "(?, ?, ?, ?, ?),"
+
+		args = append(args,
+			discount.DiscountID,
+			discount.TransactionID,
+			discount.DetailID,
+			discount.PromotionID,
+			discount.Amount,
+		)
+	}
+
+	SQL = SQL[0 : len(SQL)-1]
+
+	repository.Logger.Info("---executing sql (save discounts)...")
+	_, err := tx.ExecContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to save discounts: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *TransactionRepositoryImpl) FindDiscountsByTransactionID(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID) ([]domain.TransactionDiscount, error) {
+	SQL := `
+        SELECT td.discount_id, td.transaction_id, td.detail_id, td.promotion_id, p.promotion_name, td.amount
+        FROM Transaction_Discounts td
+        JOIN Promotions p ON td.promotion_id = p.promotion_id
+        WHERE td.transaction_id = ?
+        ORDER BY td.discount_id
+    `
+
+	repository.Logger.Info("---executing sql (get transaction discounts)...")
+	rows, err := tx.QueryContext(ctx, SQL, transactionID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get transaction discounts: %v", err)
+		return []domain.TransactionDiscount{}, err
+	}
+	defer rows.Close()
+
+	discounts := make([]domain.TransactionDiscount, 0)
+	for rows.Next() {
+		discount := domain.TransactionDiscount{}
+		err := rows.Scan(
+			&discount.DiscountID,
+			&discount.TransactionID,
+			&discount.DetailID,
+			&discount.PromotionID,
+			&discount.PromotionName,
+			&discount.Amount,
+		)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.TransactionDiscount{}, err
+		}
+		discounts = append(discounts, discount)
+	}
+
+	return discounts, rows.Err()
+}
+
+func (repository *TransactionRepositoryImpl) SavePayments(ctx context.Context, tx *sql.Tx, payments []domain.TransactionPayment) error {
+	if len(payments) == 0 {
+		return nil
+	}
+
+	SQL := "INSERT INTO Transaction_Payments (payment_id, transaction_id, method, amount, amount_tendered, change_given, reference, gift_card_id) VALUES "
+
+	var args []interface{}
+
+	for _, payment := range payments {
+		SQL += "(?, ?, ?, ?, ?, ?, ?, ?),"
+
+		args = append(args,
+			payment.PaymentID,
+			payment.TransactionID,
+			payment.Method,
+			payment.Amount,
+			payment.AmountTendered,
+			payment.ChangeGiven,
+			payment.Reference,
+			payment.GiftCardID,
+		)
+	}
+
+	SQL = SQL[0 : len(SQL)-1]
+
+	repository.Logger.Info("---executing sql (save payments)...")
+	_, err := tx.ExecContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to save payments: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *TransactionRepositoryImpl) FindPaymentsByTransactionID(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID) ([]domain.TransactionPayment, error) {
+	SQL := `
+        SELECT payment_id, transaction_id, method, amount, amount_tendered, change_given, reference, gift_card_id
+        FROM Transaction_Payments
+        WHERE transaction_id = ?
+        ORDER BY payment_id
+    `
+
+	repository.Logger.Info("---executing sql (get transaction payments)...")
+	rows, err := tx.QueryContext(ctx, SQL, transactionID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get transaction payments: %v", err)
+		return []domain.TransactionPayment{}, err
+	}
+	defer rows.Close()
+
+	payments := make([]domain.TransactionPayment, 0)
+	for rows.Next() {
+		payment := domain.TransactionPayment{}
+		err := rows.Scan(
+			&payment.PaymentID,
+			&payment.TransactionID,
+			&payment.Method,
+			&payment.Amount,
+			&payment.AmountTendered,
+			&payment.ChangeGiven,
+			&payment.Reference,
+			&payment.GiftCardID,
+		)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.TransactionPayment{}, err
+		}
+		payments = append(payments, payment)
+	}
+
+	return payments, rows.Err()
+}
+
+func (repository *TransactionRepositoryImpl) SaveReturn(ctx context.Context, tx *sql.Tx, transactionReturn domain.TransactionReturn) (domain.TransactionReturn, error) {
+	SQL := "INSERT INTO Transaction_Returns(return_id, transaction_id, user_id, reason, refund_method, shift_id, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)"
+
+	repository.Logger.Info("---executing sql (save transaction return)...")
+	_, err := tx.ExecContext(
+		ctx, SQL,
+		transactionReturn.ReturnID,
+		transactionReturn.TransactionID,
+		transactionReturn.UserID,
+		transactionReturn.Reason,
+		transactionReturn.RefundMethod,
+		transactionReturn.ShiftID,
+		transactionReturn.CreatedAt,
+	)
+	if err != nil {
+		repository.Logger.Errorf("---failed to save transaction return: %v", err)
+		return domain.TransactionReturn{}, err
+	}
+
+	repository.Logger.Info("---success save transaction return, returning back to service layer...")
+	return transactionReturn, nil
+}
+
+func (repository *TransactionRepositoryImpl) SaveReturnDetails(ctx context.Context, tx *sql.Tx, returnDetails []domain.TransactionReturnDetail) ([]domain.TransactionReturnDetail, error) {
+	if len(returnDetails) == 0 {
+		return []domain.TransactionReturnDetail{}, nil
+	}
+
+	SQL := "INSERT INTO Transaction_Return_Details (return_detail_id, return_id, detail_id, quantity, price) VALUES "
+
+	var args []interface{}
+
+	for _, item := range returnDetails {
+		SQL += "(?, ?, ?, ?, ?),"
+
+		args = append(args,
+			item.ReturnDetailID,
+			item.ReturnID,
+			item.DetailID,
+			item.Quantity,
+			item.Price,
+		)
+	}
+
+	SQL = SQL[0 : len(SQL)-1]
+
+	repository.Logger.Info("---executing sql (save return details)...")
+	_, err := tx.ExecContext(ctx, SQL, args...)
+	if err != nil {
+		repository.Logger.Errorf("---failed to save return details: %v", err)
+		return []domain.TransactionReturnDetail{}, err
+	}
+
+	repository.Logger.Info("---success save return details, returning back to service layer...")
+	return returnDetails, nil
+}
+
+func (repository *TransactionRepositoryImpl) Void(ctx context.Context, tx *sql.Tx, transactionVoid domain.TransactionVoid) error {
+	SQL := "UPDATE Transactions SET status = ?, voided_at = ?, voided_by = ?, void_reason = ? WHERE transaction_id = ? AND status = ?"
+
+	repository.Logger.Info("---executing sql (void transaction)...")
+	result, err := tx.ExecContext(
+		ctx, SQL,
+		domain.TransactionStatusVoided,
+		transactionVoid.VoidedAt,
+		transactionVoid.VoidedBy,
+		transactionVoid.VoidReason,
+		transactionVoid.TransactionID,
+		domain.TransactionStatusCompleted,
+	)
+	if err != nil {
+		repository.Logger.Errorf("---failed to void transaction: %v", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		repository.Logger.Errorf("---failed to check rows affected: %v", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		repository.Logger.Warnf("---transaction %v is not in completed state", transactionVoid.TransactionID)
+		return exception.ErrTransactionVoided
+	}
+
+	repository.Logger.Info("---success void transaction, returning back to service layer...")
+	return nil
+}
+
+func (repository *TransactionRepositoryImpl) UpdateStatus(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID, fromStatus string, toStatus string) error {
+	SQL := "UPDATE Transactions SET status = ? WHERE transaction_id = ? AND status = ?"
+
+	repository.Logger.Info("---executing sql (update transaction status)...")
+	result, err := tx.ExecContext(ctx, SQL, toStatus, transactionID, fromStatus)
+	if err != nil {
+		repository.Logger.Errorf("---failed to update transaction status: %v", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		repository.Logger.Errorf("---failed to check rows affected: %v", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		repository.Logger.Warnf("---transaction %v is not in %s state", transactionID, fromStatus)
+		return exception.ErrTransactionStateChanged
+	}
+
+	return nil
+}
+
+func (repository *TransactionRepositoryImpl) SaveIdempotencyKey(ctx context.Context, tx *sql.Tx, idempotencyKey domain.IdempotencyKey) error {
+	SQL := "INSERT INTO Idempotency_Keys(idempotency_key, user_id, transaction_id, request_hash, created_at) VALUES (?, ?, ?, ?, ?)"
+
+	repository.Logger.Info("---executing sql (save idempotency key)...")
+	_, err := tx.ExecContext(
+		ctx, SQL,
+		idempotencyKey.IdempotencyKey,
+		idempotencyKey.UserID,
+		idempotencyKey.TransactionID,
+		idempotencyKey.RequestHash,
+		idempotencyKey.CreatedAt,
+	)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			repository.Logger.Warnf("---idempotency key already used: %s", idempotencyKey.IdempotencyKey)
+			return exception.ErrDuplicateIdempotencyKey
+		}
+		repository.Logger.Errorf("---failed to save idempotency key: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *TransactionRepositoryImpl) FindIdempotencyKey(ctx context.Context, tx *sql.Tx, userID ulid.ULID, key string) (domain.IdempotencyKey, error) {
+	SQL := "SELECT idempotency_key, user_id, transaction_id, request_hash, created_at FROM Idempotency_Keys WHERE user_id = ? AND idempotency_key = ?"
+
+	var idempotencyKey domain.IdempotencyKey
+
+	repository.Logger.Info("---executing sql (find idempotency key)...")
+	err := tx.QueryRowContext(ctx, SQL, userID, key).Scan(
+		&idempotencyKey.IdempotencyKey,
+		&idempotencyKey.UserID,
+		&idempotencyKey.TransactionID,
+		&idempotencyKey.RequestHash,
+		&idempotencyKey.CreatedAt,
+	)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			repository.Logger.Errorf("---failed to find idempotency key: %v", err)
+		}
+		return domain.IdempotencyKey{}, err
+	}
+
+	return idempotencyKey, nil
+}
+
+func (repository *TransactionRepositoryImpl) DeleteFailedIdempotencyKey(ctx context.Context, tx *sql.Tx, userID ulid.ULID, key string) error {
+	SQL := `
+        DELETE k FROM Idempotency_Keys k
+        JOIN Transactions t ON k.transaction_id = t.transaction_id
+        WHERE k.user_id = ? AND k.idempotency_key = ? AND t.status = ?
+    `
+
+	repository.Logger.Info("---executing sql (delete idempotency key)...")
+	_, err := tx.ExecContext(ctx, SQL, userID, key, domain.TransactionStatusFailed)
+	if err != nil {
+		repository.Logger.Errorf("---failed to delete idempotency key: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *TransactionRepositoryImpl) FindSoldQuantities(ctx context.Context, tx *sql.Tx, locationID ulid.ULID, since time.Time) (map[ulid.ULID]int, error) {
+	SQL := `
+        SELECT s.product_id, SUM(s.quantity)
+        FROM (
+            SELECT d.product_id, d.quantity
+            FROM Transaction_Details d
+            JOIN Transactions t ON d.transaction_id = t.transaction_id
+            WHERE t.location_id = ? AND t.status = 'completed' AND t.transaction_time >= ?
+            UNION ALL
+            SELECT c.product_id, c.quantity * d.quantity
+            FROM Transaction_Detail_Components c
+            JOIN Transaction_Details d ON c.detail_id = d.detail_id
+            JOIN Transactions t ON d.transaction_id = t.transaction_id
+            WHERE t.location_id = ? AND t.status = 'completed' AND t.transaction_time >= ?
+        ) s
+        GROUP BY s.product_id
+    `
+
+	repository.Logger.Info("---executing sql (get sold quantities)...")
+	rows, err := tx.QueryContext(ctx, SQL, locationID, since, locationID, since)
+	if err != nil {
+		repository.Logger.Errorf("---failed to get sold quantities: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	quantities := make(map[ulid.ULID]int)
+	for rows.Next() {
+		var productID ulid.ULID
+		var quantity int
+		if err := rows.Scan(&productID, &quantity); err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return nil, err
+		}
+		quantities[productID] = quantity
+	}
+
+	return quantities, rows.Err()
+}
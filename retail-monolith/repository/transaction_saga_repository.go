@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/model/domain"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type TransactionSagaRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, saga domain.TransactionSaga) error
+	UpdateStatus(ctx context.Context, tx *sql.Tx, sagaID ulid.ULID, fromStatus string, toStatus string, lastError *string) error
+	RecordFailure(ctx context.Context, tx *sql.Tx, sagaID ulid.ULID, lastError string) error
+	FindRecoverable(ctx context.Context, tx *sql.Tx, pendingBefore time.Time, limit int) ([]domain.TransactionSaga, error)
+	FindByIDForUpdate(ctx context.Context, tx *sql.Tx, sagaID ulid.ULID) (domain.TransactionSaga, error)
+}
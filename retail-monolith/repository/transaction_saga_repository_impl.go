@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/exception"
+	"retail-management/model/domain"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type TransactionSagaRepositoryImpl struct {
+	Logger *logrus.Logger
+}
+
+func NewTransactionSagaRepository(logger *logrus.Logger) TransactionSagaRepository {
+	return &TransactionSagaRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+func (repository *TransactionSagaRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, saga domain.TransactionSaga) error {
+	SQL := "INSERT INTO Transaction_Sagas(saga_id, status, attempts, created_at, updated_at) VALUES (?, ?, ?, ?, ?)"
+
+	repository.Logger.Info("---executing sql (save saga)...")
+	_, err := tx.ExecContext(ctx, SQL, saga.SagaID, saga.Status, saga.Attempts, saga.CreatedAt, saga.UpdatedAt)
+	if err != nil {
+		repository.Logger.Errorf("---failed to save saga: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *TransactionSagaRepositoryImpl) UpdateStatus(ctx context.Context, tx *sql.Tx, sagaID ulid.ULID, fromStatus string, toStatus string, lastError *string) error {
+	SQL := "UPDATE Transaction_Sagas SET status = ?, last_error = COALESCE(?, last_error), updated_at = ? WHERE saga_id = ? AND status = ?"
+
+	repository.Logger.Info("---executing sql (update saga status)...")
+	result, err := tx.ExecContext(ctx, SQL, toStatus, lastError, time.Now(), sagaID, fromStatus)
+	if err != nil {
+		repository.Logger.Errorf("---failed to update saga status: %v", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		repository.Logger.Errorf("---failed to check rows affected: %v", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		repository.Logger.Warnf("---saga %v is not in %s state", sagaID, fromStatus)
+		return exception.ErrTransactionStateChanged
+	}
+
+	return nil
+}
+
+func (repository *TransactionSagaRepositoryImpl) RecordFailure(ctx context.Context, tx *sql.Tx, sagaID ulid.ULID, lastError string) error {
+	SQL := "UPDATE Transaction_Sagas SET attempts = attempts + 1, last_error = ?, updated_at = ? WHERE saga_id = ?"
+
+	if len(lastError) > 255 {
+		lastError = lastError[:255]
+	}
+
+	repository.Logger.Info("---executing sql (record saga failure)...")
+	_, err := tx.ExecContext(ctx, SQL, lastError, time.Now(), sagaID)
+	if err != nil {
+		repository.Logger.Errorf("---failed to record saga failure: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (repository *TransactionSagaRepositoryImpl) FindRecoverable(ctx context.Context, tx *sql.Tx, pendingBefore time.Time, limit int) ([]domain.TransactionSaga, error) {
+	SQL := `
+        SELECT saga_id, status, attempts, last_error, created_at, updated_at
+        FROM Transaction_Sagas
+        WHERE status = ? OR (status = ? AND updated_at < ?)
+        ORDER BY updated_at
+        LIMIT ?
+    `
+
+	repository.Logger.Info("---executing sql (find recoverable sagas)...")
+	rows, err := tx.QueryContext(ctx, SQL, domain.SagaStatusCompensating, domain.SagaStatusPending, pendingBefore, limit)
+	if err != nil {
+		repository.Logger.Errorf("---failed to find recoverable sagas: %v", err)
+		return []domain.TransactionSaga{}, err
+	}
+	defer rows.Close()
+
+	sagas := make([]domain.TransactionSaga, 0)
+	for rows.Next() {
+		saga := domain.TransactionSaga{}
+		err := rows.Scan(
+			&saga.SagaID,
+			&saga.Status,
+			&saga.Attempts,
+			&saga.LastError,
+			&saga.CreatedAt,
+			&saga.UpdatedAt,
+		)
+		if err != nil {
+			repository.Logger.Errorf("---failed to scan row: %v", err)
+			return []domain.TransactionSaga{}, err
+		}
+		sagas = append(sagas, saga)
+	}
+
+	return sagas, nil
+}
+
+func (repository *TransactionSagaRepositoryImpl) FindByIDForUpdate(ctx context.Context, tx *sql.Tx, sagaID ulid.ULID) (domain.TransactionSaga, error) {
+	SQL := "SELECT saga_id, status, attempts, last_error, created_at, updated_at FROM Transaction_Sagas WHERE saga_id = ? FOR UPDATE"
+
+	saga := domain.TransactionSaga{}
+
+	repository.Logger.Info("---executing sql (find saga for update)...")
+	err := tx.QueryRowContext(ctx, SQL, sagaID).Scan(
+		&saga.SagaID,
+		&saga.Status,
+		&saga.Attempts,
+		&saga.LastError,
+		&saga.CreatedAt,
+		&saga.UpdatedAt,
+	)
+	if err != nil {
+		repository.Logger.Errorf("---failed to find saga: %v", err)
+		return domain.TransactionSaga{}, err
+	}
+
+	return saga, nil
+}
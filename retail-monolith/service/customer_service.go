@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"retail-management/model/web"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type CustomerService interface {
+	Create(ctx context.Context, req web.CustomerRequest) (web.CustomerResponse, error)
+	FindAll(ctx context.Context, search string) ([]web.CustomerResponse, error)
+	FindByID(ctx context.Context, customerID ulid.ULID) (web.CustomerResponse, error)
+	Update(ctx context.Context, req web.CustomerUpdateRequest) (web.CustomerResponse, error)
+	Delete(ctx context.Context, customerID ulid.ULID) error
+	History(ctx context.Context, customerID ulid.ULID) (web.CustomerHistoryResponse, error)
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"retail-management/exception"
+	"retail-management/helper"
+	"retail-management/model/domain"
+	"retail-management/model/web"
+	"retail-management/repository"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+type CustomerServiceImpl struct {
+	CustomerRepository    repository.CustomerRepository
+	TransactionRepository repository.TransactionRepository
+	DB                    *sql.DB
+	Validate              *validator.Validate
+	Logger                *logrus.Logger
+}
+
+func NewCustomerService(customerRepository repository.CustomerRepository, transactionRepository repository.TransactionRepository, db *sql.DB, validate *validator.Validate, logger *logrus.Logger) CustomerService {
+	return &CustomerServiceImpl{
+		CustomerRepository:    customerRepository,
+		TransactionRepository: transactionRepository,
+		DB:                    db,
+		Validate:              validate,
+		Logger:                logger,
+	}
+}
+
+func (service *CustomerServiceImpl) Create(ctx context.Context, req web.CustomerRequest) (web.CustomerResponse, error) {
+	service.Logger.Info("-validating the request...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating request: %v", err)
+		return web.CustomerResponse{}, err
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.CustomerResponse{}, err
+	}
+	defer tx.Rollback()
+
+	t := time.Now()
+	customer := domain.Customer{
+		CustomerID:   ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(rand.Reader, 0)),
+		CustomerName: req.CustomerName,
+		PhoneNumber:  optionalString(req.PhoneNumber),
+		Email:        optionalString(req.Email),
+		Notes:        optionalString(req.Notes),
+		CreatedAt:    t,
+	}
+
+	service.Logger.Info("-executing CustomerRepository.Save()...")
+	customer, err = service.CustomerRepository.Save(ctx, tx, customer)
+	if err != nil {
+		return web.CustomerResponse{}, err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.CustomerResponse{}, errCommit
+	}
+
+	return helper.ToCustomerResponse(customer), nil
+}
+
+func (service *CustomerServiceImpl) FindAll(ctx context.Context, search string) ([]web.CustomerResponse, error) {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return []web.CustomerResponse{}, err
+	}
+	defer tx.Commit()
+
+	service.Logger.Info("-executing CustomerRepository.FindAll()...")
+	customers, err := service.CustomerRepository.FindAll(ctx, tx, search)
+	if err != nil {
+		service.Logger.Errorf("-failed to execute it: %v", err)
+		return []web.CustomerResponse{}, err
+	}
+
+	return helper.ToCustomerResponses(customers), nil
+}
+
+func (service *CustomerServiceImpl) FindByID(ctx context.Context, customerID ulid.ULID) (web.CustomerResponse, error) {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.CustomerResponse{}, err
+	}
+	defer tx.Commit()
+
+	service.Logger.Info("-executing CustomerRepository.FindByID()...")
+	customer, err := service.CustomerRepository.FindByID(ctx, tx, customerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.CustomerResponse{}, exception.ErrNotFound
+		}
+		return web.CustomerResponse{}, err
+	}
+
+	return helper.ToCustomerResponse(customer), nil
+}
+
+func (service *CustomerServiceImpl) Update(ctx context.Context, req web.CustomerUpdateRequest) (web.CustomerResponse, error) {
+	service.Logger.Info("-validating the request...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating request: %v", err)
+		return web.CustomerResponse{}, err
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.CustomerResponse{}, err
+	}
+	defer tx.Rollback()
+
+	service.Logger.Info("-executing CustomerRepository.FindByID()...")
+	customer, err := service.CustomerRepository.FindByID(ctx, tx, req.CustomerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.CustomerResponse{}, exception.ErrNotFound
+		}
+		return web.CustomerResponse{}, err
+	}
+
+	customer.CustomerName = req.CustomerName
+	customer.PhoneNumber = optionalString(req.PhoneNumber)
+	customer.Email = optionalString(req.Email)
+	customer.Notes = optionalString(req.Notes)
+
+	service.Logger.Info("-executing CustomerRepository.Update()...")
+	customer, err = service.CustomerRepository.Update(ctx, tx, customer)
+	if err != nil {
+		return web.CustomerResponse{}, err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.CustomerResponse{}, errCommit
+	}
+
+	return helper.ToCustomerResponse(customer), nil
+}
+
+func (service *CustomerServiceImpl) Delete(ctx context.Context, customerID ulid.ULID) error {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	service.Logger.Info("-executing CustomerRepository.Delete()...")
+	err = service.CustomerRepository.Delete(ctx, tx, customerID)
+	if err != nil {
+		return err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	return tx.Commit()
+}
+
+func (service *CustomerServiceImpl) History(ctx context.Context, customerID ulid.ULID) (web.CustomerHistoryResponse, error) {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.CustomerHistoryResponse{}, err
+	}
+	defer tx.Commit()
+
+	service.Logger.Info("-executing CustomerRepository.FindByID()...")
+	customer, err := service.CustomerRepository.FindByID(ctx, tx, customerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.CustomerHistoryResponse{}, exception.ErrNotFound
+		}
+		return web.CustomerHistoryResponse{}, err
+	}
+
+	service.Logger.Info("-executing TransactionRepository.FindAllByCustomerID()...")
+	transactions, err := service.TransactionRepository.FindAllByCustomerID(ctx, tx, customerID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find purchase history: %v", err)
+		return web.CustomerHistoryResponse{}, err
+	}
+
+	response := web.CustomerHistoryResponse{
+		Customer:     helper.ToCustomerResponse(customer),
+		TotalSpent:   decimal.Zero,
+		Transactions: make([]web.TransactionResponse, 0),
+	}
+	for _, transaction := range transactions {
+		transactionResponse := helper.ToTransactionResponse(transaction, nil)
+		if transaction.Status == domain.TransactionStatusCompleted {
+			response.PurchaseCount++
+			response.TotalSpent = response.TotalSpent.Add(transactionResponse.NetAmount)
+			if response.LastPurchaseAt == nil {
+				response.LastPurchaseAt = &transaction.CreatedAt
+			}
+		}
+		response.Transactions = append(response.Transactions, transactionResponse)
+	}
+
+	return response, nil
+}
+
+func optionalString(value *string) *string {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	return &trimmed
+}
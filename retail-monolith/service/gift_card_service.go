@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+	"retail-management/model/web"
+)
+
+type GiftCardService interface {
+	Issue(ctx context.Context, req web.GiftCardRequest) (web.GiftCardResponse, error)
+	FindByCode(ctx context.Context, cardCode string) (web.GiftCardResponse, error)
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"retail-management/exception"
+	"retail-management/helper"
+	"retail-management/model/domain"
+	"retail-management/model/web"
+	"retail-management/repository"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+type GiftCardServiceImpl struct {
+	GiftCardRepository repository.GiftCardRepository
+	DB                 *sql.DB
+	Validate           *validator.Validate
+	Logger             *logrus.Logger
+}
+
+func NewGiftCardService(giftCardRepository repository.GiftCardRepository, db *sql.DB, validate *validator.Validate, logger *logrus.Logger) GiftCardService {
+	return &GiftCardServiceImpl{
+		GiftCardRepository: giftCardRepository,
+		DB:                 db,
+		Validate:           validate,
+		Logger:             logger,
+	}
+}
+
+func (service *GiftCardServiceImpl) Issue(ctx context.Context, req web.GiftCardRequest) (web.GiftCardResponse, error) {
+	service.Logger.Info("-validating the request...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating request: %v", err)
+		return web.GiftCardResponse{}, err
+	}
+
+	t := time.Now()
+	if !req.Amount.IsPositive() || (req.ExpiresAt != nil && !req.ExpiresAt.After(t)) {
+		service.Logger.Warnf("-invalid gift card amount %s or expiry", req.Amount)
+		return web.GiftCardResponse{}, exception.ErrInvalidGiftCard
+	}
+
+	giftCard := domain.GiftCard{
+		GiftCardID: ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(rand.Reader, 0)),
+		CardCode:   newGiftCardCode(),
+		CardType:   req.CardType,
+		CustomerID: req.CustomerID,
+		IssuedBy:   req.UserID,
+		ExpiresAt:  req.ExpiresAt,
+		CreatedAt:  t,
+	}
+	if req.CardCode != nil {
+		giftCard.CardCode = normalizeGiftCardCode(*req.CardCode)
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.GiftCardResponse{}, err
+	}
+	defer tx.Rollback()
+
+	service.Logger.Infof("-issuing %s of %s...", req.CardType, req.Amount)
+	entry, err := issueGiftCard(ctx, tx, service.GiftCardRepository, giftCard, req.Amount, nil, t)
+	if err != nil {
+		service.Logger.Errorf("-failed to issue gift card: %v", err)
+		return web.GiftCardResponse{}, err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.GiftCardResponse{}, errCommit
+	}
+
+	return helper.ToGiftCardResponse(giftCard, entry.BalanceAmount, []domain.GiftCardEntry{entry}), nil
+}
+
+func (service *GiftCardServiceImpl) FindByCode(ctx context.Context, cardCode string) (web.GiftCardResponse, error) {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.GiftCardResponse{}, err
+	}
+	defer tx.Rollback()
+
+	service.Logger.Info("-executing GiftCardRepository.FindByCodeForUpdate()...")
+	giftCard, err := service.GiftCardRepository.FindByCodeForUpdate(ctx, tx, normalizeGiftCardCode(cardCode))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.GiftCardResponse{}, exception.ErrNotFound
+		}
+		return web.GiftCardResponse{}, err
+	}
+
+	service.Logger.Info("-writing off an expired balance...")
+	latest, err := expireGiftCard(ctx, tx, service.GiftCardRepository, giftCard, time.Now())
+	if err != nil {
+		service.Logger.Errorf("-failed to expire gift card: %v", err)
+		return web.GiftCardResponse{}, err
+	}
+
+	service.Logger.Info("-executing GiftCardRepository.FindEntries()...")
+	entries, err := service.GiftCardRepository.FindEntries(ctx, tx, giftCard.GiftCardID)
+	if err != nil {
+		return web.GiftCardResponse{}, err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.GiftCardResponse{}, errCommit
+	}
+
+	return helper.ToGiftCardResponse(giftCard, latest.BalanceAmount, entries), nil
+}
+
+const giftCardCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+func newGiftCardCode() string {
+	random := make([]byte, 16)
+	rand.Read(random)
+
+	var code strings.Builder
+	for i, b := range random {
+		if i > 0 && i%4 == 0 {
+			code.WriteByte('-')
+		}
+		code.WriteByte(giftCardCodeAlphabet[int(b)%len(giftCardCodeAlphabet)])
+	}
+	return code.String()
+}
+
+func normalizeGiftCardCode(cardCode string) string {
+	return strings.ToUpper(strings.TrimSpace(cardCode))
+}
+
+func issueGiftCard(ctx context.Context, tx *sql.Tx, giftCardRepository repository.GiftCardRepository, giftCard domain.GiftCard, amount decimal.Decimal, returnID *ulid.ULID, t time.Time) (domain.GiftCardEntry, error) {
+	_, err := giftCardRepository.Save(ctx, tx, giftCard)
+	if err != nil {
+		return domain.GiftCardEntry{}, err
+	}
+
+	return giftCardRepository.SaveEntry(ctx, tx, domain.GiftCardEntry{
+		EntryID:       ulid.MustNew(ulid.Timestamp(t), ledgerEntropy),
+		GiftCardID:    giftCard.GiftCardID,
+		EntryType:     domain.GiftCardEntryIssue,
+		ChangeAmount:  amount,
+		BalanceAmount: amount,
+		ReturnID:      returnID,
+		CreatedAt:     t,
+	})
+}
+
+func expireGiftCard(ctx context.Context, tx *sql.Tx, giftCardRepository repository.GiftCardRepository, giftCard domain.GiftCard, t time.Time) (domain.GiftCardEntry, error) {
+	latest, err := giftCardRepository.FindLatestEntry(ctx, tx, giftCard.GiftCardID)
+	if err != nil {
+		return domain.GiftCardEntry{}, err
+	}
+
+	if giftCard.ExpiresAt == nil || t.Before(*giftCard.ExpiresAt) || !latest.BalanceAmount.IsPositive() {
+		return latest, nil
+	}
+
+	return giftCardRepository.SaveEntry(ctx, tx, domain.GiftCardEntry{
+		EntryID:       nextGiftCardEntryID(latest, t),
+		GiftCardID:    giftCard.GiftCardID,
+		EntryType:     domain.GiftCardEntryExpire,
+		ChangeAmount:  latest.BalanceAmount.Neg(),
+		BalanceAmount: decimal.Zero,
+		CreatedAt:     t,
+	})
+}
+
+func postGiftCard(ctx context.Context, tx *sql.Tx, giftCardRepository repository.GiftCardRepository, giftCard domain.GiftCard, entryType string, amount decimal.Decimal, transactionID *ulid.ULID, returnID *ulid.ULID, t time.Time) error {
+	if amount.IsZero() {
+		return nil
+	}
+
+	latest, err := expireGiftCard(ctx, tx, giftCardRepository, giftCard, t)
+	if err != nil {
+		return err
+	}
+
+	if entryType == domain.GiftCardEntryRedeem {
+		if giftCard.ExpiresAt != nil && !t.Before(*giftCard.ExpiresAt) {
+			return exception.ErrGiftCardExpired
+		}
+		if latest.BalanceAmount.Add(amount).IsNegative() {
+			return exception.ErrInsufficientGiftCardBalance
+		}
+	}
+
+	_, err = giftCardRepository.SaveEntry(ctx, tx, domain.GiftCardEntry{
+		EntryID:       nextGiftCardEntryID(latest, t),
+		GiftCardID:    giftCard.GiftCardID,
+		EntryType:     entryType,
+		ChangeAmount:  amount,
+		BalanceAmount: latest.BalanceAmount.Add(amount),
+		TransactionID: transactionID,
+		ReturnID:      returnID,
+		CreatedAt:     t,
+	})
+	return err
+}
+
+func nextGiftCardEntryID(latest domain.GiftCardEntry, t time.Time) ulid.ULID {
+	return ulid.MustNew(max(ulid.Timestamp(t), latest.EntryID.Time()), ledgerEntropy)
+}
@@ -2,9 +2,13 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"database/sql"
 	"fmt"
+	"retail-management/exception"
 	"retail-management/model/web"
 	"retail-management/pb"
+	"retail-management/repository"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -13,16 +17,22 @@ import (
 )
 
 type InventoryLogServiceImpl struct {
-	InventoryClient pb.InventoryServiceClient
-	Validate        *validator.Validate
-	Logger          *logrus.Logger
+	ProductRepository repository.ProductRepository
+	CostRepository    repository.CostRepository
+	InventoryClient   pb.InventoryServiceClient
+	DB                *sql.DB
+	Validate          *validator.Validate
+	Logger            *logrus.Logger
 }
 
-func NewInventoryLogService(inventoryClient pb.InventoryServiceClient, validate *validator.Validate, logger *logrus.Logger) InventoryLogService {
+func NewInventoryLogService(productRepository repository.ProductRepository, costRepository repository.CostRepository, inventoryClient pb.InventoryServiceClient, db *sql.DB, validate *validator.Validate, logger *logrus.Logger) InventoryLogService {
 	return &InventoryLogServiceImpl{
-		InventoryClient: inventoryClient,
-		Validate:        validate,
-		Logger:          logger,
+		ProductRepository: productRepository,
+		CostRepository:    costRepository,
+		InventoryClient:   inventoryClient,
+		DB:                db,
+		Validate:          validate,
+		Logger:            logger,
 	}
 }
 
@@ -41,36 +51,84 @@ func (service *InventoryLogServiceImpl) Adjust(ctx context.Context, req web.Inve
 		reason = "-"
 	}
 
-	service.Logger.Info("-forwarding adjust request to microservice...")
+	locationID, err := locationOrDefault(req.LocationID)
+	if err != nil {
+		service.Logger.Errorf("-failed to resolve location: %v", err)
+		return web.InventoryLogResponse{}, err
+	}
 
-	resp, err := service.InventoryClient.AdjustStock(ctx, &pb.AdjustStockRequest{
-		ProductId:      req.ProductID.String(),
-		QuantityChange: int32(req.ChangeQuantity),
-		Reason:         reason,
-		UserId:         req.UserID.String(),
-	})
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.InventoryLogResponse{}, err
+	}
+	defer tx.Rollback()
 
+	product, err := service.ProductRepository.FindByID(ctx, tx, req.ProductID)
 	if err != nil {
-		service.Logger.Errorf("-grpc call failed: %v", err)
+		service.Logger.Errorf("-failed to find product: %v", err)
+		if err == sql.ErrNoRows {
+			return web.InventoryLogResponse{}, exception.ErrNotFound
+		}
 		return web.InventoryLogResponse{}, err
 	}
 
-	if !resp.Success {
+	service.Logger.Info("-recording the adjustment cost...")
+	t := time.Now()
+	reference := fmt.Sprintf("adjustment:%s", ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(rand.Reader, 0)).String())
+	if req.ChangeQuantity > 0 {
+		err = receiveCost(ctx, tx, service.CostRepository, req.ProductID, req.ChangeQuantity, product.PurchasePrice, reference, t)
+	} else {
+		_, err = issueCost(ctx, tx, service.CostRepository, req.ProductID, -req.ChangeQuantity, product.PurchasePrice, reference, t)
+	}
+	if err != nil {
+		service.Logger.Errorf("-failed to record adjustment cost: %v", err)
+		return web.InventoryLogResponse{}, err
+	}
+
+	service.Logger.Info("-forwarding adjust request to microservice...")
+	resp, err := service.InventoryClient.BatchAdjustStock(ctx, &pb.BatchAdjustStockRequest{
+		Adjustments: []*pb.StockAdjustment{{
+			ProductId:      req.ProductID.String(),
+			QuantityChange: int32(req.ChangeQuantity),
+			LotNumber:      req.LotNumber,
+			ExpiryDate:     req.ExpiryDate,
+		}},
+		UserId:      req.UserID.String(),
+		LocationId:  locationID.String(),
+		Reason:      reason,
+		OperationId: reference,
+	})
+	if err != nil {
+		service.Logger.Errorf("-grpc call failed: %v", err)
+		return web.InventoryLogResponse{}, err
+	}
+	if !resp.Success || len(resp.Items) == 0 {
 		return web.InventoryLogResponse{}, fmt.Errorf("error: %v", resp.Message)
 	}
 
-	realLogID, err := ulid.Parse(resp.LogId)
+	realLogID, err := ulid.Parse(resp.Items[0].LogId)
 	if err != nil {
 		service.Logger.Warnf("failed to parse returned log id: %v", err)
+		cancelStockOperation(ctx, service.InventoryClient, service.Logger, reference, req.UserID, fmt.Sprintf("rollback stock adjustment: %s", reference))
 		return web.InventoryLogResponse{}, err
 	}
 
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		service.Logger.Errorf("-failed commit, cancelling the adjusted stock: %v", errCommit)
+		cancelStockOperation(ctx, service.InventoryClient, service.Logger, reference, req.UserID, fmt.Sprintf("rollback stock adjustment: %s", reference))
+		return web.InventoryLogResponse{}, errCommit
+	}
+
 	return web.InventoryLogResponse{
 		LogID:          realLogID,
 		ProductID:      req.ProductID,
+		LocationID:     locationID,
 		UserID:         req.UserID,
 		ChangeQuantity: req.ChangeQuantity,
 		Reason:         req.Reason,
-		CreatedAt:      time.Now(),
+		CreatedAt:      t,
 	}, nil
 }
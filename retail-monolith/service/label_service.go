@@ -0,0 +1,10 @@
+package service
+
+import (
+	"context"
+	"retail-management/model/web"
+)
+
+type LabelService interface {
+	Render(ctx context.Context, req web.LabelRequest) (web.LabelResponse, error)
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"retail-management/exception"
+	"retail-management/helper"
+	"retail-management/model/domain"
+	"retail-management/model/web"
+	"retail-management/repository"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type LabelServiceImpl struct {
+	ProductRepository repository.ProductRepository
+	DB                *sql.DB
+	Validate          *validator.Validate
+	Logger            *logrus.Logger
+}
+
+func NewLabelService(productRepository repository.ProductRepository, db *sql.DB, validate *validator.Validate, logger *logrus.Logger) LabelService {
+	return &LabelServiceImpl{
+		ProductRepository: productRepository,
+		DB:                db,
+		Validate:          validate,
+		Logger:            logger,
+	}
+}
+
+var labelContentTypes = map[string]string{
+	domain.LabelFormatPDF: "application/pdf",
+	domain.LabelFormatZPL: "application/octet-stream",
+}
+
+func (service *LabelServiceImpl) Render(ctx context.Context, req web.LabelRequest) (web.LabelResponse, error) {
+	service.Logger.Info("-validating the request...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating request: %v", err)
+		return web.LabelResponse{}, err
+	}
+
+	if (len(req.ProductIDs) == 0) == (req.CategoryID == nil) {
+		service.Logger.Warn("-labels need exactly one of product_ids or category_id")
+		return web.LabelResponse{}, exception.ErrInvalidLabelRequest
+	}
+	if req.Format == "" {
+		req.Format = domain.LabelFormatPDF
+	}
+	if req.Copies == 0 {
+		req.Copies = 1
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.LabelResponse{}, err
+	}
+	defer tx.Commit()
+
+	var products []domain.Product
+	if req.CategoryID != nil {
+		service.Logger.Info("-executing ProductRepository.FindByCategoryID()...")
+		products, err = service.ProductRepository.FindByCategoryID(ctx, tx, *req.CategoryID)
+		if err != nil {
+			service.Logger.Errorf("-failed to find products of category: %v", err)
+			return web.LabelResponse{}, err
+		}
+		products = withoutParents(products)
+		if len(products) == 0 {
+			service.Logger.Warnf("-category %s has no products to label", *req.CategoryID)
+			return web.LabelResponse{}, exception.ErrNotFound
+		}
+	}
+	for _, productID := range req.ProductIDs {
+		service.Logger.Info("-executing ProductRepository.FindByID()...")
+		product, err := service.ProductRepository.FindByID(ctx, tx, productID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return web.LabelResponse{}, exception.ErrNotFound
+			}
+			service.Logger.Errorf("-failed to find product: %v", err)
+			return web.LabelResponse{}, err
+		}
+		products = append(products, product)
+	}
+
+	productIDs := make([]ulid.ULID, 0, len(products))
+	for _, product := range products {
+		productIDs = append(productIDs, product.ProductID)
+	}
+
+	service.Logger.Info("-executing ProductRepository.FindBarcodes()...")
+	barcodes, err := service.ProductRepository.FindBarcodes(ctx, tx, productIDs)
+	if err != nil {
+		service.Logger.Errorf("-failed to find barcodes: %v", err)
+		return web.LabelResponse{}, err
+	}
+
+	labels := make([]domain.Label, 0, len(products))
+	for _, product := range products {
+		label := domain.Label{
+			ProductID:    product.ProductID,
+			ProductName:  product.ProductName,
+			SellingPrice: product.SellingPrice,
+			Copies:       req.Copies,
+		}
+		label.Code, label.Symbology = labelCode(product, barcodes[product.ProductID])
+		labels = append(labels, label)
+	}
+
+	service.Logger.Infof("-rendering %d labels as %s...", len(labels), req.Format)
+	body, err := helper.RenderLabels(labels, req.Format, labelLayout())
+	if err != nil {
+		service.Logger.Errorf("-failed to render labels: %v", err)
+		return web.LabelResponse{}, err
+	}
+
+	return web.LabelResponse{
+		ContentType: labelContentTypes[req.Format],
+		FileName:    fmt.Sprintf("labels-%s.%s", time.Now().Format("20060102-150405"), req.Format),
+		Body:        body,
+	}, nil
+}
+
+func labelCode(product domain.Product, barcodes []domain.ProductBarcode) (string, string) {
+	if len(barcodes) > 0 {
+		code := barcodes[0].Barcode
+		if barcodes[0].BarcodeType == domain.BarcodeTypeUPCA {
+			code = "0" + code
+		}
+		return code, domain.LabelSymbologyEAN13
+	}
+	if product.SKU != nil && isCode128Text(*product.SKU) {
+		return *product.SKU, domain.LabelSymbologyCode128
+	}
+	return product.ProductID.String(), domain.LabelSymbologyCode128
+}
+
+func withoutParents(products []domain.Product) []domain.Product {
+	parents := make(map[ulid.ULID]bool)
+	for _, product := range products {
+		if product.ParentProductID != nil {
+			parents[*product.ParentProductID] = true
+		}
+	}
+
+	sellable := make([]domain.Product, 0, len(products))
+	for _, product := range products {
+		if !parents[product.ProductID] {
+			sellable = append(sellable, product)
+		}
+	}
+	return sellable
+}
+
+func isCode128Text(text string) bool {
+	for _, char := range text {
+		if char < 32 || char > 126 {
+			return false
+		}
+	}
+	return true
+}
+
+func labelLayout() domain.LabelLayout {
+	setting := func(envName string, fallback int, lowest int, highest int) int {
+		value, err := strconv.Atoi(os.Getenv(envName))
+		if err != nil || value < lowest || value > highest {
+			return fallback
+		}
+		return value
+	}
+	return domain.LabelLayout{
+		Columns:   setting("LABEL_COLUMNS", 3, 1, 6),
+		Rows:      setting("LABEL_ROWS", 8, 1, 20),
+		ZPLWidth:  setting("LABEL_ZPL_WIDTH", 406, 200, 2400),
+		ZPLHeight: setting("LABEL_ZPL_HEIGHT", 254, 150, 2400),
+	}
+}
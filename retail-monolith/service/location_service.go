@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+	"retail-management/model/web"
+)
+
+type LocationService interface {
+	Create(ctx context.Context, req web.LocationRequest) (web.LocationResponse, error)
+	FindAll(ctx context.Context) ([]web.LocationResponse, error)
+}
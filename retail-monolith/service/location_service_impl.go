@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"retail-management/exception"
+	"retail-management/model/web"
+	"retail-management/pb"
+	"retail-management/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type LocationServiceImpl struct {
+	InventoryClient pb.InventoryServiceClient
+	Validate        *validator.Validate
+	Logger          *logrus.Logger
+}
+
+func NewLocationService(inventoryClient pb.InventoryServiceClient, validate *validator.Validate, logger *logrus.Logger) LocationService {
+	return &LocationServiceImpl{
+		InventoryClient: inventoryClient,
+		Validate:        validate,
+		Logger:          logger,
+	}
+}
+
+func (service *LocationServiceImpl) Create(ctx context.Context, req web.LocationRequest) (web.LocationResponse, error) {
+	service.Logger.Info("-validating the req body...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating the req: %v", err)
+		return web.LocationResponse{}, err
+	}
+
+	service.Logger.Info("-forwarding create location request to microservice...")
+	location, err := service.InventoryClient.CreateLocation(ctx, &pb.CreateLocationRequest{
+		LocationName: req.LocationName,
+		LocationType: req.LocationType,
+	})
+	if err != nil {
+		service.Logger.Errorf("-grpc call failed: %v", err)
+		if status.Code(err) == codes.AlreadyExists {
+			return web.LocationResponse{}, exception.ErrLocationExists
+		}
+		return web.LocationResponse{}, err
+	}
+
+	return toLocationResponse(location), nil
+}
+
+func (service *LocationServiceImpl) FindAll(ctx context.Context) ([]web.LocationResponse, error) {
+	service.Logger.Info("-fetching locations from microservice...")
+	resp, err := service.InventoryClient.ListLocations(ctx, &pb.ListLocationsRequest{})
+	if err != nil {
+		service.Logger.Errorf("-grpc call failed: %v", err)
+		return []web.LocationResponse{}, err
+	}
+
+	locations := make([]web.LocationResponse, 0)
+	for _, location := range resp.Locations {
+		locations = append(locations, toLocationResponse(location))
+	}
+	return locations, nil
+}
+
+func toLocationResponse(location *pb.Location) web.LocationResponse {
+	locationID, _ := ulid.Parse(location.LocationId)
+	return web.LocationResponse{
+		LocationID:   locationID,
+		LocationName: location.LocationName,
+		LocationType: location.LocationType,
+	}
+}
+
+func resolveLocation(ctx context.Context, tx *sql.Tx, registerRepository repository.RegisterRepository, userID ulid.ULID, requested *ulid.ULID) (ulid.ULID, *ulid.ULID, error) {
+	if requested != nil {
+		return *requested, nil, nil
+	}
+
+	register, err := registerRepository.FindByUserID(ctx, tx, userID)
+	if err == nil {
+		return register.LocationID, &register.RegisterID, nil
+	}
+	if err != sql.ErrNoRows {
+		return ulid.ULID{}, nil, err
+	}
+
+	locationID, ok := defaultLocationID()
+	if !ok {
+		return ulid.ULID{}, nil, exception.ErrLocationNotResolved
+	}
+	return locationID, nil, nil
+}
+
+func locationOrDefault(requested *ulid.ULID) (ulid.ULID, error) {
+	if requested != nil {
+		return *requested, nil
+	}
+
+	locationID, ok := defaultLocationID()
+	if !ok {
+		return ulid.ULID{}, exception.ErrLocationNotResolved
+	}
+	return locationID, nil
+}
+
+func defaultLocationID() (ulid.ULID, bool) {
+	locationID, err := ulid.Parse(os.Getenv("DEFAULT_LOCATION_ID"))
+	if err != nil {
+		return ulid.ULID{}, false
+	}
+	return locationID, true
+}
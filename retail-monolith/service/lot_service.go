@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"retail-management/model/web"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type LotService interface {
+	FindExpiring(ctx context.Context, locationID *ulid.ULID, withinDays int) ([]web.LotResponse, error)
+	WriteOffExpired(ctx context.Context, req web.LotWriteOffRequest) (web.LotWriteOffResponse, error)
+}
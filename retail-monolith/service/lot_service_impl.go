@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"retail-management/exception"
+	"retail-management/model/web"
+	"retail-management/pb"
+	"retail-management/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type LotServiceImpl struct {
+	ProductRepository repository.ProductRepository
+	CostRepository    repository.CostRepository
+	InventoryClient   pb.InventoryServiceClient
+	DB                *sql.DB
+	Validate          *validator.Validate
+	Logger            *logrus.Logger
+}
+
+func NewLotService(productRepository repository.ProductRepository, costRepository repository.CostRepository, inventoryClient pb.InventoryServiceClient, db *sql.DB, validate *validator.Validate, logger *logrus.Logger) LotService {
+	return &LotServiceImpl{
+		ProductRepository: productRepository,
+		CostRepository:    costRepository,
+		InventoryClient:   inventoryClient,
+		DB:                db,
+		Validate:          validate,
+		Logger:            logger,
+	}
+}
+
+func (service *LotServiceImpl) FindExpiring(ctx context.Context, locationID *ulid.ULID, withinDays int) ([]web.LotResponse, error) {
+	location, err := locationOrDefault(locationID)
+	if err != nil {
+		service.Logger.Errorf("-failed to resolve location: %v", err)
+		return []web.LotResponse{}, err
+	}
+
+	service.Logger.Info("-fetching expiring lots from microservice...")
+	resp, err := service.InventoryClient.ListExpiringLots(ctx, &pb.ListExpiringLotsRequest{
+		LocationId: location.String(),
+		WithinDays: int32(withinDays),
+	})
+	if err != nil {
+		service.Logger.Errorf("-grpc call failed: %v", err)
+		return []web.LotResponse{}, lotError(err)
+	}
+
+	return toLotResponses(resp.Lots), nil
+}
+
+func (service *LotServiceImpl) WriteOffExpired(ctx context.Context, req web.LotWriteOffRequest) (web.LotWriteOffResponse, error) {
+	service.Logger.Info("-validating the req body...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating the req: %v", err)
+		return web.LotWriteOffResponse{}, err
+	}
+
+	location, err := locationOrDefault(req.LocationID)
+	if err != nil {
+		service.Logger.Errorf("-failed to resolve location: %v", err)
+		return web.LotWriteOffResponse{}, err
+	}
+
+	var lotIDs []string
+	for _, lotID := range req.LotIDs {
+		lotIDs = append(lotIDs, lotID.String())
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.LotWriteOffResponse{}, err
+	}
+	defer tx.Rollback()
+
+	service.Logger.Info("-forwarding write-off request to microservice...")
+	resp, err := service.InventoryClient.WriteOffExpiredLots(ctx, &pb.WriteOffExpiredLotsRequest{
+		LocationId: location.String(),
+		LotIds:     lotIDs,
+		UserId:     req.UserID.String(),
+		Reason:     req.Reason,
+	})
+	if err != nil {
+		service.Logger.Errorf("-grpc call failed: %v", err)
+		return web.LotWriteOffResponse{}, lotError(err)
+	}
+
+	service.Logger.Info("-recording written-off lot costs...")
+	t := time.Now()
+	for _, lot := range resp.Lots {
+		productID, _ := ulid.Parse(lot.ProductId)
+		product, err := service.ProductRepository.FindByID(ctx, tx, productID)
+		if err != nil {
+			service.Logger.Errorf("-failed to find product %s: %v", productID, err)
+			return web.LotWriteOffResponse{}, err
+		}
+
+		_, err = issueCost(ctx, tx, service.CostRepository, productID, int(lot.Quantity), product.PurchasePrice, fmt.Sprintf("lot-write-off:%s", lot.LotId), t)
+		if err != nil {
+			service.Logger.Errorf("-failed to record write-off cost for lot %s: %v", lot.LotId, err)
+			return web.LotWriteOffResponse{}, err
+		}
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.LotWriteOffResponse{}, errCommit
+	}
+
+	return web.LotWriteOffResponse{
+		Message: resp.Message,
+		Lots:    toLotResponses(resp.Lots),
+	}, nil
+}
+
+func lotError(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return exception.ErrNotFound
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %s", exception.ErrInvalidLotRequest, status.Convert(err).Message())
+	case codes.FailedPrecondition:
+		return fmt.Errorf("%w: %s", exception.ErrLotNotExpired, status.Convert(err).Message())
+	}
+	return err
+}
+
+func toLotResponses(lots []*pb.StockLot) []web.LotResponse {
+	responses := make([]web.LotResponse, 0)
+	for _, lot := range lots {
+		lotID, _ := ulid.Parse(lot.LotId)
+		locationID, _ := ulid.Parse(lot.LocationId)
+		productID, _ := ulid.Parse(lot.ProductId)
+		receivedAt, _ := time.Parse(time.RFC3339, lot.ReceivedAt)
+
+		responses = append(responses, web.LotResponse{
+			LotID:      lotID,
+			LocationID: locationID,
+			ProductID:  productID,
+			LotNumber:  lot.LotNumber,
+			ReceivedAt: receivedAt,
+			ExpiryDate: lot.ExpiryDate,
+			Quantity:   int(lot.Quantity),
+		})
+	}
+	return responses
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+	"retail-management/model/web"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type LoyaltyService interface {
+	Balance(ctx context.Context, customerID ulid.ULID) (web.LoyaltyResponse, error)
+}
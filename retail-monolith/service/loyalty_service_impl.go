@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"retail-management/exception"
+	"retail-management/helper"
+	"retail-management/model/domain"
+	"retail-management/model/web"
+	"retail-management/repository"
+	"strconv"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+type LoyaltyServiceImpl struct {
+	LoyaltyRepository repository.LoyaltyRepository
+	DB                *sql.DB
+	Logger            *logrus.Logger
+}
+
+func NewLoyaltyService(loyaltyRepository repository.LoyaltyRepository, db *sql.DB, logger *logrus.Logger) LoyaltyService {
+	return &LoyaltyServiceImpl{
+		LoyaltyRepository: loyaltyRepository,
+		DB:                db,
+		Logger:            logger,
+	}
+}
+
+func (service *LoyaltyServiceImpl) Balance(ctx context.Context, customerID ulid.ULID) (web.LoyaltyResponse, error) {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.LoyaltyResponse{}, err
+	}
+	defer tx.Rollback()
+
+	service.Logger.Info("-locking the customer's points ledger...")
+	err = service.LoyaltyRepository.LockCustomer(ctx, tx, customerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.LoyaltyResponse{}, exception.ErrNotFound
+		}
+		return web.LoyaltyResponse{}, err
+	}
+
+	service.Logger.Info("-writing off expired points...")
+	latest, err := expirePoints(ctx, tx, service.LoyaltyRepository, customerID, time.Now())
+	if err != nil {
+		service.Logger.Errorf("-failed to expire points: %v", err)
+		return web.LoyaltyResponse{}, err
+	}
+
+	service.Logger.Info("-executing LoyaltyRepository.FindEntries()...")
+	entries, err := service.LoyaltyRepository.FindEntries(ctx, tx, customerID)
+	if err != nil {
+		return web.LoyaltyResponse{}, err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.LoyaltyResponse{}, errCommit
+	}
+
+	pointValue := loyaltyPointValue()
+	return web.LoyaltyResponse{
+		CustomerID:   customerID,
+		Balance:      latest.BalancePoints,
+		PointValue:   pointValue,
+		BalanceValue: pointValue.Mul(decimal.NewFromInt(int64(latest.BalancePoints))),
+		Entries:      helper.ToLoyaltyEntryResponses(entries),
+	}, nil
+}
+
+func loyaltyEarnAmount() decimal.Decimal {
+	amount, err := decimal.NewFromString(os.Getenv("LOYALTY_EARN_AMOUNT"))
+	if err != nil || !amount.IsPositive() {
+		return decimal.NewFromInt(10000)
+	}
+	return amount
+}
+
+func loyaltyPointValue() decimal.Decimal {
+	value, err := decimal.NewFromString(os.Getenv("LOYALTY_POINT_VALUE"))
+	if err != nil || !value.IsPositive() {
+		return decimal.NewFromInt(100)
+	}
+	return value
+}
+
+func loyaltyExpiryDays() int {
+	days, err := strconv.Atoi(os.Getenv("LOYALTY_POINTS_EXPIRY_DAYS"))
+	if err != nil || days < 0 {
+		return 365
+	}
+	return days
+}
+
+func earnedPoints(amount decimal.Decimal) int {
+	if !amount.IsPositive() {
+		return 0
+	}
+	return int(amount.Div(loyaltyEarnAmount()).Floor().IntPart())
+}
+
+func expirePoints(ctx context.Context, tx *sql.Tx, loyaltyRepository repository.LoyaltyRepository, customerID ulid.ULID, t time.Time) (domain.LoyaltyEntry, error) {
+	latest, err := loyaltyRepository.FindLatestEntry(ctx, tx, customerID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return domain.LoyaltyEntry{}, err
+		}
+		latest = domain.LoyaltyEntry{CustomerID: customerID}
+	}
+
+	expired, err := loyaltyRepository.FindExpiredPoints(ctx, tx, customerID, t)
+	if err != nil {
+		return domain.LoyaltyEntry{}, err
+	}
+	expired = min(expired, latest.BalancePoints)
+	if expired <= 0 {
+		return latest, nil
+	}
+
+	return loyaltyRepository.SaveEntry(ctx, tx, domain.LoyaltyEntry{
+		EntryID:       nextLoyaltyEntryID(latest, t),
+		CustomerID:    customerID,
+		EntryType:     domain.LoyaltyEntryExpire,
+		ChangePoints:  -expired,
+		BalancePoints: latest.BalancePoints - expired,
+		CreatedAt:     t,
+	})
+}
+
+func postPoints(ctx context.Context, tx *sql.Tx, loyaltyRepository repository.LoyaltyRepository, customerID ulid.ULID, entryType string, points int, transactionID *ulid.ULID, returnID *ulid.ULID, t time.Time) error {
+	if points == 0 {
+		return nil
+	}
+
+	err := loyaltyRepository.LockCustomer(ctx, tx, customerID)
+	if err != nil {
+		return err
+	}
+
+	latest, err := expirePoints(ctx, tx, loyaltyRepository, customerID, t)
+	if err != nil {
+		return err
+	}
+
+	if entryType == domain.LoyaltyEntryRedeem && latest.BalancePoints+points < 0 {
+		return exception.ErrInsufficientPoints
+	}
+
+	entry := domain.LoyaltyEntry{
+		EntryID:       nextLoyaltyEntryID(latest, t),
+		CustomerID:    customerID,
+		EntryType:     entryType,
+		ChangePoints:  points,
+		BalancePoints: latest.BalancePoints + points,
+		TransactionID: transactionID,
+		ReturnID:      returnID,
+		CreatedAt:     t,
+	}
+	if points > 0 && loyaltyExpiryDays() > 0 {
+		expiresAt := t.AddDate(0, 0, loyaltyExpiryDays())
+		entry.ExpiresAt = &expiresAt
+	}
+
+	_, err = loyaltyRepository.SaveEntry(ctx, tx, entry)
+	return err
+}
+
+func nextLoyaltyEntryID(latest domain.LoyaltyEntry, t time.Time) ulid.ULID {
+	return ulid.MustNew(max(ulid.Timestamp(t), latest.EntryID.Time()), ledgerEntropy)
+}
@@ -9,8 +9,9 @@ import (
 
 type ProductService interface {
 	Create(ctx context.Context, req web.ProductRequest) (web.ProductResponse, error)
-	FindAll(ctx context.Context) ([]web.ProductResponse, error)
-	FindByID(ctx context.Context, productID ulid.ULID) (web.ProductResponse, error)
+	FindAll(ctx context.Context, locationID *ulid.ULID) ([]web.ProductResponse, error)
+	FindByID(ctx context.Context, productID ulid.ULID, locationID *ulid.ULID) (web.ProductResponse, error)
+	Lookup(ctx context.Context, barcode string, sku string, locationID *ulid.ULID) (web.ProductResponse, error)
 	Update(ctx context.Context, req web.ProductUpdateRequest) (web.ProductUpdateResponse, error)
 	UpdateStock(ctx context.Context, req web.ProductUpdateStockRequest) (web.ProductUpdateResponse, error)
 	Delete(ctx context.Context, productID ulid.ULID) error
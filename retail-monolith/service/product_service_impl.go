@@ -4,11 +4,14 @@ import (
 	"context"
 	"crypto/rand"
 	"database/sql"
+	"retail-management/exception"
 	"retail-management/helper"
 	"retail-management/model/domain"
 	"retail-management/model/web"
 	"retail-management/pb"
 	"retail-management/repository"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -18,15 +21,17 @@ import (
 
 type ProductServiceImpl struct {
 	ProductRepository repository.ProductRepository
+	CostRepository    repository.CostRepository
 	InventoryClient   pb.InventoryServiceClient
 	DB                *sql.DB
 	Validate          *validator.Validate
 	Logger            *logrus.Logger
 }
 
-func NewProductService(productRepository repository.ProductRepository, inventoryClient pb.InventoryServiceClient, db *sql.DB, validate *validator.Validate, logger *logrus.Logger) ProductService {
+func NewProductService(productRepository repository.ProductRepository, costRepository repository.CostRepository, inventoryClient pb.InventoryServiceClient, db *sql.DB, validate *validator.Validate, logger *logrus.Logger) ProductService {
 	return &ProductServiceImpl{
 		ProductRepository: productRepository,
+		CostRepository:    costRepository,
 		InventoryClient:   inventoryClient,
 		DB:                db,
 		Validate:          validate,
@@ -42,6 +47,24 @@ func (service *ProductServiceImpl) Create(ctx context.Context, req web.ProductRe
 		return web.ProductResponse{}, err
 	}
 
+	barcodes, err := parseBarcodes(req.Barcodes)
+	if err != nil {
+		service.Logger.Warnf("-invalid barcodes %v", req.Barcodes)
+		return web.ProductResponse{}, err
+	}
+
+	attributes, err := parseAttributes(req.Attributes)
+	if err != nil || (req.ParentProductID == nil) != (len(attributes) == 0) {
+		service.Logger.Warnf("-invalid variant attributes %v", req.Attributes)
+		return web.ProductResponse{}, exception.ErrInvalidVariant
+	}
+
+	components, err := parseComponents(req.Components, nil)
+	if err != nil || (req.Components != nil && (len(components) == 0 || req.StockQuantity != 0 || req.ParentProductID != nil)) {
+		service.Logger.Warnf("-invalid bundle components %v", req.Components)
+		return web.ProductResponse{}, exception.ErrInvalidBundle
+	}
+
 	service.Logger.Info("-trying to begin tx...")
 	tx, err := service.DB.Begin()
 	if err != nil {
@@ -49,19 +72,75 @@ func (service *ProductServiceImpl) Create(ctx context.Context, req web.ProductRe
 	}
 	defer tx.Rollback()
 
+	if req.ParentProductID != nil {
+		service.Logger.Info("-executing ProductRepository.FindByID() (Parent)...")
+		parent, err := service.ProductRepository.FindByID(ctx, tx, *req.ParentProductID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return web.ProductResponse{}, exception.ErrNotFound
+			}
+			service.Logger.Errorf("-failed to find parent product: %v", err)
+			return web.ProductResponse{}, err
+		}
+		if parent.ParentProductID != nil {
+			service.Logger.Warnf("-product %s is a variant itself", parent.ProductID)
+			return web.ProductResponse{}, exception.ErrInvalidVariant
+		}
+
+		parentComponents, err := service.ProductRepository.FindComponents(ctx, tx, []ulid.ULID{parent.ProductID})
+		if err != nil {
+			service.Logger.Errorf("-failed to find bundle components: %v", err)
+			return web.ProductResponse{}, err
+		}
+		if len(parentComponents[parent.ProductID]) > 0 {
+			service.Logger.Warnf("-product %s is a bundle, it cannot have variants", parent.ProductID)
+			return web.ProductResponse{}, exception.ErrInvalidVariant
+		}
+
+		err = service.checkVariantAttributes(ctx, tx, parent.ProductID, nil, attributes)
+		if err != nil {
+			return web.ProductResponse{}, err
+		}
+
+		if req.ProductName == "" {
+			req.ProductName = variantName(parent.ProductName, attributes)
+		}
+		if req.CategoryID == (ulid.ULID{}) {
+			req.CategoryID = parent.CategoryID
+		}
+		if req.SupplierID == (ulid.ULID{}) {
+			req.SupplierID = parent.SupplierID
+		}
+	}
+
+	if len(components) > 0 {
+		components, err = service.checkComponents(ctx, tx, components)
+		if err != nil {
+			return web.ProductResponse{}, err
+		}
+	}
+
+	locationID, err := locationOrDefault(req.LocationID)
+	if err != nil {
+		service.Logger.Errorf("-failed to resolve location for initial stock: %v", err)
+		return web.ProductResponse{}, err
+	}
+
 	service.Logger.Info("-implementing ulid...")
 	entropy := ulid.Monotonic(rand.Reader, 0)
 	t := time.Now()
 
 	productID := ulid.MustNew(ulid.Timestamp(t), entropy)
 	product := domain.Product{
-		ProductID:     productID,
-		ProductName:   req.ProductName,
-		PurchasePrice: req.PurchasePrice,
-		SellingPrice:  req.SellingPrice,
-		StockQuantity: req.StockQuantity,
-		CategoryID:    req.CategoryID,
-		SupplierID:    req.SupplierID,
+		ProductID:       productID,
+		ProductName:     req.ProductName,
+		SKU:             normalizeSKU(req.SKU),
+		PurchasePrice:   req.PurchasePrice,
+		SellingPrice:    req.SellingPrice,
+		StockQuantity:   req.StockQuantity,
+		CategoryID:      req.CategoryID,
+		SupplierID:      req.SupplierID,
+		ParentProductID: req.ParentProductID,
 	}
 	savedProduct, err := service.ProductRepository.Save(ctx, tx, product)
 	if err != nil {
@@ -73,18 +152,52 @@ func (service *ProductServiceImpl) Create(ctx context.Context, req web.ProductRe
 		return web.ProductResponse{}, err
 	}
 
-	service.Logger.Info("-syncing to inventory microservice...")
-	_, errGrpc := service.InventoryClient.AdjustStock(ctx, &pb.AdjustStockRequest{
-		ProductId:      product.ProductID.String(),
-		QuantityChange: int32(req.StockQuantity),
-		Reason:         "init stock from monolith",
-		UserId:         "admin",
-	})
+	service.Logger.Info("-executing ProductRepository.SaveBarcodes()...")
+	err = service.ProductRepository.SaveBarcodes(ctx, tx, productID, barcodes)
+	if err != nil {
+		service.Logger.Errorf("-failed to save barcodes: %v", err)
+		return web.ProductResponse{}, err
+	}
+	savedProduct.Barcodes = barcodes
 
-	if errGrpc != nil {
-		tx.Rollback()
-		service.Logger.Errorf("-failed to sync inventory: %v", errGrpc)
-		return web.ProductResponse{}, errGrpc
+	service.Logger.Info("-executing ProductRepository.SaveAttributes()...")
+	err = service.ProductRepository.SaveAttributes(ctx, tx, productID, attributes)
+	if err != nil {
+		service.Logger.Errorf("-failed to save variant attributes: %v", err)
+		return web.ProductResponse{}, err
+	}
+	savedProduct.Attributes = attributes
+
+	service.Logger.Info("-executing ProductRepository.SaveComponents()...")
+	err = service.ProductRepository.SaveComponents(ctx, tx, productID, components)
+	if err != nil {
+		service.Logger.Errorf("-failed to save bundle components: %v", err)
+		return web.ProductResponse{}, err
+	}
+	savedProduct.Components = components
+
+	if len(components) == 0 {
+		service.Logger.Info("-recording the initial stock cost layer...")
+		err = receiveCost(ctx, tx, service.CostRepository, productID, req.StockQuantity, req.PurchasePrice, "init stock", t)
+		if err != nil {
+			service.Logger.Errorf("-failed to record initial stock cost: %v", err)
+			return web.ProductResponse{}, err
+		}
+
+		service.Logger.Info("-syncing to inventory microservice...")
+		_, errGrpc := service.InventoryClient.AdjustStock(ctx, &pb.AdjustStockRequest{
+			ProductId:      product.ProductID.String(),
+			QuantityChange: int32(req.StockQuantity),
+			Reason:         "init stock from monolith",
+			UserId:         "admin",
+			LocationId:     locationID.String(),
+		})
+
+		if errGrpc != nil {
+			tx.Rollback()
+			service.Logger.Errorf("-failed to sync inventory: %v", errGrpc)
+			return web.ProductResponse{}, errGrpc
+		}
 	}
 
 	service.Logger.Info("-trying to commit tx...")
@@ -96,7 +209,13 @@ func (service *ProductServiceImpl) Create(ctx context.Context, req web.ProductRe
 	return helper.ToProductResponse(savedProduct), nil
 }
 
-func (service *ProductServiceImpl) FindAll(ctx context.Context) ([]web.ProductResponse, error) {
+func (service *ProductServiceImpl) FindAll(ctx context.Context, locationID *ulid.ULID) ([]web.ProductResponse, error) {
+	stockLocation, err := locationOrDefault(locationID)
+	if err != nil {
+		service.Logger.Errorf("-failed to resolve location: %v", err)
+		return []web.ProductResponse{}, err
+	}
+
 	service.Logger.Info("-trying to begin tx...")
 	tx, err := service.DB.Begin()
 	if err != nil {
@@ -120,29 +239,62 @@ func (service *ProductServiceImpl) FindAll(ctx context.Context) ([]web.ProductRe
 	}
 
 	var productIDs []string
+	var productULIDs []ulid.ULID
 	for _, p := range selectedProducts {
 		productIDs = append(productIDs, p.ProductID.String())
+		productULIDs = append(productULIDs, p.ProductID)
+	}
+
+	service.Logger.Info("-executing ProductRepository.FindBarcodes()...")
+	barcodes, err := service.ProductRepository.FindBarcodes(ctx, tx, productULIDs)
+	if err != nil {
+		service.Logger.Errorf("-failed to find barcodes: %v", err)
+		return []web.ProductResponse{}, err
+	}
+
+	service.Logger.Info("-executing ProductRepository.FindAttributes()...")
+	attributes, err := service.ProductRepository.FindAttributes(ctx, tx, productULIDs)
+	if err != nil {
+		service.Logger.Errorf("-failed to find variant attributes: %v", err)
+		return []web.ProductResponse{}, err
+	}
+
+	service.Logger.Info("-executing ProductRepository.FindComponents()...")
+	components, err := service.ProductRepository.FindComponents(ctx, tx, productULIDs)
+	if err != nil {
+		service.Logger.Errorf("-failed to find bundle components: %v", err)
+		return []web.ProductResponse{}, err
 	}
 
 	service.Logger.Info("-fetching batch stock from microservice...")
 	batchResp, errGrpc := service.InventoryClient.GetBatchStock(ctx, &pb.GetBatchStockRequest{
 		ProductIds: productIDs,
+		LocationId: stockLocation.String(),
 	})
 
-	stockMap := make(map[string]int)
+	stockMap := make(map[string]*pb.BatchStockItem)
 	if errGrpc != nil {
 
 		service.Logger.Warnf("-failed to fetch batch stock: %v", errGrpc)
 	} else {
 		for _, item := range batchResp.Items {
-			stockMap[item.ProductId] = int(item.Quantity)
+			stockMap[item.ProductId] = item
 		}
 	}
 
 	for i := range selectedProducts {
 		pid := selectedProducts[i].ProductID.String()
-		selectedProducts[i].StockQuantity = stockMap[pid]
+		selectedProducts[i].StockQuantity = int(stockMap[pid].GetQuantity())
+		selectedProducts[i].ReservedQuantity = int(stockMap[pid].GetReserved())
+		selectedProducts[i].AvailableQuantity = int(stockMap[pid].GetAvailable())
+		selectedProducts[i].Barcodes = barcodes[selectedProducts[i].ProductID]
+		selectedProducts[i].Attributes = attributes[selectedProducts[i].ProductID]
+		selectedProducts[i].Components = components[selectedProducts[i].ProductID]
+		if len(selectedProducts[i].Components) > 0 {
+			applyBundleStock(&selectedProducts[i], stockMap)
+		}
 	}
+	selectedProducts = groupVariants(selectedProducts)
 
 	service.Logger.Info("successfully fetched all products with live stock")
 
@@ -156,7 +308,13 @@ func (service *ProductServiceImpl) FindAll(ctx context.Context) ([]web.ProductRe
 	return helper.ToProductResponses(selectedProducts), nil
 }
 
-func (service *ProductServiceImpl) FindByID(ctx context.Context, productID ulid.ULID) (web.ProductResponse, error) {
+func (service *ProductServiceImpl) FindByID(ctx context.Context, productID ulid.ULID, locationID *ulid.ULID) (web.ProductResponse, error) {
+	stockLocation, err := locationOrDefault(locationID)
+	if err != nil {
+		service.Logger.Errorf("-failed to resolve location: %v", err)
+		return web.ProductResponse{}, err
+	}
+
 	service.Logger.Info("-trying to begin tx...")
 	tx, err := service.DB.Begin()
 	if err != nil {
@@ -175,18 +333,106 @@ func (service *ProductServiceImpl) FindByID(ctx context.Context, productID ulid.
 		return web.ProductResponse{}, err
 	}
 
-	service.Logger.Info("-fetching live stock from microservice...")
-	stockResp, errGrpc := service.InventoryClient.GetStock(ctx, &pb.GetStockRequest{
-		ProductId: selectedProduct.ProductID.String(),
-	})
+	service.Logger.Info("-executing ProductRepository.FindByParentID()...")
+	variants, err := service.ProductRepository.FindByParentID(ctx, tx, productID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find variants: %v", err)
+		return web.ProductResponse{}, err
+	}
 
-	realStock := 0
-	if errGrpc != nil {
-		service.Logger.Warnf("-failed to fetch stock from microservice: %v", errGrpc)
+	relatedIDs := []ulid.ULID{productID}
+	variantIDs := make([]string, 0, len(variants))
+	for _, variant := range variants {
+		relatedIDs = append(relatedIDs, variant.ProductID)
+		variantIDs = append(variantIDs, variant.ProductID.String())
+	}
+
+	service.Logger.Info("-executing ProductRepository.FindBarcodes()...")
+	barcodes, err := service.ProductRepository.FindBarcodes(ctx, tx, relatedIDs)
+	if err != nil {
+		service.Logger.Errorf("-failed to find barcodes: %v", err)
+		return web.ProductResponse{}, err
+	}
+
+	service.Logger.Info("-executing ProductRepository.FindAttributes()...")
+	attributes, err := service.ProductRepository.FindAttributes(ctx, tx, relatedIDs)
+	if err != nil {
+		service.Logger.Errorf("-failed to find variant attributes: %v", err)
+		return web.ProductResponse{}, err
+	}
+	selectedProduct.Barcodes = barcodes[productID]
+	selectedProduct.Attributes = attributes[productID]
+
+	service.Logger.Info("-executing ProductRepository.FindComponents()...")
+	components, err := service.ProductRepository.FindComponents(ctx, tx, []ulid.ULID{productID})
+	if err != nil {
+		service.Logger.Errorf("-failed to find bundle components: %v", err)
+		return web.ProductResponse{}, err
+	}
+	selectedProduct.Components = components[productID]
+
+	if len(variants) > 0 {
+		service.Logger.Info("-fetching batch stock of the variants from microservice...")
+		batchResp, errGrpc := service.InventoryClient.GetBatchStock(ctx, &pb.GetBatchStockRequest{
+			ProductIds: variantIDs,
+			LocationId: stockLocation.String(),
+		})
+
+		stockMap := make(map[string]*pb.BatchStockItem)
+		if errGrpc != nil {
+			service.Logger.Warnf("-failed to fetch batch stock: %v", errGrpc)
+		} else {
+			for _, item := range batchResp.Items {
+				stockMap[item.ProductId] = item
+			}
+		}
+
+		for i := range variants {
+			pid := variants[i].ProductID.String()
+			variants[i].StockQuantity = int(stockMap[pid].GetQuantity())
+			variants[i].ReservedQuantity = int(stockMap[pid].GetReserved())
+			variants[i].AvailableQuantity = int(stockMap[pid].GetAvailable())
+			variants[i].Barcodes = barcodes[variants[i].ProductID]
+			variants[i].Attributes = attributes[variants[i].ProductID]
+		}
+		selectedProduct.Variants = variants
+	}
+
+	if len(selectedProduct.Components) > 0 {
+		componentIDs := make([]string, 0, len(selectedProduct.Components))
+		for _, component := range selectedProduct.Components {
+			componentIDs = append(componentIDs, component.ProductID.String())
+		}
+
+		service.Logger.Info("-fetching batch stock of the components from microservice...")
+		batchResp, errGrpc := service.InventoryClient.GetBatchStock(ctx, &pb.GetBatchStockRequest{
+			ProductIds: componentIDs,
+			LocationId: stockLocation.String(),
+		})
+
+		stockMap := make(map[string]*pb.BatchStockItem)
+		if errGrpc != nil {
+			service.Logger.Warnf("-failed to fetch batch stock: %v", errGrpc)
+		} else {
+			for _, item := range batchResp.Items {
+				stockMap[item.ProductId] = item
+			}
+		}
+		applyBundleStock(&selectedProduct, stockMap)
 	} else {
-		realStock = int(stockResp.Quantity)
+		service.Logger.Info("-fetching live stock from microservice...")
+		stockResp, errGrpc := service.InventoryClient.GetStock(ctx, &pb.GetStockRequest{
+			ProductId:  selectedProduct.ProductID.String(),
+			LocationId: stockLocation.String(),
+		})
+
+		if errGrpc != nil {
+			service.Logger.Warnf("-failed to fetch stock from microservice: %v", errGrpc)
+		}
+		selectedProduct.StockQuantity = int(stockResp.GetQuantity())
+		selectedProduct.ReservedQuantity = int(stockResp.GetReserved())
+		selectedProduct.AvailableQuantity = int(stockResp.GetAvailable())
 	}
-	selectedProduct.StockQuantity = realStock
 	service.Logger.Info("successfully fetched product with live stock")
 
 	service.Logger.Info("-trying to commit tx...")
@@ -207,6 +453,33 @@ func (service *ProductServiceImpl) Update(ctx context.Context, req web.ProductUp
 		return web.ProductUpdateResponse{}, err
 	}
 
+	var attributes []domain.ProductAttribute
+	if req.Attributes != nil {
+		attributes, err = parseAttributes(*req.Attributes)
+		if err != nil || len(attributes) == 0 {
+			service.Logger.Warnf("-invalid variant attributes %v", *req.Attributes)
+			return web.ProductUpdateResponse{}, exception.ErrInvalidVariant
+		}
+	}
+
+	var barcodes []domain.ProductBarcode
+	if req.Barcodes != nil {
+		barcodes, err = parseBarcodes(*req.Barcodes)
+		if err != nil {
+			service.Logger.Warnf("-invalid barcodes %v", *req.Barcodes)
+			return web.ProductUpdateResponse{}, err
+		}
+	}
+
+	var components []domain.ProductComponent
+	if req.Components != nil {
+		components, err = parseComponents(*req.Components, &req.ProductID)
+		if err != nil || len(components) == 0 {
+			service.Logger.Warnf("-invalid bundle components %v", *req.Components)
+			return web.ProductUpdateResponse{}, exception.ErrInvalidBundle
+		}
+	}
+
 	service.Logger.Info("-trying to begin tx...")
 	tx, err := service.DB.Begin()
 	if err != nil {
@@ -228,6 +501,39 @@ func (service *ProductServiceImpl) Update(ctx context.Context, req web.ProductUp
 	if req.ProductName == nil {
 		req.ProductName = &selectedProduct.ProductName
 	}
+	if req.Attributes != nil {
+		if selectedProduct.ParentProductID == nil {
+			service.Logger.Warnf("-product %s is not a variant, it has no attributes", req.ProductID)
+			return web.ProductUpdateResponse{}, exception.ErrInvalidVariant
+		}
+		err = service.checkVariantAttributes(ctx, tx, *selectedProduct.ParentProductID, &req.ProductID, attributes)
+		if err != nil {
+			return web.ProductUpdateResponse{}, err
+		}
+	}
+
+	service.Logger.Info("-executing ProductRepository.FindComponents()...")
+	savedComponents, err := service.ProductRepository.FindComponents(ctx, tx, []ulid.ULID{req.ProductID})
+	if err != nil {
+		service.Logger.Errorf("-failed to find bundle components: %v", err)
+		return web.ProductUpdateResponse{}, err
+	}
+	if req.Components != nil {
+		if len(savedComponents[req.ProductID]) == 0 {
+			service.Logger.Warnf("-product %s is not a bundle, it has no components", req.ProductID)
+			return web.ProductUpdateResponse{}, exception.ErrInvalidBundle
+		}
+		components, err = service.checkComponents(ctx, tx, components)
+		if err != nil {
+			return web.ProductUpdateResponse{}, err
+		}
+		savedComponents[req.ProductID] = components
+	}
+
+	sku := selectedProduct.SKU
+	if req.SKU != nil {
+		sku = normalizeSKU(req.SKU)
+	}
 	if req.PurchasePrice == nil {
 		req.PurchasePrice = &selectedProduct.PurchasePrice
 	}
@@ -244,6 +550,7 @@ func (service *ProductServiceImpl) Update(ctx context.Context, req web.ProductUp
 	product := domain.ProductUpdate{
 		ProductID:     req.ProductID,
 		ProductName:   req.ProductName,
+		SKU:           sku,
 		PurchasePrice: req.PurchasePrice,
 		SellingPrice:  req.SellingPrice,
 		StockQuantity: &selectedProduct.StockQuantity,
@@ -262,6 +569,50 @@ func (service *ProductServiceImpl) Update(ctx context.Context, req web.ProductUp
 		return web.ProductUpdateResponse{}, err
 	}
 
+	if req.Barcodes != nil {
+		service.Logger.Info("-executing ProductRepository.SaveBarcodes()...")
+		err = service.ProductRepository.SaveBarcodes(ctx, tx, req.ProductID, barcodes)
+		if err != nil {
+			service.Logger.Errorf("-failed to replace barcodes: %v", err)
+			return web.ProductUpdateResponse{}, err
+		}
+	}
+
+	if req.Attributes != nil {
+		service.Logger.Info("-executing ProductRepository.SaveAttributes()...")
+		err = service.ProductRepository.SaveAttributes(ctx, tx, req.ProductID, attributes)
+		if err != nil {
+			service.Logger.Errorf("-failed to replace variant attributes: %v", err)
+			return web.ProductUpdateResponse{}, err
+		}
+	}
+
+	if req.Components != nil {
+		service.Logger.Info("-executing ProductRepository.SaveComponents()...")
+		err = service.ProductRepository.SaveComponents(ctx, tx, req.ProductID, components)
+		if err != nil {
+			service.Logger.Errorf("-failed to replace bundle components: %v", err)
+			return web.ProductUpdateResponse{}, err
+		}
+	}
+	updatedProduct.Components = savedComponents[req.ProductID]
+
+	service.Logger.Info("-executing ProductRepository.FindBarcodes()...")
+	savedBarcodes, err := service.ProductRepository.FindBarcodes(ctx, tx, []ulid.ULID{req.ProductID})
+	if err != nil {
+		service.Logger.Errorf("-failed to find barcodes: %v", err)
+		return web.ProductUpdateResponse{}, err
+	}
+	updatedProduct.Barcodes = savedBarcodes[req.ProductID]
+
+	service.Logger.Info("-executing ProductRepository.FindAttributes()...")
+	savedAttributes, err := service.ProductRepository.FindAttributes(ctx, tx, []ulid.ULID{req.ProductID})
+	if err != nil {
+		service.Logger.Errorf("-failed to find variant attributes: %v", err)
+		return web.ProductUpdateResponse{}, err
+	}
+	updatedProduct.Attributes = savedAttributes[req.ProductID]
+
 	service.Logger.Info("-trying to commit tx...")
 	errCommit := tx.Commit()
 	if errCommit != nil {
@@ -271,6 +622,45 @@ func (service *ProductServiceImpl) Update(ctx context.Context, req web.ProductUp
 	return helper.ToProductUpdateResponse(updatedProduct), nil
 }
 
+func (service *ProductServiceImpl) Lookup(ctx context.Context, barcode string, sku string, locationID *ulid.ULID) (web.ProductResponse, error) {
+	barcode = strings.TrimSpace(barcode)
+	sku = strings.TrimSpace(sku)
+	if (barcode == "") == (sku == "") {
+		service.Logger.Warnf("-lookup needs exactly one of barcode %q or sku %q", barcode, sku)
+		return web.ProductResponse{}, exception.ErrInvalidProductLookup
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.ProductResponse{}, err
+	}
+	defer tx.Rollback()
+
+	var productID ulid.ULID
+	if barcode != "" {
+		productID, err = findProductIDByBarcode(ctx, tx, service.ProductRepository, barcode)
+	} else {
+		service.Logger.Info("-executing ProductRepository.FindIDBySKU()...")
+		productID, err = service.ProductRepository.FindIDBySKU(ctx, tx, sku)
+		if err == sql.ErrNoRows {
+			err = exception.ErrNotFound
+		}
+	}
+	if err != nil {
+		service.Logger.Errorf("-failed to look the product up: %v", err)
+		return web.ProductResponse{}, err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.ProductResponse{}, errCommit
+	}
+
+	return service.FindByID(ctx, productID, locationID)
+}
+
 func (service *ProductServiceImpl) UpdateStock(ctx context.Context, req web.ProductUpdateStockRequest) (web.ProductUpdateResponse, error) {
 	service.Logger.Info("-validating the request body...")
 	err := service.Validate.Struct(req)
@@ -345,3 +735,235 @@ func (service *ProductServiceImpl) Delete(ctx context.Context, ProductID ulid.UL
 	service.Logger.Info("-returning back to controller layer...")
 	return err
 }
+
+func (service *ProductServiceImpl) checkVariantAttributes(ctx context.Context, tx *sql.Tx, parentProductID ulid.ULID, productID *ulid.ULID, attributes []domain.ProductAttribute) error {
+	service.Logger.Info("-executing ProductRepository.FindByParentID()...")
+	siblings, err := service.ProductRepository.FindByParentID(ctx, tx, parentProductID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find variants: %v", err)
+		return err
+	}
+
+	siblingIDs := make([]ulid.ULID, 0, len(siblings))
+	for _, sibling := range siblings {
+		if productID == nil || sibling.ProductID != *productID {
+			siblingIDs = append(siblingIDs, sibling.ProductID)
+		}
+	}
+
+	siblingAttributes, err := service.ProductRepository.FindAttributes(ctx, tx, siblingIDs)
+	if err != nil {
+		service.Logger.Errorf("-failed to find variant attributes: %v", err)
+		return err
+	}
+
+	key := variantKey(attributes)
+	for _, siblingID := range siblingIDs {
+		if variantKey(siblingAttributes[siblingID]) == key {
+			service.Logger.Warnf("-variant %s of %s already has attributes %s", siblingID, parentProductID, key)
+			return exception.ErrVariantExists
+		}
+	}
+	return nil
+}
+
+func (service *ProductServiceImpl) checkComponents(ctx context.Context, tx *sql.Tx, components []domain.ProductComponent) ([]domain.ProductComponent, error) {
+	componentIDs := make([]ulid.ULID, 0, len(components))
+	for i, component := range components {
+		service.Logger.Info("-executing ProductRepository.FindByID() (Component)...")
+		product, err := service.ProductRepository.FindByID(ctx, tx, component.ProductID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, exception.ErrNotFound
+			}
+			service.Logger.Errorf("-failed to find component product: %v", err)
+			return nil, err
+		}
+		components[i].ProductName = product.ProductName
+
+		variantCount, err := service.ProductRepository.CountVariants(ctx, tx, component.ProductID)
+		if err != nil {
+			service.Logger.Errorf("-failed to count variants: %v", err)
+			return nil, err
+		}
+		if variantCount > 0 {
+			service.Logger.Warnf("-component %s has variants, one of them must be used", component.ProductID)
+			return nil, exception.ErrInvalidBundle
+		}
+		componentIDs = append(componentIDs, component.ProductID)
+	}
+
+	service.Logger.Info("-executing ProductRepository.FindComponents() (Component)...")
+	nested, err := service.ProductRepository.FindComponents(ctx, tx, componentIDs)
+	if err != nil {
+		service.Logger.Errorf("-failed to find bundle components: %v", err)
+		return nil, err
+	}
+	if len(nested) > 0 {
+		service.Logger.Warnf("-bundles cannot be components of another bundle")
+		return nil, exception.ErrInvalidBundle
+	}
+
+	return components, nil
+}
+
+func applyBundleStock(bundle *domain.Product, stockMap map[string]*pb.BatchStockItem) {
+	bundle.ReservedQuantity = 0
+	for i, component := range bundle.Components {
+		item := stockMap[component.ProductID.String()]
+		stock := max(int(item.GetQuantity())/component.Quantity, 0)
+		available := max(int(item.GetAvailable())/component.Quantity, 0)
+		if i == 0 || stock < bundle.StockQuantity {
+			bundle.StockQuantity = stock
+		}
+		if i == 0 || available < bundle.AvailableQuantity {
+			bundle.AvailableQuantity = available
+		}
+	}
+}
+
+func parseComponents(values []web.ProductComponentRequest, bundleProductID *ulid.ULID) ([]domain.ProductComponent, error) {
+	components := make([]domain.ProductComponent, 0, len(values))
+	seen := make(map[ulid.ULID]bool)
+	for _, value := range values {
+		if seen[value.ProductID] || (bundleProductID != nil && value.ProductID == *bundleProductID) {
+			return nil, exception.ErrInvalidBundle
+		}
+		seen[value.ProductID] = true
+		components = append(components, domain.ProductComponent{ProductID: value.ProductID, Quantity: value.Quantity})
+	}
+	return components, nil
+}
+
+func groupVariants(products []domain.Product) []domain.Product {
+	variants := make(map[ulid.ULID][]domain.Product)
+	for _, product := range products {
+		if product.ParentProductID != nil {
+			variants[*product.ParentProductID] = append(variants[*product.ParentProductID], product)
+		}
+	}
+
+	grouped := make([]domain.Product, 0)
+	for _, product := range products {
+		if product.ParentProductID == nil {
+			product.Variants = variants[product.ProductID]
+			grouped = append(grouped, product)
+		}
+	}
+	return grouped
+}
+
+func parseAttributes(values map[string]string) ([]domain.ProductAttribute, error) {
+	attributes := make([]domain.ProductAttribute, 0, len(values))
+	seen := make(map[string]bool)
+	for name, value := range values {
+		name = strings.ToLower(strings.TrimSpace(name))
+		value = strings.TrimSpace(value)
+		if name == "" || value == "" || seen[name] {
+			return nil, exception.ErrInvalidVariant
+		}
+		seen[name] = true
+		attributes = append(attributes, domain.ProductAttribute{AttributeName: name, AttributeValue: value})
+	}
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].AttributeName < attributes[j].AttributeName
+	})
+	return attributes, nil
+}
+
+func variantKey(attributes []domain.ProductAttribute) string {
+	parts := make([]string, 0, len(attributes))
+	for _, attribute := range attributes {
+		parts = append(parts, attribute.AttributeName+"="+strings.ToLower(attribute.AttributeValue))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func variantName(parentName string, attributes []domain.ProductAttribute) string {
+	values := make([]string, 0, len(attributes))
+	for _, attribute := range attributes {
+		values = append(values, attribute.AttributeValue)
+	}
+	return parentName + " - " + strings.Join(values, " / ")
+}
+
+func findProductIDByBarcode(ctx context.Context, tx *sql.Tx, productRepository repository.ProductRepository, code string) (ulid.ULID, error) {
+	barcode, err := parseBarcode(code)
+	if err != nil {
+		return ulid.ULID{}, err
+	}
+
+	productID, err := productRepository.FindIDByBarcode(ctx, tx, barcode.Barcode)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ulid.ULID{}, exception.ErrNotFound
+		}
+		return ulid.ULID{}, err
+	}
+
+	return productID, nil
+}
+
+func parseBarcodes(codes []string) ([]domain.ProductBarcode, error) {
+	barcodes := make([]domain.ProductBarcode, 0, len(codes))
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		barcode, err := parseBarcode(code)
+		if err != nil {
+			return nil, err
+		}
+		if seen[barcode.Barcode] {
+			continue
+		}
+		seen[barcode.Barcode] = true
+		barcodes = append(barcodes, barcode)
+	}
+	return barcodes, nil
+}
+
+func parseBarcode(code string) (domain.ProductBarcode, error) {
+	code = strings.TrimSpace(code)
+	for _, digit := range code {
+		if digit < '0' || digit > '9' {
+			return domain.ProductBarcode{}, exception.ErrInvalidBarcode
+		}
+	}
+	if len(code) == 13 && code[0] == '0' {
+		code = code[1:]
+	}
+	if (len(code) != 12 && len(code) != 13) || !validGS1CheckDigit(code) {
+		return domain.ProductBarcode{}, exception.ErrInvalidBarcode
+	}
+
+	barcode := domain.ProductBarcode{Barcode: code, BarcodeType: domain.BarcodeTypeEAN13}
+	switch {
+	case len(code) == 12:
+		barcode.BarcodeType = domain.BarcodeTypeUPCA
+	case code[0] == '2':
+		barcode.BarcodeType = domain.BarcodeTypeInternal
+	}
+	return barcode, nil
+}
+
+func validGS1CheckDigit(code string) bool {
+	sum := 0
+	for i := len(code) - 2; i >= 0; i-- {
+		digit := int(code[i] - '0')
+		if (len(code)-2-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return (10-sum%10)%10 == int(code[len(code)-1]-'0')
+}
+
+func normalizeSKU(sku *string) *string {
+	if sku == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*sku)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"retail-management/model/web"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type PromotionService interface {
+	Create(ctx context.Context, req web.PromotionRequest) (web.PromotionResponse, error)
+	FindAll(ctx context.Context, activeOnly bool) ([]web.PromotionResponse, error)
+	FindByID(ctx context.Context, promotionID ulid.ULID) (web.PromotionResponse, error)
+	Update(ctx context.Context, req web.PromotionUpdateRequest) (web.PromotionResponse, error)
+	Delete(ctx context.Context, promotionID ulid.ULID) error
+}
@@ -0,0 +1,389 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"retail-management/exception"
+	"retail-management/helper"
+	"retail-management/model/domain"
+	"retail-management/model/web"
+	"retail-management/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+type PromotionServiceImpl struct {
+	PromotionRepository repository.PromotionRepository
+	DB                  *sql.DB
+	Validate            *validator.Validate
+	Logger              *logrus.Logger
+}
+
+func NewPromotionService(promotionRepository repository.PromotionRepository, db *sql.DB, validate *validator.Validate, logger *logrus.Logger) PromotionService {
+	return &PromotionServiceImpl{
+		PromotionRepository: promotionRepository,
+		DB:                  db,
+		Validate:            validate,
+		Logger:              logger,
+	}
+}
+
+func (service *PromotionServiceImpl) Create(ctx context.Context, req web.PromotionRequest) (web.PromotionResponse, error) {
+	service.Logger.Info("-validating the request...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating request: %v", err)
+		return web.PromotionResponse{}, err
+	}
+
+	t := time.Now()
+	promotion := domain.Promotion{
+		PromotionID:   ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(rand.Reader, 0)),
+		PromotionName: req.PromotionName,
+		PromotionType: req.PromotionType,
+		ProductID:     req.ProductID,
+		CategoryID:    req.CategoryID,
+		Value:         req.Value,
+		BuyQuantity:   req.BuyQuantity,
+		GetQuantity:   req.GetQuantity,
+		CouponCode:    req.CouponCode,
+		StartsAt:      req.StartsAt,
+		EndsAt:        req.EndsAt,
+		UsageLimit:    req.UsageLimit,
+		Active:        req.Active == nil || *req.Active,
+		CreatedAt:     t,
+	}
+	if req.MinSpend != nil {
+		promotion.MinSpend = decimal.NewNullDecimal(*req.MinSpend)
+	}
+
+	err = validatePromotion(promotion)
+	if err != nil {
+		service.Logger.Warnf("-invalid %s promotion %q", promotion.PromotionType, promotion.PromotionName)
+		return web.PromotionResponse{}, err
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.PromotionResponse{}, err
+	}
+	defer tx.Rollback()
+
+	service.Logger.Info("-executing PromotionRepository.Save()...")
+	promotion, err = service.PromotionRepository.Save(ctx, tx, promotion)
+	if err != nil {
+		return web.PromotionResponse{}, err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.PromotionResponse{}, errCommit
+	}
+
+	return helper.ToPromotionResponse(promotion), nil
+}
+
+func (service *PromotionServiceImpl) FindAll(ctx context.Context, activeOnly bool) ([]web.PromotionResponse, error) {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return []web.PromotionResponse{}, err
+	}
+	defer tx.Commit()
+
+	service.Logger.Info("-executing PromotionRepository.FindAll()...")
+	promotions, err := service.PromotionRepository.FindAll(ctx, tx, activeOnly)
+	if err != nil {
+		service.Logger.Errorf("-failed to execute it: %v", err)
+		return []web.PromotionResponse{}, err
+	}
+
+	return helper.ToPromotionResponses(promotions), nil
+}
+
+func (service *PromotionServiceImpl) FindByID(ctx context.Context, promotionID ulid.ULID) (web.PromotionResponse, error) {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.PromotionResponse{}, err
+	}
+	defer tx.Commit()
+
+	service.Logger.Info("-executing PromotionRepository.FindByID()...")
+	promotion, err := service.PromotionRepository.FindByID(ctx, tx, promotionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.PromotionResponse{}, exception.ErrNotFound
+		}
+		return web.PromotionResponse{}, err
+	}
+
+	return helper.ToPromotionResponse(promotion), nil
+}
+
+func (service *PromotionServiceImpl) Update(ctx context.Context, req web.PromotionUpdateRequest) (web.PromotionResponse, error) {
+	service.Logger.Info("-validating the request...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating request: %v", err)
+		return web.PromotionResponse{}, err
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.PromotionResponse{}, err
+	}
+	defer tx.Rollback()
+
+	service.Logger.Info("-executing PromotionRepository.FindByID()...")
+	promotion, err := service.PromotionRepository.FindByID(ctx, tx, req.PromotionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.PromotionResponse{}, exception.ErrNotFound
+		}
+		return web.PromotionResponse{}, err
+	}
+
+	promotion.PromotionName = req.PromotionName
+	promotion.PromotionType = req.PromotionType
+	promotion.ProductID = req.ProductID
+	promotion.CategoryID = req.CategoryID
+	promotion.Value = req.Value
+	promotion.BuyQuantity = req.BuyQuantity
+	promotion.GetQuantity = req.GetQuantity
+	promotion.MinSpend = decimal.NullDecimal{}
+	if req.MinSpend != nil {
+		promotion.MinSpend = decimal.NewNullDecimal(*req.MinSpend)
+	}
+	promotion.CouponCode = req.CouponCode
+	promotion.StartsAt = req.StartsAt
+	promotion.EndsAt = req.EndsAt
+	promotion.UsageLimit = req.UsageLimit
+	if req.Active != nil {
+		promotion.Active = *req.Active
+	}
+
+	err = validatePromotion(promotion)
+	if err != nil {
+		service.Logger.Warnf("-invalid %s promotion %q", promotion.PromotionType, promotion.PromotionName)
+		return web.PromotionResponse{}, err
+	}
+
+	service.Logger.Info("-executing PromotionRepository.Update()...")
+	promotion, err = service.PromotionRepository.Update(ctx, tx, promotion)
+	if err != nil {
+		return web.PromotionResponse{}, err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.PromotionResponse{}, errCommit
+	}
+
+	return helper.ToPromotionResponse(promotion), nil
+}
+
+func (service *PromotionServiceImpl) Delete(ctx context.Context, promotionID ulid.ULID) error {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	service.Logger.Info("-executing PromotionRepository.Delete()...")
+	err = service.PromotionRepository.Delete(ctx, tx, promotionID)
+	if err != nil {
+		return err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	return tx.Commit()
+}
+
+func validatePromotion(promotion domain.Promotion) error {
+	if promotion.ProductID != nil && promotion.CategoryID != nil {
+		return exception.ErrInvalidPromotion
+	}
+	if promotion.MinSpend.Valid && promotion.MinSpend.Decimal.IsNegative() {
+		return exception.ErrInvalidPromotion
+	}
+	if promotion.StartsAt != nil && promotion.EndsAt != nil && !promotion.EndsAt.After(*promotion.StartsAt) {
+		return exception.ErrInvalidPromotion
+	}
+
+	targeted := promotion.ProductID != nil || promotion.CategoryID != nil
+	switch promotion.PromotionType {
+	case domain.PromotionTypePercentage:
+		if !promotion.Value.IsPositive() || promotion.Value.GreaterThan(decimal.NewFromInt(100)) {
+			return exception.ErrInvalidPromotion
+		}
+	case domain.PromotionTypeFixedAmount:
+		if !promotion.Value.IsPositive() {
+			return exception.ErrInvalidPromotion
+		}
+	case domain.PromotionTypeBuyXGetY:
+		if !targeted || promotion.BuyQuantity < 1 || promotion.GetQuantity < 1 {
+			return exception.ErrInvalidPromotion
+		}
+	case domain.PromotionTypeBundlePrice:
+		if !targeted || promotion.BuyQuantity < 2 || !promotion.Value.IsPositive() {
+			return exception.ErrInvalidPromotion
+		}
+	}
+
+	return nil
+}
+
+type basketLine struct {
+	DetailID        ulid.ULID
+	ProductID       ulid.ULID
+	ParentProductID *ulid.ULID
+	CategoryID      ulid.ULID
+	Quantity        int
+	Price           decimal.Decimal
+}
+
+func applyPromotions(lines []basketLine, promotions []domain.Promotion) ([]decimal.Decimal, []domain.TransactionDiscount) {
+	lineDiscounts := make([]decimal.Decimal, len(lines))
+	var discounts []domain.TransactionDiscount
+
+	grossAmount := decimal.Zero
+	for _, line := range lines {
+		grossAmount = grossAmount.Add(line.Price.Mul(decimal.NewFromInt(int64(line.Quantity))))
+	}
+
+	eligible := make([]domain.Promotion, 0, len(promotions))
+	for _, promotion := range promotions {
+		if promotion.MinSpend.Valid && grossAmount.LessThan(promotion.MinSpend.Decimal) {
+			continue
+		}
+		eligible = append(eligible, promotion)
+	}
+
+	remaining := decimal.Zero
+	for i, line := range lines {
+		lineDiscounts[i] = decimal.Zero
+		var best *domain.Promotion
+		for j := range eligible {
+			promotion := &eligible[j]
+			if !promotionTargets(*promotion, line) {
+				continue
+			}
+			amount := lineDiscount(*promotion, line)
+			if promotionBeats(promotion, amount, best, lineDiscounts[i]) {
+				lineDiscounts[i] = amount
+				best = promotion
+			}
+		}
+		if best != nil {
+			discounts = append(discounts, domain.TransactionDiscount{
+				DetailID:      &lines[i].DetailID,
+				PromotionID:   best.PromotionID,
+				PromotionName: best.PromotionName,
+				Amount:        lineDiscounts[i],
+			})
+		}
+		remaining = remaining.Add(line.Price.Mul(decimal.NewFromInt(int64(line.Quantity))).Sub(lineDiscounts[i]))
+	}
+
+	if !remaining.IsPositive() {
+		return lineDiscounts, discounts
+	}
+
+	basketDiscount := decimal.Zero
+	var best *domain.Promotion
+	for j := range eligible {
+		promotion := &eligible[j]
+		if promotion.ProductID != nil || promotion.CategoryID != nil {
+			continue
+		}
+		amount := decimal.Zero
+		switch promotion.PromotionType {
+		case domain.PromotionTypePercentage:
+			amount = remaining.Mul(promotion.Value).Div(decimal.NewFromInt(100)).Round(2)
+		case domain.PromotionTypeFixedAmount:
+			amount = decimal.Min(promotion.Value, remaining)
+		}
+		if promotionBeats(promotion, amount, best, basketDiscount) {
+			basketDiscount = amount
+			best = promotion
+		}
+	}
+	if best == nil {
+		return lineDiscounts, discounts
+	}
+
+	allocated := decimal.Zero
+	for i, line := range lines {
+		lineNet := line.Price.Mul(decimal.NewFromInt(int64(line.Quantity))).Sub(lineDiscounts[i])
+		share := basketDiscount.Sub(allocated)
+		if i < len(lines)-1 {
+			share = basketDiscount.Mul(lineNet).Div(remaining).Round(2)
+		}
+		share = decimal.Max(decimal.Zero, decimal.Min(share, lineNet))
+		lineDiscounts[i] = lineDiscounts[i].Add(share)
+		allocated = allocated.Add(share)
+	}
+	discounts = append(discounts, domain.TransactionDiscount{
+		PromotionID:   best.PromotionID,
+		PromotionName: best.PromotionName,
+		Amount:        allocated,
+	})
+
+	return lineDiscounts, discounts
+}
+
+func promotionBeats(promotion *domain.Promotion, amount decimal.Decimal, best *domain.Promotion, bestAmount decimal.Decimal) bool {
+	if !amount.IsPositive() {
+		return false
+	}
+	if best == nil {
+		return true
+	}
+	if (promotion.CouponCode != nil) != (best.CouponCode != nil) {
+		return promotion.CouponCode != nil
+	}
+	return amount.GreaterThan(bestAmount)
+}
+
+func promotionTargets(promotion domain.Promotion, line basketLine) bool {
+	if promotion.ProductID != nil {
+		return *promotion.ProductID == line.ProductID || (line.ParentProductID != nil && *promotion.ProductID == *line.ParentProductID)
+	}
+	if promotion.CategoryID != nil {
+		return *promotion.CategoryID == line.CategoryID
+	}
+	return false
+}
+
+func lineDiscount(promotion domain.Promotion, line basketLine) decimal.Decimal {
+	quantity := decimal.NewFromInt(int64(line.Quantity))
+	lineAmount := line.Price.Mul(quantity)
+
+	amount := decimal.Zero
+	switch promotion.PromotionType {
+	case domain.PromotionTypePercentage:
+		amount = lineAmount.Mul(promotion.Value).Div(decimal.NewFromInt(100))
+	case domain.PromotionTypeFixedAmount:
+		amount = promotion.Value.Mul(quantity)
+	case domain.PromotionTypeBuyXGetY:
+		free := line.Quantity / (promotion.BuyQuantity + promotion.GetQuantity) * promotion.GetQuantity
+		amount = line.Price.Mul(decimal.NewFromInt(int64(free)))
+	case domain.PromotionTypeBundlePrice:
+		bundles := decimal.NewFromInt(int64(line.Quantity / promotion.BuyQuantity))
+		saving := line.Price.Mul(decimal.NewFromInt(int64(promotion.BuyQuantity))).Sub(promotion.Value)
+		amount = decimal.Max(saving, decimal.Zero).Mul(bundles)
+	}
+
+	return decimal.Min(amount, lineAmount).Round(2)
+}
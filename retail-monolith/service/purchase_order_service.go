@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"retail-management/model/web"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type PurchaseOrderService interface {
+	Create(ctx context.Context, req web.PurchaseOrderRequest) (web.PurchaseOrderResponse, error)
+	FindAll(ctx context.Context, status string, supplierID *ulid.ULID) ([]web.PurchaseOrderResponse, error)
+	FindByID(ctx context.Context, purchaseOrderID ulid.ULID) (web.PurchaseOrderResponse, error)
+	Send(ctx context.Context, purchaseOrderID ulid.ULID) (web.PurchaseOrderResponse, error)
+	Receive(ctx context.Context, req web.GoodsReceiptRequest) (web.PurchaseOrderResponse, error)
+	Close(ctx context.Context, purchaseOrderID ulid.ULID) (web.PurchaseOrderResponse, error)
+}
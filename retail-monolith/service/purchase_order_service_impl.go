@@ -0,0 +1,511 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"fmt"
+	"retail-management/exception"
+	"retail-management/helper"
+	"retail-management/model/domain"
+	"retail-management/model/web"
+	"retail-management/pb"
+	"retail-management/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type PurchaseOrderServiceImpl struct {
+	PurchaseOrderRepository repository.PurchaseOrderRepository
+	ProductRepository       repository.ProductRepository
+	SupplierRepository      repository.SupplierRepository
+	CostRepository          repository.CostRepository
+	InventoryClient         pb.InventoryServiceClient
+	DB                      *sql.DB
+	Validate                *validator.Validate
+	Logger                  *logrus.Logger
+}
+
+func NewPurchaseOrderService(purchaseOrderRepository repository.PurchaseOrderRepository, productRepository repository.ProductRepository, supplierRepository repository.SupplierRepository, costRepository repository.CostRepository, inventoryClient pb.InventoryServiceClient, db *sql.DB, validate *validator.Validate, logger *logrus.Logger) PurchaseOrderService {
+	return &PurchaseOrderServiceImpl{
+		PurchaseOrderRepository: purchaseOrderRepository,
+		ProductRepository:       productRepository,
+		SupplierRepository:      supplierRepository,
+		CostRepository:          costRepository,
+		InventoryClient:         inventoryClient,
+		DB:                      db,
+		Validate:                validate,
+		Logger:                  logger,
+	}
+}
+
+func (service *PurchaseOrderServiceImpl) Create(ctx context.Context, req web.PurchaseOrderRequest) (web.PurchaseOrderResponse, error) {
+	service.Logger.Info("-validating the request...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating request: %v", err)
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	locationID, err := locationOrDefault(req.LocationID)
+	if err != nil {
+		service.Logger.Errorf("-failed to resolve receiving location: %v", err)
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	service.Logger.Info("-checking the location on inventory microservice...")
+	_, err = service.InventoryClient.GetLocation(ctx, &pb.GetLocationRequest{
+		LocationId: locationID.String(),
+	})
+	if err != nil {
+		service.Logger.Errorf("-failed to get location: %v", err)
+		if status.Code(err) == codes.NotFound {
+			return web.PurchaseOrderResponse{}, exception.ErrNotFound
+		}
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+	defer tx.Rollback()
+
+	_, err = service.SupplierRepository.FindByID(ctx, tx, req.SupplierID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.PurchaseOrderResponse{}, exception.ErrNotFound
+		}
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	t := time.Now()
+	purchaseOrder := domain.PurchaseOrder{
+		PurchaseOrderID: ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(rand.Reader, 0)),
+		SupplierID:      req.SupplierID,
+		LocationID:      locationID,
+		Status:          domain.PurchaseOrderStatusDraft,
+		Note:            req.Note,
+		CreatedBy:       req.UserID,
+		CreatedAt:       t,
+	}
+
+	var items []domain.PurchaseOrderItem
+	itemIndex := make(map[ulid.ULID]int)
+	for _, line := range req.Items {
+		if i, ok := itemIndex[line.ProductID]; ok {
+			items[i].Quantity += line.Quantity
+			items[i].PurchasePrice = line.PurchasePrice
+			continue
+		}
+
+		product, err := service.ProductRepository.FindByID(ctx, tx, line.ProductID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return web.PurchaseOrderResponse{}, exception.ErrNotFound
+			}
+			return web.PurchaseOrderResponse{}, err
+		}
+		if product.SupplierID != req.SupplierID {
+			service.Logger.Warnf("-product %s is not supplied by %s", product.ProductID, req.SupplierID)
+			return web.PurchaseOrderResponse{}, fmt.Errorf("%w: %s", exception.ErrProductSupplierMismatch, product.ProductName)
+		}
+
+		itemIndex[line.ProductID] = len(items)
+		items = append(items, domain.PurchaseOrderItem{
+			PurchaseOrderID: purchaseOrder.PurchaseOrderID,
+			ProductID:       line.ProductID,
+			Quantity:        line.Quantity,
+			PurchasePrice:   line.PurchasePrice,
+		})
+	}
+
+	service.Logger.Info("-executing PurchaseOrderRepository.Save()...")
+	_, err = service.PurchaseOrderRepository.Save(ctx, tx, purchaseOrder)
+	if err != nil {
+		service.Logger.Errorf("-failed to save purchase order: %v", err)
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	_, err = service.PurchaseOrderRepository.SaveItems(ctx, tx, items)
+	if err != nil {
+		service.Logger.Errorf("-failed to save purchase order items: %v", err)
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	response, err := service.loadPurchaseOrder(ctx, tx, purchaseOrder.PurchaseOrderID)
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.PurchaseOrderResponse{}, errCommit
+	}
+
+	return response, nil
+}
+
+func (service *PurchaseOrderServiceImpl) FindAll(ctx context.Context, orderStatus string, supplierID *ulid.ULID) ([]web.PurchaseOrderResponse, error) {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return []web.PurchaseOrderResponse{}, err
+	}
+	defer tx.Commit()
+
+	service.Logger.Info("-executing PurchaseOrderRepository.FindAll()...")
+	purchaseOrders, err := service.PurchaseOrderRepository.FindAll(ctx, tx, orderStatus, supplierID)
+	if err != nil {
+		service.Logger.Errorf("-failed to execute it: %v", err)
+		return []web.PurchaseOrderResponse{}, err
+	}
+
+	return helper.ToPurchaseOrderResponses(purchaseOrders), nil
+}
+
+func (service *PurchaseOrderServiceImpl) FindByID(ctx context.Context, purchaseOrderID ulid.ULID) (web.PurchaseOrderResponse, error) {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+	defer tx.Commit()
+
+	return service.loadPurchaseOrder(ctx, tx, purchaseOrderID)
+}
+
+func (service *PurchaseOrderServiceImpl) Send(ctx context.Context, purchaseOrderID ulid.ULID) (web.PurchaseOrderResponse, error) {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+	defer tx.Rollback()
+
+	purchaseOrder, err := service.lockPurchaseOrder(ctx, tx, purchaseOrderID)
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	if purchaseOrder.Status != domain.PurchaseOrderStatusDraft {
+		service.Logger.Warnf("-cannot send purchase order in status %s", purchaseOrder.Status)
+		return web.PurchaseOrderResponse{}, exception.ErrPurchaseOrderState
+	}
+
+	t := time.Now()
+	purchaseOrder.Status = domain.PurchaseOrderStatusSent
+	purchaseOrder.SentAt = &t
+
+	service.Logger.Info("-executing PurchaseOrderRepository.UpdateStatus()...")
+	err = service.PurchaseOrderRepository.UpdateStatus(ctx, tx, purchaseOrder)
+	if err != nil {
+		service.Logger.Errorf("-failed to execute it: %v", err)
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	response, err := service.loadPurchaseOrder(ctx, tx, purchaseOrderID)
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.PurchaseOrderResponse{}, errCommit
+	}
+
+	return response, nil
+}
+
+func (service *PurchaseOrderServiceImpl) Receive(ctx context.Context, req web.GoodsReceiptRequest) (web.PurchaseOrderResponse, error) {
+	service.Logger.Info("-validating the request...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating request: %v", err)
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+	defer tx.Rollback()
+
+	purchaseOrder, err := service.lockPurchaseOrder(ctx, tx, req.PurchaseOrderID)
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	if req.IdempotencyKey != "" {
+		_, err = service.PurchaseOrderRepository.FindReceiptByIdempotencyKey(ctx, tx, req.PurchaseOrderID, req.IdempotencyKey)
+		if err == nil {
+			service.Logger.Infof("-goods receipt with idempotency key %s already recorded, replaying result", req.IdempotencyKey)
+			return service.loadPurchaseOrder(ctx, tx, req.PurchaseOrderID)
+		}
+		if err != sql.ErrNoRows {
+			service.Logger.Errorf("-failed to find goods receipt by idempotency key: %v", err)
+			return web.PurchaseOrderResponse{}, err
+		}
+	}
+
+	if purchaseOrder.Status != domain.PurchaseOrderStatusSent && purchaseOrder.Status != domain.PurchaseOrderStatusPartiallyReceived {
+		service.Logger.Warnf("-cannot receive goods for purchase order in status %s", purchaseOrder.Status)
+		return web.PurchaseOrderResponse{}, exception.ErrPurchaseOrderState
+	}
+
+	items, err := service.PurchaseOrderRepository.FindItems(ctx, tx, req.PurchaseOrderID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find purchase order items: %v", err)
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	itemIndex := make(map[ulid.ULID]int)
+	for i, item := range items {
+		itemIndex[item.ProductID] = i
+	}
+
+	t := time.Now()
+	receipt := domain.GoodsReceipt{
+		ReceiptID:       ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(rand.Reader, 0)),
+		PurchaseOrderID: req.PurchaseOrderID,
+		ReceivedBy:      req.UserID,
+		Note:            req.Note,
+		ReceivedAt:      t,
+		IdempotencyKey:  req.IdempotencyKey,
+	}
+
+	var receiptItems []domain.GoodsReceiptItem
+	var stockItems []*pb.Item
+	receiptIndex := make(map[ulid.ULID]int)
+	for _, line := range req.Items {
+		i, ok := itemIndex[line.ProductID]
+		if !ok {
+			service.Logger.Warnf("-product %s is not on purchase order %s", line.ProductID, req.PurchaseOrderID)
+			return web.PurchaseOrderResponse{}, exception.ErrInvalidReceiptItem
+		}
+
+		if items[i].QuantityReceived+line.Quantity > items[i].Quantity {
+			service.Logger.Warnf("-receipt for %s exceeds ordered quantity", line.ProductID)
+			return web.PurchaseOrderResponse{}, fmt.Errorf("%w: %s", exception.ErrReceiptExceedsOrdered, items[i].ProductName)
+		}
+		items[i].QuantityReceived += line.Quantity
+
+		purchasePrice := items[i].PurchasePrice
+		if line.PurchasePrice != nil {
+			purchasePrice = *line.PurchasePrice
+		}
+
+		stockItems = append(stockItems, &pb.Item{
+			ProductId:  line.ProductID.String(),
+			Quantity:   int32(line.Quantity),
+			LotNumber:  line.LotNumber,
+			ExpiryDate: line.ExpiryDate,
+		})
+
+		if j, ok := receiptIndex[line.ProductID]; ok {
+			receiptItems[j].Quantity += line.Quantity
+			receiptItems[j].PurchasePrice = purchasePrice
+			continue
+		}
+
+		receiptIndex[line.ProductID] = len(receiptItems)
+		receiptItems = append(receiptItems, domain.GoodsReceiptItem{
+			ReceiptID:     receipt.ReceiptID,
+			ProductID:     line.ProductID,
+			Quantity:      line.Quantity,
+			PurchasePrice: purchasePrice,
+		})
+	}
+
+	service.Logger.Info("-executing PurchaseOrderRepository.SaveReceipt()...")
+	_, err = service.PurchaseOrderRepository.SaveReceipt(ctx, tx, receipt)
+	if err != nil {
+		service.Logger.Errorf("-failed to save goods receipt: %v", err)
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	_, err = service.PurchaseOrderRepository.SaveReceiptItems(ctx, tx, receiptItems)
+	if err != nil {
+		service.Logger.Errorf("-failed to save goods receipt items: %v", err)
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	for _, receiptItem := range receiptItems {
+		err = service.PurchaseOrderRepository.UpdateItemReceived(ctx, tx, items[itemIndex[receiptItem.ProductID]])
+		if err != nil {
+			service.Logger.Errorf("-failed to update received quantity: %v", err)
+			return web.PurchaseOrderResponse{}, err
+		}
+
+		service.Logger.Info("-updating the product purchase price...")
+		err = service.ProductRepository.UpdatePurchasePrice(ctx, tx, receiptItem.ProductID, receiptItem.PurchasePrice)
+		if err != nil {
+			service.Logger.Errorf("-failed to update purchase price: %v", err)
+			return web.PurchaseOrderResponse{}, err
+		}
+
+		service.Logger.Info("-recording the received cost layer...")
+		err = receiveCost(ctx, tx, service.CostRepository, receiptItem.ProductID, receiptItem.Quantity, receiptItem.PurchasePrice, fmt.Sprintf("purchase-receipt:%s", receipt.ReceiptID.String()), t)
+		if err != nil {
+			service.Logger.Errorf("-failed to record received cost: %v", err)
+			return web.PurchaseOrderResponse{}, err
+		}
+	}
+
+	purchaseOrder.Status = domain.PurchaseOrderStatusReceived
+	for _, item := range items {
+		if item.QuantityReceived < item.Quantity {
+			purchaseOrder.Status = domain.PurchaseOrderStatusPartiallyReceived
+			break
+		}
+	}
+
+	err = service.PurchaseOrderRepository.UpdateStatus(ctx, tx, purchaseOrder)
+	if err != nil {
+		service.Logger.Errorf("-failed to update purchase order status: %v", err)
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	response, err := service.loadPurchaseOrder(ctx, tx, req.PurchaseOrderID)
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	service.Logger.Info("-posting received stock to inventory microservice...")
+	operationID := receiptOperationID(receipt)
+	stockResp, err := service.InventoryClient.RestoreStock(ctx, &pb.RestoreStockRequest{
+		Items:       stockItems,
+		UserId:      req.UserID.String(),
+		OperationId: operationID,
+		Reason:      fmt.Sprintf("Purchase order receipt: %s", req.PurchaseOrderID.String()),
+		LocationId:  purchaseOrder.LocationID.String(),
+	})
+	if err != nil {
+		service.Logger.Errorf("-failed to post received stock: %v", err)
+		return web.PurchaseOrderResponse{}, err
+	}
+	if !stockResp.Success {
+		service.Logger.Errorf("-inventory rejected received stock: %s", stockResp.Message)
+		return web.PurchaseOrderResponse{}, fmt.Errorf("failed to post received stock: %s", stockResp.Message)
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		service.Logger.Errorf("-failed commit, cancelling the posted stock: %v", errCommit)
+		cancelStockOperation(ctx, service.InventoryClient, service.Logger, operationID, req.UserID, fmt.Sprintf("rollback purchase order receipt: %s", req.PurchaseOrderID.String()))
+		return web.PurchaseOrderResponse{}, errCommit
+	}
+
+	return response, nil
+}
+
+func (service *PurchaseOrderServiceImpl) Close(ctx context.Context, purchaseOrderID ulid.ULID) (web.PurchaseOrderResponse, error) {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+	defer tx.Rollback()
+
+	purchaseOrder, err := service.lockPurchaseOrder(ctx, tx, purchaseOrderID)
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	if purchaseOrder.Status == domain.PurchaseOrderStatusClosed {
+		service.Logger.Warn("-purchase order is already closed")
+		return web.PurchaseOrderResponse{}, exception.ErrPurchaseOrderState
+	}
+
+	t := time.Now()
+	purchaseOrder.Status = domain.PurchaseOrderStatusClosed
+	purchaseOrder.ClosedAt = &t
+
+	service.Logger.Info("-executing PurchaseOrderRepository.UpdateStatus()...")
+	err = service.PurchaseOrderRepository.UpdateStatus(ctx, tx, purchaseOrder)
+	if err != nil {
+		service.Logger.Errorf("-failed to execute it: %v", err)
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	response, err := service.loadPurchaseOrder(ctx, tx, purchaseOrderID)
+	if err != nil {
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.PurchaseOrderResponse{}, errCommit
+	}
+
+	return response, nil
+}
+
+func (service *PurchaseOrderServiceImpl) lockPurchaseOrder(ctx context.Context, tx *sql.Tx, purchaseOrderID ulid.ULID) (domain.PurchaseOrder, error) {
+	service.Logger.Info("-executing PurchaseOrderRepository.FindByIDForUpdate()...")
+	purchaseOrder, err := service.PurchaseOrderRepository.FindByIDForUpdate(ctx, tx, purchaseOrderID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.PurchaseOrder{}, exception.ErrNotFound
+		}
+		service.Logger.Errorf("-failed to lock purchase order: %v", err)
+		return domain.PurchaseOrder{}, err
+	}
+	return purchaseOrder, nil
+}
+
+func (service *PurchaseOrderServiceImpl) loadPurchaseOrder(ctx context.Context, tx *sql.Tx, purchaseOrderID ulid.ULID) (web.PurchaseOrderResponse, error) {
+	service.Logger.Info("-executing PurchaseOrderRepository.FindByID()...")
+	purchaseOrder, err := service.PurchaseOrderRepository.FindByID(ctx, tx, purchaseOrderID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.PurchaseOrderResponse{}, exception.ErrNotFound
+		}
+		service.Logger.Errorf("-failed to find purchase order: %v", err)
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	items, err := service.PurchaseOrderRepository.FindItems(ctx, tx, purchaseOrderID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find purchase order items: %v", err)
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	receipts, err := service.PurchaseOrderRepository.FindReceipts(ctx, tx, purchaseOrderID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find goods receipts: %v", err)
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	receiptItems, err := service.PurchaseOrderRepository.FindReceiptItems(ctx, tx, purchaseOrderID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find goods receipt items: %v", err)
+		return web.PurchaseOrderResponse{}, err
+	}
+
+	response := helper.ToPurchaseOrderResponse(purchaseOrder)
+	response.Items = helper.ToPurchaseOrderItemResponses(items)
+	response.Receipts = helper.ToGoodsReceiptResponses(receipts, receiptItems)
+	return response, nil
+}
+
+// retries with the same idempotency key post their stock under one operation, whatever receipt id they were given
+func receiptOperationID(receipt domain.GoodsReceipt) string {
+	if receipt.IdempotencyKey == "" {
+		return fmt.Sprintf("purchase-receipt:%s", receipt.ReceiptID.String())
+	}
+	sum := sha256.Sum256([]byte(receipt.PurchaseOrderID.String() + ":" + receipt.IdempotencyKey))
+	return fmt.Sprintf("purchase-receipt:%x", sum[:16])
+}
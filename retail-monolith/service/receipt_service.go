@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+	"retail-management/model/web"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type ReceiptService interface {
+	Render(ctx context.Context, requesterUserID ulid.ULID, requesterRole string, transactionID ulid.ULID, format string) (web.ReceiptResponse, error)
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"retail-management/exception"
+	"retail-management/helper"
+	"retail-management/model/domain"
+	"retail-management/model/web"
+	"retail-management/repository"
+	"strconv"
+	"strings"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type ReceiptServiceImpl struct {
+	TransactionRepository repository.TransactionRepository
+	UserRepository        repository.UserRepository
+	CustomerRepository    repository.CustomerRepository
+	RegisterRepository    repository.RegisterRepository
+	DB                    *sql.DB
+	Logger                *logrus.Logger
+}
+
+func NewReceiptService(transactionRepository repository.TransactionRepository, userRepository repository.UserRepository, customerRepository repository.CustomerRepository, registerRepository repository.RegisterRepository, db *sql.DB, logger *logrus.Logger) ReceiptService {
+	return &ReceiptServiceImpl{
+		TransactionRepository: transactionRepository,
+		UserRepository:        userRepository,
+		CustomerRepository:    customerRepository,
+		RegisterRepository:    registerRepository,
+		DB:                    db,
+		Logger:                logger,
+	}
+}
+
+var receiptContentTypes = map[string]string{
+	domain.ReceiptFormatText:   "text/plain; charset=utf-8",
+	domain.ReceiptFormatHTML:   "text/html; charset=utf-8",
+	domain.ReceiptFormatESCPOS: "application/octet-stream",
+	domain.ReceiptFormatPDF:    "application/pdf",
+}
+
+var receiptFileExtensions = map[string]string{
+	domain.ReceiptFormatText:   "txt",
+	domain.ReceiptFormatHTML:   "html",
+	domain.ReceiptFormatESCPOS: "bin",
+	domain.ReceiptFormatPDF:    "pdf",
+}
+
+var paymentMethodLabels = map[string]string{
+	domain.PaymentMethodCash:        "Cash",
+	domain.PaymentMethodCard:        "Card",
+	domain.PaymentMethodEWallet:     "E-Wallet",
+	domain.PaymentMethodQRIS:        "QRIS",
+	domain.PaymentMethodGiftCard:    "Gift Card",
+	domain.PaymentMethodStoreCredit: "Store Credit",
+	domain.PaymentMethodLoyalty:     "Loyalty Points",
+}
+
+func (service *ReceiptServiceImpl) Render(ctx context.Context, requesterUserID ulid.ULID, requesterRole string, transactionID ulid.ULID, format string) (web.ReceiptResponse, error) {
+	service.Logger.Infof("-executing ReceiptService.Render(%s, %s)...", transactionID, format)
+
+	if format == "" {
+		format = domain.ReceiptFormatText
+	}
+	contentType, ok := receiptContentTypes[format]
+	if !ok {
+		service.Logger.Warnf("-unknown receipt format %s", format)
+		return web.ReceiptResponse{}, exception.ErrInvalidReceiptFormat
+	}
+
+	service.Logger.Info("-trying to begin tx (read)...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.ReceiptResponse{}, err
+	}
+	defer tx.Commit()
+
+	service.Logger.Info("-executing Repo.FindByID (Header)...")
+	header, err := service.TransactionRepository.FindByID(ctx, tx, transactionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.ReceiptResponse{}, exception.ErrNotFound
+		}
+		service.Logger.Errorf("-failed to find transaction header: %v", err)
+		return web.ReceiptResponse{}, err
+	}
+
+	if requesterRole != "admin" && header.UserID != requesterUserID {
+		service.Logger.Warnf("-security alert: user %s tried to print the receipt of transaction %s belonging to %s", requesterUserID, transactionID, header.UserID)
+		return web.ReceiptResponse{}, exception.ErrForbidden
+	}
+
+	if header.Status != domain.TransactionStatusCompleted && header.Status != domain.TransactionStatusVoided {
+		service.Logger.Warnf("-transaction %s is %s, it has no receipt", transactionID, header.Status)
+		return web.ReceiptResponse{}, exception.ErrTransactionNotCompleted
+	}
+
+	receipt := domain.Receipt{
+		StoreName:      receiptStoreName(),
+		HeaderLines:    receiptLines("RECEIPT_HEADER"),
+		FooterLines:    receiptLines("RECEIPT_FOOTER"),
+		TransactionID:  header.TransactionID,
+		Status:         header.Status,
+		CreatedAt:      header.CreatedAt,
+		GrossAmount:    header.GrossAmount,
+		DiscountAmount: header.DiscountAmount,
+		TaxAmount:      header.TaxAmount,
+		TotalAmount:    header.TotalAmount,
+		ReturnedAmount: header.ReturnedAmount,
+	}
+
+	service.Logger.Info("-executing UserRepository.FindByID (Cashier)...")
+	cashier, err := service.UserRepository.FindByID(ctx, tx, header.UserID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find cashier: %v", err)
+		return web.ReceiptResponse{}, err
+	}
+	receipt.Cashier = cashier.Username
+
+	if header.RegisterID != nil {
+		register, err := service.RegisterRepository.FindByID(ctx, tx, *header.RegisterID)
+		if err != nil && err != sql.ErrNoRows {
+			return web.ReceiptResponse{}, err
+		}
+		receipt.Register = register.RegisterName
+	}
+
+	if header.CustomerID != nil {
+		customer, err := service.CustomerRepository.FindByID(ctx, tx, *header.CustomerID)
+		if err != nil && err != sql.ErrNoRows {
+			return web.ReceiptResponse{}, err
+		}
+		receipt.Customer = customer.CustomerName
+	}
+
+	service.Logger.Info("-executing Repo.FindDetailsByTransactionID (Items)...")
+	details, err := service.TransactionRepository.FindDetailsByTransactionID(ctx, tx, transactionID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find transaction details: %v", err)
+		return web.ReceiptResponse{}, err
+	}
+	for _, detail := range details {
+		receipt.TaxInclusive = detail.TaxInclusive
+		receipt.Lines = append(receipt.Lines, domain.ReceiptLine{
+			ProductName:      detail.ProductName,
+			Quantity:         detail.Quantity,
+			ReturnedQuantity: detail.ReturnedQuantity,
+			Price:            detail.PriceAtSale,
+			SubTotal:         detail.SubTotal,
+			Discount:         detail.DiscountAmount,
+			LineTotal:        detail.LineTotal,
+		})
+	}
+
+	service.Logger.Info("-executing Repo.FindPaymentsByTransactionID (Payments)...")
+	payments, err := service.TransactionRepository.FindPaymentsByTransactionID(ctx, tx, transactionID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find transaction payments: %v", err)
+		return web.ReceiptResponse{}, err
+	}
+	for _, payment := range payments {
+		receipt.Payments = append(receipt.Payments, domain.ReceiptPayment{
+			Method:         paymentMethodLabels[payment.Method],
+			Amount:         payment.Amount,
+			AmountTendered: payment.AmountTendered,
+			ChangeGiven:    payment.ChangeGiven,
+		})
+	}
+
+	service.Logger.Infof("-rendering the receipt as %s...", format)
+	body, err := helper.RenderReceipt(receipt, format, receiptWidth())
+	if err != nil {
+		service.Logger.Errorf("-failed to render receipt: %v", err)
+		return web.ReceiptResponse{}, err
+	}
+
+	return web.ReceiptResponse{
+		ContentType: contentType,
+		FileName:    fmt.Sprintf("receipt-%s.%s", transactionID, receiptFileExtensions[format]),
+		Body:        body,
+	}, nil
+}
+
+func receiptStoreName() string {
+	name := os.Getenv("RECEIPT_STORE_NAME")
+	if name == "" {
+		return "Retail Store"
+	}
+	return name
+}
+
+func receiptLines(envName string) []string {
+	var lines []string
+	for _, line := range strings.Split(os.Getenv(envName), "|") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func receiptWidth() int {
+	width, err := strconv.Atoi(os.Getenv("RECEIPT_WIDTH"))
+	if err != nil || width < 24 {
+		return 42
+	}
+	return width
+}
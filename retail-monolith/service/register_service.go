@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+	"retail-management/model/web"
+)
+
+type RegisterService interface {
+	Create(ctx context.Context, req web.RegisterRequest) (web.RegisterResponse, error)
+	FindAll(ctx context.Context) ([]web.RegisterResponse, error)
+	AssignUser(ctx context.Context, req web.RegisterAssignRequest) error
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"retail-management/exception"
+	"retail-management/helper"
+	"retail-management/model/domain"
+	"retail-management/model/web"
+	"retail-management/pb"
+	"retail-management/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type RegisterServiceImpl struct {
+	RegisterRepository repository.RegisterRepository
+	InventoryClient    pb.InventoryServiceClient
+	DB                 *sql.DB
+	Validate           *validator.Validate
+	Logger             *logrus.Logger
+}
+
+func NewRegisterService(registerRepository repository.RegisterRepository, inventoryClient pb.InventoryServiceClient, db *sql.DB, validate *validator.Validate, logger *logrus.Logger) RegisterService {
+	return &RegisterServiceImpl{
+		RegisterRepository: registerRepository,
+		InventoryClient:    inventoryClient,
+		DB:                 db,
+		Validate:           validate,
+		Logger:             logger,
+	}
+}
+
+func (service *RegisterServiceImpl) Create(ctx context.Context, req web.RegisterRequest) (web.RegisterResponse, error) {
+	service.Logger.Info("-validating the request...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating request: %v", err)
+		return web.RegisterResponse{}, err
+	}
+
+	service.Logger.Info("-checking the location on inventory microservice...")
+	_, err = service.InventoryClient.GetLocation(ctx, &pb.GetLocationRequest{
+		LocationId: req.LocationID.String(),
+	})
+	if err != nil {
+		service.Logger.Errorf("-failed to get location: %v", err)
+		if status.Code(err) == codes.NotFound {
+			return web.RegisterResponse{}, exception.ErrNotFound
+		}
+		return web.RegisterResponse{}, err
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.RegisterResponse{}, err
+	}
+	defer tx.Rollback()
+
+	t := time.Now()
+	register := domain.Register{
+		RegisterID:   ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(rand.Reader, 0)),
+		RegisterName: req.RegisterName,
+		LocationID:   req.LocationID,
+		CreatedAt:    t,
+	}
+
+	savedRegister, err := service.RegisterRepository.Save(ctx, tx, register)
+	if err != nil {
+		service.Logger.Errorf("-failed to save a register: %v", err)
+		return web.RegisterResponse{}, err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.RegisterResponse{}, errCommit
+	}
+
+	return helper.ToRegisterResponse(savedRegister), nil
+}
+
+func (service *RegisterServiceImpl) FindAll(ctx context.Context) ([]web.RegisterResponse, error) {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return []web.RegisterResponse{}, err
+	}
+	defer tx.Commit()
+
+	service.Logger.Info("-executing RegisterRepository.FindAll()...")
+	registers, err := service.RegisterRepository.FindAll(ctx, tx)
+	if err != nil {
+		service.Logger.Errorf("-failed to execute it: %v", err)
+		return []web.RegisterResponse{}, err
+	}
+
+	return helper.ToRegisterResponses(registers), nil
+}
+
+func (service *RegisterServiceImpl) AssignUser(ctx context.Context, req web.RegisterAssignRequest) error {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if req.RegisterID != nil {
+		_, err = service.RegisterRepository.FindByID(ctx, tx, *req.RegisterID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return exception.ErrNotFound
+			}
+			return err
+		}
+	}
+
+	service.Logger.Info("-executing RegisterRepository.AssignUser()...")
+	err = service.RegisterRepository.AssignUser(ctx, tx, req.UserID, req.RegisterID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return exception.ErrNotFound
+		}
+		service.Logger.Errorf("-failed to assign register: %v", err)
+		return err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	return tx.Commit()
+}
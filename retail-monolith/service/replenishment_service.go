@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"retail-management/model/web"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type ReplenishmentService interface {
+	SaveRule(ctx context.Context, req web.ReorderRuleRequest) (web.ReorderRuleResponse, error)
+	FindRule(ctx context.Context, productID ulid.ULID) (web.ReorderRuleResponse, error)
+	Suggest(ctx context.Context, locationID *ulid.ULID) (web.ReplenishmentResponse, error)
+	CreatePurchaseOrders(ctx context.Context, req web.ReplenishmentRequest) ([]web.PurchaseOrderResponse, error)
+}
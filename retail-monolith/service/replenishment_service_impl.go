@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"os"
+	"retail-management/exception"
+	"retail-management/helper"
+	"retail-management/model/domain"
+	"retail-management/model/web"
+	"retail-management/pb"
+	"retail-management/repository"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+type ReplenishmentServiceImpl struct {
+	ReorderRuleRepository   repository.ReorderRuleRepository
+	ProductRepository       repository.ProductRepository
+	PurchaseOrderRepository repository.PurchaseOrderRepository
+	TransactionRepository   repository.TransactionRepository
+	PurchaseOrderService    PurchaseOrderService
+	InventoryClient         pb.InventoryServiceClient
+	DB                      *sql.DB
+	Validate                *validator.Validate
+	Logger                  *logrus.Logger
+}
+
+func NewReplenishmentService(reorderRuleRepository repository.ReorderRuleRepository, productRepository repository.ProductRepository, purchaseOrderRepository repository.PurchaseOrderRepository, transactionRepository repository.TransactionRepository, purchaseOrderService PurchaseOrderService, inventoryClient pb.InventoryServiceClient, db *sql.DB, validate *validator.Validate, logger *logrus.Logger) ReplenishmentService {
+	return &ReplenishmentServiceImpl{
+		ReorderRuleRepository:   reorderRuleRepository,
+		ProductRepository:       productRepository,
+		PurchaseOrderRepository: purchaseOrderRepository,
+		TransactionRepository:   transactionRepository,
+		PurchaseOrderService:    purchaseOrderService,
+		InventoryClient:         inventoryClient,
+		DB:                      db,
+		Validate:                validate,
+		Logger:                  logger,
+	}
+}
+
+func (service *ReplenishmentServiceImpl) SaveRule(ctx context.Context, req web.ReorderRuleRequest) (web.ReorderRuleResponse, error) {
+	service.Logger.Info("-validating the request...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating request: %v", err)
+		return web.ReorderRuleResponse{}, err
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.ReorderRuleResponse{}, err
+	}
+	defer tx.Rollback()
+
+	_, err = service.ProductRepository.FindByID(ctx, tx, req.ProductID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.ReorderRuleResponse{}, exception.ErrNotFound
+		}
+		return web.ReorderRuleResponse{}, err
+	}
+
+	rule, err := service.ReorderRuleRepository.Save(ctx, tx, domain.ReorderRule{
+		ProductID:       req.ProductID,
+		MinQuantity:     req.MinQuantity,
+		MaxQuantity:     req.MaxQuantity,
+		ReorderQuantity: req.ReorderQuantity,
+		UpdatedAt:       time.Now(),
+	})
+	if err != nil {
+		service.Logger.Errorf("-failed to save reorder rule: %v", err)
+		return web.ReorderRuleResponse{}, err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	if err := tx.Commit(); err != nil {
+		return web.ReorderRuleResponse{}, err
+	}
+
+	return helper.ToReorderRuleResponse(rule), nil
+}
+
+func (service *ReplenishmentServiceImpl) FindRule(ctx context.Context, productID ulid.ULID) (web.ReorderRuleResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.ReorderRuleResponse{}, err
+	}
+	defer tx.Commit()
+
+	rule, err := service.ReorderRuleRepository.FindByProductID(ctx, tx, productID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.ReorderRuleResponse{}, exception.ErrNotFound
+		}
+		return web.ReorderRuleResponse{}, err
+	}
+
+	return helper.ToReorderRuleResponse(rule), nil
+}
+
+func (service *ReplenishmentServiceImpl) Suggest(ctx context.Context, locationID *ulid.ULID) (web.ReplenishmentResponse, error) {
+	location, err := locationOrDefault(locationID)
+	if err != nil {
+		service.Logger.Errorf("-failed to resolve location: %v", err)
+		return web.ReplenishmentResponse{}, err
+	}
+
+	windowDays := salesWindowDays()
+	leadDays := leadTimeDays()
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.ReplenishmentResponse{}, err
+	}
+	defer tx.Commit()
+
+	rules, err := service.ReorderRuleRepository.FindAll(ctx, tx)
+	if err != nil {
+		return web.ReplenishmentResponse{}, err
+	}
+
+	response := web.ReplenishmentResponse{
+		LocationID:      location,
+		SalesWindowDays: windowDays,
+		LeadTimeDays:    leadDays,
+		Suppliers:       make([]web.ReplenishmentSupplierResponse, 0),
+	}
+	if len(rules) == 0 {
+		return response, nil
+	}
+
+	onOrder, err := service.PurchaseOrderRepository.FindOpenQuantities(ctx, tx, location)
+	if err != nil {
+		return web.ReplenishmentResponse{}, err
+	}
+
+	sold, err := service.TransactionRepository.FindSoldQuantities(ctx, tx, location, time.Now().AddDate(0, 0, -windowDays))
+	if err != nil {
+		return web.ReplenishmentResponse{}, err
+	}
+
+	productIDs := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		productIDs = append(productIDs, rule.ProductID.String())
+	}
+
+	service.Logger.Info("-fetching batch stock from microservice...")
+	batchResp, err := service.InventoryClient.GetBatchStock(ctx, &pb.GetBatchStockRequest{
+		ProductIds: productIDs,
+		LocationId: location.String(),
+	})
+	if err != nil {
+		service.Logger.Errorf("-failed to fetch batch stock: %v", err)
+		return web.ReplenishmentResponse{}, err
+	}
+
+	stockMap := make(map[string]*pb.BatchStockItem)
+	for _, item := range batchResp.Items {
+		stockMap[item.ProductId] = item
+	}
+
+	supplierIndex := make(map[ulid.ULID]int)
+	for _, rule := range rules {
+		available := int(stockMap[rule.ProductID.String()].GetAvailable())
+		velocity := float64(sold[rule.ProductID]) / float64(windowDays)
+		demand := int(math.Ceil(velocity * float64(leadDays)))
+
+		projected := available + onOrder[rule.ProductID] - demand
+		if projected > rule.MinQuantity {
+			continue
+		}
+
+		quantity := roundUpToMultiple(rule.MaxQuantity-projected, rule.ReorderQuantity)
+		if quantity <= 0 {
+			continue
+		}
+
+		index, ok := supplierIndex[rule.SupplierID]
+		if !ok {
+			response.Suppliers = append(response.Suppliers, web.ReplenishmentSupplierResponse{
+				SupplierID:     rule.SupplierID,
+				SupplierName:   rule.SupplierName,
+				EstimatedTotal: decimal.Zero,
+				Items:          make([]web.ReplenishmentItemResponse, 0),
+			})
+			index = len(response.Suppliers) - 1
+			supplierIndex[rule.SupplierID] = index
+		}
+
+		supplier := &response.Suppliers[index]
+		supplier.Items = append(supplier.Items, web.ReplenishmentItemResponse{
+			ProductID:         rule.ProductID,
+			ProductName:       rule.ProductName,
+			AvailableQuantity: available,
+			OnOrderQuantity:   onOrder[rule.ProductID],
+			SoldQuantity:      sold[rule.ProductID],
+			DailyVelocity:     math.Round(velocity*100) / 100,
+			LeadTimeDemand:    demand,
+			MinQuantity:       rule.MinQuantity,
+			MaxQuantity:       rule.MaxQuantity,
+			ReorderQuantity:   rule.ReorderQuantity,
+			SuggestedQuantity: quantity,
+			PurchasePrice:     rule.PurchasePrice,
+		})
+		supplier.EstimatedTotal = supplier.EstimatedTotal.Add(rule.PurchasePrice.Mul(decimal.NewFromInt(int64(quantity))))
+	}
+
+	return response, nil
+}
+
+func (service *ReplenishmentServiceImpl) CreatePurchaseOrders(ctx context.Context, req web.ReplenishmentRequest) ([]web.PurchaseOrderResponse, error) {
+	suggestion, err := service.Suggest(ctx, req.LocationID)
+	if err != nil {
+		return []web.PurchaseOrderResponse{}, err
+	}
+
+	purchaseOrders := make([]web.PurchaseOrderResponse, 0)
+	for _, supplier := range suggestion.Suppliers {
+		items := make([]web.PurchaseOrderItemRequest, 0, len(supplier.Items))
+		for _, item := range supplier.Items {
+			items = append(items, web.PurchaseOrderItemRequest{
+				ProductID:     item.ProductID,
+				Quantity:      item.SuggestedQuantity,
+				PurchasePrice: item.PurchasePrice,
+			})
+		}
+
+		service.Logger.Infof("-creating draft purchase order for supplier %s...", supplier.SupplierID)
+		purchaseOrder, err := service.PurchaseOrderService.Create(ctx, web.PurchaseOrderRequest{
+			UserID:     req.UserID,
+			SupplierID: supplier.SupplierID,
+			LocationID: &suggestion.LocationID,
+			Note:       "replenishment suggestion",
+			Items:      items,
+		})
+		if err != nil {
+			service.Logger.Errorf("-failed to create purchase order: %v", err)
+			return purchaseOrders, err
+		}
+		purchaseOrders = append(purchaseOrders, purchaseOrder)
+	}
+
+	return purchaseOrders, nil
+}
+
+func roundUpToMultiple(quantity int, multiple int) int {
+	if quantity <= 0 || multiple <= 1 {
+		return quantity
+	}
+	return ((quantity + multiple - 1) / multiple) * multiple
+}
+
+func salesWindowDays() int {
+	days, err := strconv.Atoi(os.Getenv("REPLENISHMENT_SALES_WINDOW_DAYS"))
+	if err != nil || days <= 0 {
+		days = 30
+	}
+	return days
+}
+
+func leadTimeDays() int {
+	days, err := strconv.Atoi(os.Getenv("REPLENISHMENT_LEAD_TIME_DAYS"))
+	if err != nil || days < 0 {
+		days = 7
+	}
+	return days
+}
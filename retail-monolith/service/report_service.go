@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+	"retail-management/model/web"
+)
+
+type ReportService interface {
+	Sales(ctx context.Context, req web.SalesReportRequest) (web.SalesReportResponse, error)
+	Payments(ctx context.Context, req web.PaymentReportRequest) (web.PaymentReportResponse, error)
+	Tax(ctx context.Context, req web.TaxReportRequest) (web.TaxReportResponse, error)
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"retail-management/exception"
+	"retail-management/helper"
+	"retail-management/model/domain"
+	"retail-management/model/web"
+	"retail-management/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+type ReportServiceImpl struct {
+	ReportRepository repository.ReportRepository
+	DB               *sql.DB
+	Validate         *validator.Validate
+	Logger           *logrus.Logger
+}
+
+func NewReportService(reportRepository repository.ReportRepository, db *sql.DB, validate *validator.Validate, logger *logrus.Logger) ReportService {
+	return &ReportServiceImpl{
+		ReportRepository: reportRepository,
+		DB:               db,
+		Validate:         validate,
+		Logger:           logger,
+	}
+}
+
+func (service *ReportServiceImpl) Sales(ctx context.Context, req web.SalesReportRequest) (web.SalesReportResponse, error) {
+	service.Logger.Info("-validating the request...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating request: %v", err)
+		return web.SalesReportResponse{}, err
+	}
+
+	from, to, err := reportRange(req.From, req.To)
+	if err != nil {
+		service.Logger.Warnf("-report range %s to %s is inverted", req.From, req.To)
+		return web.SalesReportResponse{}, err
+	}
+
+	filter := domain.ReportFilter{
+		GroupBy:    req.GroupBy,
+		From:       from,
+		To:         to.AddDate(0, 0, 1),
+		LocationID: req.LocationID,
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.SalesReportResponse{}, err
+	}
+	defer tx.Commit()
+
+	service.Logger.Info("-executing ReportRepository.FindSales()...")
+	rows, err := service.ReportRepository.FindSales(ctx, tx, filter)
+	if err != nil {
+		service.Logger.Errorf("-failed to find sales report: %v", err)
+		return web.SalesReportResponse{}, err
+	}
+
+	total, err := service.ReportRepository.FindSalesTotal(ctx, tx, filter)
+	if err != nil {
+		service.Logger.Errorf("-failed to find sales report total: %v", err)
+		return web.SalesReportResponse{}, err
+	}
+
+	response := web.SalesReportResponse{
+		GroupBy:    req.GroupBy,
+		From:       from.Format("2006-01-02"),
+		To:         to.Format("2006-01-02"),
+		LocationID: req.LocationID,
+		Total:      helper.ToSalesReportRowResponse(total),
+		Rows:       make([]web.SalesReportRowResponse, 0),
+	}
+	for _, row := range rows {
+		response.Rows = append(response.Rows, helper.ToSalesReportRowResponse(row))
+	}
+
+	return response, nil
+}
+
+func (service *ReportServiceImpl) Payments(ctx context.Context, req web.PaymentReportRequest) (web.PaymentReportResponse, error) {
+	service.Logger.Info("-validating the request...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating request: %v", err)
+		return web.PaymentReportResponse{}, err
+	}
+
+	from, to, err := reportRange(req.From, req.To)
+	if err != nil {
+		service.Logger.Warnf("-report range %s to %s is inverted", req.From, req.To)
+		return web.PaymentReportResponse{}, err
+	}
+
+	filter := domain.ReportFilter{
+		From:       from,
+		To:         to.AddDate(0, 0, 1),
+		LocationID: req.LocationID,
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.PaymentReportResponse{}, err
+	}
+	defer tx.Commit()
+
+	service.Logger.Info("-executing ReportRepository.FindPayments()...")
+	rows, err := service.ReportRepository.FindPayments(ctx, tx, filter)
+	if err != nil {
+		service.Logger.Errorf("-failed to find payment report: %v", err)
+		return web.PaymentReportResponse{}, err
+	}
+
+	response := web.PaymentReportResponse{
+		From:       from.Format("2006-01-02"),
+		To:         to.Format("2006-01-02"),
+		LocationID: req.LocationID,
+		Total:      web.PaymentReportRowResponse{Method: "total", Amount: decimal.Zero, ChangeGiven: decimal.Zero},
+		Rows:       make([]web.PaymentReportRowResponse, 0),
+	}
+	for _, row := range rows {
+		response.Total.Amount = response.Total.Amount.Add(row.Amount)
+		response.Total.ChangeGiven = response.Total.ChangeGiven.Add(row.ChangeGiven)
+		response.Rows = append(response.Rows, helper.ToPaymentReportRowResponse(row))
+	}
+
+	service.Logger.Info("-executing ReportRepository.FindSalesTotal()...")
+	total, err := service.ReportRepository.FindSalesTotal(ctx, tx, filter)
+	if err != nil {
+		service.Logger.Errorf("-failed to find sales report total: %v", err)
+		return web.PaymentReportResponse{}, err
+	}
+	response.Total.Transactions = total.Transactions
+
+	return response, nil
+}
+
+func (service *ReportServiceImpl) Tax(ctx context.Context, req web.TaxReportRequest) (web.TaxReportResponse, error) {
+	service.Logger.Info("-validating the request...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating request: %v", err)
+		return web.TaxReportResponse{}, err
+	}
+
+	from, to, err := reportRange(req.From, req.To)
+	if err != nil {
+		service.Logger.Warnf("-report range %s to %s is inverted", req.From, req.To)
+		return web.TaxReportResponse{}, err
+	}
+
+	filter := domain.ReportFilter{
+		From:       from,
+		To:         to.AddDate(0, 0, 1),
+		LocationID: req.LocationID,
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.TaxReportResponse{}, err
+	}
+	defer tx.Commit()
+
+	service.Logger.Info("-executing ReportRepository.FindTax()...")
+	rows, err := service.ReportRepository.FindTax(ctx, tx, filter)
+	if err != nil {
+		service.Logger.Errorf("-failed to find tax report: %v", err)
+		return web.TaxReportResponse{}, err
+	}
+
+	total := domain.TaxReportRow{Period: "total", TaxName: "total", TaxableAmount: decimal.Zero, TaxAmount: decimal.Zero}
+	response := web.TaxReportResponse{
+		From:       from.Format("2006-01-02"),
+		To:         to.Format("2006-01-02"),
+		LocationID: req.LocationID,
+		Rows:       make([]web.TaxReportRowResponse, 0),
+	}
+	for _, row := range rows {
+		total.TaxableAmount = total.TaxableAmount.Add(row.TaxableAmount)
+		total.TaxAmount = total.TaxAmount.Add(row.TaxAmount)
+		response.Rows = append(response.Rows, helper.ToTaxReportRowResponse(row))
+	}
+	response.Total = helper.ToTaxReportRowResponse(total)
+
+	return response, nil
+}
+
+func reportRange(fromDate string, toDate string) (time.Time, time.Time, error) {
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if toDate != "" {
+		to, _ = time.Parse("2006-01-02", toDate)
+	}
+	from := to.AddDate(0, 0, -29)
+	if fromDate != "" {
+		from, _ = time.Parse("2006-01-02", fromDate)
+	}
+	if from.After(to) {
+		return time.Time{}, time.Time{}, exception.ErrInvalidReportRange
+	}
+	return from, to, nil
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"retail-management/model/web"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type ShiftService interface {
+	Open(ctx context.Context, req web.ShiftOpenRequest) (web.ShiftResponse, error)
+	FindAll(ctx context.Context, requesterUserID ulid.ULID, requesterRole string, status string) ([]web.ShiftResponse, error)
+	FindCurrent(ctx context.Context, userID ulid.ULID) (web.ShiftReportResponse, error)
+	Report(ctx context.Context, requesterUserID ulid.ULID, requesterRole string, shiftID ulid.ULID) (web.ShiftReportResponse, error)
+	Close(ctx context.Context, requesterRole string, req web.ShiftCloseRequest) (web.ShiftReportResponse, error)
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"retail-management/exception"
+	"retail-management/helper"
+	"retail-management/model/domain"
+	"retail-management/model/web"
+	"retail-management/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+type ShiftServiceImpl struct {
+	ShiftRepository    repository.ShiftRepository
+	RegisterRepository repository.RegisterRepository
+	DB                 *sql.DB
+	Validate           *validator.Validate
+	Logger             *logrus.Logger
+}
+
+func NewShiftService(shiftRepository repository.ShiftRepository, registerRepository repository.RegisterRepository, db *sql.DB, validate *validator.Validate, logger *logrus.Logger) ShiftService {
+	return &ShiftServiceImpl{
+		ShiftRepository:    shiftRepository,
+		RegisterRepository: registerRepository,
+		DB:                 db,
+		Validate:           validate,
+		Logger:             logger,
+	}
+}
+
+func (service *ShiftServiceImpl) Open(ctx context.Context, req web.ShiftOpenRequest) (web.ShiftResponse, error) {
+	service.Logger.Info("-validating the request...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating request: %v", err)
+		return web.ShiftResponse{}, err
+	}
+
+	if req.OpeningFloat.IsNegative() {
+		service.Logger.Warnf("-negative opening float %s", req.OpeningFloat)
+		return web.ShiftResponse{}, exception.ErrInvalidCashAmount
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.ShiftResponse{}, err
+	}
+	defer tx.Rollback()
+
+	_, err = service.ShiftRepository.FindOpenByUserID(ctx, tx, req.UserID)
+	if err == nil {
+		service.Logger.Warnf("-user %s already has an open shift", req.UserID)
+		return web.ShiftResponse{}, exception.ErrShiftAlreadyOpen
+	}
+	if err != sql.ErrNoRows {
+		service.Logger.Errorf("-failed to find open shift: %v", err)
+		return web.ShiftResponse{}, err
+	}
+
+	service.Logger.Info("-resolving the location the cashier sells from...")
+	locationID, registerID, err := resolveLocation(ctx, tx, service.RegisterRepository, req.UserID, req.LocationID)
+	if err != nil {
+		service.Logger.Errorf("-failed to resolve location: %v", err)
+		return web.ShiftResponse{}, err
+	}
+
+	t := time.Now()
+	shift := domain.Shift{
+		ShiftID:      ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(rand.Reader, 0)),
+		UserID:       req.UserID,
+		LocationID:   locationID,
+		RegisterID:   registerID,
+		Status:       domain.ShiftStatusOpen,
+		OpeningFloat: req.OpeningFloat,
+		Note:         req.Note,
+		OpenedAt:     t,
+	}
+
+	service.Logger.Info("-executing ShiftRepository.Save()...")
+	shift, err = service.ShiftRepository.Save(ctx, tx, shift)
+	if err != nil {
+		return web.ShiftResponse{}, err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.ShiftResponse{}, errCommit
+	}
+
+	return helper.ToShiftResponse(shift), nil
+}
+
+func (service *ShiftServiceImpl) FindAll(ctx context.Context, requesterUserID ulid.ULID, requesterRole string, shiftStatus string) ([]web.ShiftResponse, error) {
+	var userID *ulid.ULID
+	if requesterRole != "admin" {
+		userID = &requesterUserID
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return []web.ShiftResponse{}, err
+	}
+	defer tx.Commit()
+
+	service.Logger.Info("-executing ShiftRepository.FindAll()...")
+	shifts, err := service.ShiftRepository.FindAll(ctx, tx, shiftStatus, userID)
+	if err != nil {
+		service.Logger.Errorf("-failed to execute it: %v", err)
+		return []web.ShiftResponse{}, err
+	}
+
+	return helper.ToShiftResponses(shifts), nil
+}
+
+func (service *ShiftServiceImpl) FindCurrent(ctx context.Context, userID ulid.ULID) (web.ShiftReportResponse, error) {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.ShiftReportResponse{}, err
+	}
+	defer tx.Commit()
+
+	shift, err := service.ShiftRepository.FindOpenByUserID(ctx, tx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.ShiftReportResponse{}, exception.ErrNoOpenShift
+		}
+		service.Logger.Errorf("-failed to find open shift: %v", err)
+		return web.ShiftReportResponse{}, err
+	}
+
+	return service.report(ctx, tx, shift)
+}
+
+func (service *ShiftServiceImpl) Report(ctx context.Context, requesterUserID ulid.ULID, requesterRole string, shiftID ulid.ULID) (web.ShiftReportResponse, error) {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.ShiftReportResponse{}, err
+	}
+	defer tx.Commit()
+
+	service.Logger.Info("-executing ShiftRepository.FindByID()...")
+	shift, err := service.ShiftRepository.FindByID(ctx, tx, shiftID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.ShiftReportResponse{}, exception.ErrNotFound
+		}
+		service.Logger.Errorf("-failed to find shift: %v", err)
+		return web.ShiftReportResponse{}, err
+	}
+
+	if requesterRole != "admin" && shift.UserID != requesterUserID {
+		service.Logger.Warnf("-security alert: user %s tried to read shift %s belonging to %s", requesterUserID, shiftID, shift.UserID)
+		return web.ShiftReportResponse{}, exception.ErrForbidden
+	}
+
+	return service.report(ctx, tx, shift)
+}
+
+func (service *ShiftServiceImpl) Close(ctx context.Context, requesterRole string, req web.ShiftCloseRequest) (web.ShiftReportResponse, error) {
+	service.Logger.Info("-validating the request...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating request: %v", err)
+		return web.ShiftReportResponse{}, err
+	}
+
+	if req.CountedCash.IsNegative() {
+		service.Logger.Warnf("-negative counted cash %s", req.CountedCash)
+		return web.ShiftReportResponse{}, exception.ErrInvalidCashAmount
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.ShiftReportResponse{}, err
+	}
+	defer tx.Rollback()
+
+	service.Logger.Info("-executing ShiftRepository.FindByIDForUpdate()...")
+	shift, err := service.ShiftRepository.FindByIDForUpdate(ctx, tx, req.ShiftID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.ShiftReportResponse{}, exception.ErrNotFound
+		}
+		service.Logger.Errorf("-failed to lock shift: %v", err)
+		return web.ShiftReportResponse{}, err
+	}
+
+	if requesterRole != "admin" && shift.UserID != req.UserID {
+		service.Logger.Warnf("-security alert: user %s tried to close shift %s belonging to %s", req.UserID, req.ShiftID, shift.UserID)
+		return web.ShiftReportResponse{}, exception.ErrForbidden
+	}
+
+	if shift.Status != domain.ShiftStatusOpen {
+		service.Logger.Warnf("-shift %s is already closed", req.ShiftID)
+		return web.ShiftReportResponse{}, exception.ErrShiftClosed
+	}
+
+	closedAt := time.Now()
+	summary, err := service.ShiftRepository.Summarize(ctx, tx, shift, closedAt)
+	if err != nil {
+		return web.ShiftReportResponse{}, err
+	}
+
+	expectedCash := expectedShiftCash(shift, summary)
+	shift.Status = domain.ShiftStatusClosed
+	shift.ClosedAt = &closedAt
+	shift.Summary = summary
+	shift.ExpectedCash = decimal.NewNullDecimal(expectedCash)
+	shift.CountedCash = decimal.NewNullDecimal(req.CountedCash)
+	shift.OverShort = decimal.NewNullDecimal(req.CountedCash.Sub(expectedCash))
+	if req.Note != "" {
+		shift.Note = req.Note
+	}
+
+	service.Logger.Info("-executing ShiftRepository.Close()...")
+	err = service.ShiftRepository.Close(ctx, tx, shift)
+	if err != nil {
+		return web.ShiftReportResponse{}, err
+	}
+
+	response, err := service.report(ctx, tx, shift)
+	if err != nil {
+		return web.ShiftReportResponse{}, err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.ShiftReportResponse{}, errCommit
+	}
+
+	return response, nil
+}
+
+func (service *ShiftServiceImpl) report(ctx context.Context, tx *sql.Tx, shift domain.Shift) (web.ShiftReportResponse, error) {
+	reportType := "Z"
+	if shift.Status == domain.ShiftStatusOpen {
+		reportType = "X"
+		summary, err := service.ShiftRepository.Summarize(ctx, tx, shift, time.Now())
+		if err != nil {
+			return web.ShiftReportResponse{}, err
+		}
+		shift.Summary = summary
+		shift.ExpectedCash = decimal.NewNullDecimal(expectedShiftCash(shift, summary))
+	}
+
+	response := web.ShiftReportResponse{
+		ReportType:    reportType,
+		Shift:         helper.ToShiftResponse(shift),
+		SalesCount:    shift.Summary.SalesCount,
+		SalesAmount:   shift.Summary.SalesAmount,
+		ReturnsCount:  shift.Summary.ReturnsCount,
+		ReturnsAmount: shift.Summary.ReturnsAmount,
+		VoidsCount:    shift.Summary.VoidsCount,
+		VoidsAmount:   shift.Summary.VoidsAmount,
+		NetSales:      netShiftSales(shift.Summary),
+		CashSales:     shift.Summary.CashAmount,
+		CashRefunds:   shift.Summary.CashRefunds,
+		ExpectedCash:  shift.ExpectedCash.Decimal,
+	}
+	if shift.CountedCash.Valid {
+		response.CountedCash = &shift.CountedCash.Decimal
+		response.OverShort = &shift.OverShort.Decimal
+	}
+
+	return response, nil
+}
+
+func netShiftSales(summary domain.ShiftSummary) decimal.Decimal {
+	return summary.SalesAmount.Sub(summary.VoidsAmount).Sub(summary.ReturnsAmount)
+}
+
+func expectedShiftCash(shift domain.Shift, summary domain.ShiftSummary) decimal.Decimal {
+	return shift.OpeningFloat.Add(summary.CashAmount).Sub(summary.CashRefunds)
+}
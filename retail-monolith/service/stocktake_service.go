@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"retail-management/model/web"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type StocktakeService interface {
+	Create(ctx context.Context, req web.StocktakeRequest) (web.StocktakeResponse, error)
+	FindAll(ctx context.Context, status string, locationID *ulid.ULID) ([]web.StocktakeResponse, error)
+	FindByID(ctx context.Context, sessionID ulid.ULID, userID ulid.ULID, revealExpected bool) (web.StocktakeResponse, error)
+	SubmitCounts(ctx context.Context, req web.StocktakeCountRequest) (web.StocktakeResponse, error)
+	Variance(ctx context.Context, sessionID ulid.ULID) (web.StocktakeVarianceResponse, error)
+	Approve(ctx context.Context, sessionID ulid.ULID, userID ulid.ULID) (web.StocktakeVarianceResponse, error)
+	Cancel(ctx context.Context, sessionID ulid.ULID) (web.StocktakeResponse, error)
+}
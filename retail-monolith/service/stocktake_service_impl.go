@@ -0,0 +1,544 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"retail-management/exception"
+	"retail-management/helper"
+	"retail-management/model/domain"
+	"retail-management/model/web"
+	"retail-management/pb"
+	"retail-management/repository"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type StocktakeServiceImpl struct {
+	StocktakeRepository repository.StocktakeRepository
+	ProductRepository   repository.ProductRepository
+	CostRepository      repository.CostRepository
+	InventoryClient     pb.InventoryServiceClient
+	DB                  *sql.DB
+	Validate            *validator.Validate
+	Logger              *logrus.Logger
+}
+
+func NewStocktakeService(stocktakeRepository repository.StocktakeRepository, productRepository repository.ProductRepository, costRepository repository.CostRepository, inventoryClient pb.InventoryServiceClient, db *sql.DB, validate *validator.Validate, logger *logrus.Logger) StocktakeService {
+	return &StocktakeServiceImpl{
+		StocktakeRepository: stocktakeRepository,
+		ProductRepository:   productRepository,
+		CostRepository:      costRepository,
+		InventoryClient:     inventoryClient,
+		DB:                  db,
+		Validate:            validate,
+		Logger:              logger,
+	}
+}
+
+func (service *StocktakeServiceImpl) Create(ctx context.Context, req web.StocktakeRequest) (web.StocktakeResponse, error) {
+	service.Logger.Info("-validating the request...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating request: %v", err)
+		return web.StocktakeResponse{}, err
+	}
+
+	locationID, err := locationOrDefault(req.LocationID)
+	if err != nil {
+		service.Logger.Errorf("-failed to resolve stocktake location: %v", err)
+		return web.StocktakeResponse{}, err
+	}
+
+	service.Logger.Info("-checking the location on inventory microservice...")
+	_, err = service.InventoryClient.GetLocation(ctx, &pb.GetLocationRequest{
+		LocationId: locationID.String(),
+	})
+	if err != nil {
+		service.Logger.Errorf("-failed to get location: %v", err)
+		if status.Code(err) == codes.NotFound {
+			return web.StocktakeResponse{}, exception.ErrNotFound
+		}
+		return web.StocktakeResponse{}, err
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+	defer tx.Rollback()
+
+	products, err := service.ProductRepository.FindAll(ctx, tx)
+	if err != nil {
+		service.Logger.Errorf("-failed to find products: %v", err)
+		return web.StocktakeResponse{}, err
+	}
+
+	var productIDs []string
+	for _, product := range products {
+		if req.CategoryID != nil && product.CategoryID != *req.CategoryID {
+			continue
+		}
+		productIDs = append(productIDs, product.ProductID.String())
+	}
+	if len(productIDs) == 0 {
+		service.Logger.Warn("-no products in the stocktake scope")
+		return web.StocktakeResponse{}, exception.ErrEmptyStocktake
+	}
+
+	service.Logger.Info("-freezing expected quantities from inventory microservice...")
+	batchResp, err := service.InventoryClient.GetBatchStock(ctx, &pb.GetBatchStockRequest{
+		ProductIds: productIDs,
+		LocationId: locationID.String(),
+	})
+	if err != nil {
+		service.Logger.Errorf("-failed to fetch batch stock: %v", err)
+		return web.StocktakeResponse{}, err
+	}
+
+	t := time.Now()
+	session := domain.StocktakeSession{
+		SessionID:  ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(rand.Reader, 0)),
+		LocationID: locationID,
+		CategoryID: req.CategoryID,
+		Blind:      req.Blind,
+		Status:     domain.StocktakeStatusOpen,
+		Note:       req.Note,
+		CreatedBy:  req.UserID,
+		CreatedAt:  t,
+	}
+
+	var items []domain.StocktakeItem
+	for _, stock := range batchResp.Items {
+		productID, err := ulid.Parse(stock.ProductId)
+		if err != nil {
+			return web.StocktakeResponse{}, err
+		}
+		items = append(items, domain.StocktakeItem{
+			SessionID:        session.SessionID,
+			ProductID:        productID,
+			ExpectedQuantity: int(stock.Quantity),
+		})
+	}
+
+	service.Logger.Info("-executing StocktakeRepository.Save()...")
+	_, err = service.StocktakeRepository.Save(ctx, tx, session)
+	if err != nil {
+		service.Logger.Errorf("-failed to save stocktake session: %v", err)
+		return web.StocktakeResponse{}, err
+	}
+
+	_, err = service.StocktakeRepository.SaveItems(ctx, tx, items)
+	if err != nil {
+		service.Logger.Errorf("-failed to save stocktake items: %v", err)
+		return web.StocktakeResponse{}, err
+	}
+
+	response, err := service.loadStocktake(ctx, tx, session.SessionID, req.UserID, true)
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.StocktakeResponse{}, errCommit
+	}
+
+	return response, nil
+}
+
+func (service *StocktakeServiceImpl) FindAll(ctx context.Context, sessionStatus string, locationID *ulid.ULID) ([]web.StocktakeResponse, error) {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return []web.StocktakeResponse{}, err
+	}
+	defer tx.Commit()
+
+	service.Logger.Info("-executing StocktakeRepository.FindAll()...")
+	sessions, err := service.StocktakeRepository.FindAll(ctx, tx, sessionStatus, locationID)
+	if err != nil {
+		service.Logger.Errorf("-failed to execute it: %v", err)
+		return []web.StocktakeResponse{}, err
+	}
+
+	return helper.ToStocktakeResponses(sessions), nil
+}
+
+func (service *StocktakeServiceImpl) FindByID(ctx context.Context, sessionID ulid.ULID, userID ulid.ULID, revealExpected bool) (web.StocktakeResponse, error) {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+	defer tx.Commit()
+
+	return service.loadStocktake(ctx, tx, sessionID, userID, revealExpected)
+}
+
+func (service *StocktakeServiceImpl) SubmitCounts(ctx context.Context, req web.StocktakeCountRequest) (web.StocktakeResponse, error) {
+	service.Logger.Info("-validating the request...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating request: %v", err)
+		return web.StocktakeResponse{}, err
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+	defer tx.Rollback()
+
+	session, err := service.lockStocktake(ctx, tx, req.SessionID)
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+
+	if session.Status != domain.StocktakeStatusOpen {
+		service.Logger.Warnf("-cannot count stocktake session in status %s", session.Status)
+		return web.StocktakeResponse{}, exception.ErrStocktakeState
+	}
+
+	items, err := service.StocktakeRepository.FindItems(ctx, tx, req.SessionID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find stocktake items: %v", err)
+		return web.StocktakeResponse{}, err
+	}
+
+	inSession := make(map[ulid.ULID]bool)
+	for _, item := range items {
+		inSession[item.ProductID] = true
+	}
+
+	t := time.Now()
+	var counts []domain.StocktakeCount
+	countIndex := make(map[ulid.ULID]int)
+	for _, line := range req.Items {
+		if !inSession[line.ProductID] {
+			service.Logger.Warnf("-product %s is not part of stocktake %s", line.ProductID, req.SessionID)
+			return web.StocktakeResponse{}, exception.ErrInvalidStocktakeItem
+		}
+
+		if i, ok := countIndex[line.ProductID]; ok {
+			counts[i].CountedQuantity = line.CountedQuantity
+			continue
+		}
+
+		countIndex[line.ProductID] = len(counts)
+		counts = append(counts, domain.StocktakeCount{
+			SessionID:       req.SessionID,
+			ProductID:       line.ProductID,
+			UserID:          req.UserID,
+			CountedQuantity: line.CountedQuantity,
+			CountedAt:       t,
+		})
+	}
+
+	service.Logger.Info("-executing StocktakeRepository.SaveCounts()...")
+	err = service.StocktakeRepository.SaveCounts(ctx, tx, counts)
+	if err != nil {
+		service.Logger.Errorf("-failed to save stocktake counts: %v", err)
+		return web.StocktakeResponse{}, err
+	}
+
+	response, err := service.loadStocktake(ctx, tx, req.SessionID, req.UserID, !session.Blind)
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.StocktakeResponse{}, errCommit
+	}
+
+	return response, nil
+}
+
+func (service *StocktakeServiceImpl) Variance(ctx context.Context, sessionID ulid.ULID) (web.StocktakeVarianceResponse, error) {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.StocktakeVarianceResponse{}, err
+	}
+	defer tx.Commit()
+
+	session, err := service.StocktakeRepository.FindByID(ctx, tx, sessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.StocktakeVarianceResponse{}, exception.ErrNotFound
+		}
+		return web.StocktakeVarianceResponse{}, err
+	}
+
+	return service.buildVariance(ctx, tx, session)
+}
+
+func (service *StocktakeServiceImpl) Approve(ctx context.Context, sessionID ulid.ULID, userID ulid.ULID) (web.StocktakeVarianceResponse, error) {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.StocktakeVarianceResponse{}, err
+	}
+	defer tx.Rollback()
+
+	session, err := service.lockStocktake(ctx, tx, sessionID)
+	if err != nil {
+		return web.StocktakeVarianceResponse{}, err
+	}
+
+	if session.Status != domain.StocktakeStatusOpen {
+		service.Logger.Warnf("-cannot approve stocktake session in status %s", session.Status)
+		return web.StocktakeVarianceResponse{}, exception.ErrStocktakeState
+	}
+
+	variance, err := service.buildVariance(ctx, tx, session)
+	if err != nil {
+		return web.StocktakeVarianceResponse{}, err
+	}
+
+	var adjustments []*pb.StockAdjustment
+	for _, item := range variance.Items {
+		if item.CountedQuantity == nil || item.Variance == 0 {
+			continue
+		}
+		adjustments = append(adjustments, &pb.StockAdjustment{
+			ProductId:      item.ProductID.String(),
+			QuantityChange: int32(item.Variance),
+		})
+	}
+
+	t := time.Now()
+	session.Status = domain.StocktakeStatusApproved
+	session.ApprovedBy = &userID
+	session.ApprovedAt = &t
+
+	service.Logger.Info("-executing StocktakeRepository.UpdateStatus()...")
+	err = service.StocktakeRepository.UpdateStatus(ctx, tx, session)
+	if err != nil {
+		service.Logger.Errorf("-failed to update stocktake session status: %v", err)
+		return web.StocktakeVarianceResponse{}, err
+	}
+
+	service.Logger.Info("-recording stocktake variance costs...")
+	reference := fmt.Sprintf("stocktake:%s", sessionID.String())
+	for _, item := range variance.Items {
+		if item.CountedQuantity == nil || item.Variance == 0 {
+			continue
+		}
+
+		product, err := service.ProductRepository.FindByID(ctx, tx, item.ProductID)
+		if err != nil {
+			service.Logger.Errorf("-failed to find product %s: %v", item.ProductID, err)
+			return web.StocktakeVarianceResponse{}, err
+		}
+
+		if item.Variance > 0 {
+			err = receiveCost(ctx, tx, service.CostRepository, item.ProductID, item.Variance, product.PurchasePrice, reference, t)
+		} else {
+			_, err = issueCost(ctx, tx, service.CostRepository, item.ProductID, -item.Variance, product.PurchasePrice, reference, t)
+		}
+		if err != nil {
+			service.Logger.Errorf("-failed to record variance cost for %s: %v", item.ProductID, err)
+			return web.StocktakeVarianceResponse{}, err
+		}
+	}
+
+	if len(adjustments) > 0 {
+		service.Logger.Info("-posting stocktake adjustments to inventory microservice...")
+		_, err = service.InventoryClient.BatchAdjustStock(ctx, &pb.BatchAdjustStockRequest{
+			Adjustments: adjustments,
+			UserId:      userID.String(),
+			LocationId:  session.LocationID.String(),
+			Reason:      fmt.Sprintf("Stocktake: %s", sessionID.String()),
+			OperationId: reference,
+		})
+		if err != nil {
+			service.Logger.Errorf("-failed to post stocktake adjustments: %v", err)
+			if code := status.Code(err); code == codes.InvalidArgument || code == codes.FailedPrecondition {
+				return web.StocktakeVarianceResponse{}, fmt.Errorf("%w: %s", exception.ErrStocktakeRejected, status.Convert(err).Message())
+			}
+			return web.StocktakeVarianceResponse{}, err
+		}
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.StocktakeVarianceResponse{}, errCommit
+	}
+
+	variance.Status = session.Status
+	return variance, nil
+}
+
+func (service *StocktakeServiceImpl) Cancel(ctx context.Context, sessionID ulid.ULID) (web.StocktakeResponse, error) {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+	defer tx.Rollback()
+
+	session, err := service.lockStocktake(ctx, tx, sessionID)
+	if err != nil {
+		return web.StocktakeResponse{}, err
+	}
+
+	if session.Status != domain.StocktakeStatusOpen {
+		service.Logger.Warnf("-cannot cancel stocktake session in status %s", session.Status)
+		return web.StocktakeResponse{}, exception.ErrStocktakeState
+	}
+
+	session.Status = domain.StocktakeStatusCancelled
+
+	service.Logger.Info("-executing StocktakeRepository.UpdateStatus()...")
+	err = service.StocktakeRepository.UpdateStatus(ctx, tx, session)
+	if err != nil {
+		service.Logger.Errorf("-failed to execute it: %v", err)
+		return web.StocktakeResponse{}, err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.StocktakeResponse{}, errCommit
+	}
+
+	return helper.ToStocktakeResponse(session), nil
+}
+
+func (service *StocktakeServiceImpl) lockStocktake(ctx context.Context, tx *sql.Tx, sessionID ulid.ULID) (domain.StocktakeSession, error) {
+	service.Logger.Info("-executing StocktakeRepository.FindByIDForUpdate()...")
+	session, err := service.StocktakeRepository.FindByIDForUpdate(ctx, tx, sessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.StocktakeSession{}, exception.ErrNotFound
+		}
+		service.Logger.Errorf("-failed to lock stocktake session: %v", err)
+		return domain.StocktakeSession{}, err
+	}
+	return session, nil
+}
+
+func (service *StocktakeServiceImpl) loadStocktake(ctx context.Context, tx *sql.Tx, sessionID ulid.ULID, userID ulid.ULID, revealExpected bool) (web.StocktakeResponse, error) {
+	service.Logger.Info("-executing StocktakeRepository.FindByID()...")
+	session, err := service.StocktakeRepository.FindByID(ctx, tx, sessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.StocktakeResponse{}, exception.ErrNotFound
+		}
+		service.Logger.Errorf("-failed to find stocktake session: %v", err)
+		return web.StocktakeResponse{}, err
+	}
+
+	items, err := service.StocktakeRepository.FindItems(ctx, tx, sessionID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find stocktake items: %v", err)
+		return web.StocktakeResponse{}, err
+	}
+
+	counts, err := service.StocktakeRepository.FindCounts(ctx, tx, sessionID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find stocktake counts: %v", err)
+		return web.StocktakeResponse{}, err
+	}
+
+	myCounts := make(map[ulid.ULID]int)
+	for _, count := range counts {
+		if count.UserID == userID {
+			myCounts[count.ProductID] = count.CountedQuantity
+		}
+	}
+
+	response := helper.ToStocktakeResponse(session)
+	response.Items = make([]web.StocktakeItemResponse, 0)
+	for _, item := range items {
+		itemResponse := web.StocktakeItemResponse{
+			ProductID:   item.ProductID,
+			ProductName: item.ProductName,
+		}
+		if revealExpected || !session.Blind {
+			expected := item.ExpectedQuantity
+			itemResponse.ExpectedQuantity = &expected
+		}
+		if counted, ok := myCounts[item.ProductID]; ok {
+			itemResponse.MyCountedQuantity = &counted
+		}
+		response.Items = append(response.Items, itemResponse)
+	}
+
+	return response, nil
+}
+
+func (service *StocktakeServiceImpl) buildVariance(ctx context.Context, tx *sql.Tx, session domain.StocktakeSession) (web.StocktakeVarianceResponse, error) {
+	items, err := service.StocktakeRepository.FindItems(ctx, tx, session.SessionID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find stocktake items: %v", err)
+		return web.StocktakeVarianceResponse{}, err
+	}
+
+	counts, err := service.StocktakeRepository.FindCounts(ctx, tx, session.SessionID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find stocktake counts: %v", err)
+		return web.StocktakeVarianceResponse{}, err
+	}
+
+	countsByProduct := make(map[ulid.ULID][]web.StocktakeCountResponse)
+	for _, count := range counts {
+		countsByProduct[count.ProductID] = append(countsByProduct[count.ProductID], web.StocktakeCountResponse{
+			UserID:          count.UserID,
+			CountedQuantity: count.CountedQuantity,
+			CountedAt:       count.CountedAt,
+		})
+	}
+
+	response := web.StocktakeVarianceResponse{
+		SessionID:          session.SessionID,
+		LocationID:         session.LocationID,
+		Status:             session.Status,
+		TotalVarianceValue: decimal.Zero,
+		Items:              make([]web.StocktakeVarianceItemResp, 0),
+	}
+
+	for _, item := range items {
+		itemResponse := web.StocktakeVarianceItemResp{
+			ProductID:        item.ProductID,
+			ProductName:      item.ProductName,
+			ExpectedQuantity: item.ExpectedQuantity,
+			VarianceValue:    decimal.Zero,
+			Counts:           make([]web.StocktakeCountResponse, 0),
+		}
+
+		if productCounts, ok := countsByProduct[item.ProductID]; ok {
+			counted := 0
+			for _, count := range productCounts {
+				counted += count.CountedQuantity
+			}
+			itemResponse.CountedQuantity = &counted
+			itemResponse.Variance = counted - item.ExpectedQuantity
+			itemResponse.VarianceValue = item.PurchasePrice.Mul(decimal.NewFromInt(int64(itemResponse.Variance)))
+			itemResponse.Counts = productCounts
+
+			response.CountedProducts++
+			response.TotalVarianceValue = response.TotalVarianceValue.Add(itemResponse.VarianceValue)
+		} else {
+			response.UncountedProducts++
+		}
+
+		response.Items = append(response.Items, itemResponse)
+	}
+
+	return response, nil
+}
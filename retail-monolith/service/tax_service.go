@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"retail-management/model/web"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type TaxService interface {
+	Create(ctx context.Context, req web.TaxRateRequest) (web.TaxRateResponse, error)
+	FindAll(ctx context.Context) ([]web.TaxRateResponse, error)
+	Update(ctx context.Context, req web.TaxRateUpdateRequest) (web.TaxRateResponse, error)
+	Delete(ctx context.Context, taxRateID ulid.ULID) error
+	AssignToCategory(ctx context.Context, req web.TaxRateAssignRequest) error
+	AssignToProduct(ctx context.Context, req web.TaxRateAssignRequest) error
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"os"
+	"retail-management/exception"
+	"retail-management/helper"
+	"retail-management/model/domain"
+	"retail-management/model/web"
+	"retail-management/repository"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+type TaxServiceImpl struct {
+	TaxRepository repository.TaxRepository
+	DB            *sql.DB
+	Validate      *validator.Validate
+	Logger        *logrus.Logger
+}
+
+func NewTaxService(taxRepository repository.TaxRepository, db *sql.DB, validate *validator.Validate, logger *logrus.Logger) TaxService {
+	return &TaxServiceImpl{
+		TaxRepository: taxRepository,
+		DB:            db,
+		Validate:      validate,
+		Logger:        logger,
+	}
+}
+
+func (service *TaxServiceImpl) Create(ctx context.Context, req web.TaxRateRequest) (web.TaxRateResponse, error) {
+	service.Logger.Info("-validating the request...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating request: %v", err)
+		return web.TaxRateResponse{}, err
+	}
+
+	if !validTaxRate(req.Rate) {
+		service.Logger.Warnf("-invalid tax rate %s", req.Rate)
+		return web.TaxRateResponse{}, exception.ErrInvalidTaxRate
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.TaxRateResponse{}, err
+	}
+	defer tx.Rollback()
+
+	t := time.Now()
+	taxRate := domain.TaxRate{
+		TaxRateID: ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(rand.Reader, 0)),
+		TaxName:   req.TaxName,
+		Rate:      req.Rate,
+		CreatedAt: t,
+	}
+
+	service.Logger.Info("-executing TaxRepository.Save()...")
+	taxRate, err = service.TaxRepository.Save(ctx, tx, taxRate)
+	if err != nil {
+		return web.TaxRateResponse{}, err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.TaxRateResponse{}, errCommit
+	}
+
+	return helper.ToTaxRateResponse(taxRate), nil
+}
+
+func (service *TaxServiceImpl) FindAll(ctx context.Context) ([]web.TaxRateResponse, error) {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return []web.TaxRateResponse{}, err
+	}
+	defer tx.Commit()
+
+	service.Logger.Info("-executing TaxRepository.FindAll()...")
+	taxRates, err := service.TaxRepository.FindAll(ctx, tx)
+	if err != nil {
+		service.Logger.Errorf("-failed to execute it: %v", err)
+		return []web.TaxRateResponse{}, err
+	}
+
+	return helper.ToTaxRateResponses(taxRates), nil
+}
+
+func (service *TaxServiceImpl) Update(ctx context.Context, req web.TaxRateUpdateRequest) (web.TaxRateResponse, error) {
+	service.Logger.Info("-validating the request...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating request: %v", err)
+		return web.TaxRateResponse{}, err
+	}
+
+	if !validTaxRate(req.Rate) {
+		service.Logger.Warnf("-invalid tax rate %s", req.Rate)
+		return web.TaxRateResponse{}, exception.ErrInvalidTaxRate
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.TaxRateResponse{}, err
+	}
+	defer tx.Rollback()
+
+	service.Logger.Info("-executing TaxRepository.FindByID()...")
+	taxRate, err := service.TaxRepository.FindByID(ctx, tx, req.TaxRateID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.TaxRateResponse{}, exception.ErrNotFound
+		}
+		return web.TaxRateResponse{}, err
+	}
+
+	taxRate.TaxName = req.TaxName
+	taxRate.Rate = req.Rate
+
+	service.Logger.Info("-executing TaxRepository.Update()...")
+	taxRate, err = service.TaxRepository.Update(ctx, tx, taxRate)
+	if err != nil {
+		return web.TaxRateResponse{}, err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		return web.TaxRateResponse{}, errCommit
+	}
+
+	return helper.ToTaxRateResponse(taxRate), nil
+}
+
+func (service *TaxServiceImpl) Delete(ctx context.Context, taxRateID ulid.ULID) error {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	service.Logger.Info("-executing TaxRepository.Delete()...")
+	err = service.TaxRepository.Delete(ctx, tx, taxRateID)
+	if err != nil {
+		return err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	return tx.Commit()
+}
+
+func (service *TaxServiceImpl) AssignToCategory(ctx context.Context, req web.TaxRateAssignRequest) error {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	service.Logger.Info("-executing TaxRepository.AssignToCategory()...")
+	err = service.TaxRepository.AssignToCategory(ctx, tx, req.TargetID, req.TaxRateID)
+	if err != nil {
+		return err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	return tx.Commit()
+}
+
+func (service *TaxServiceImpl) AssignToProduct(ctx context.Context, req web.TaxRateAssignRequest) error {
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	service.Logger.Info("-executing TaxRepository.AssignToProduct()...")
+	err = service.TaxRepository.AssignToProduct(ctx, tx, req.TargetID, req.TaxRateID)
+	if err != nil {
+		return err
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	return tx.Commit()
+}
+
+func validTaxRate(rate decimal.Decimal) bool {
+	return !rate.IsNegative() && rate.LessThanOrEqual(decimal.NewFromInt(100))
+}
+
+func pricesIncludeTax() bool {
+	include, err := strconv.ParseBool(os.Getenv("PRICES_INCLUDE_TAX"))
+	if err != nil {
+		return true
+	}
+	return include
+}
+
+func lineTax(amount decimal.Decimal, rate decimal.Decimal, inclusive bool) decimal.Decimal {
+	if !rate.IsPositive() || !amount.IsPositive() {
+		return decimal.Zero
+	}
+	if inclusive {
+		return amount.Mul(rate).Div(rate.Add(decimal.NewFromInt(100))).Round(2)
+	}
+	return amount.Mul(rate).Div(decimal.NewFromInt(100)).Round(2)
+}
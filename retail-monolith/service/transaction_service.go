@@ -11,4 +11,7 @@ type TransactionService interface {
 	Create(ctx context.Context, req web.TransactionRequest) (web.TransactionResponse, error)
 	FindAll(ctx context.Context, requesterUserID ulid.ULID, requesterRole string) ([]web.TransactionResponse, error)
 	FindByID(ctx context.Context, requesterUserID ulid.ULID, requesterRole string, transactionID ulid.ULID) (web.TransactionResponse, error)
+	CreateReturn(ctx context.Context, requesterRole string, req web.TransactionReturnRequest) (web.TransactionReturnResponse, error)
+	Void(ctx context.Context, requesterRole string, req web.TransactionVoidRequest) (web.TransactionResponse, error)
+	RecoverSagas(ctx context.Context) error
 }
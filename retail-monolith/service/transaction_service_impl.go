@@ -2,15 +2,23 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"math/rand"
+	"os"
 	"retail-management/exception"
 	"retail-management/helper"
 	"retail-management/model/domain"
 	"retail-management/model/web"
 	"retail-management/pb"
 	"retail-management/repository"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -20,32 +28,72 @@ import (
 )
 
 type TransactionServiceImpl struct {
-	TransactionRepository repository.TransactionRepository
-	ProductRepository     repository.ProductRepository
-	InventoryClient       pb.InventoryServiceClient
-	DB                    *sql.DB
-	Validate              *validator.Validate
-	Logger                *logrus.Logger
+	TransactionRepository     repository.TransactionRepository
+	TransactionSagaRepository repository.TransactionSagaRepository
+	ProductRepository         repository.ProductRepository
+	PromotionRepository       repository.PromotionRepository
+	TaxRepository             repository.TaxRepository
+	CustomerRepository        repository.CustomerRepository
+	LoyaltyRepository         repository.LoyaltyRepository
+	GiftCardRepository        repository.GiftCardRepository
+	ShiftRepository           repository.ShiftRepository
+	CostRepository            repository.CostRepository
+	InventoryClient           pb.InventoryServiceClient
+	DB                        *sql.DB
+	Validate                  *validator.Validate
+	Logger                    *logrus.Logger
 }
 
-func NewTransactionService(transactionRepository repository.TransactionRepository, productRepository repository.ProductRepository, inventoryClient pb.InventoryServiceClient, db *sql.DB, validate *validator.Validate, logger *logrus.Logger) TransactionService {
+func NewTransactionService(transactionRepository repository.TransactionRepository, transactionSagaRepository repository.TransactionSagaRepository, productRepository repository.ProductRepository, promotionRepository repository.PromotionRepository, taxRepository repository.TaxRepository, customerRepository repository.CustomerRepository, loyaltyRepository repository.LoyaltyRepository, giftCardRepository repository.GiftCardRepository, shiftRepository repository.ShiftRepository, costRepository repository.CostRepository, inventoryClient pb.InventoryServiceClient, db *sql.DB, validate *validator.Validate, logger *logrus.Logger) TransactionService {
 	return &TransactionServiceImpl{
-		TransactionRepository: transactionRepository,
-		ProductRepository:     productRepository,
-		InventoryClient:       inventoryClient,
-		DB:                    db,
-		Validate:              validate,
-		Logger:                logger,
+		TransactionRepository:     transactionRepository,
+		TransactionSagaRepository: transactionSagaRepository,
+		ProductRepository:         productRepository,
+		PromotionRepository:       promotionRepository,
+		TaxRepository:             taxRepository,
+		CustomerRepository:        customerRepository,
+		LoyaltyRepository:         loyaltyRepository,
+		GiftCardRepository:        giftCardRepository,
+		ShiftRepository:           shiftRepository,
+		CostRepository:            costRepository,
+		InventoryClient:           inventoryClient,
+		DB:                        db,
+		Validate:                  validate,
+		Logger:                    logger,
 	}
 }
 
 func (service *TransactionServiceImpl) Create(ctx context.Context, req web.TransactionRequest) (web.TransactionResponse, error) {
-	entropySrc := rand.New(rand.NewSource(time.Now().UnixNano()))
-	monotonicEntropy := ulid.Monotonic(entropySrc, 0)
+	service.Logger.Info("-validating the req body...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating the req: %v", err)
+		return web.TransactionResponse{}, err
+	}
 
+	entropySrc := rand.New(rand.NewSource(time.Now().UnixNano()))
 	t := time.Now()
+	transactionID := ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(entropySrc, 0))
+
+	if req.IdempotencyKey != "" {
+		service.Logger.Infof("-checking idempotency key %s...", req.IdempotencyKey)
+		replayed, found, err := service.replayIdempotencyKey(ctx, req)
+		if err != nil {
+			return web.TransactionResponse{}, err
+		}
+		if found {
+			service.Logger.Infof("-idempotency key %s already used, replaying transaction %s", req.IdempotencyKey, replayed.TransactionID)
+			return replayed, nil
+		}
+	}
+
+	return service.createTransaction(ctx, req, transactionID, t)
+}
+
+func (service *TransactionServiceImpl) createTransaction(ctx context.Context, req web.TransactionRequest, transactionID ulid.ULID, t time.Time) (web.TransactionResponse, error) {
+	entropySrc := rand.New(rand.NewSource(time.Now().UnixNano()))
+	monotonicEntropy := ulid.Monotonic(entropySrc, 0)
 	timestamp := ulid.Timestamp(t)
-	transactionID := ulid.MustNew(timestamp, monotonicEntropy)
 
 	service.Logger.Info("-trying to begin tx...")
 	tx, err := service.DB.Begin()
@@ -54,26 +102,75 @@ func (service *TransactionServiceImpl) Create(ctx context.Context, req web.Trans
 	}
 	defer tx.Rollback()
 
+	service.Logger.Info("-finding the open shift the cashier sells in...")
+	shift, err := service.ShiftRepository.FindOpenByUserID(ctx, tx, req.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			service.Logger.Warnf("-user %s has no open shift", req.UserID)
+			return web.TransactionResponse{}, exception.ErrNoOpenShift
+		}
+		service.Logger.Errorf("-failed to find open shift: %v", err)
+		return web.TransactionResponse{}, err
+	}
+	locationID, registerID := shift.LocationID, shift.RegisterID
+
+	if req.CustomerID != nil {
+		service.Logger.Infof("-checking customer %s exists...", *req.CustomerID)
+		_, err = service.CustomerRepository.FindByID(ctx, tx, *req.CustomerID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return web.TransactionResponse{}, exception.ErrNotFound
+			}
+			return web.TransactionResponse{}, err
+		}
+	}
+
 	var detailsDomain []domain.TransactionDetail
 	var detailsResponse []web.TransactionItemResp
-	totalAmount := decimal.Zero
+	var basketLines []basketLine
+	grossAmount := decimal.Zero
 
+	var productIDs []ulid.ULID
 	var grpcItems []*pb.Item
+	var detailComponents []domain.TransactionDetailComponent
 
 	for _, itemReq := range req.Items {
-		product, err := service.ProductRepository.FindByID(ctx, tx, itemReq.ProductID)
+		productID, err := service.resolveItemProduct(ctx, tx, itemReq)
+		if err != nil {
+			service.Logger.Errorf("-failed to resolve the product of an item: %v", err)
+			return web.TransactionResponse{}, err
+		}
+
+		product, err := service.ProductRepository.FindByID(ctx, tx, productID)
 		if err != nil {
 			service.Logger.Errorf("-product not found: %v", err)
 			return web.TransactionResponse{}, exception.ErrNotFound
 		}
 
+		variantCount, err := service.ProductRepository.CountVariants(ctx, tx, productID)
+		if err != nil {
+			service.Logger.Errorf("-failed to count variants: %v", err)
+			return web.TransactionResponse{}, err
+		}
+		if variantCount > 0 {
+			service.Logger.Warnf("-product %s has %d variants, one of them must be sold", productID, variantCount)
+			return web.TransactionResponse{}, exception.ErrProductHasVariants
+		}
+
+		bundleComponents, err := service.ProductRepository.FindComponents(ctx, tx, []ulid.ULID{productID})
+		if err != nil {
+			service.Logger.Errorf("-failed to find bundle components: %v", err)
+			return web.TransactionResponse{}, err
+		}
+
 		currentPrice := product.SellingPrice
 		qtyDecimal := decimal.NewFromInt(int64(itemReq.Quantity))
 		subTotal := currentPrice.Mul(qtyDecimal)
-		totalAmount = totalAmount.Add(subTotal)
+		grossAmount = grossAmount.Add(subTotal)
 
+		detailID := ulid.MustNew(timestamp, monotonicEntropy)
 		detailsDomain = append(detailsDomain, domain.TransactionDetail{
-			DetailID:      ulid.MustNew(timestamp, monotonicEntropy),
+			DetailID:      detailID,
 			TransactionID: transactionID,
 			ProductID:     product.ProductID,
 			Quantity:      itemReq.Quantity,
@@ -81,6 +178,7 @@ func (service *TransactionServiceImpl) Create(ctx context.Context, req web.Trans
 		})
 
 		detailsResponse = append(detailsResponse, web.TransactionItemResp{
+			DetailID:    detailID,
 			ProductID:   product.ProductID,
 			ProductName: product.ProductName,
 			Quantity:    itemReq.Quantity,
@@ -88,142 +186,1334 @@ func (service *TransactionServiceImpl) Create(ctx context.Context, req web.Trans
 			SubTotal:    subTotal,
 		})
 
-		grpcItems = append(grpcItems, &pb.Item{
-			ProductId: itemReq.ProductID.String(),
-			Quantity:  int32(itemReq.Quantity),
+		basketLines = append(basketLines, basketLine{
+			DetailID:        detailID,
+			ProductID:       product.ProductID,
+			ParentProductID: product.ParentProductID,
+			CategoryID:      product.CategoryID,
+			Quantity:        itemReq.Quantity,
+			Price:           currentPrice,
 		})
+
+		productIDs = append(productIDs, product.ProductID)
+
+		var lineComponents []domain.TransactionDetailComponent
+		for _, component := range bundleComponents[productID] {
+			lineComponents = append(lineComponents, domain.TransactionDetailComponent{
+				DetailID:  detailID,
+				ProductID: component.ProductID,
+				Quantity:  component.Quantity,
+			})
+		}
+		detailComponents = append(detailComponents, lineComponents...)
+
+		grpcItems = addStockItems(grpcItems, product.ProductID, lineComponents, itemReq.Quantity)
 	}
 
-	service.Logger.Info("-calling inventory microservice to decrease stock...")
-	decreaseResp, err := service.InventoryClient.DecreaseStock(ctx, &pb.DecreaseStockRequest{
-		Items:         grpcItems,
-		UserId:        req.UserID.String(),
-		TransactionId: transactionID.String(),
-	})
+	service.Logger.Info("-evaluating active promotions against the basket...")
+	promotions, err := service.PromotionRepository.FindApplicable(ctx, tx, t, req.CouponCode)
+	if err != nil {
+		service.Logger.Errorf("-failed to find applicable promotions: %v", err)
+		return web.TransactionResponse{}, err
+	}
+
+	lineDiscounts, discounts := applyPromotions(basketLines, promotions)
 
+	service.Logger.Info("-finding the tax rates of the sold products...")
+	taxRates, err := service.TaxRepository.FindRatesByProductIDs(ctx, tx, productIDs)
 	if err != nil {
-		service.Logger.Errorf("-grpc call failed: %v", err)
-		return web.TransactionResponse{}, fmt.Errorf("inventory service unavailable")
+		service.Logger.Errorf("-failed to find tax rates: %v", err)
+		return web.TransactionResponse{}, err
 	}
 
-	if !decreaseResp.Success {
-		service.Logger.Warnf("-inventory rejected: %s", decreaseResp.Message)
-		return web.TransactionResponse{}, fmt.Errorf("error: %v", decreaseResp.Message)
+	inclusive := pricesIncludeTax()
+	discountAmount := decimal.Zero
+	taxAmount := decimal.Zero
+	totalAmount := decimal.Zero
+	for i := range detailsDomain {
+		lineAmount := detailsResponse[i].SubTotal.Sub(lineDiscounts[i])
+		detailsDomain[i].Discount = lineDiscounts[i]
+		detailsDomain[i].TaxInclusive = inclusive
+		if taxRate, ok := taxRates[detailsDomain[i].ProductID]; ok {
+			detailsDomain[i].TaxRateID = &taxRate.TaxRateID
+			detailsDomain[i].TaxRate = taxRate.Rate
+			detailsDomain[i].TaxAmount = lineTax(lineAmount, taxRate.Rate, inclusive)
+		}
+		if !inclusive {
+			lineAmount = lineAmount.Add(detailsDomain[i].TaxAmount)
+		}
+
+		detailsResponse[i].DiscountAmount = lineDiscounts[i]
+		detailsResponse[i].TaxRate = detailsDomain[i].TaxRate
+		detailsResponse[i].TaxAmount = detailsDomain[i].TaxAmount
+		detailsResponse[i].TaxInclusive = inclusive
+		detailsResponse[i].LineTotal = lineAmount
+
+		discountAmount = discountAmount.Add(lineDiscounts[i])
+		taxAmount = taxAmount.Add(detailsDomain[i].TaxAmount)
+		totalAmount = totalAmount.Add(lineAmount)
+	}
+
+	err = service.claimPromotions(ctx, tx, promotions, discounts, req.CouponCode)
+	if err != nil {
+		service.Logger.Warnf("-promotions rejected: %v", err)
+		return web.TransactionResponse{}, err
+	}
+	for i := range discounts {
+		discounts[i].DiscountID = ulid.MustNew(timestamp, monotonicEntropy)
+		discounts[i].TransactionID = transactionID
+	}
+
+	service.Logger.Info("-checking the payments cover the total...")
+	payments, err := toTransactionPayments(req.Payments, transactionID, totalAmount, timestamp, monotonicEntropy)
+	if err != nil {
+		service.Logger.Warnf("-payments rejected for total %s: %v", totalAmount, err)
+		return web.TransactionResponse{}, err
+	}
+
+	redeemPoints, err := loyaltyRedemption(payments, req.CustomerID)
+	if err != nil {
+		service.Logger.Warnf("-loyalty points payment rejected: %v", err)
+		return web.TransactionResponse{}, err
+	}
+	if redeemPoints > 0 {
+		// locked before the sale references the customer, so concurrent redemptions queue instead of deadlocking
+		err = service.LoyaltyRepository.LockCustomer(ctx, tx, *req.CustomerID)
+		if err != nil {
+			return web.TransactionResponse{}, err
+		}
 	}
 
 	transactionHeader := domain.Transaction{
 		TransactionID: transactionID,
 		UserID:        req.UserID,
+		Status:        domain.TransactionStatusPending,
+		LocationID:    locationID,
+		RegisterID:    registerID,
+		ShiftID:       &shift.ShiftID,
+		CustomerID:    req.CustomerID,
 		CreatedAt:     t,
 	}
 
 	_, err = service.TransactionRepository.Save(ctx, tx, transactionHeader)
 	if err != nil {
-		service.Logger.Errorf("-stock decreased but save process failed, reverting stock...")
-		for _, item := range grpcItems {
-			service.InventoryClient.AdjustStock(ctx, &pb.AdjustStockRequest{
-				ProductId:      item.ProductId,
-				QuantityChange: item.Quantity,
-				Reason:         fmt.Sprintf("rollback tx: %s", transactionID.String()),
-				UserId:         req.UserID.String(),
-			})
-		}
+		service.Logger.Errorf("-failed to save transaction header: %v", err)
 		return web.TransactionResponse{}, err
 	}
 
+	if req.IdempotencyKey != "" {
+		service.Logger.Infof("-claiming idempotency key %s...", req.IdempotencyKey)
+		err = service.claimIdempotencyKey(ctx, tx, req, transactionID, t)
+		if err != nil {
+			return web.TransactionResponse{}, err
+		}
+	}
+
 	_, err = service.TransactionRepository.SaveDetails(ctx, tx, detailsDomain)
 	if err != nil {
-		service.Logger.Errorf("-CRITICAL: Save Details failed! Reverting stock...")
-		for _, item := range grpcItems {
-			service.InventoryClient.AdjustStock(ctx, &pb.AdjustStockRequest{
-				ProductId:      item.ProductId,
-				QuantityChange: item.Quantity,
-				Reason:         fmt.Sprintf("Rollback TX: %s", transactionID.String()),
-				UserId:         req.UserID.String(),
-			})
+		service.Logger.Errorf("-failed to save transaction details: %v", err)
+		return web.TransactionResponse{}, err
+	}
+
+	err = service.TransactionRepository.SaveDetailComponents(ctx, tx, detailComponents)
+	if err != nil {
+		service.Logger.Errorf("-failed to save bundle components of the details: %v", err)
+		return web.TransactionResponse{}, err
+	}
+
+	err = service.TransactionRepository.SaveDiscounts(ctx, tx, discounts)
+	if err != nil {
+		service.Logger.Errorf("-failed to save transaction discounts: %v", err)
+		return web.TransactionResponse{}, err
+	}
+
+	err = service.redeemGiftCards(ctx, tx, payments, req.Payments, transactionID, t)
+	if err != nil {
+		service.Logger.Warnf("-gift card payment rejected: %v", err)
+		return web.TransactionResponse{}, err
+	}
+
+	err = service.TransactionRepository.SavePayments(ctx, tx, payments)
+	if err != nil {
+		service.Logger.Errorf("-failed to save transaction payments: %v", err)
+		return web.TransactionResponse{}, err
+	}
+
+	if redeemPoints > 0 {
+		service.Logger.Infof("-redeeming %d loyalty points of customer %s...", redeemPoints, *req.CustomerID)
+		err = postPoints(ctx, tx, service.LoyaltyRepository, *req.CustomerID, domain.LoyaltyEntryRedeem, -redeemPoints, &transactionID, nil, t)
+		if err != nil {
+			service.Logger.Warnf("-failed to redeem loyalty points: %v", err)
+			return web.TransactionResponse{}, err
 		}
+	}
+
+	err = service.TransactionSagaRepository.Save(ctx, tx, domain.TransactionSaga{
+		SagaID:    transactionID,
+		Status:    domain.SagaStatusPending,
+		CreatedAt: t,
+		UpdatedAt: t,
+	})
+	if err != nil {
+		service.Logger.Errorf("-failed to save transaction saga: %v", err)
 		return web.TransactionResponse{}, err
 	}
 
-	service.Logger.Info("-trying to commit tx...")
+	service.Logger.Info("-trying to commit pending transaction...")
 	errCommit := tx.Commit()
 	if errCommit != nil {
-		service.Logger.Errorf("-failed commit: %v", err)
+		service.Logger.Errorf("-failed commit: %v", errCommit)
+		return web.TransactionResponse{}, errCommit
+	}
+
+	service.Logger.Info("-calling inventory microservice to decrease stock...")
+	decreaseResp, err := service.InventoryClient.DecreaseStock(ctx, &pb.DecreaseStockRequest{
+		Items:         grpcItems,
+		UserId:        req.UserID.String(),
+		TransactionId: transactionID.String(),
+		LocationId:    locationID.String(),
+	})
+
+	if err != nil {
+		service.Logger.Errorf("-grpc call failed, scheduling compensation: %v", err)
+		lastError := err.Error()
+		service.finishSaga(ctx, transactionID, "", domain.SagaStatusCompensating, &lastError)
+		return web.TransactionResponse{}, fmt.Errorf("inventory service unavailable")
+	}
+
+	if !decreaseResp.Success {
+		service.Logger.Warnf("-inventory rejected: %s", decreaseResp.Message)
+		service.finishSaga(ctx, transactionID, domain.TransactionStatusFailed, domain.SagaStatusCompensated, &decreaseResp.Message)
+		return web.TransactionResponse{}, fmt.Errorf("error: %v", decreaseResp.Message)
+	}
+
+	err = service.finishSaga(ctx, transactionID, domain.TransactionStatusCompleted, domain.SagaStatusCompleted, nil)
+	if err != nil {
 		return web.TransactionResponse{}, err
 	}
 
 	service.Logger.Info("-success, returning back to controller layer")
 	return web.TransactionResponse{
-		TransactionID: transactionID,
-		UserID:        req.UserID,
-		TotalAmount:   totalAmount,
-		CreatedAt:     t,
-		Items:         detailsResponse,
+		TransactionID:  transactionID,
+		UserID:         req.UserID,
+		Status:         domain.TransactionStatusCompleted,
+		LocationID:     locationID,
+		RegisterID:     registerID,
+		CustomerID:     req.CustomerID,
+		GrossAmount:    grossAmount,
+		DiscountAmount: discountAmount,
+		TaxAmount:      taxAmount,
+		TotalAmount:    totalAmount,
+		NetAmount:      totalAmount,
+		CreatedAt:      t,
+		Items:          detailsResponse,
+		Discounts:      helper.ToTransactionDiscountResponses(discounts),
+		Payments:       helper.ToTransactionPaymentResponses(payments),
 	}, nil
 }
 
-func (service *TransactionServiceImpl) FindAll(ctx context.Context, requesterUserID ulid.ULID, requesterRole string) ([]web.TransactionResponse, error) {
-	service.Logger.Info("-executing TransactionService.FindAll()...")
-	service.Logger.Info("-trying to begin tx (read)...")
+func (service *TransactionServiceImpl) resolveItemProduct(ctx context.Context, tx *sql.Tx, itemReq web.TransactionItemReq) (ulid.ULID, error) {
+	if itemReq.Barcode == "" {
+		if itemReq.ProductID == (ulid.ULID{}) {
+			return ulid.ULID{}, exception.ErrInvalidTransactionItem
+		}
+		return itemReq.ProductID, nil
+	}
+
+	productID, err := findProductIDByBarcode(ctx, tx, service.ProductRepository, itemReq.Barcode)
+	if err != nil {
+		return ulid.ULID{}, err
+	}
+	if itemReq.ProductID != (ulid.ULID{}) && itemReq.ProductID != productID {
+		service.Logger.Warnf("-barcode %s does not belong to product %s", itemReq.Barcode, itemReq.ProductID)
+		return ulid.ULID{}, exception.ErrInvalidTransactionItem
+	}
+	return productID, nil
+}
+
+func (service *TransactionServiceImpl) claimPromotions(ctx context.Context, tx *sql.Tx, promotions []domain.Promotion, discounts []domain.TransactionDiscount, couponCode *string) error {
+	usedPromotions := make(map[ulid.ULID]bool)
+	for _, discount := range discounts {
+		usedPromotions[discount.PromotionID] = true
+	}
+
+	couponApplied := false
+	var promotionIDs []ulid.ULID
+	for _, promotion := range promotions {
+		if !usedPromotions[promotion.PromotionID] {
+			continue
+		}
+		if promotion.CouponCode != nil {
+			couponApplied = true
+		}
+		promotionIDs = append(promotionIDs, promotion.PromotionID)
+	}
+
+	if couponCode != nil && !couponApplied {
+		return exception.ErrInvalidCoupon
+	}
+
+	// claimed in id order so concurrent sales lock the promotion rows the same way
+	slices.SortFunc(promotionIDs, func(a, b ulid.ULID) int { return a.Compare(b) })
+	for _, promotionID := range promotionIDs {
+		err := service.PromotionRepository.IncrementUsage(ctx, tx, promotionID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (service *TransactionServiceImpl) finishSaga(ctx context.Context, transactionID ulid.ULID, transactionStatus string, sagaStatus string, lastError *string) error {
+	service.Logger.Infof("-moving saga %s to %s...", transactionID, sagaStatus)
 	tx, err := service.DB.Begin()
 	if err != nil {
-		return []web.TransactionResponse{}, err
+		service.Logger.Errorf("-failed to finish saga %s: %v", transactionID, err)
+		return err
 	}
-	defer tx.Commit()
+	defer tx.Rollback()
 
-	var transactionsDomain []domain.TransactionWithTotal
+	err = service.TransactionSagaRepository.UpdateStatus(ctx, tx, transactionID, domain.SagaStatusPending, sagaStatus, lastError)
+	if err != nil {
+		service.Logger.Errorf("-failed to finish saga %s: %v", transactionID, err)
+		return err
+	}
 
-	if requesterRole == "admin" {
-		service.Logger.Info("-user is admin, fetching ALL transactions...")
-		transactionsDomain, err = service.TransactionRepository.FindAll(ctx, tx)
-	} else {
-		service.Logger.Infof("-user is cashier (%s), fetching OWN transactions...", requesterUserID)
-		transactionsDomain, err = service.TransactionRepository.FindAllByUserID(ctx, tx, requesterUserID)
+	if transactionStatus != "" {
+		err = service.TransactionRepository.UpdateStatus(ctx, tx, transactionID, domain.TransactionStatusPending, transactionStatus)
+		if err != nil {
+			service.Logger.Errorf("-failed to finish saga %s: %v", transactionID, err)
+			return err
+		}
+	}
+
+	if transactionStatus == domain.TransactionStatusFailed {
+		err = service.PromotionRepository.ReleaseUsage(ctx, tx, transactionID)
+		if err != nil {
+			service.Logger.Errorf("-failed to release promotion usage of %s: %v", transactionID, err)
+			return err
+		}
+
+		err = service.restoreRedeemedPoints(ctx, tx, transactionID, time.Now())
+		if err != nil {
+			service.Logger.Errorf("-failed to restore redeemed points of %s: %v", transactionID, err)
+			return err
+		}
+
+		header, err := service.TransactionRepository.FindByID(ctx, tx, transactionID)
+		if err != nil {
+			return err
+		}
+		err = service.restoreGiftCards(ctx, tx, header, time.Now())
+		if err != nil {
+			service.Logger.Errorf("-failed to restore gift card balances of %s: %v", transactionID, err)
+			return err
+		}
+	}
+
+	if transactionStatus == domain.TransactionStatusCompleted {
+		err = service.recordCostOfGoodsSold(ctx, tx, transactionID)
+		if err != nil {
+			service.Logger.Errorf("-failed to record cost of goods sold for %s: %v", transactionID, err)
+			return err
+		}
+
+		header, err := service.TransactionRepository.FindByID(ctx, tx, transactionID)
+		if err != nil {
+			return err
+		}
+		err = service.settleEarnedPoints(ctx, tx, header, header.TotalAmount, nil, time.Now())
+		if err != nil {
+			service.Logger.Errorf("-failed to award loyalty points for %s: %v", transactionID, err)
+			return err
+		}
 	}
 
+	err = tx.Commit()
 	if err != nil {
-		service.Logger.Errorf("-failed to fetch transactions: %v", err)
-		return []web.TransactionResponse{}, err
+		service.Logger.Errorf("-failed to finish saga %s: %v", transactionID, err)
+		return err
 	}
 
-	service.Logger.Info("-successfully fetched transactions history")
-	return helper.ToTransactionResponses(transactionsDomain), nil
+	return nil
 }
 
-func (service *TransactionServiceImpl) FindByID(ctx context.Context, requesterUserID ulid.ULID, requesterRole string, transactionID ulid.ULID) (web.TransactionResponse, error) {
-	service.Logger.Infof("-executing TransactionService.FindByID(%s)...", transactionID)
+func (service *TransactionServiceImpl) recordCostOfGoodsSold(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID) error {
+	details, err := service.TransactionRepository.FindDetailsByTransactionID(ctx, tx, transactionID)
+	if err != nil {
+		return err
+	}
 
-	service.Logger.Info("-trying to begin tx (read)...")
-	tx, err := service.DB.Begin()
+	detailComponents, err := service.TransactionRepository.FindDetailComponents(ctx, tx, transactionID)
 	if err != nil {
-		return web.TransactionResponse{}, err
+		return err
 	}
-	defer tx.Commit()
 
-	service.Logger.Info("-executing Repo.FindByID (Header)...")
-	header, err := service.TransactionRepository.FindByID(ctx, tx, transactionID)
+	t := time.Now()
+	method := costingMethod()
+	reference := fmt.Sprintf("sale:%s", transactionID.String())
+	var costs []domain.TransactionDetailCost
+	for _, detail := range details {
+		var costAmount decimal.Decimal
+		if components, ok := detailComponents[detail.DetailID]; ok {
+			costAmount, err = service.issueComponentCosts(ctx, tx, detail, components, reference, t)
+		} else {
+			costAmount, err = issueCost(ctx, tx, service.CostRepository, detail.ProductID, detail.Quantity, detail.UnitCost, reference, t)
+		}
+		if err != nil {
+			return err
+		}
+
+		costs = append(costs, domain.TransactionDetailCost{
+			DetailID:      detail.DetailID,
+			TransactionID: transactionID,
+			ProductID:     detail.ProductID,
+			Quantity:      detail.Quantity,
+			UnitCost:      costAmount.Div(decimal.NewFromInt(int64(detail.Quantity))).Round(4),
+			CostAmount:    costAmount,
+			CostingMethod: method,
+		})
+	}
+
+	return service.CostRepository.SaveDetailCosts(ctx, tx, costs)
+}
+
+func (service *TransactionServiceImpl) issueComponentCosts(ctx context.Context, tx *sql.Tx, detail domain.TransactionDetailWithProduct, components []domain.TransactionDetailComponent, reference string, t time.Time) (decimal.Decimal, error) {
+	costAmount := decimal.Zero
+	for _, component := range components {
+		quantity := component.Quantity * detail.Quantity
+		componentCost, err := issueCost(ctx, tx, service.CostRepository, component.ProductID, quantity, component.UnitCost, reference, t)
+		if err != nil {
+			return decimal.Zero, err
+		}
+
+		unitCost := componentCost.Div(decimal.NewFromInt(int64(quantity))).Round(4)
+		err = service.TransactionRepository.UpdateDetailComponentCost(ctx, tx, detail.DetailID, component.ProductID, unitCost)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		costAmount = costAmount.Add(componentCost)
+	}
+	return costAmount, nil
+}
+
+func (service *TransactionServiceImpl) receiveLineCost(ctx context.Context, tx *sql.Tx, detail domain.TransactionDetailWithProduct, components []domain.TransactionDetailComponent, quantity int, reference string, t time.Time) error {
+	if len(components) == 0 {
+		return receiveCost(ctx, tx, service.CostRepository, detail.ProductID, quantity, detail.UnitCost, reference, t)
+	}
+	for _, component := range components {
+		err := receiveCost(ctx, tx, service.CostRepository, component.ProductID, component.Quantity*quantity, component.UnitCost, reference, t)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (service *TransactionServiceImpl) settleEarnedPoints(ctx context.Context, tx *sql.Tx, header domain.TransactionWithTotal, remainingAmount decimal.Decimal, returnID *ulid.ULID, t time.Time) error {
+	if header.CustomerID == nil {
+		return nil
+	}
+
+	payments, err := service.TransactionRepository.FindPaymentsByTransactionID(ctx, tx, header.TransactionID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return web.TransactionResponse{}, exception.ErrNotFound
+		return err
+	}
+	for _, payment := range payments {
+		if payment.Method == domain.PaymentMethodLoyalty {
+			remainingAmount = remainingAmount.Sub(payment.Amount)
 		}
-		service.Logger.Errorf("-failed to find transaction header: %v", err)
-		return web.TransactionResponse{}, err
 	}
 
-	if requesterRole != "admin" && header.UserID != requesterUserID {
-		service.Logger.Warnf("-security alert: user %s tried to access transaction %s belonging to %s", requesterUserID, transactionID, header.UserID)
-		return web.TransactionResponse{}, exception.ErrForbidden
+	points, err := service.LoyaltyRepository.FindTransactionPoints(ctx, tx, header.TransactionID)
+	if err != nil {
+		return err
 	}
 
-	service.Logger.Info("-executing Repo.FindDetailsByTransactionID (Items)...")
-	detailsDomain, err := service.TransactionRepository.FindDetailsByTransactionID(ctx, tx, transactionID)
+	change := earnedPoints(remainingAmount) - (points[domain.LoyaltyEntryEarn] + points[domain.LoyaltyEntryReverseEarn])
+	entryType := domain.LoyaltyEntryEarn
+	if change < 0 {
+		entryType = domain.LoyaltyEntryReverseEarn
+	}
+
+	return postPoints(ctx, tx, service.LoyaltyRepository, *header.CustomerID, entryType, change, &header.TransactionID, returnID, t)
+}
+
+func (service *TransactionServiceImpl) restoreRedeemedPoints(ctx context.Context, tx *sql.Tx, transactionID ulid.ULID, t time.Time) error {
+	header, err := service.TransactionRepository.FindByID(ctx, tx, transactionID)
 	if err != nil {
-		service.Logger.Errorf("-failed to find transaction details: %v", err)
-		return web.TransactionResponse{}, err
+		return err
+	}
+	if header.CustomerID == nil {
+		return nil
 	}
 
-	service.Logger.Info("-successfully fetched transaction detail")
-	itemsResponse := helper.ToTransactionItemResponses(detailsDomain)
+	points, err := service.LoyaltyRepository.FindTransactionPoints(ctx, tx, transactionID)
+	if err != nil {
+		return err
+	}
+
+	redeemed := points[domain.LoyaltyEntryRedeem] + points[domain.LoyaltyEntryReverseRedeem]
+	return postPoints(ctx, tx, service.LoyaltyRepository, *header.CustomerID, domain.LoyaltyEntryReverseRedeem, -redeemed, &transactionID, nil, t)
+}
+
+func (service *TransactionServiceImpl) redeemGiftCards(ctx context.Context, tx *sql.Tx, payments []domain.TransactionPayment, paymentsReq []web.TransactionPaymentReq, transactionID ulid.ULID, t time.Time) error {
+	for i, paymentReq := range paymentsReq {
+		if paymentReq.CardCode == nil {
+			continue
+		}
+
+		giftCard, err := service.GiftCardRepository.FindByCodeForUpdate(ctx, tx, normalizeGiftCardCode(*paymentReq.CardCode))
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return exception.ErrNotFound
+			}
+			return err
+		}
+		if giftCard.CardType != paymentReq.Method {
+			return exception.ErrInvalidCardPayment
+		}
+
+		service.Logger.Infof("-redeeming %s from %s %s...", payments[i].Amount, giftCard.CardType, giftCard.GiftCardID)
+		err = postGiftCard(ctx, tx, service.GiftCardRepository, giftCard, domain.GiftCardEntryRedeem, payments[i].Amount.Neg(), &transactionID, nil, t)
+		if err != nil {
+			return err
+		}
+		payments[i].GiftCardID = &giftCard.GiftCardID
+	}
+
+	return nil
+}
+
+func (service *TransactionServiceImpl) restoreGiftCards(ctx context.Context, tx *sql.Tx, header domain.TransactionWithTotal, t time.Time) error {
+	amounts, err := service.GiftCardRepository.FindTransactionAmounts(ctx, tx, header.TransactionID)
+	if err != nil || len(amounts) == 0 {
+		return err
+	}
+
+	payments, err := service.TransactionRepository.FindPaymentsByTransactionID(ctx, tx, header.TransactionID)
+	if err != nil {
+		return err
+	}
+	remaining := header.TotalAmount.Sub(header.ReturnedAmount)
+	for _, payment := range payments {
+		if payment.Method == domain.PaymentMethodCash {
+			remaining = remaining.Sub(payment.Amount)
+		}
+	}
+
+	giftCardIDs := make([]ulid.ULID, 0, len(amounts))
+	for giftCardID := range amounts {
+		giftCardIDs = append(giftCardIDs, giftCardID)
+	}
+	slices.SortFunc(giftCardIDs, func(a, b ulid.ULID) int { return a.Compare(b) })
+
+	for _, giftCardID := range giftCardIDs {
+		refund := decimal.Min(amounts[giftCardID].Neg(), remaining)
+		if !refund.IsPositive() {
+			continue
+		}
+
+		giftCard, err := service.GiftCardRepository.FindByIDForUpdate(ctx, tx, giftCardID)
+		if err != nil {
+			return err
+		}
+		err = postGiftCard(ctx, tx, service.GiftCardRepository, giftCard, domain.GiftCardEntryRefund, refund, &header.TransactionID, nil, t)
+		if err != nil {
+			return err
+		}
+		remaining = remaining.Sub(refund)
+	}
+
+	return nil
+}
+
+func (service *TransactionServiceImpl) RecoverSagas(ctx context.Context) error {
+	service.Logger.Info("-executing TransactionService.RecoverSagas()...")
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Commit()
+
+	sagas, err := service.TransactionSagaRepository.FindRecoverable(ctx, tx, time.Now().Add(-sagaPendingTimeout()), 20)
+	if err != nil {
+		service.Logger.Errorf("-failed to find recoverable sagas: %v", err)
+		return err
+	}
+
+	for _, saga := range sagas {
+		err = service.recoverSaga(ctx, saga.SagaID)
+		if err != nil {
+			service.Logger.Errorf("-failed to recover saga %s: %v", saga.SagaID, err)
+		}
+	}
+
+	if len(sagas) > 0 {
+		service.Logger.Infof("-processed %d recoverable sagas", len(sagas))
+	}
+	return nil
+}
+
+func (service *TransactionServiceImpl) recoverSaga(ctx context.Context, sagaID ulid.ULID) error {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	saga, err := service.TransactionSagaRepository.FindByIDForUpdate(ctx, tx, sagaID)
+	if err != nil {
+		return err
+	}
+	if saga.Status != domain.SagaStatusPending && saga.Status != domain.SagaStatusCompensating {
+		service.Logger.Infof("-saga %s is already %s", sagaID, saga.Status)
+		return nil
+	}
+
+	header, err := service.TransactionRepository.FindByID(ctx, tx, sagaID)
+	if err != nil {
+		return err
+	}
+
+	service.Logger.Infof("-compensating transaction %s (attempt %d)...", sagaID, saga.Attempts+1)
+	cancelResp, err := service.InventoryClient.CancelStockOperation(ctx, &pb.CancelStockOperationRequest{
+		TransactionId: sagaID.String(),
+		UserId:        header.UserID.String(),
+		Reason:        fmt.Sprintf("Cancel: %s", sagaID.String()),
+	})
+	if err == nil && !cancelResp.Success {
+		err = fmt.Errorf("error: %v", cancelResp.Message)
+	}
+	if err != nil {
+		service.Logger.Errorf("-failed to compensate transaction %s: %v", sagaID, err)
+		lastError := err.Error()
+		err = service.TransactionSagaRepository.RecordFailure(ctx, tx, sagaID, lastError)
+		if err != nil {
+			return err
+		}
+
+		if saga.Attempts+1 >= sagaMaxAttempts() {
+			service.Logger.Errorf("-giving up on saga %s after %d attempts, moving it to %s for manual review: %s", sagaID, saga.Attempts+1, domain.SagaStatusDeadLetter, lastError)
+			err = service.TransactionSagaRepository.UpdateStatus(ctx, tx, sagaID, saga.Status, domain.SagaStatusDeadLetter, nil)
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Commit()
+	}
+
+	err = service.TransactionSagaRepository.UpdateStatus(ctx, tx, sagaID, saga.Status, domain.SagaStatusCompensated, nil)
+	if err != nil {
+		return err
+	}
+
+	err = service.TransactionRepository.UpdateStatus(ctx, tx, sagaID, domain.TransactionStatusPending, domain.TransactionStatusFailed)
+	if err != nil {
+		return err
+	}
+
+	err = service.PromotionRepository.ReleaseUsage(ctx, tx, sagaID)
+	if err != nil {
+		return err
+	}
+
+	err = service.restoreRedeemedPoints(ctx, tx, sagaID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	err = service.restoreGiftCards(ctx, tx, header, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func sagaPendingTimeout() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("SAGA_PENDING_TIMEOUT_SECONDS"))
+	if err != nil || seconds <= 0 {
+		seconds = 120
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func sagaMaxAttempts() int {
+	attempts, err := strconv.Atoi(os.Getenv("SAGA_MAX_ATTEMPTS"))
+	if err != nil || attempts <= 0 {
+		attempts = 10
+	}
+	return attempts
+}
+
+func (service *TransactionServiceImpl) FindAll(ctx context.Context, requesterUserID ulid.ULID, requesterRole string) ([]web.TransactionResponse, error) {
+	service.Logger.Info("-executing TransactionService.FindAll()...")
+	service.Logger.Info("-trying to begin tx (read)...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return []web.TransactionResponse{}, err
+	}
+	defer tx.Commit()
+
+	var transactionsDomain []domain.TransactionWithTotal
+
+	if requesterRole == "admin" {
+		service.Logger.Info("-user is admin, fetching ALL transactions...")
+		transactionsDomain, err = service.TransactionRepository.FindAll(ctx, tx)
+	} else {
+		service.Logger.Infof("-user is cashier (%s), fetching OWN transactions...", requesterUserID)
+		transactionsDomain, err = service.TransactionRepository.FindAllByUserID(ctx, tx, requesterUserID)
+	}
+
+	if err != nil {
+		service.Logger.Errorf("-failed to fetch transactions: %v", err)
+		return []web.TransactionResponse{}, err
+	}
+
+	service.Logger.Info("-successfully fetched transactions history")
+	return helper.ToTransactionResponses(transactionsDomain), nil
+}
+
+func (service *TransactionServiceImpl) FindByID(ctx context.Context, requesterUserID ulid.ULID, requesterRole string, transactionID ulid.ULID) (web.TransactionResponse, error) {
+	service.Logger.Infof("-executing TransactionService.FindByID(%s)...", transactionID)
+
+	service.Logger.Info("-trying to begin tx (read)...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.TransactionResponse{}, err
+	}
+	defer tx.Commit()
+
+	service.Logger.Info("-executing Repo.FindByID (Header)...")
+	header, err := service.TransactionRepository.FindByID(ctx, tx, transactionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.TransactionResponse{}, exception.ErrNotFound
+		}
+		service.Logger.Errorf("-failed to find transaction header: %v", err)
+		return web.TransactionResponse{}, err
+	}
+
+	if requesterRole != "admin" && header.UserID != requesterUserID {
+		service.Logger.Warnf("-security alert: user %s tried to access transaction %s belonging to %s", requesterUserID, transactionID, header.UserID)
+		return web.TransactionResponse{}, exception.ErrForbidden
+	}
+
+	service.Logger.Info("-executing Repo.FindDetailsByTransactionID (Items)...")
+	detailsDomain, err := service.TransactionRepository.FindDetailsByTransactionID(ctx, tx, transactionID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find transaction details: %v", err)
+		return web.TransactionResponse{}, err
+	}
+
+	service.Logger.Info("-executing Repo.FindDiscountsByTransactionID (Discounts)...")
+	discounts, err := service.TransactionRepository.FindDiscountsByTransactionID(ctx, tx, transactionID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find transaction discounts: %v", err)
+		return web.TransactionResponse{}, err
+	}
+
+	service.Logger.Info("-executing Repo.FindPaymentsByTransactionID (Payments)...")
+	payments, err := service.TransactionRepository.FindPaymentsByTransactionID(ctx, tx, transactionID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find transaction payments: %v", err)
+		return web.TransactionResponse{}, err
+	}
+
+	service.Logger.Info("-successfully fetched transaction detail")
+	response := helper.ToTransactionResponse(header, helper.ToTransactionItemResponses(detailsDomain))
+	response.Discounts = helper.ToTransactionDiscountResponses(discounts)
+	response.Payments = helper.ToTransactionPaymentResponses(payments)
+
+	return response, nil
+}
+
+func (service *TransactionServiceImpl) CreateReturn(ctx context.Context, requesterRole string, req web.TransactionReturnRequest) (web.TransactionReturnResponse, error) {
+	service.Logger.Infof("-executing TransactionService.CreateReturn(%s)...", req.TransactionID)
+
+	service.Logger.Info("-validating the req body...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating the req: %v", err)
+		return web.TransactionReturnResponse{}, err
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.TransactionReturnResponse{}, err
+	}
+	defer tx.Rollback()
+
+	service.Logger.Info("-locking transaction header...")
+	err = service.TransactionRepository.LockByID(ctx, tx, req.TransactionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.TransactionReturnResponse{}, exception.ErrNotFound
+		}
+		return web.TransactionReturnResponse{}, err
+	}
+
+	header, err := service.TransactionRepository.FindByID(ctx, tx, req.TransactionID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find transaction header: %v", err)
+		return web.TransactionReturnResponse{}, err
+	}
+
+	if requesterRole != "admin" && header.UserID != req.UserID {
+		service.Logger.Warnf("-security alert: user %s tried to return items of transaction %s belonging to %s", req.UserID, req.TransactionID, header.UserID)
+		return web.TransactionReturnResponse{}, exception.ErrForbidden
+	}
+
+	if header.Status == domain.TransactionStatusVoided {
+		service.Logger.Warnf("-transaction %s is voided, cannot return items", req.TransactionID)
+		return web.TransactionReturnResponse{}, exception.ErrTransactionVoided
+	}
+
+	if header.Status != domain.TransactionStatusCompleted {
+		service.Logger.Warnf("-transaction %s is %s, cannot return items", req.TransactionID, header.Status)
+		return web.TransactionReturnResponse{}, exception.ErrTransactionNotCompleted
+	}
+
+	service.Logger.Info("-executing Repo.FindDetailsByTransactionID (Items)...")
+	soldDetails, err := service.TransactionRepository.FindDetailsByTransactionID(ctx, tx, req.TransactionID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find transaction details: %v", err)
+		return web.TransactionReturnResponse{}, err
+	}
+
+	soldMap := make(map[ulid.ULID]domain.TransactionDetailWithProduct)
+	for _, detail := range soldDetails {
+		soldMap[detail.DetailID] = detail
+	}
+
+	detailComponents, err := service.TransactionRepository.FindDetailComponents(ctx, tx, req.TransactionID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find bundle components of the details: %v", err)
+		return web.TransactionReturnResponse{}, err
+	}
+
+	entropySrc := rand.New(rand.NewSource(time.Now().UnixNano()))
+	entropy := ulid.Monotonic(entropySrc, 0)
+	t := time.Now()
+	returnID := ulid.MustNew(ulid.Timestamp(t), entropy)
+
+	var returnDetails []domain.TransactionReturnDetail
+	var itemsResponse []web.TransactionReturnItemResp
+	requestedMap := make(map[ulid.ULID]int)
+	refundAmount := decimal.Zero
+
+	for _, itemReq := range req.Items {
+		sold, ok := soldMap[itemReq.DetailID]
+		if !ok {
+			service.Logger.Warnf("-detail %s is not part of transaction %s", itemReq.DetailID, req.TransactionID)
+			return web.TransactionReturnResponse{}, exception.ErrInvalidReturnItem
+		}
+
+		requestedMap[itemReq.DetailID] += itemReq.Quantity
+		if sold.ReturnedQuantity+requestedMap[itemReq.DetailID] > sold.Quantity {
+			service.Logger.Warnf("-return exceeds sold quantity for detail %s", itemReq.DetailID)
+			return web.TransactionReturnResponse{}, exception.ErrReturnExceedsSold
+		}
+
+		// unit k refunds round(total*k/n) - round(total*(k-1)/n), so the refunds add up to the line total
+		returnedBefore := sold.ReturnedQuantity + requestedMap[itemReq.DetailID] - itemReq.Quantity
+		unitPrice := sold.LineTotal.Div(decimal.NewFromInt(int64(sold.Quantity))).Round(2)
+		subTotal := decimal.Zero
+
+		var detail *domain.TransactionReturnDetail
+		for k := returnedBefore + 1; k <= returnedBefore+itemReq.Quantity; k++ {
+			price := refundedUpTo(sold.LineTotal, sold.Quantity, k).Sub(refundedUpTo(sold.LineTotal, sold.Quantity, k-1))
+			subTotal = subTotal.Add(price)
+
+			if detail != nil && detail.Price.Equal(price) {
+				detail.Quantity++
+				continue
+			}
+			returnDetails = append(returnDetails, domain.TransactionReturnDetail{
+				ReturnDetailID: ulid.MustNew(ulid.Timestamp(t), entropy),
+				ReturnID:       returnID,
+				DetailID:       sold.DetailID,
+				Quantity:       1,
+				Price:          price,
+			})
+			detail = &returnDetails[len(returnDetails)-1]
+		}
+		refundAmount = refundAmount.Add(subTotal)
+
+		itemsResponse = append(itemsResponse, web.TransactionReturnItemResp{
+			DetailID:    sold.DetailID,
+			ProductID:   sold.ProductID,
+			ProductName: sold.ProductName,
+			Quantity:    itemReq.Quantity,
+			Price:       unitPrice,
+			SubTotal:    subTotal,
+		})
+	}
+
+	transactionReturn := domain.TransactionReturn{
+		ReturnID:      returnID,
+		TransactionID: req.TransactionID,
+		UserID:        req.UserID,
+		Reason:        req.Reason,
+		RefundMethod:  domain.RefundMethodCash,
+		CreatedAt:     t,
+	}
+	if req.RefundMethod != "" {
+		transactionReturn.RefundMethod = req.RefundMethod
+	}
+
+	shift, err := service.ShiftRepository.FindOpenByUserID(ctx, tx, req.UserID)
+	if err == nil {
+		transactionReturn.ShiftID = &shift.ShiftID
+	} else if err != sql.ErrNoRows {
+		service.Logger.Errorf("-failed to find open shift: %v", err)
+		return web.TransactionReturnResponse{}, err
+	}
+
+	_, err = service.TransactionRepository.SaveReturn(ctx, tx, transactionReturn)
+	if err != nil {
+		service.Logger.Errorf("-failed to save return header: %v", err)
+		return web.TransactionReturnResponse{}, err
+	}
+
+	_, err = service.TransactionRepository.SaveReturnDetails(ctx, tx, returnDetails)
+	if err != nil {
+		service.Logger.Errorf("-failed to save return details: %v", err)
+		return web.TransactionReturnResponse{}, err
+	}
+
+	var storeCredit *web.GiftCardResponse
+	if transactionReturn.RefundMethod == domain.RefundMethodStoreCredit {
+		service.Logger.Info("-issuing the refund as store credit...")
+		giftCard := domain.GiftCard{
+			GiftCardID: ulid.MustNew(ulid.Timestamp(t), entropy),
+			CardCode:   newGiftCardCode(),
+			CardType:   domain.GiftCardTypeStoreCredit,
+			CustomerID: header.CustomerID,
+			IssuedBy:   req.UserID,
+			CreatedAt:  t,
+		}
+		entry, err := issueGiftCard(ctx, tx, service.GiftCardRepository, giftCard, refundAmount, &returnID, t)
+		if err != nil {
+			service.Logger.Errorf("-failed to issue store credit: %v", err)
+			return web.TransactionReturnResponse{}, err
+		}
+		response := helper.ToGiftCardResponse(giftCard, entry.BalanceAmount, []domain.GiftCardEntry{entry})
+		storeCredit = &response
+	}
+
+	service.Logger.Info("-taking back the loyalty points earned on the returned items...")
+	err = service.settleEarnedPoints(ctx, tx, header, header.TotalAmount.Sub(header.ReturnedAmount).Sub(refundAmount), &returnID, t)
+	if err != nil {
+		service.Logger.Errorf("-failed to reverse loyalty points: %v", err)
+		return web.TransactionReturnResponse{}, err
+	}
+
+	service.Logger.Info("-recording returned items back at their sale cost...")
+	var restockItems []*pb.Item
+	for _, returnDetail := range returnDetails {
+		sold := soldMap[returnDetail.DetailID]
+		err = service.receiveLineCost(ctx, tx, sold, detailComponents[sold.DetailID], returnDetail.Quantity, fmt.Sprintf("return:%s", returnID.String()), t)
+		if err != nil {
+			service.Logger.Errorf("-failed to record return cost: %v", err)
+			return web.TransactionReturnResponse{}, err
+		}
+		restockItems = addStockItems(restockItems, sold.ProductID, detailComponents[sold.DetailID], returnDetail.Quantity)
+	}
+
+	service.Logger.Info("-calling inventory microservice to restock returned items...")
+	operationID := fmt.Sprintf("return:%s", returnID.String())
+	restoreResp, err := service.InventoryClient.RestoreStock(ctx, &pb.RestoreStockRequest{
+		Items:               restockItems,
+		UserId:              req.UserID.String(),
+		OperationId:         operationID,
+		Reason:              fmt.Sprintf("Return: %s (Transaction: %s)", returnID.String(), req.TransactionID.String()),
+		LocationId:          header.LocationID.String(),
+		SourceTransactionId: req.TransactionID.String(),
+	})
+	if err != nil {
+		service.Logger.Errorf("-grpc call failed: %v", err)
+		return web.TransactionReturnResponse{}, fmt.Errorf("inventory service unavailable")
+	}
+	if !restoreResp.Success {
+		service.Logger.Warnf("-inventory rejected: %s", restoreResp.Message)
+		return web.TransactionReturnResponse{}, fmt.Errorf("error: %v", restoreResp.Message)
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		service.Logger.Errorf("-failed commit, cancelling the restock: %v", errCommit)
+		cancelStockOperation(ctx, service.InventoryClient, service.Logger, operationID, req.UserID, fmt.Sprintf("rollback return: %s", returnID.String()))
+		return web.TransactionReturnResponse{}, errCommit
+	}
+
+	service.Logger.Info("-success, returning back to controller layer")
+	return web.TransactionReturnResponse{
+		ReturnID:      returnID,
+		TransactionID: req.TransactionID,
+		UserID:        req.UserID,
+		Reason:        req.Reason,
+		RefundAmount:  refundAmount,
+		RefundMethod:  transactionReturn.RefundMethod,
+		StoreCredit:   storeCredit,
+		CreatedAt:     t,
+		Items:         itemsResponse,
+	}, nil
+}
+
+func (service *TransactionServiceImpl) Void(ctx context.Context, requesterRole string, req web.TransactionVoidRequest) (web.TransactionResponse, error) {
+	service.Logger.Infof("-executing TransactionService.Void(%s)...", req.TransactionID)
+
+	service.Logger.Info("-validating the req body...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating the req: %v", err)
+		return web.TransactionResponse{}, err
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.TransactionResponse{}, err
+	}
+	defer tx.Rollback()
+
+	service.Logger.Info("-locking transaction header...")
+	err = service.TransactionRepository.LockByID(ctx, tx, req.TransactionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.TransactionResponse{}, exception.ErrNotFound
+		}
+		return web.TransactionResponse{}, err
+	}
+
+	header, err := service.TransactionRepository.FindByID(ctx, tx, req.TransactionID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find transaction header: %v", err)
+		return web.TransactionResponse{}, err
+	}
+
+	if header.Status == domain.TransactionStatusVoided {
+		service.Logger.Warnf("-transaction %s is already voided", req.TransactionID)
+		return web.TransactionResponse{}, exception.ErrTransactionVoided
+	}
+
+	if header.Status != domain.TransactionStatusCompleted {
+		service.Logger.Warnf("-transaction %s is %s, cannot void", req.TransactionID, header.Status)
+		return web.TransactionResponse{}, exception.ErrTransactionNotCompleted
+	}
+
+	if requesterRole != "admin" {
+		if header.UserID != req.UserID {
+			service.Logger.Warnf("-security alert: user %s tried to void transaction %s belonging to %s", req.UserID, req.TransactionID, header.UserID)
+			return web.TransactionResponse{}, exception.ErrForbidden
+		}
+		if time.Since(header.CreatedAt) > voidWindow() {
+			service.Logger.Warnf("-void window expired for transaction %s", req.TransactionID)
+			return web.TransactionResponse{}, exception.ErrVoidWindowExpired
+		}
+	}
+
+	service.Logger.Info("-executing Repo.FindDetailsByTransactionID (Items)...")
+	detailsDomain, err := service.TransactionRepository.FindDetailsByTransactionID(ctx, tx, req.TransactionID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find transaction details: %v", err)
+		return web.TransactionResponse{}, err
+	}
+
+	detailComponents, err := service.TransactionRepository.FindDetailComponents(ctx, tx, req.TransactionID)
+	if err != nil {
+		service.Logger.Errorf("-failed to find bundle components of the details: %v", err)
+		return web.TransactionResponse{}, err
+	}
+
+	var grpcItems []*pb.Item
+	for _, detail := range detailsDomain {
+		remaining := detail.Quantity - detail.ReturnedQuantity
+		if remaining <= 0 {
+			continue
+		}
+		grpcItems = addStockItems(grpcItems, detail.ProductID, detailComponents[detail.DetailID], remaining)
+	}
+
+	voidedAt := time.Now()
+	service.Logger.Info("-recording voided items back at their sale cost...")
+	for _, detail := range detailsDomain {
+		err = service.receiveLineCost(ctx, tx, detail, detailComponents[detail.DetailID], detail.Quantity-detail.ReturnedQuantity, fmt.Sprintf("void:%s", req.TransactionID.String()), voidedAt)
+		if err != nil {
+			service.Logger.Errorf("-failed to record void cost: %v", err)
+			return web.TransactionResponse{}, err
+		}
+	}
+
+	err = service.TransactionRepository.Void(ctx, tx, domain.TransactionVoid{
+		TransactionID: req.TransactionID,
+		VoidedBy:      req.UserID,
+		VoidReason:    req.Reason,
+		VoidedAt:      voidedAt,
+	})
+	if err != nil {
+		service.Logger.Errorf("-failed to void transaction: %v", err)
+		return web.TransactionResponse{}, err
+	}
+
+	service.Logger.Info("-reversing the loyalty points of the sale...")
+	err = service.settleEarnedPoints(ctx, tx, header, decimal.Zero, nil, voidedAt)
+	if err != nil {
+		service.Logger.Errorf("-failed to reverse earned points: %v", err)
+		return web.TransactionResponse{}, err
+	}
+
+	err = service.restoreRedeemedPoints(ctx, tx, req.TransactionID, voidedAt)
+	if err != nil {
+		service.Logger.Errorf("-failed to restore redeemed points: %v", err)
+		return web.TransactionResponse{}, err
+	}
+
+	service.Logger.Info("-putting the sale's gift card tender back on its cards...")
+	err = service.restoreGiftCards(ctx, tx, header, voidedAt)
+	if err != nil {
+		service.Logger.Errorf("-failed to restore gift card balances: %v", err)
+		return web.TransactionResponse{}, err
+	}
+
+	operationID := fmt.Sprintf("void:%s", req.TransactionID.String())
+	if len(grpcItems) > 0 {
+		service.Logger.Info("-calling inventory microservice to restore stock...")
+		restoreResp, err := service.InventoryClient.RestoreStock(ctx, &pb.RestoreStockRequest{
+			Items:               grpcItems,
+			UserId:              req.UserID.String(),
+			OperationId:         operationID,
+			Reason:              fmt.Sprintf("Void: %s", req.TransactionID.String()),
+			LocationId:          header.LocationID.String(),
+			SourceTransactionId: req.TransactionID.String(),
+		})
+		if err != nil {
+			service.Logger.Errorf("-grpc call failed: %v", err)
+			return web.TransactionResponse{}, fmt.Errorf("inventory service unavailable")
+		}
+		if !restoreResp.Success {
+			service.Logger.Warnf("-inventory rejected: %s", restoreResp.Message)
+			return web.TransactionResponse{}, fmt.Errorf("error: %v", restoreResp.Message)
+		}
+	}
+
+	service.Logger.Info("-trying to commit tx...")
+	errCommit := tx.Commit()
+	if errCommit != nil {
+		service.Logger.Errorf("-failed commit, cancelling the restock: %v", errCommit)
+		if len(grpcItems) > 0 {
+			cancelStockOperation(ctx, service.InventoryClient, service.Logger, operationID, req.UserID, fmt.Sprintf("rollback void: %s", req.TransactionID.String()))
+		}
+		return web.TransactionResponse{}, errCommit
+	}
+
+	header.Status = domain.TransactionStatusVoided
+	header.VoidedAt = &voidedAt
+	header.VoidedBy = &req.UserID
+	header.VoidReason = &req.Reason
+
+	service.Logger.Info("-success, returning back to controller layer")
+	return helper.ToTransactionResponse(header, helper.ToTransactionItemResponses(detailsDomain)), nil
+}
+
+func addStockItems(items []*pb.Item, productID ulid.ULID, components []domain.TransactionDetailComponent, quantity int) []*pb.Item {
+	if len(components) == 0 {
+		return addStockItem(items, productID.String(), quantity)
+	}
+	for _, component := range components {
+		items = addStockItem(items, component.ProductID.String(), component.Quantity*quantity)
+	}
+	return items
+}
+
+func cancelStockOperation(ctx context.Context, inventoryClient pb.InventoryServiceClient, logger *logrus.Logger, operationID string, userID ulid.ULID, reason string) {
+	cancelResp, err := inventoryClient.CancelStockOperation(ctx, &pb.CancelStockOperationRequest{
+		OperationId: operationID,
+		UserId:      userID.String(),
+		Reason:      reason,
+	})
+	if err == nil && !cancelResp.Success {
+		err = fmt.Errorf("error: %v", cancelResp.Message)
+	}
+	if err != nil {
+		logger.Errorf("-failed to cancel stock operation %s, stock needs a manual correction: %v", operationID, err)
+	}
+}
+
+func addStockItem(items []*pb.Item, productID string, quantity int) []*pb.Item {
+	for _, item := range items {
+		if item.ProductId == productID {
+			item.Quantity += int32(quantity)
+			return items
+		}
+	}
+	return append(items, &pb.Item{ProductId: productID, Quantity: int32(quantity)})
+}
+
+func refundedUpTo(lineTotal decimal.Decimal, quantity int, units int) decimal.Decimal {
+	return lineTotal.Mul(decimal.NewFromInt(int64(units))).Div(decimal.NewFromInt(int64(quantity))).Round(2)
+}
+
+func voidWindow() time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv("VOID_WINDOW_MINUTES"))
+	if err != nil || minutes <= 0 {
+		minutes = 15
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+func toTransactionPayments(paymentsReq []web.TransactionPaymentReq, transactionID ulid.ULID, totalAmount decimal.Decimal, timestamp uint64, entropy io.Reader) ([]domain.TransactionPayment, error) {
+	payments := make([]domain.TransactionPayment, 0, len(paymentsReq))
+	paidAmount := decimal.Zero
+
+	for _, paymentReq := range paymentsReq {
+		if !paymentReq.Amount.IsPositive() {
+			return nil, exception.ErrInvalidPayment
+		}
+
+		cardMethod := paymentReq.Method == domain.PaymentMethodGiftCard || paymentReq.Method == domain.PaymentMethodStoreCredit
+		if cardMethod != (paymentReq.CardCode != nil) {
+			return nil, exception.ErrInvalidCardPayment
+		}
+
+		payment := domain.TransactionPayment{
+			PaymentID:     ulid.MustNew(timestamp, entropy),
+			TransactionID: transactionID,
+			Method:        paymentReq.Method,
+			Amount:        paymentReq.Amount,
+			ChangeGiven:   decimal.Zero,
+			Reference:     paymentReq.Reference,
+		}
+
+		if paymentReq.AmountTendered != nil {
+			if paymentReq.Method != domain.PaymentMethodCash || paymentReq.AmountTendered.LessThan(paymentReq.Amount) {
+				return nil, exception.ErrInvalidPayment
+			}
+			payment.AmountTendered = decimal.NewNullDecimal(*paymentReq.AmountTendered)
+			payment.ChangeGiven = paymentReq.AmountTendered.Sub(paymentReq.Amount)
+		}
+
+		paidAmount = paidAmount.Add(paymentReq.Amount)
+		payments = append(payments, payment)
+	}
+
+	if !paidAmount.Equal(totalAmount) {
+		return nil, exception.ErrPaymentMismatch
+	}
+
+	return payments, nil
+}
+
+func loyaltyRedemption(payments []domain.TransactionPayment, customerID *ulid.ULID) (int, error) {
+	amount := decimal.Zero
+	for _, payment := range payments {
+		if payment.Method == domain.PaymentMethodLoyalty {
+			amount = amount.Add(payment.Amount)
+		}
+	}
+	if amount.IsZero() {
+		return 0, nil
+	}
+
+	if customerID == nil {
+		return 0, exception.ErrLoyaltyCustomerRequired
+	}
+
+	points := amount.Div(loyaltyPointValue())
+	if !points.IsInteger() {
+		return 0, exception.ErrInvalidPayment
+	}
+
+	return int(points.IntPart()), nil
+}
+
+func (service *TransactionServiceImpl) replayIdempotencyKey(ctx context.Context, req web.TransactionRequest) (web.TransactionResponse, bool, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.TransactionResponse{}, false, err
+	}
+	defer tx.Rollback()
+
+	claimedKey, err := service.TransactionRepository.FindIdempotencyKey(ctx, tx, req.UserID, req.IdempotencyKey)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.TransactionResponse{}, false, nil
+		}
+		return web.TransactionResponse{}, false, err
+	}
+
+	if claimedKey.RequestHash != idempotencyRequestHash(req) {
+		service.Logger.Warnf("-idempotency key %s was used for a different request", req.IdempotencyKey)
+		return web.TransactionResponse{}, false, exception.ErrIdempotencyKeyMismatch
+	}
+
+	header, err := service.TransactionRepository.FindByID(ctx, tx, claimedKey.TransactionID)
+	if err != nil {
+		return web.TransactionResponse{}, false, err
+	}
+
+	if header.Status == domain.TransactionStatusPending {
+		return web.TransactionResponse{}, false, exception.ErrIdempotencyKeyInProgress
+	}
+
+	if header.Status == domain.TransactionStatusFailed {
+		service.Logger.Infof("-transaction %s behind idempotency key failed, the key will be reclaimed", header.TransactionID)
+		return web.TransactionResponse{}, false, nil
+	}
+
+	detailsDomain, err := service.TransactionRepository.FindDetailsByTransactionID(ctx, tx, claimedKey.TransactionID)
+	if err != nil {
+		return web.TransactionResponse{}, false, err
+	}
+
+	discounts, err := service.TransactionRepository.FindDiscountsByTransactionID(ctx, tx, claimedKey.TransactionID)
+	if err != nil {
+		return web.TransactionResponse{}, false, err
+	}
+
+	payments, err := service.TransactionRepository.FindPaymentsByTransactionID(ctx, tx, claimedKey.TransactionID)
+	if err != nil {
+		return web.TransactionResponse{}, false, err
+	}
+
+	response := helper.ToTransactionResponse(header, helper.ToTransactionItemResponses(detailsDomain))
+	response.Discounts = helper.ToTransactionDiscountResponses(discounts)
+	response.Payments = helper.ToTransactionPaymentResponses(payments)
+
+	return response, true, tx.Commit()
+}
+
+func (service *TransactionServiceImpl) claimIdempotencyKey(ctx context.Context, tx *sql.Tx, req web.TransactionRequest, transactionID ulid.ULID, t time.Time) error {
+	err := service.TransactionRepository.DeleteFailedIdempotencyKey(ctx, tx, req.UserID, req.IdempotencyKey)
+	if err != nil {
+		service.Logger.Errorf("-failed to reclaim idempotency key: %v", err)
+		return err
+	}
+
+	err = service.TransactionRepository.SaveIdempotencyKey(ctx, tx, domain.IdempotencyKey{
+		IdempotencyKey: req.IdempotencyKey,
+		UserID:         req.UserID,
+		TransactionID:  transactionID,
+		RequestHash:    idempotencyRequestHash(req),
+		CreatedAt:      t,
+	})
+	if errors.Is(err, exception.ErrDuplicateIdempotencyKey) {
+		return exception.ErrIdempotencyKeyInProgress
+	}
+	if err != nil {
+		service.Logger.Errorf("-failed to claim idempotency key: %v", err)
+	}
+	return err
+}
 
-	return helper.ToTransactionResponse(header, itemsResponse), nil
+func idempotencyRequestHash(req web.TransactionRequest) string {
+	body, _ := json.Marshal(req)
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
 }
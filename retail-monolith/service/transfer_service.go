@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"retail-management/model/web"
+
+	"github.com/oklog/ulid/v2"
+)
+
+type TransferService interface {
+	Create(ctx context.Context, req web.TransferRequest) (web.TransferResponse, error)
+	Dispatch(ctx context.Context, transferID ulid.ULID, userID ulid.ULID) (web.TransferResponse, error)
+	Receive(ctx context.Context, req web.TransferReceiveRequest) (web.TransferResponse, error)
+	Cancel(ctx context.Context, transferID ulid.ULID, userID ulid.ULID) (web.TransferResponse, error)
+	FindByID(ctx context.Context, transferID ulid.ULID) (web.TransferResponse, error)
+	FindAll(ctx context.Context, locationID *ulid.ULID, status string) ([]web.TransferResponse, error)
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"retail-management/exception"
+	"retail-management/model/web"
+	"retail-management/pb"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type TransferServiceImpl struct {
+	InventoryClient pb.InventoryServiceClient
+	Validate        *validator.Validate
+	Logger          *logrus.Logger
+}
+
+func NewTransferService(inventoryClient pb.InventoryServiceClient, validate *validator.Validate, logger *logrus.Logger) TransferService {
+	return &TransferServiceImpl{
+		InventoryClient: inventoryClient,
+		Validate:        validate,
+		Logger:          logger,
+	}
+}
+
+func (service *TransferServiceImpl) Create(ctx context.Context, req web.TransferRequest) (web.TransferResponse, error) {
+	service.Logger.Info("-validating the req body...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating the req: %v", err)
+		return web.TransferResponse{}, err
+	}
+
+	var items []*pb.Item
+	for _, item := range req.Items {
+		items = append(items, &pb.Item{
+			ProductId: item.ProductID.String(),
+			Quantity:  int32(item.Quantity),
+		})
+	}
+
+	service.Logger.Info("-forwarding create transfer request to microservice...")
+	transfer, err := service.InventoryClient.CreateTransfer(ctx, &pb.CreateTransferRequest{
+		SourceLocationId:      req.SourceLocationID.String(),
+		DestinationLocationId: req.DestinationLocationID.String(),
+		Items:                 items,
+		UserId:                req.UserID.String(),
+		Note:                  req.Note,
+	})
+	if err != nil {
+		service.Logger.Errorf("-grpc call failed: %v", err)
+		return web.TransferResponse{}, transferError(err)
+	}
+
+	return toTransferResponse(transfer), nil
+}
+
+func (service *TransferServiceImpl) Dispatch(ctx context.Context, transferID ulid.ULID, userID ulid.ULID) (web.TransferResponse, error) {
+	service.Logger.Info("-forwarding dispatch transfer request to microservice...")
+	transfer, err := service.InventoryClient.DispatchTransfer(ctx, &pb.DispatchTransferRequest{
+		TransferId: transferID.String(),
+		UserId:     userID.String(),
+	})
+	if err != nil {
+		service.Logger.Errorf("-grpc call failed: %v", err)
+		return web.TransferResponse{}, transferError(err)
+	}
+
+	return toTransferResponse(transfer), nil
+}
+
+func (service *TransferServiceImpl) Receive(ctx context.Context, req web.TransferReceiveRequest) (web.TransferResponse, error) {
+	service.Logger.Info("-validating the req body...")
+	err := service.Validate.Struct(req)
+	if err != nil {
+		service.Logger.Errorf("-there is an error when validating the req: %v", err)
+		return web.TransferResponse{}, err
+	}
+
+	var items []*pb.TransferReceiptItem
+	for _, item := range req.Items {
+		items = append(items, &pb.TransferReceiptItem{
+			ProductId:           item.ProductID.String(),
+			QuantityReceived:    int32(item.QuantityReceived),
+			QuantityDiscrepancy: int32(item.QuantityDiscrepancy),
+			DiscrepancyNote:     item.DiscrepancyNote,
+		})
+	}
+
+	service.Logger.Info("-forwarding receive transfer request to microservice...")
+	transfer, err := service.InventoryClient.ReceiveTransfer(ctx, &pb.ReceiveTransferRequest{
+		TransferId: req.TransferID.String(),
+		UserId:     req.UserID.String(),
+		Items:      items,
+		ReceiptKey: req.IdempotencyKey,
+	})
+	if err != nil {
+		service.Logger.Errorf("-grpc call failed: %v", err)
+		return web.TransferResponse{}, transferError(err)
+	}
+
+	return toTransferResponse(transfer), nil
+}
+
+func (service *TransferServiceImpl) Cancel(ctx context.Context, transferID ulid.ULID, userID ulid.ULID) (web.TransferResponse, error) {
+	service.Logger.Info("-forwarding cancel transfer request to microservice...")
+	transfer, err := service.InventoryClient.CancelTransfer(ctx, &pb.CancelTransferRequest{
+		TransferId: transferID.String(),
+		UserId:     userID.String(),
+	})
+	if err != nil {
+		service.Logger.Errorf("-grpc call failed: %v", err)
+		return web.TransferResponse{}, transferError(err)
+	}
+
+	return toTransferResponse(transfer), nil
+}
+
+func (service *TransferServiceImpl) FindByID(ctx context.Context, transferID ulid.ULID) (web.TransferResponse, error) {
+	service.Logger.Info("-fetching transfer from microservice...")
+	transfer, err := service.InventoryClient.GetTransfer(ctx, &pb.GetTransferRequest{
+		TransferId: transferID.String(),
+	})
+	if err != nil {
+		service.Logger.Errorf("-grpc call failed: %v", err)
+		return web.TransferResponse{}, transferError(err)
+	}
+
+	return toTransferResponse(transfer), nil
+}
+
+func (service *TransferServiceImpl) FindAll(ctx context.Context, locationID *ulid.ULID, transferStatus string) ([]web.TransferResponse, error) {
+	req := &pb.ListTransfersRequest{Status: transferStatus}
+	if locationID != nil {
+		req.LocationId = locationID.String()
+	}
+
+	service.Logger.Info("-fetching transfers from microservice...")
+	resp, err := service.InventoryClient.ListTransfers(ctx, req)
+	if err != nil {
+		service.Logger.Errorf("-grpc call failed: %v", err)
+		return []web.TransferResponse{}, transferError(err)
+	}
+
+	transfers := make([]web.TransferResponse, 0)
+	for _, transfer := range resp.Transfers {
+		transfers = append(transfers, toTransferResponse(transfer))
+	}
+	return transfers, nil
+}
+
+func transferError(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return exception.ErrNotFound
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %s", exception.ErrInvalidTransfer, status.Convert(err).Message())
+	case codes.FailedPrecondition:
+		return fmt.Errorf("%w: %s", exception.ErrTransferRejected, status.Convert(err).Message())
+	}
+	return err
+}
+
+func toTransferResponse(transfer *pb.StockTransfer) web.TransferResponse {
+	transferID, _ := ulid.Parse(transfer.TransferId)
+	sourceID, _ := ulid.Parse(transfer.SourceLocationId)
+	destinationID, _ := ulid.Parse(transfer.DestinationLocationId)
+	createdBy, _ := ulid.Parse(transfer.CreatedBy)
+	createdAt, _ := time.Parse(time.RFC3339, transfer.CreatedAt)
+
+	response := web.TransferResponse{
+		TransferID:            transferID,
+		SourceLocationID:      sourceID,
+		DestinationLocationID: destinationID,
+		Status:                transfer.Status,
+		Note:                  transfer.Note,
+		CreatedBy:             createdBy,
+		CreatedAt:             createdAt,
+		Items:                 make([]web.TransferItemResponse, 0),
+	}
+	if dispatchedAt, err := time.Parse(time.RFC3339, transfer.DispatchedAt); err == nil {
+		response.DispatchedAt = &dispatchedAt
+	}
+	if receivedAt, err := time.Parse(time.RFC3339, transfer.ReceivedAt); err == nil {
+		response.ReceivedAt = &receivedAt
+	}
+
+	for _, item := range transfer.Items {
+		productID, _ := ulid.Parse(item.ProductId)
+		response.Items = append(response.Items, web.TransferItemResponse{
+			ProductID:           productID,
+			Quantity:            int(item.Quantity),
+			QuantityReceived:    int(item.QuantityReceived),
+			QuantityDiscrepancy: int(item.QuantityDiscrepancy),
+			InTransit:           int(item.InTransit),
+			DiscrepancyNote:     item.DiscrepancyNote,
+		})
+	}
+
+	return response
+}
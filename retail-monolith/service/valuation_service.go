@@ -0,0 +1,10 @@
+package service
+
+import (
+	"context"
+	"retail-management/model/web"
+)
+
+type ValuationService interface {
+	Valuate(ctx context.Context, date string) (web.ValuationResponse, error)
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"os"
+	"retail-management/exception"
+	"retail-management/helper"
+	"retail-management/model/domain"
+	"retail-management/model/web"
+	"retail-management/repository"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+var ledgerEntropy = &ulid.LockedMonotonicReader{MonotonicReader: ulid.Monotonic(rand.Reader, 0)}
+
+type ValuationServiceImpl struct {
+	CostRepository repository.CostRepository
+	DB             *sql.DB
+	Logger         *logrus.Logger
+}
+
+func NewValuationService(costRepository repository.CostRepository, db *sql.DB, logger *logrus.Logger) ValuationService {
+	return &ValuationServiceImpl{
+		CostRepository: costRepository,
+		DB:             db,
+		Logger:         logger,
+	}
+}
+
+func (service *ValuationServiceImpl) Valuate(ctx context.Context, date string) (web.ValuationResponse, error) {
+	asOf := time.Now().UTC().Truncate(24 * time.Hour)
+	if date != "" {
+		parsed, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			service.Logger.Warnf("-invalid valuation date %q: %v", date, err)
+			return web.ValuationResponse{}, exception.ErrInvalidValuationDate
+		}
+		asOf = parsed
+	}
+
+	service.Logger.Info("-trying to begin tx...")
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return web.ValuationResponse{}, err
+	}
+	defer tx.Commit()
+
+	service.Logger.Info("-executing CostRepository.FindValuation()...")
+	valuations, err := service.CostRepository.FindValuation(ctx, tx, asOf.AddDate(0, 0, 1))
+	if err != nil {
+		service.Logger.Errorf("-failed to find inventory valuation: %v", err)
+		return web.ValuationResponse{}, err
+	}
+
+	response := web.ValuationResponse{
+		AsOf:          asOf.Format("2006-01-02"),
+		CostingMethod: costingMethod(),
+		TotalValue:    decimal.Zero,
+		Items:         make([]web.ValuationItemResponse, 0),
+	}
+	for _, valuation := range valuations {
+		response.TotalQuantity += valuation.Quantity
+		response.TotalValue = response.TotalValue.Add(valuation.Value)
+		response.Items = append(response.Items, helper.ToValuationItemResponse(valuation))
+	}
+
+	return response, nil
+}
+
+func costingMethod() string {
+	if os.Getenv("COSTING_METHOD") == domain.CostingMethodWeightedAverage {
+		return domain.CostingMethodWeightedAverage
+	}
+	return domain.CostingMethodFIFO
+}
+
+func receiveCost(ctx context.Context, tx *sql.Tx, costRepository repository.CostRepository, productID ulid.ULID, quantity int, unitCost decimal.Decimal, reference string, t time.Time) error {
+	if quantity <= 0 {
+		return nil
+	}
+
+	latest, err := costRepository.FindLatestEntryForUpdate(ctx, tx, productID)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	_, err = costRepository.SaveLayer(ctx, tx, domain.CostLayer{
+		LayerID:           ulid.MustNew(ulid.Timestamp(t), ulid.Monotonic(rand.Reader, 0)),
+		ProductID:         productID,
+		UnitCost:          unitCost,
+		QuantityReceived:  quantity,
+		QuantityRemaining: quantity,
+		Reference:         reference,
+		ReceivedAt:        t,
+	})
+	if err != nil {
+		return err
+	}
+
+	value := unitCost.Mul(decimal.NewFromInt(int64(quantity))).Round(2)
+	_, err = costRepository.SaveEntry(ctx, tx, domain.CostLedgerEntry{
+		EntryID:         nextEntryID(latest, t),
+		ProductID:       productID,
+		ChangeQuantity:  quantity,
+		ChangeValue:     value,
+		BalanceQuantity: latest.BalanceQuantity + quantity,
+		BalanceValue:    latest.BalanceValue.Add(value),
+		Reference:       reference,
+		CreatedAt:       t,
+	})
+	return err
+}
+
+func issueCost(ctx context.Context, tx *sql.Tx, costRepository repository.CostRepository, productID ulid.ULID, quantity int, fallbackCost decimal.Decimal, reference string, t time.Time) (decimal.Decimal, error) {
+	if quantity <= 0 {
+		return decimal.Zero, nil
+	}
+
+	latest, err := costRepository.FindLatestEntryForUpdate(ctx, tx, productID)
+	if err != nil && err != sql.ErrNoRows {
+		return decimal.Zero, err
+	}
+
+	layers, err := costRepository.FindOpenLayersForUpdate(ctx, tx, productID)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	remaining := quantity
+	cost := decimal.Zero
+	for _, layer := range layers {
+		if remaining == 0 {
+			break
+		}
+		take := min(layer.QuantityRemaining, remaining)
+		err = costRepository.UpdateLayerRemaining(ctx, tx, layer.LayerID, layer.QuantityRemaining-take)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		cost = cost.Add(layer.UnitCost.Mul(decimal.NewFromInt(int64(take))))
+		remaining -= take
+	}
+	cost = cost.Add(fallbackCost.Mul(decimal.NewFromInt(int64(remaining))))
+
+	if costingMethod() == domain.CostingMethodWeightedAverage && latest.BalanceQuantity > 0 {
+		averageCost := latest.BalanceValue.Div(decimal.NewFromInt(int64(latest.BalanceQuantity)))
+		cost = averageCost.Mul(decimal.NewFromInt(int64(quantity)))
+	}
+	cost = cost.Round(2)
+
+	balanceQuantity := latest.BalanceQuantity - quantity
+	balanceValue := latest.BalanceValue.Sub(cost)
+	if balanceQuantity <= 0 || balanceValue.IsNegative() {
+		balanceQuantity = max(balanceQuantity, 0)
+		balanceValue = decimal.Zero
+	}
+
+	_, err = costRepository.SaveEntry(ctx, tx, domain.CostLedgerEntry{
+		EntryID:         nextEntryID(latest, t),
+		ProductID:       productID,
+		ChangeQuantity:  -quantity,
+		ChangeValue:     cost.Neg(),
+		BalanceQuantity: balanceQuantity,
+		BalanceValue:    balanceValue,
+		Reference:       reference,
+		CreatedAt:       t,
+	})
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return cost, nil
+}
+
+// the latest ledger entry is found by entry_id, so a new one must never sort before it
+func nextEntryID(latest domain.CostLedgerEntry, t time.Time) ulid.ULID {
+	return ulid.MustNew(max(ulid.Timestamp(t), latest.EntryID.Time()), ledgerEntropy)
+}